@@ -434,3 +434,42 @@ func VerifyObjectCopyAccess(ctx context.Context, be backend.Backend, copySource
 
 	return nil
 }
+
+// VerifyObjectMoveAccess verifies the copy access and, as the source
+// object is removed after the move, the source object delete access
+func VerifyObjectMoveAccess(ctx context.Context, be backend.Backend, copySource string, opts AccessOptions) error {
+	if err := VerifyObjectCopyAccess(ctx, be, copySource, opts); err != nil {
+		return err
+	}
+	if opts.IsRoot {
+		return nil
+	}
+	if opts.Acc.Role == RoleAdmin {
+		return nil
+	}
+
+	srcBucket, srcObject, found := strings.Cut(copySource, "/")
+	if !found {
+		return s3err.GetAPIError(s3err.ErrInvalidCopySource)
+	}
+
+	srcBucketACLBytes, err := be.GetBucketAcl(ctx, &s3.GetBucketAclInput{Bucket: &srcBucket})
+	if err != nil {
+		return err
+	}
+
+	var srcBucketAcl ACL
+	if err := json.Unmarshal(srcBucketACLBytes, &srcBucketAcl); err != nil {
+		return err
+	}
+
+	return VerifyAccess(ctx, be, AccessOptions{
+		Acl:           srcBucketAcl,
+		AclPermission: types.PermissionWrite,
+		IsRoot:        opts.IsRoot,
+		Acc:           opts.Acc,
+		Bucket:        srcBucket,
+		Object:        srcObject,
+		Action:        DeleteObjectAction,
+	})
+}
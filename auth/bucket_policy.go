@@ -17,6 +17,7 @@ package auth
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/versity/versitygw/s3err"
@@ -32,6 +33,11 @@ var (
 	errInvalidAction = errors.New("Policy has invalid action")
 )
 
+func errMissingField(field string) error {
+	//lint:ignore ST1005 Reason: This error message is intended for end-user clarity and follows their expectations
+	return fmt.Errorf("Missing required field %v", field)
+}
+
 type BucketPolicy struct {
 	Statement []BucketPolicyItem `json:"Statement"`
 }
@@ -47,19 +53,22 @@ func (bp *BucketPolicy) Validate(bucket string, iam IAMService) error {
 	return nil
 }
 
+// isAllowed checks if any of the statements allows the action, an explicit
+// deny overrides the allows regardless of the statements order
 func (bp *BucketPolicy) isAllowed(principal string, action Action, resource string) bool {
+	var isAllowed bool
 	for _, statement := range bp.Statement {
 		if statement.findMatch(principal, action, resource) {
 			switch statement.Effect {
 			case BucketPolicyAccessTypeAllow:
-				return true
+				isAllowed = true
 			case BucketPolicyAccessTypeDeny:
 				return false
 			}
 		}
 	}
 
-	return false
+	return isAllowed
 }
 
 type BucketPolicyItem struct {
@@ -70,6 +79,18 @@ type BucketPolicyItem struct {
 }
 
 func (bpi *BucketPolicyItem) Validate(bucket string, iam IAMService) error {
+	if bpi.Effect == "" {
+		return errMissingField("Effect")
+	}
+	if len(bpi.Principals) == 0 {
+		return errMissingField("Principal")
+	}
+	if len(bpi.Actions) == 0 {
+		return errMissingField("Action")
+	}
+	if len(bpi.Resources) == 0 {
+		return errMissingField("Resource")
+	}
 	if err := bpi.Effect.Validate(); err != nil {
 		return err
 	}
@@ -150,3 +171,9 @@ func VerifyBucketPolicy(policy []byte, access, bucket, object string, action Act
 
 	return nil
 }
+
+// VerifyPublicBucketPolicy checks if the bucket policy allows
+// the action for all users("*"), i.e. unauthenticated requests
+func VerifyPublicBucketPolicy(policy []byte, bucket, object string, action Action) error {
+	return VerifyBucketPolicy(policy, "*", bucket, object, action)
+}
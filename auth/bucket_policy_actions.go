@@ -58,6 +58,9 @@ const (
 	BypassGovernanceRetentionAction        Action = "s3:BypassGovernanceRetention"
 	PutBucketOwnershipControlsAction       Action = "s3:PutBucketOwnershipControls"
 	GetBucketOwnershipControlsAction       Action = "s3:GetBucketOwnershipControls"
+	PutAccelerateConfigurationAction       Action = "s3:PutAccelerateConfiguration"
+	GetAccelerateConfigurationAction       Action = "s3:GetAccelerateConfiguration"
+	GetBucketLocationAction                Action = "s3:GetBucketLocation"
 	AllActions                             Action = "s3:*"
 )
 
@@ -97,6 +100,9 @@ var supportedActionList = map[Action]struct{}{
 	BypassGovernanceRetentionAction:        {},
 	PutBucketOwnershipControlsAction:       {},
 	GetBucketOwnershipControlsAction:       {},
+	PutAccelerateConfigurationAction:       {},
+	GetAccelerateConfigurationAction:       {},
+	GetBucketLocationAction:                {},
 	AllActions:                             {},
 }
 
@@ -17,9 +17,11 @@ package azure
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -66,6 +68,8 @@ const (
 	keyObjLegalHold        key = "Objectlegalhold"
 	onameAttr              key = "Objname"
 	onameAttrLower         key = "objname"
+	keyMD5ETag             key = "Md5etag"
+	keyMD5ETagLower        key = "md5etag"
 	metaTmpMultipartPrefix key = ".sgwtmp" + "/multipart"
 )
 
@@ -79,6 +83,7 @@ func (key) Table() map[string]struct{} {
 		"objectretention":   {},
 		"objectlegalhold":   {},
 		"objname":           {},
+		"md5etag":           {},
 		".sgwtmp/multipart": {},
 	}
 }
@@ -91,11 +96,16 @@ type Azure struct {
 	defaultCreds   *azidentity.DefaultAzureCredential
 	serviceURL     string
 	sasToken       string
+
+	// md5ETags enables the ETag compatibility mode: the object ETags
+	// are the MD5 of the data (or the multipart "-N" form) computed
+	// on write, instead of the azure blob ETags
+	md5ETags bool
 }
 
 var _ backend.Backend = &Azure{}
 
-func New(accountName, accountKey, serviceURL, sasToken string) (*Azure, error) {
+func New(accountName, accountKey, serviceURL, sasToken string, md5ETags bool) (*Azure, error) {
 	url := serviceURL
 	if serviceURL == "" && accountName != "" {
 		// if not otherwise specified, use the typical form:
@@ -108,7 +118,7 @@ func New(accountName, accountKey, serviceURL, sasToken string) (*Azure, error) {
 		if err != nil {
 			return nil, fmt.Errorf("init client: %w", err)
 		}
-		return &Azure{client: client, serviceURL: serviceURL, sasToken: sasToken}, nil
+		return &Azure{client: client, serviceURL: serviceURL, sasToken: sasToken, md5ETags: md5ETags}, nil
 	}
 
 	if accountName == "" {
@@ -125,7 +135,7 @@ func New(accountName, accountKey, serviceURL, sasToken string) (*Azure, error) {
 		if err != nil {
 			return nil, fmt.Errorf("init client: %w", err)
 		}
-		return &Azure{client: client, serviceURL: url, defaultCreds: cred}, nil
+		return &Azure{client: client, serviceURL: url, defaultCreds: cred, md5ETags: md5ETags}, nil
 	}
 
 	cred, err := azblob.NewSharedKeyCredential(accountName, accountKey)
@@ -138,7 +148,7 @@ func New(accountName, accountKey, serviceURL, sasToken string) (*Azure, error) {
 		return nil, fmt.Errorf("init client: %w", err)
 	}
 
-	return &Azure{client: client, serviceURL: url, sharedkeyCreds: cred}, nil
+	return &Azure{client: client, serviceURL: url, sharedkeyCreds: cred, md5ETags: md5ETags}, nil
 }
 
 func (az *Azure) Shutdown() {}
@@ -287,6 +297,10 @@ func (az *Azure) DeleteBucketOwnershipControls(ctx context.Context, bucket strin
 }
 
 func (az *Azure) PutObject(ctx context.Context, po *s3.PutObjectInput) (s3response.PutObjectOutput, error) {
+	if po.SSECustomerKey != nil {
+		return s3response.PutObjectOutput{}, s3err.GetAPIError(s3err.ErrNotImplemented)
+	}
+
 	tags, err := parseTags(po.Tagging)
 	if err != nil {
 		return s3response.PutObjectOutput{}, err
@@ -301,6 +315,7 @@ func (az *Azure) PutObject(ctx context.Context, po *s3.PutObjectInput) (s3respon
 	opts.HTTPHeaders.BlobContentEncoding = po.ContentEncoding
 	opts.HTTPHeaders.BlobContentLanguage = po.ContentLanguage
 	opts.HTTPHeaders.BlobContentDisposition = po.ContentDisposition
+	opts.HTTPHeaders.BlobCacheControl = po.CacheControl
 	if strings.HasSuffix(*po.Key, "/") {
 		// Hardcode "application/x-directory" for direcoty objects
 		opts.HTTPHeaders.BlobContentType = backend.GetStringPtr(backend.DirContentType)
@@ -312,11 +327,38 @@ func (az *Azure) PutObject(ctx context.Context, po *s3.PutObjectInput) (s3respon
 		opts.HTTPHeaders.BlobContentType = backend.GetStringPtr(backend.DefaultContentType)
 	}
 
-	uploadResp, err := az.client.UploadStream(ctx, *po.Bucket, *po.Key, po.Body, opts)
+	body := po.Body
+	hash := md5.New()
+	if az.md5ETags {
+		body = io.TeeReader(po.Body, hash)
+	}
+
+	uploadResp, err := az.client.UploadStream(ctx, *po.Bucket, *po.Key, body, opts)
 	if err != nil {
 		return s3response.PutObjectOutput{}, azureErrToS3Err(err)
 	}
 
+	etag := string(*uploadResp.ETag)
+	if az.md5ETags {
+		// the md5 sum is known only after the data is uploaded,
+		// so it is added to the blob metadata afterwards
+		etag = hex.EncodeToString(hash.Sum(nil))
+		meta := opts.Metadata
+		if meta == nil {
+			meta = map[string]*string{}
+		}
+		meta[string(keyMD5ETag)] = &etag
+
+		blobClient, err := az.getBlobClient(*po.Bucket, *po.Key)
+		if err != nil {
+			return s3response.PutObjectOutput{}, err
+		}
+		_, err = blobClient.SetMetadata(ctx, meta, nil)
+		if err != nil {
+			return s3response.PutObjectOutput{}, azureErrToS3Err(err)
+		}
+	}
+
 	// Set object legal hold
 	if po.ObjectLockLegalHoldStatus == types.ObjectLockLegalHoldStatusOn {
 		err := az.PutObjectLegalHold(ctx, *po.Bucket, *po.Key, "", true)
@@ -342,7 +384,7 @@ func (az *Azure) PutObject(ctx context.Context, po *s3.PutObjectInput) (s3respon
 	}
 
 	return s3response.PutObjectOutput{
-		ETag: string(*uploadResp.ETag),
+		ETag: etag,
 	}, nil
 }
 
@@ -383,12 +425,33 @@ func (az *Azure) DeleteBucketTagging(ctx context.Context, bucket string) error {
 }
 
 func (az *Azure) GetObject(ctx context.Context, input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	if input.SSECustomerKey != nil {
+		return nil, s3err.GetAPIError(s3err.ErrSSECustomerKeyNotApplicable)
+	}
+
 	var opts *azblob.DownloadStreamOptions
 	if *input.Range != "" {
-		offset, count, err := backend.ParseRange(0, *input.Range)
+		// the object size is needed to resolve the suffix and
+		// unsatisfiable ranges, azure doesn't support them
+		client, err := az.getBlobClient(*input.Bucket, *input.Key)
 		if err != nil {
 			return nil, err
 		}
+		props, err := client.GetProperties(ctx, nil)
+		if err != nil {
+			return nil, azureErrToS3Err(err)
+		}
+		var size int64
+		if props.ContentLength != nil {
+			size = *props.ContentLength
+		}
+
+		offset, count, err := backend.ParseGetObjectRange(size, *input.Range)
+		if err != nil {
+			return &s3.GetObjectOutput{
+				ContentRange: backend.GetStringPtr(backend.UnsatisfiableContentRange(size)),
+			}, err
+		}
 		opts = &azblob.DownloadStreamOptions{
 			Range: blob.HTTPRange{
 				Count:  count,
@@ -412,21 +475,27 @@ func (az *Azure) GetObject(ctx context.Context, input *s3.GetObjectInput) (*s3.G
 	}
 
 	return &s3.GetObjectOutput{
-		AcceptRanges:    input.Range,
-		ContentLength:   blobDownloadResponse.ContentLength,
-		ContentEncoding: blobDownloadResponse.ContentEncoding,
-		ContentType:     contentType,
-		ETag:            (*string)(blobDownloadResponse.ETag),
-		LastModified:    blobDownloadResponse.LastModified,
-		Metadata:        parseAzMetadata(blobDownloadResponse.Metadata),
-		TagCount:        &tagcount,
-		ContentRange:    blobDownloadResponse.ContentRange,
-		Body:            blobDownloadResponse.Body,
-		StorageClass:    types.StorageClassStandard,
+		AcceptRanges:       input.Range,
+		ContentLength:      blobDownloadResponse.ContentLength,
+		ContentEncoding:    blobDownloadResponse.ContentEncoding,
+		ContentType:        contentType,
+		CacheControl:       blobDownloadResponse.CacheControl,
+		ContentDisposition: blobDownloadResponse.ContentDisposition,
+		ETag:               az.objectETag(blobDownloadResponse.Metadata, blobDownloadResponse.ETag),
+		LastModified:       blobDownloadResponse.LastModified,
+		Metadata:           parseAzMetadata(blobDownloadResponse.Metadata),
+		TagCount:           &tagcount,
+		ContentRange:       blobDownloadResponse.ContentRange,
+		Body:               blobDownloadResponse.Body,
+		StorageClass:       types.StorageClassStandard,
 	}, nil
 }
 
 func (az *Azure) HeadObject(ctx context.Context, input *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
+	if input.SSECustomerKey != nil {
+		return nil, s3err.GetAPIError(s3err.ErrSSECustomerKeyNotApplicable)
+	}
+
 	if input.PartNumber != nil {
 		client, err := az.getBlockBlobClient(*input.Bucket, *input.Key)
 		if err != nil {
@@ -449,7 +518,7 @@ func (az *Azure) HeadObject(ctx context.Context, input *s3.HeadObjectInput) (*s3
 			if partNumber == int(*input.PartNumber) {
 				return &s3.HeadObjectOutput{
 					ContentLength: block.Size,
-					ETag:          block.Name,
+					ETag:          backend.GetStringPtr(az.partETag(*block.Name)),
 					PartsCount:    &partsCount,
 					StorageClass:  types.StorageClassStandard,
 				}, nil
@@ -476,7 +545,8 @@ func (az *Azure) HeadObject(ctx context.Context, input *s3.HeadObjectInput) (*s3
 		ContentEncoding:    resp.ContentEncoding,
 		ContentLanguage:    resp.ContentLanguage,
 		ContentDisposition: resp.ContentDisposition,
-		ETag:               (*string)(resp.ETag),
+		CacheControl:       resp.CacheControl,
+		ETag:               az.objectETag(resp.Metadata, resp.ETag),
 		LastModified:       resp.LastModified,
 		Metadata:           parseAzMetadata(resp.Metadata),
 		Expires:            resp.ExpiresOn,
@@ -530,6 +600,7 @@ func (az *Azure) ListObjects(ctx context.Context, input *s3.ListObjectsInput) (s
 		Marker:     input.Marker,
 		MaxResults: input.MaxKeys,
 		Prefix:     input.Prefix,
+		Include:    container.ListBlobsInclude{Metadata: az.md5ETags},
 	})
 
 	var objects []s3response.Object
@@ -555,7 +626,7 @@ Pager:
 				break Pager
 			}
 			objects = append(objects, s3response.Object{
-				ETag:         (*string)(v.Properties.ETag),
+				ETag:         az.objectETag(v.Metadata, v.Properties.ETag),
 				Key:          v.Name,
 				LastModified: v.Properties.LastModified,
 				Size:         v.Properties.ContentLength,
@@ -612,6 +683,7 @@ func (az *Azure) ListObjectsV2(ctx context.Context, input *s3.ListObjectsV2Input
 		Marker:     &marker,
 		MaxResults: input.MaxKeys,
 		Prefix:     input.Prefix,
+		Include:    container.ListBlobsInclude{Metadata: az.md5ETags},
 	})
 
 	var objects []s3response.Object
@@ -637,7 +709,7 @@ Pager:
 				break Pager
 			}
 			objects = append(objects, s3response.Object{
-				ETag:         (*string)(v.Properties.ETag),
+				ETag:         az.objectETag(v.Metadata, v.Properties.ETag),
 				Key:          v.Name,
 				LastModified: v.Properties.LastModified,
 				Size:         v.Properties.ContentLength,
@@ -738,6 +810,8 @@ func (az *Azure) CopyObject(ctx context.Context, input *s3.CopyObjectInput) (*s3
 		}
 
 		mdmap := props.Metadata
+		// the md5 etag is not a part of the object user metadata
+		delete(mdmap, string(keyMD5ETag))
 		if isMetaSame(mdmap, input.Metadata) {
 			return nil, s3err.GetAPIError(s3err.ErrInvalidCopyDest)
 		}
@@ -748,17 +822,43 @@ func (az *Azure) CopyObject(ctx context.Context, input *s3.CopyObjectInput) (*s3
 		return nil, err
 	}
 
+	meta := parseMetadata(input.Metadata)
+	var srcETag *string
+	if az.md5ETags {
+		// the copy has the same data, so it keeps the source md5 etag
+		srcBucket, srcObject, _ := strings.Cut(*input.CopySource, "/")
+		srcClient, err := az.getBlobClient(srcBucket, srcObject)
+		if err != nil {
+			return nil, err
+		}
+		props, err := srcClient.GetProperties(ctx, nil)
+		if err != nil {
+			return nil, azureErrToS3Err(err)
+		}
+		srcETag = props.Metadata[string(keyMD5ETag)]
+		// the source metadata, including the etag, is copied if
+		// no metadata is specified for the destination
+		if meta != nil && srcETag != nil {
+			meta[string(keyMD5ETag)] = srcETag
+		}
+	}
+
 	resp, err := bclient.CopyFromURL(ctx, az.serviceURL+"/"+*input.CopySource, &blob.CopyFromURLOptions{
 		BlobTags: tags,
-		Metadata: parseMetadata(input.Metadata),
+		Metadata: meta,
 	})
 	if err != nil {
 		return nil, azureErrToS3Err(err)
 	}
 
+	etag := (*string)(resp.ETag)
+	if srcETag != nil {
+		etag = srcETag
+	}
+
 	return &s3.CopyObjectOutput{
 		CopyObjectResult: &types.CopyObjectResult{
-			ETag:         (*string)(resp.ETag),
+			ETag:         etag,
 			LastModified: resp.LastModified,
 		},
 	}, nil
@@ -875,8 +975,10 @@ func (az *Azure) CreateMultipartUpload(ctx context.Context, input *s3.CreateMult
 	}
 	if getString(input.ContentType) != "" {
 		opts.HTTPHeaders = &blob.HTTPHeaders{
-			BlobContentType:     input.ContentType,
-			BlobContentEncoding: input.ContentEncoding,
+			BlobContentType:        input.ContentType,
+			BlobContentEncoding:    input.ContentEncoding,
+			BlobCacheControl:       input.CacheControl,
+			BlobContentDisposition: input.ContentDisposition,
 		}
 	}
 
@@ -901,11 +1003,17 @@ func (az *Azure) UploadPart(ctx context.Context, input *s3.UploadPartInput) (eta
 		return "", err
 	}
 
+	body := input.Body
+	hash := md5.New()
+	if az.md5ETags {
+		body = io.TeeReader(input.Body, hash)
+	}
+
 	// TODO: request streamable version of StageBlock()
 	// (*blockblob.Client).StageBlock does not have a streamable
 	// version of this function at this time, so we need to cache
 	// the body in memory to create an io.ReadSeekCloser
-	rdr, err := getReadSeekCloser(input.Body)
+	rdr, err := getReadSeekCloser(body)
 	if err != nil {
 		return "", err
 	}
@@ -915,6 +1023,18 @@ func (az *Azure) UploadPart(ctx context.Context, input *s3.UploadPartInput) (eta
 		return "", err
 	}
 
+	if az.md5ETags {
+		// the part md5 sum is kept in the block id to be
+		// able to build the multipart etag on complete
+		sum := hash.Sum(nil)
+		_, err = client.StageBlock(ctx, blockIDWithMD5(*input.PartNumber, sum), rdr, nil)
+		if err != nil {
+			return "", parseMpError(err)
+		}
+
+		return hex.EncodeToString(sum), nil
+	}
+
 	// block id serves as etag here
 	etag = blockIDInt32ToBase64(*input.PartNumber)
 	_, err = client.StageBlock(ctx, etag, rdr, nil)
@@ -926,6 +1046,12 @@ func (az *Azure) UploadPart(ctx context.Context, input *s3.UploadPartInput) (eta
 }
 
 func (az *Azure) UploadPartCopy(ctx context.Context, input *s3.UploadPartCopyInput) (s3response.CopyObjectResult, error) {
+	if az.md5ETags {
+		// the block id of the part requires the md5 sum of the
+		// copied data, which is not available for the copy
+		return s3response.CopyObjectResult{}, s3err.GetAPIError(s3err.ErrNotImplemented)
+	}
+
 	client, err := az.getBlockBlobClient(*input.Bucket, *input.Key)
 	if err != nil {
 		return s3response.CopyObjectResult{}, nil
@@ -997,7 +1123,7 @@ func (az *Azure) ListParts(ctx context.Context, input *s3.ListPartsInput) (s3res
 		}
 		parts = append(parts, s3response.Part{
 			Size:         *el.Size,
-			ETag:         *el.Name,
+			ETag:         az.partETag(*el.Name),
 			PartNumber:   partNumber,
 			LastModified: time.Now(),
 		})
@@ -1155,6 +1281,10 @@ func (az *Azure) AbortMultipartUpload(ctx context.Context, input *s3.AbortMultip
 // Deletes the multipart upload 'blob' from .sgwtmp namespace
 // It indicates the end of the multipart upload
 func (az *Azure) CompleteMultipartUpload(ctx context.Context, input *s3.CompleteMultipartUploadInput) (*s3.CompleteMultipartUploadOutput, error) {
+	if input.IfNoneMatch != nil {
+		// the create-only complete isn't supported
+		return nil, s3err.GetAPIError(s3err.ErrNotImplemented)
+	}
 	tmpPath := createMetaTmpPath(*input.Key, *input.UploadId)
 	blobClient, err := az.getBlobClient(*input.Bucket, tmpPath)
 	if err != nil {
@@ -1174,36 +1304,27 @@ func (az *Azure) CompleteMultipartUpload(ctx context.Context, input *s3.Complete
 	if err != nil {
 		return nil, err
 	}
-	blockIds := []string{}
-
 	blockList, err := client.GetBlockList(ctx, blockblob.BlockListTypeUncommitted, nil)
 	if err != nil {
 		return nil, azureErrToS3Err(err)
 	}
 
-	if len(blockList.UncommittedBlocks) != len(input.MultipartUpload.Parts) {
-		return nil, s3err.GetAPIError(s3err.ErrInvalidPart)
-	}
-
-	slices.SortFunc(blockList.UncommittedBlocks, func(a *blockblob.Block, b *blockblob.Block) int {
-		ptNumber, _ := decodeBlockId(*a.Name)
-		nextPtNumber, _ := decodeBlockId(*b.Name)
-		return ptNumber - nextPtNumber
-	})
-
-	for i, block := range blockList.UncommittedBlocks {
-		ptNumber, err := decodeBlockId(*block.Name)
+	var blockIds []string
+	if az.md5ETags {
+		blockIds, err = md5BlockIds(blockList.UncommittedBlocks, input.MultipartUpload.Parts)
 		if err != nil {
-			return nil, s3err.GetAPIError(s3err.ErrInvalidPart)
+			return nil, err
 		}
-
-		if *input.MultipartUpload.Parts[i].ETag != *block.Name {
-			return nil, s3err.GetAPIError(s3err.ErrInvalidPart)
+		if props.Metadata == nil {
+			props.Metadata = map[string]*string{}
 		}
-		if *input.MultipartUpload.Parts[i].PartNumber != int32(ptNumber) {
-			return nil, s3err.GetAPIError(s3err.ErrInvalidPart)
+		props.Metadata[string(keyMD5ETag)] = backend.GetStringPtr(
+			backend.GetMultipartMD5(input.MultipartUpload.Parts))
+	} else {
+		blockIds, err = partBlockIds(blockList.UncommittedBlocks, input.MultipartUpload.Parts)
+		if err != nil {
+			return nil, err
 		}
-		blockIds = append(blockIds, *block.Name)
 	}
 
 	opts := &blockblob.CommitBlockListOptions{
@@ -1211,8 +1332,10 @@ func (az *Azure) CompleteMultipartUpload(ctx context.Context, input *s3.Complete
 		Tags:     parseAzTags(tags.BlobTagSet),
 	}
 	opts.HTTPHeaders = &blob.HTTPHeaders{
-		BlobContentType:     props.ContentType,
-		BlobContentEncoding: props.ContentEncoding,
+		BlobContentType:        props.ContentType,
+		BlobContentEncoding:    props.ContentEncoding,
+		BlobCacheControl:       props.CacheControl,
+		BlobContentDisposition: props.ContentDisposition,
 	}
 
 	resp, err := client.CommitBlockList(ctx, blockIds, opts)
@@ -1229,7 +1352,7 @@ func (az *Azure) CompleteMultipartUpload(ctx context.Context, input *s3.Complete
 	return &s3.CompleteMultipartUploadOutput{
 		Bucket: input.Bucket,
 		Key:    input.Key,
-		ETag:   (*string)(resp.ETag),
+		ETag:   az.objectETag(props.Metadata, resp.ETag),
 	}, nil
 }
 
@@ -1618,6 +1741,130 @@ func blockIDInt32ToBase64(blockID int32) string {
 	return base64.StdEncoding.EncodeToString(binaryBlockID[:])
 }
 
+// partBlockIds returns the ids of the uncommitted blocks of the completed
+// parts, the block ids serve as the part etags
+func partBlockIds(blocks []*blockblob.Block, parts []types.CompletedPart) ([]string, error) {
+	if len(blocks) != len(parts) {
+		return nil, s3err.GetAPIError(s3err.ErrInvalidPart)
+	}
+
+	var last int32
+	for _, part := range parts {
+		if part.PartNumber == nil || part.ETag == nil {
+			return nil, s3err.GetAPIError(s3err.ErrInvalidPart)
+		}
+		if *part.PartNumber <= last {
+			return nil, s3err.GetAPIError(s3err.ErrInvalidPartOrder)
+		}
+		last = *part.PartNumber
+	}
+
+	slices.SortFunc(blocks, func(a *blockblob.Block, b *blockblob.Block) int {
+		ptNumber, _ := decodeBlockId(*a.Name)
+		nextPtNumber, _ := decodeBlockId(*b.Name)
+		return ptNumber - nextPtNumber
+	})
+
+	blockIds := []string{}
+	for i, block := range blocks {
+		ptNumber, err := decodeBlockId(*block.Name)
+		if err != nil {
+			return nil, s3err.GetAPIError(s3err.ErrInvalidPart)
+		}
+
+		if *parts[i].ETag != *block.Name {
+			return nil, s3err.GetAPIError(s3err.ErrInvalidPart)
+		}
+		if *parts[i].PartNumber != int32(ptNumber) {
+			return nil, s3err.GetAPIError(s3err.ErrInvalidPart)
+		}
+		if i < len(blocks)-1 && block.Size != nil && *block.Size < backend.MinPartSize {
+			return nil, s3err.GetAPIError(s3err.ErrEntityTooSmall)
+		}
+		blockIds = append(blockIds, *block.Name)
+	}
+
+	return blockIds, nil
+}
+
+// Creates a new Base64 encoded block id from a 32 bit integer followed
+// by the md5 sum of the block data
+func blockIDWithMD5(blockID int32, sum []byte) string {
+	binaryBlockID := make([]byte, 4, 4+len(sum))
+	binary.LittleEndian.PutUint32(binaryBlockID, uint32(blockID))
+	return base64.StdEncoding.EncodeToString(append(binaryBlockID, sum...))
+}
+
+// md5BlockIds returns the ids of the uncommitted blocks of the completed
+// parts, the block ids contain the part etags in the md5 etag mode
+func md5BlockIds(blocks []*blockblob.Block, parts []types.CompletedPart) ([]string, error) {
+	uncommitted := make(map[string]int64, len(blocks))
+	for _, block := range blocks {
+		var size int64
+		if block.Size != nil {
+			size = *block.Size
+		}
+		uncommitted[*block.Name] = size
+	}
+
+	blockIds := make([]string, 0, len(parts))
+	var last int32
+	for i, part := range parts {
+		if part.PartNumber == nil || part.ETag == nil {
+			return nil, s3err.GetAPIError(s3err.ErrInvalidPart)
+		}
+		if *part.PartNumber <= last {
+			return nil, s3err.GetAPIError(s3err.ErrInvalidPartOrder)
+		}
+		last = *part.PartNumber
+
+		sum, err := hex.DecodeString(strings.Trim(*part.ETag, `"`))
+		if err != nil {
+			return nil, s3err.GetAPIError(s3err.ErrInvalidPart)
+		}
+
+		blockId := blockIDWithMD5(*part.PartNumber, sum)
+		size, ok := uncommitted[blockId]
+		if !ok {
+			return nil, s3err.GetAPIError(s3err.ErrInvalidPart)
+		}
+		if i < len(parts)-1 && size < backend.MinPartSize {
+			return nil, s3err.GetAPIError(s3err.ErrEntityTooSmall)
+		}
+		blockIds = append(blockIds, blockId)
+	}
+
+	return blockIds, nil
+}
+
+// partETag returns the etag of the part staged as the block, which
+// is the md5 sum kept in the block id in the md5 etag mode
+func (az *Azure) partETag(blockID string) string {
+	if !az.md5ETags {
+		return blockID
+	}
+	b, err := base64.StdEncoding.DecodeString(blockID)
+	if err != nil || len(b) <= 4 {
+		return blockID
+	}
+	return hex.EncodeToString(b[4:])
+}
+
+// objectETag returns the md5 etag from the blob metadata in the md5 etag
+// mode, the azure blob etag is returned otherwise or for the blobs
+// created before the md5 etag mode was enabled
+func (az *Azure) objectETag(meta map[string]*string, etag *azcore.ETag) *string {
+	if az.md5ETags {
+		if v, ok := meta[string(keyMD5ETag)]; ok {
+			return v
+		}
+		if v, ok := meta[string(keyMD5ETagLower)]; ok {
+			return v
+		}
+	}
+	return (*string)(etag)
+}
+
 // Decodes Base64 encoded string to integer
 func decodeBlockId(blockID string) (int, error) {
 	slice, err := base64.StdEncoding.DecodeString(blockID)
@@ -34,6 +34,10 @@ const (
 	// this is the media type for directories in AWS and Nextcloud
 	DirContentType     = "application/x-directory"
 	DefaultContentType = "binary/octet-stream"
+
+	// MinPartSize is the smallest size allowed for all but the last
+	// part of a completed multipart upload
+	MinPartSize = 5 * 1024 * 1024
 )
 
 func IsValidBucketName(name string) bool { return true }
@@ -109,6 +113,145 @@ func ParseRange(size int64, acceptRange string) (int64, int64, error) {
 	return startOffset, endOffset - startOffset + 1, nil
 }
 
+// parseByteRange parses the "bytes=start-end", "bytes=start-" and the
+// suffix "bytes=-length" range forms. The start is -1 for a suffix range
+// and the end is -1 for an open ended one. ok is false if the range is
+// malformed.
+func parseByteRange(acceptRange string) (start, end int64, ok bool) {
+	unit, spec, found := strings.Cut(acceptRange, "=")
+	if !found || unit != "bytes" {
+		return 0, 0, false
+	}
+
+	first, last, found := strings.Cut(spec, "-")
+	if !found || first == "" && last == "" {
+		return 0, 0, false
+	}
+
+	start, end = -1, -1
+	if first != "" {
+		start, ok = parseRangeOffset(first)
+		if !ok {
+			return 0, 0, false
+		}
+	}
+	if last != "" {
+		end, ok = parseRangeOffset(last)
+		if !ok {
+			return 0, 0, false
+		}
+	}
+	if start != -1 && end != -1 && end < start {
+		return 0, 0, false
+	}
+
+	return start, end, true
+}
+
+// parseRangeOffset parses a range offset, which only consists of digits
+func parseRangeOffset(s string) (int64, bool) {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	return n, err == nil
+}
+
+// IsValidRange returns true if the GetObject range header is well formed.
+// A malformed range is ignored, and the whole object returned.
+func IsValidRange(acceptRange string) bool {
+	_, _, ok := parseByteRange(acceptRange)
+	return ok
+}
+
+// ParseGetObjectRange parses the GetObject range header against the object
+// size and returns the start offset and length of the requested bytes.
+// Besides "bytes=start-end" it accepts the open ended "bytes=start-" and
+// the suffix "bytes=-length" forms. A malformed range is ignored and the
+// whole object returned. An end past the object is truncated to the
+// object size, a start at or past the object size can't be satisfied and
+// returns InvalidRange.
+func ParseGetObjectRange(size int64, acceptRange string) (int64, int64, error) {
+	if acceptRange == "" {
+		return 0, size, nil
+	}
+
+	startOffset, endOffset, ok := parseByteRange(acceptRange)
+	if !ok {
+		return 0, size, nil
+	}
+
+	if startOffset == -1 {
+		// suffix range, the last n bytes of the object
+		suffix := endOffset
+		if suffix == 0 || size == 0 {
+			return 0, 0, errInvalidRange
+		}
+		if suffix > size {
+			suffix = size
+		}
+		return size - suffix, suffix, nil
+	}
+
+	if startOffset >= size {
+		return 0, 0, errInvalidRange
+	}
+
+	if endOffset == -1 || endOffset >= size {
+		endOffset = size - 1
+	}
+
+	return startOffset, endOffset - startOffset + 1, nil
+}
+
+// UnsatisfiableContentRange returns the Content-Range header value sent
+// along with an InvalidRange error for an object of the given size.
+func UnsatisfiableContentRange(size int64) string {
+	return fmt.Sprintf("bytes */%v", size)
+}
+
+// EvaluatePreconditions evaluates the conditional request headers
+// against the object etag and last modified time. If-Match and
+// If-Unmodified-Since failures return PreconditionFailed, If-None-Match
+// and If-Modified-Since failures return NotModified.
+func EvaluatePreconditions(etag string, modTime time.Time, ifMatch, ifNoneMatch string, ifModSince, ifUnmodSince *time.Time) error {
+	// the http dates have a second precision
+	modTime = modTime.Truncate(time.Second)
+
+	if ifMatch != "" {
+		if !etagMatches(etag, ifMatch) {
+			return s3err.GetAPIError(s3err.ErrPreconditionFailed)
+		}
+	} else if ifUnmodSince != nil && modTime.After(*ifUnmodSince) {
+		return s3err.GetAPIError(s3err.ErrPreconditionFailed)
+	}
+
+	if ifNoneMatch != "" {
+		if etagMatches(etag, ifNoneMatch) {
+			return s3err.GetAPIError(s3err.ErrNotModified)
+		}
+	} else if ifModSince != nil && !modTime.After(*ifModSince) {
+		return s3err.GetAPIError(s3err.ErrNotModified)
+	}
+
+	return nil
+}
+
+// etagMatches checks if the etag is in the comma separated
+// conditional header etags list, '*' matches any etag
+func etagMatches(etag, hdr string) bool {
+	etag = strings.Trim(etag, "\"")
+	for _, e := range strings.Split(hdr, ",") {
+		e = strings.TrimSpace(e)
+		if e == "*" || strings.Trim(e, "\"") == etag {
+			return true
+		}
+	}
+	return false
+}
+
 // ParseCopySource parses x-amz-copy-source header and returns source bucket,
 // source object, versionId, error respectively
 func ParseCopySource(copySourceHeader string) (string, string, string, error) {
@@ -0,0 +1,72 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package backend_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/versity/versitygw/backend"
+	"github.com/versity/versitygw/s3err"
+)
+
+func TestParseGetObjectRange(t *testing.T) {
+	invalid := s3err.GetAPIError(s3err.ErrInvalidRange)
+
+	tests := []struct {
+		rng    string
+		size   int64
+		start  int64
+		length int64
+		err    error
+	}{
+		{"", 100, 0, 100, nil},
+		{"bytes=10-19", 100, 10, 10, nil},
+		{"bytes=90-200", 100, 90, 10, nil},
+		{"bytes=90-", 100, 90, 10, nil},
+		{"bytes=99-", 100, 99, 1, nil},
+		{"bytes=-10", 100, 90, 10, nil},
+		{"bytes=-200", 100, 0, 100, nil},
+		{"bytes=100-", 100, 0, 0, invalid},
+		{"bytes=100-110", 100, 0, 0, invalid},
+		{"bytes=0-", 0, 0, 0, invalid},
+		{"bytes=-10", 0, 0, 0, invalid},
+		{"bytes=-0", 100, 0, 0, invalid},
+		// the malformed ranges are ignored
+		{"bytes=20-10", 100, 0, 100, nil},
+		{"bytes=abc", 100, 0, 100, nil},
+		{"bytes=invalid-range", 100, 0, 100, nil},
+		{"bytes=1-2-3", 100, 0, 100, nil},
+		{"bytes=-", 100, 0, 100, nil},
+		{"bytes=+1-2", 100, 0, 100, nil},
+		{"bits=1-2", 100, 0, 100, nil},
+		{"bytes=abc", 0, 0, 0, nil},
+	}
+
+	for _, tt := range tests {
+		start, length, err := backend.ParseGetObjectRange(tt.size, tt.rng)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%q size %v: expected error %v, got %v", tt.rng, tt.size, tt.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if start != tt.start || length != tt.length {
+			t.Errorf("%q size %v: expected start %v length %v, got start %v length %v",
+				tt.rng, tt.size, tt.start, tt.length, start, length)
+		}
+	}
+}
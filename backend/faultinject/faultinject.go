@@ -0,0 +1,171 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package faultinject wraps a backend to make the selected backend
+// operations fail, e.g. with syscall.ENOSPC or syscall.EIO, so that
+// the gateway handling of the backend failures can be tested without
+// breaking a real filesystem. It is meant to be used only by tests.
+package faultinject
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/versity/versitygw/backend"
+	"github.com/versity/versitygw/s3response"
+)
+
+// The backend operations the faults can be injected into
+const (
+	OpCreateBucket            = "CreateBucket"
+	OpPutObject               = "PutObject"
+	OpUploadPart              = "UploadPart"
+	OpCompleteMultipartUpload = "CompleteMultipartUpload"
+	OpGetObject               = "GetObject"
+	OpHeadObject              = "HeadObject"
+	OpCopyObject              = "CopyObject"
+	OpDeleteObject            = "DeleteObject"
+)
+
+// Fault is the failure of a backend operation
+type Fault struct {
+	// Err is returned by the operation, e.g. syscall.ENOSPC
+	Err error
+	// AfterBytes is the number of object data bytes read by the
+	// backend before the failure for PutObject and UploadPart.
+	// The object data is passed through to the backend, so that the
+	// failure happens in the middle of the backend write.
+	AfterBytes int64
+}
+
+// Backend passes the operations through to the wrapped backend,
+// unless a fault is injected for the operation
+type Backend struct {
+	backend.Backend
+
+	mu     sync.RWMutex
+	faults map[string]Fault
+}
+
+var _ backend.Backend = &Backend{}
+
+// New wraps the backend without any faults injected
+func New(be backend.Backend) *Backend {
+	return &Backend{
+		Backend: be,
+		faults:  make(map[string]Fault),
+	}
+}
+
+// Inject makes the operation fail with the fault until cleared
+func (b *Backend) Inject(op string, f Fault) {
+	b.mu.Lock()
+	b.faults[op] = f
+	b.mu.Unlock()
+}
+
+// Clear removes the fault of the operation
+func (b *Backend) Clear(op string) {
+	b.mu.Lock()
+	delete(b.faults, op)
+	b.mu.Unlock()
+}
+
+func (b *Backend) fault(op string) (Fault, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	f, ok := b.faults[op]
+	return f, ok
+}
+
+// faultReader returns the fault error after the fault bytes are read
+type faultReader struct {
+	r     io.Reader
+	left  int64
+	fault error
+}
+
+func (fr *faultReader) Read(p []byte) (int, error) {
+	if fr.left <= 0 {
+		return 0, fr.fault
+	}
+	if int64(len(p)) > fr.left {
+		p = p[:fr.left]
+	}
+	n, err := fr.r.Read(p)
+	fr.left -= int64(n)
+	return n, err
+}
+
+func (b *Backend) CreateBucket(ctx context.Context, input *s3.CreateBucketInput, defaultACL []byte) error {
+	if f, ok := b.fault(OpCreateBucket); ok {
+		return f.Err
+	}
+	return b.Backend.CreateBucket(ctx, input, defaultACL)
+}
+
+func (b *Backend) PutObject(ctx context.Context, input *s3.PutObjectInput) (s3response.PutObjectOutput, error) {
+	if f, ok := b.fault(OpPutObject); ok {
+		in := *input
+		in.Body = &faultReader{r: input.Body, left: f.AfterBytes, fault: f.Err}
+		return b.Backend.PutObject(ctx, &in)
+	}
+	return b.Backend.PutObject(ctx, input)
+}
+
+func (b *Backend) UploadPart(ctx context.Context, input *s3.UploadPartInput) (string, error) {
+	if f, ok := b.fault(OpUploadPart); ok {
+		in := *input
+		in.Body = &faultReader{r: input.Body, left: f.AfterBytes, fault: f.Err}
+		return b.Backend.UploadPart(ctx, &in)
+	}
+	return b.Backend.UploadPart(ctx, input)
+}
+
+func (b *Backend) CompleteMultipartUpload(ctx context.Context, input *s3.CompleteMultipartUploadInput) (*s3.CompleteMultipartUploadOutput, error) {
+	if f, ok := b.fault(OpCompleteMultipartUpload); ok {
+		return nil, f.Err
+	}
+	return b.Backend.CompleteMultipartUpload(ctx, input)
+}
+
+func (b *Backend) GetObject(ctx context.Context, input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	if f, ok := b.fault(OpGetObject); ok {
+		return nil, f.Err
+	}
+	return b.Backend.GetObject(ctx, input)
+}
+
+func (b *Backend) HeadObject(ctx context.Context, input *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
+	if f, ok := b.fault(OpHeadObject); ok {
+		return nil, f.Err
+	}
+	return b.Backend.HeadObject(ctx, input)
+}
+
+func (b *Backend) CopyObject(ctx context.Context, input *s3.CopyObjectInput) (*s3.CopyObjectOutput, error) {
+	if f, ok := b.fault(OpCopyObject); ok {
+		return nil, f.Err
+	}
+	return b.Backend.CopyObject(ctx, input)
+}
+
+func (b *Backend) DeleteObject(ctx context.Context, input *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error) {
+	if f, ok := b.fault(OpDeleteObject); ok {
+		return nil, f.Err
+	}
+	return b.Backend.DeleteObject(ctx, input)
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package backend
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// NestedFileObj is the name a file object is stored under when a
+// directory of the same name exists. The "foo" and "foo/" keys are
+// distinct objects, and "foo" is also the prefix of any "foo/..." key,
+// but a path can't be both a file and a directory. So when both exist
+// the "foo" file object is stored as "foo/.sgwfileobj" within the
+// "foo" directory.
+const NestedFileObj = ".sgwfileobj"
+
+// StorageKey returns the bucket relative path the object is stored
+// under. This is the object key itself unless the key is a file object
+// that shares its name with a directory.
+func StorageKey(bucket, object string) string {
+	if object == "" || strings.HasSuffix(object, "/") {
+		return object
+	}
+
+	fi, err := os.Stat(filepath.Join(bucket, object))
+	if err != nil || !fi.IsDir() {
+		return object
+	}
+
+	return object + "/" + NestedFileObj
+}
+
+// EntryStorageKey returns the StorageKey of the object the walk functions
+// list at path with the directory entry d.
+func EntryStorageKey(path string, d fs.DirEntry) string {
+	if _, ok := d.(nestedFileEntry); ok {
+		return path + "/" + NestedFileObj
+	}
+	return path
+}
+
+// nestedFileEntry is the directory entry of a file object nested within
+// the directory of the same name.
+type nestedFileEntry struct {
+	name string
+	info fs.FileInfo
+}
+
+func (e nestedFileEntry) Name() string               { return e.name }
+func (e nestedFileEntry) IsDir() bool                { return false }
+func (e nestedFileEntry) Type() fs.FileMode          { return e.info.Mode().Type() }
+func (e nestedFileEntry) Info() (fs.FileInfo, error) { return e.info, nil }
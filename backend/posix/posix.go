@@ -72,6 +72,9 @@ type Posix struct {
 
 	// newDirPerm is the permission to set on newly created directories
 	newDirPerm fs.FileMode
+
+	// usage tracks the per bucket object count and size
+	usage *usageTracker
 }
 
 var _ backend.Backend = &Posix{}
@@ -80,10 +83,13 @@ const (
 	metaTmpDir          = ".sgwtmp"
 	metaTmpMultipartDir = metaTmpDir + "/multipart"
 	onameAttr           = "objname"
+	initiatorAttr       = "initiator"
 	tagHdr              = "X-Amz-Tagging"
 	metaHdr             = "X-Amz-Meta"
 	contentTypeHdr      = "content-type"
 	contentEncHdr       = "content-encoding"
+	cacheControlHdr     = "cache-control"
+	contentDispHdr      = "content-disposition"
 	emptyMD5            = "d41d8cd98f00b204e9800998ecf8427e"
 	aclkey              = "acl"
 	ownershipkey        = "ownership"
@@ -93,8 +99,13 @@ const (
 	objectRetentionKey  = "object-retention"
 	objectLegalHoldKey  = "object-legal-hold"
 	versioningKey       = "versioning"
+	accelerateKey       = "accelerate"
 	deleteMarkerKey     = "delete-marker"
 	versionIdKey        = "version-id"
+	partSizeKey         = "part-size"
+	sseCIVKey           = "sse-c-iv"
+	sseCKeyHMACKey      = "sse-c-key-hmac"
+	checksumKey         = "checksum"
 
 	nullVersionId = "null"
 
@@ -163,6 +174,7 @@ func New(rootdir string, meta meta.MetadataStorer, opts PosixOpts) (*Posix, erro
 	fmt.Printf("Bucket versioning enabled with directory: %v\n", verioningdirAbs)
 
 	return &Posix{
+		usage:         newUsageTracker(),
 		meta:          meta,
 		rootfd:        f,
 		rootdir:       rootdir,
@@ -189,6 +201,16 @@ func (p *Posix) versioningEnabled() bool {
 	return p.versioningDir != ""
 }
 
+// objStorageKey returns the backend.StorageKey of object. The multipart
+// upload directories hold the upload metadata themselves and never nest
+// a file object, so their paths are returned unchanged.
+func objStorageKey(bucket, object string) string {
+	if strings.HasPrefix(object, metaTmpMultipartDir+"/") {
+		return object
+	}
+	return backend.StorageKey(bucket, object)
+}
+
 func (p *Posix) doesBucketAndObjectExist(bucket, object string) error {
 	_, err := os.Stat(bucket)
 	if errors.Is(err, fs.ErrNotExist) {
@@ -198,7 +220,7 @@ func (p *Posix) doesBucketAndObjectExist(bucket, object string) error {
 		return fmt.Errorf("stat bucket: %w", err)
 	}
 
-	_, err = os.Stat(filepath.Join(bucket, object))
+	_, err = os.Stat(filepath.Join(bucket, objStorageKey(bucket, object)))
 	if errors.Is(err, fs.ErrNotExist) {
 		return s3err.GetAPIError(s3err.ErrNoSuchKey)
 	}
@@ -424,6 +446,7 @@ func (p *Posix) DeleteBucket(_ context.Context, bucket string) error {
 	if err != nil {
 		return fmt.Errorf("remove bucket: %w", err)
 	}
+	p.usage.drop(bucket)
 	// Remove the bucket from versioning directory
 	if p.versioningEnabled() {
 		err = os.RemoveAll(filepath.Join(p.versioningDir, bucket))
@@ -492,6 +515,46 @@ func (p *Posix) DeleteBucketOwnershipControls(_ context.Context, bucket string)
 	return nil
 }
 
+// PutBucketAccelerateConfiguration only stores the transfer
+// acceleration status, as the gateway doesn't accelerate transfers.
+// This is here for the clients, that query the configuration.
+func (p *Posix) PutBucketAccelerateConfiguration(_ context.Context, bucket string, status types.BucketAccelerateStatus) error {
+	_, err := os.Stat(bucket)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s3err.GetAPIError(s3err.ErrNoSuchBucket)
+	}
+	if err != nil {
+		return fmt.Errorf("stat bucket: %w", err)
+	}
+
+	err = p.meta.StoreAttribute(nil, bucket, "", accelerateKey, []byte(status))
+	if err != nil {
+		return fmt.Errorf("set accelerate configuration: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Posix) GetBucketAccelerateConfiguration(_ context.Context, bucket string) (types.BucketAccelerateStatus, error) {
+	_, err := os.Stat(bucket)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", s3err.GetAPIError(s3err.ErrNoSuchBucket)
+	}
+	if err != nil {
+		return "", fmt.Errorf("stat bucket: %w", err)
+	}
+
+	status, err := p.meta.RetrieveAttribute(nil, bucket, "", accelerateKey)
+	if errors.Is(err, meta.ErrNoSuchKey) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get accelerate configuration: %w", err)
+	}
+
+	return types.BucketAccelerateStatus(status), nil
+}
+
 func (p *Posix) PutBucketVersioning(ctx context.Context, bucket string, status types.BucketVersioningStatus) error {
 	if !p.versioningEnabled() {
 		return s3err.GetAPIError(s3err.ErrVersioningNotConfigured)
@@ -625,14 +688,15 @@ func (p *Posix) deleteNullVersionIdObject(bucket, key string) error {
 
 // Creates a new copy(version) of an object in the versioning directory
 func (p *Posix) createObjVersion(bucket, key string, size int64, acc auth.Account) (versionPath string, err error) {
-	sf, err := os.Open(filepath.Join(bucket, key))
+	storageKey := backend.StorageKey(bucket, key)
+	sf, err := os.Open(filepath.Join(bucket, storageKey))
 	if err != nil {
 		return "", err
 	}
 	defer sf.Close()
 
 	var versionId string
-	data, err := p.meta.RetrieveAttribute(sf, bucket, key, versionIdKey)
+	data, err := p.meta.RetrieveAttribute(sf, bucket, storageKey, versionIdKey)
 	if err != nil && !errors.Is(err, meta.ErrNoSuchKey) {
 		return versionPath, fmt.Errorf("get object versionId: %w", err)
 	}
@@ -642,7 +706,7 @@ func (p *Posix) createObjVersion(bucket, key string, size int64, acc auth.Accoun
 		versionId = nullVersionId
 	}
 
-	attrs, err := p.meta.ListAttributes(bucket, key)
+	attrs, err := p.meta.ListAttributes(bucket, storageKey)
 	if err != nil {
 		return versionPath, fmt.Errorf("load object attributes: %w", err)
 	}
@@ -670,7 +734,7 @@ func (p *Posix) createObjVersion(bucket, key string, size int64, acc auth.Accoun
 
 	// Copy the object attributes(metadata)
 	for _, attr := range attrs {
-		data, err := p.meta.RetrieveAttribute(sf, bucket, key, attr)
+		data, err := p.meta.RetrieveAttribute(sf, bucket, storageKey, attr)
 		if err != nil {
 			return versionPath, fmt.Errorf("list %v attribute: %w", attr, err)
 		}
@@ -817,7 +881,8 @@ func (p *Posix) fileToObjVersions(bucket string) backend.GetVersionsFunc {
 		}
 
 		// file object, get object info and fill out object data
-		etagBytes, err := p.meta.RetrieveAttribute(nil, bucket, path, etagkey)
+		storageKey := backend.EntryStorageKey(path, d)
+		etagBytes, err := p.meta.RetrieveAttribute(nil, bucket, storageKey, etagkey)
 		if errors.Is(err, fs.ErrNotExist) {
 			return nil, backend.ErrSkipObj
 		}
@@ -830,7 +895,7 @@ func (p *Posix) fileToObjVersions(bucket string) backend.GetVersionsFunc {
 
 		// If the object doesn't have versionId, it's 'null'
 		versionId := "null"
-		versionIdBytes, err := p.meta.RetrieveAttribute(nil, bucket, path, versionIdKey)
+		versionIdBytes, err := p.meta.RetrieveAttribute(nil, bucket, storageKey, versionIdKey)
 		if err == nil {
 			versionId = string(versionIdBytes)
 		}
@@ -848,7 +913,7 @@ func (p *Posix) fileToObjVersions(bucket string) backend.GetVersionsFunc {
 
 			size := fi.Size()
 
-			isDel, err := p.isObjDeleteMarker(bucket, path)
+			isDel, err := p.isObjDeleteMarker(bucket, storageKey)
 			if err != nil {
 				return nil, err
 			}
@@ -1175,6 +1240,19 @@ func (p *Posix) CreateMultipartUpload(ctx context.Context, mpu *s3.CreateMultipa
 		return s3response.InitiateMultipartUploadResult{}, fmt.Errorf("set name attr for upload: %w", err)
 	}
 
+	// set the upload initiator, to be listed as the upload owner
+	acct, ok := ctx.Value("account").(auth.Account)
+	if !ok {
+		acct = auth.Account{}
+	}
+	err = p.meta.StoreAttribute(nil, bucket, filepath.Join(objdir, uploadID),
+		initiatorAttr, []byte(acct.Access))
+	if err != nil {
+		os.RemoveAll(filepath.Join(tmppath, uploadID))
+		os.Remove(tmppath)
+		return s3response.InitiateMultipartUploadResult{}, fmt.Errorf("set initiator attr for upload: %w", err)
+	}
+
 	// set user metadata
 	for k, v := range mpu.Metadata {
 		err := p.meta.StoreAttribute(nil, bucket, filepath.Join(objdir, uploadID),
@@ -1224,6 +1302,23 @@ func (p *Posix) CreateMultipartUpload(ctx context.Context, mpu *s3.CreateMultipa
 		}
 	}
 
+	// set cache-control and content-disposition
+	for hdr, val := range map[string]string{
+		cacheControlHdr: getString(mpu.CacheControl),
+		contentDispHdr:  getString(mpu.ContentDisposition),
+	} {
+		if val == "" {
+			continue
+		}
+		err := p.meta.StoreAttribute(nil, bucket, filepath.Join(objdir, uploadID), hdr, []byte(val))
+		if err != nil {
+			// cleanup object if returning error
+			os.RemoveAll(filepath.Join(tmppath, uploadID))
+			os.Remove(tmppath)
+			return s3response.InitiateMultipartUploadResult{}, fmt.Errorf("set %v: %w", hdr, err)
+		}
+	}
+
 	// set object legal hold
 	if mpu.ObjectLockLegalHoldStatus == types.ObjectLockLegalHoldStatusOn {
 		err := p.PutObjectLegalHold(ctx, bucket, filepath.Join(objdir, uploadID), "", true)
@@ -1288,6 +1383,8 @@ func (p *Posix) CompleteMultipartUpload(ctx context.Context, input *s3.CompleteM
 	if !ok {
 		acct = auth.Account{}
 	}
+	// "If-None-Match: *" requests must not replace an existing object
+	createOnly := input.IfNoneMatch != nil && *input.IfNoneMatch == "*"
 
 	if input.Bucket == nil {
 		return nil, s3err.GetAPIError(s3err.ErrInvalidBucketName)
@@ -1326,10 +1423,16 @@ func (p *Posix) CompleteMultipartUpload(ctx context.Context, input *s3.CompleteM
 	last := len(parts) - 1
 	partsize := int64(0)
 	var totalsize int64
+	var prevPartNumber int32
 	for i, part := range parts {
 		if part.PartNumber == nil || *part.PartNumber < 1 {
 			return nil, s3err.GetAPIError(s3err.ErrInvalidPart)
 		}
+		// parts must be listed in ascending part number order
+		if *part.PartNumber <= prevPartNumber {
+			return nil, s3err.GetAPIError(s3err.ErrInvalidPartOrder)
+		}
+		prevPartNumber = *part.PartNumber
 
 		partObjPath := filepath.Join(objdir, uploadID, fmt.Sprintf("%v", *part.PartNumber))
 		fullPartPath := filepath.Join(bucket, partObjPath)
@@ -1341,6 +1444,10 @@ func (p *Posix) CompleteMultipartUpload(ctx context.Context, input *s3.CompleteM
 		if i == 0 {
 			partsize = fi.Size()
 		}
+		// all parts except the last need to be at least the minimum size
+		if i < last && fi.Size() < backend.MinPartSize {
+			return nil, s3err.GetAPIError(s3err.ErrEntityTooSmall)
+		}
 		totalsize += fi.Size()
 		// all parts except the last need to be the same size
 		if i < last && partsize != fi.Size() {
@@ -1357,7 +1464,18 @@ func (p *Posix) CompleteMultipartUpload(ctx context.Context, input *s3.CompleteM
 		}
 	}
 
-	f, err := p.openTmpFile(filepath.Join(bucket, metaTmpDir), bucket, object,
+	storageKey := backend.StorageKey(bucket, object)
+
+	if createOnly {
+		// fail early before assembling the parts, the final link
+		// below still guards against a racing put of the same key
+		_, err := os.Lstat(filepath.Join(bucket, storageKey))
+		if err == nil {
+			return nil, s3err.GetAPIError(s3err.ErrPreconditionFailed)
+		}
+	}
+
+	f, err := p.openTmpFile(filepath.Join(bucket, metaTmpDir), bucket, storageKey,
 		totalsize, acct, skipFalloc)
 	if err != nil {
 		if errors.Is(err, syscall.EDQUOT) {
@@ -1390,13 +1508,15 @@ func (p *Posix) CompleteMultipartUpload(ctx context.Context, input *s3.CompleteM
 
 	userMetaData := make(map[string]string)
 	upiddir := filepath.Join(objdir, uploadID)
-	cType, cEnc, _ := p.loadUserMetaData(bucket, upiddir, userMetaData)
+	cType, cEnc, upEnts := p.loadUserMetaData(bucket, upiddir, userMetaData)
+	cacheControl, cDisp := p.loadContentHeaders(bucket, upiddir, upEnts)
 
-	objname := filepath.Join(bucket, object)
-	dir := filepath.Dir(objname)
-	if dir != "" {
+	objname := filepath.Join(bucket, storageKey)
+	dirObjParent := p.hasDirObjectParent(bucket, storageKey)
+	dir := filepath.Dir(storageKey)
+	if dir != "." {
 		uid, gid, doChown := p.getChownIDs(acct)
-		err = backend.MkdirAll(dir, uid, gid, doChown, p.newDirPerm)
+		err = p.mkdirObjAll(bucket, dir, uid, gid, doChown)
 		if err != nil {
 			return nil, err
 		}
@@ -1409,6 +1529,9 @@ func (p *Posix) CompleteMultipartUpload(ctx context.Context, input *s3.CompleteM
 	vEnabled := p.isBucketVersioningEnabled(vStatus)
 
 	d, err := os.Stat(objname)
+	if createOnly && err == nil {
+		return nil, s3err.GetAPIError(s3err.ErrPreconditionFailed)
+	}
 
 	// if the versioninng is enabled first create the file object version
 	if p.versioningEnabled() && vEnabled && err == nil && !d.IsDir() {
@@ -1423,14 +1546,14 @@ func (p *Posix) CompleteMultipartUpload(ctx context.Context, input *s3.CompleteM
 	if p.versioningEnabled() && vEnabled {
 		versionID = ulid.Make().String()
 
-		err := p.meta.StoreAttribute(f.File(), bucket, object, versionIdKey, []byte(versionID))
+		err := p.meta.StoreAttribute(f.File(), bucket, storageKey, versionIdKey, []byte(versionID))
 		if err != nil {
 			return nil, fmt.Errorf("set versionId attr: %w", err)
 		}
 	}
 
 	for k, v := range userMetaData {
-		err = p.meta.StoreAttribute(f.File(), bucket, object, fmt.Sprintf("%v.%v", metaHdr, k), []byte(v))
+		err = p.meta.StoreAttribute(f.File(), bucket, storageKey, fmt.Sprintf("%v.%v", metaHdr, k), []byte(v))
 		if err != nil {
 			return nil, fmt.Errorf("set user attr %q: %w", k, err)
 		}
@@ -1442,7 +1565,7 @@ func (p *Posix) CompleteMultipartUpload(ctx context.Context, input *s3.CompleteM
 		return nil, fmt.Errorf("get object tagging: %w", err)
 	}
 	if err == nil {
-		err := p.meta.StoreAttribute(f.File(), bucket, object, tagHdr, tagging)
+		err := p.meta.StoreAttribute(f.File(), bucket, storageKey, tagHdr, tagging)
 		if err != nil {
 			return nil, fmt.Errorf("set object tagging: %w", err)
 		}
@@ -1450,7 +1573,7 @@ func (p *Posix) CompleteMultipartUpload(ctx context.Context, input *s3.CompleteM
 
 	// set content-type
 	if cType != "" {
-		err := p.meta.StoreAttribute(f.File(), bucket, object, contentTypeHdr, []byte(cType))
+		err := p.meta.StoreAttribute(f.File(), bucket, storageKey, contentTypeHdr, []byte(cType))
 		if err != nil {
 			return nil, fmt.Errorf("set object content type: %w", err)
 		}
@@ -1458,19 +1581,33 @@ func (p *Posix) CompleteMultipartUpload(ctx context.Context, input *s3.CompleteM
 
 	// set content-encoding
 	if cEnc != "" {
-		err := p.meta.StoreAttribute(f.File(), bucket, object, contentEncHdr, []byte(cEnc))
+		err := p.meta.StoreAttribute(f.File(), bucket, storageKey, contentEncHdr, []byte(cEnc))
 		if err != nil {
 			return nil, fmt.Errorf("set object content encoding: %w", err)
 		}
 	}
 
+	// set cache-control and content-disposition
+	for hdr, val := range map[string]string{
+		cacheControlHdr: cacheControl,
+		contentDispHdr:  cDisp,
+	} {
+		if val == "" {
+			continue
+		}
+		err := p.meta.StoreAttribute(f.File(), bucket, storageKey, hdr, []byte(val))
+		if err != nil {
+			return nil, fmt.Errorf("set object %v: %w", hdr, err)
+		}
+	}
+
 	// load and set legal hold
 	lHold, err := p.meta.RetrieveAttribute(nil, bucket, upiddir, objectLegalHoldKey)
 	if err != nil && !errors.Is(err, meta.ErrNoSuchKey) {
 		return nil, fmt.Errorf("get object legal hold: %w", err)
 	}
 	if err == nil {
-		err := p.meta.StoreAttribute(f.File(), bucket, object, objectLegalHoldKey, lHold)
+		err := p.meta.StoreAttribute(f.File(), bucket, storageKey, objectLegalHoldKey, lHold)
 		if err != nil {
 			return nil, fmt.Errorf("set object legal hold: %w", err)
 		}
@@ -1482,7 +1619,7 @@ func (p *Posix) CompleteMultipartUpload(ctx context.Context, input *s3.CompleteM
 		return nil, fmt.Errorf("get object retention: %w", err)
 	}
 	if err == nil {
-		err := p.meta.StoreAttribute(f.File(), bucket, object, objectRetentionKey, ret)
+		err := p.meta.StoreAttribute(f.File(), bucket, storageKey, objectRetentionKey, ret)
 		if err != nil {
 			return nil, fmt.Errorf("set object retention: %w", err)
 		}
@@ -1491,12 +1628,40 @@ func (p *Posix) CompleteMultipartUpload(ctx context.Context, input *s3.CompleteM
 	// Calculate s3 compatible md5sum for complete multipart.
 	s3MD5 := backend.GetMultipartMD5(parts)
 
-	err = p.meta.StoreAttribute(f.File(), bucket, object, etagkey, []byte(s3MD5))
+	err = p.meta.StoreAttribute(f.File(), bucket, storageKey, etagkey, []byte(s3MD5))
 	if err != nil {
 		return nil, fmt.Errorf("set etag attr: %w", err)
 	}
 
-	err = f.link()
+	// all parts but the last are the same size, keep the size
+	// to be able to list the object parts
+	err = p.meta.StoreAttribute(f.File(), bucket, storageKey, partSizeKey,
+		[]byte(strconv.FormatInt(partsize, 10)))
+	if err != nil {
+		return nil, fmt.Errorf("set part size attr: %w", err)
+	}
+
+	err = p.usage.update(func() error {
+		existed, oldSize := replacedObject(objname)
+		var err error
+		if createOnly {
+			err = f.linkNoReplace()
+		} else {
+			err = f.link()
+		}
+		if err != nil {
+			return err
+		}
+		if dirObjParent {
+			p.usage.dropLocked(bucket)
+		} else {
+			p.trackObjectPut(bucket, existed, oldSize, totalsize)
+		}
+		return nil
+	})
+	if createOnly && errors.Is(err, fs.ErrExist) {
+		return nil, s3err.GetAPIError(s3err.ErrPreconditionFailed)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("link object in namespace: %w", err)
 	}
@@ -1576,6 +1741,85 @@ func (p *Posix) loadUserMetaData(bucket, object string, m map[string]string) (st
 	return contentType, contentEncoding, ents
 }
 
+// loadContentHeaders returns the cache-control and content-disposition
+// headers stored with the object, ents are the object attribute names
+func (p *Posix) loadContentHeaders(bucket, object string, ents []string) (string, string) {
+	var cacheControl, contentDisposition string
+	if slices.Contains(ents, cacheControlHdr) {
+		b, _ := p.meta.RetrieveAttribute(nil, bucket, object, cacheControlHdr)
+		cacheControl = string(b)
+	}
+	if slices.Contains(ents, contentDispHdr) {
+		b, _ := p.meta.RetrieveAttribute(nil, bucket, object, contentDispHdr)
+		contentDisposition = string(b)
+	}
+
+	return cacheControl, contentDisposition
+}
+
+// checksumAttr returns the attribute name of the object additional checksum
+func checksumAttr(algo types.ChecksumAlgorithm) string {
+	return fmt.Sprintf("%v-%v", checksumKey, strings.ToLower(string(algo)))
+}
+
+// loadChecksums returns the additional checksums stored with
+// the object, ents are the object attribute names
+func (p *Posix) loadChecksums(bucket, object string, ents []string) types.Checksum {
+	var checksums types.Checksum
+	for algo, sum := range map[types.ChecksumAlgorithm]**string{
+		types.ChecksumAlgorithmCrc32:  &checksums.ChecksumCRC32,
+		types.ChecksumAlgorithmCrc32c: &checksums.ChecksumCRC32C,
+		types.ChecksumAlgorithmSha1:   &checksums.ChecksumSHA1,
+		types.ChecksumAlgorithmSha256: &checksums.ChecksumSHA256,
+	} {
+		if !slices.Contains(ents, checksumAttr(algo)) {
+			continue
+		}
+		b, err := p.meta.RetrieveAttribute(nil, bucket, object, checksumAttr(algo))
+		if err == nil {
+			*sum = backend.GetStringPtr(string(b))
+		}
+	}
+
+	return checksums
+}
+
+// checkSSECustomerKey validates the request SSE-C key against the object,
+// ents are the object attribute names. It returns the decoded key and the
+// initialization vector of the SSE-C encrypted objects, and nil for the
+// unencrypted ones.
+func (p *Posix) checkSSECustomerKey(bucket, object string, ents []string, sseCKey *string) ([]byte, []byte, error) {
+	if !slices.Contains(ents, sseCIVKey) {
+		if getString(sseCKey) != "" {
+			return nil, nil, s3err.GetAPIError(s3err.ErrSSECustomerKeyNotApplicable)
+		}
+		return nil, nil, nil
+	}
+	if getString(sseCKey) == "" {
+		return nil, nil, s3err.GetAPIError(s3err.ErrSSECustomerKeyRequired)
+	}
+
+	key, err := backend.DecodeSSECustomerKey(*sseCKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	iv, err := p.meta.RetrieveAttribute(nil, bucket, object, sseCIVKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get sse-c iv: %w", err)
+	}
+	keyHMAC, err := p.meta.RetrieveAttribute(nil, bucket, object, sseCKeyHMACKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get sse-c key hmac: %w", err)
+	}
+
+	err = backend.VerifySSECustomerKey(key, iv, keyHMAC)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return key, iv, nil
+}
+
 func isValidMeta(val string) bool {
 	if strings.HasPrefix(val, metaHdr) {
 		return true
@@ -1707,11 +1951,25 @@ func (p *Posix) ListMultipartUploads(_ context.Context, mpu *s3.ListMultipartUpl
 			if keyMarkerInd == -1 && objectName == keyMarker {
 				keyMarkerInd = len(uploads)
 			}
+
+			// the uploads created before storing the initiator
+			// have an empty initiator and owner
+			initiator, _ := p.meta.RetrieveAttribute(nil, bucket,
+				filepath.Join(metaTmpMultipartDir, obj.Name(), uploadID), initiatorAttr)
+
 			uploads = append(uploads, s3response.Upload{
 				Key:          objectName,
 				UploadID:     uploadID,
 				StorageClass: types.StorageClassStandard,
 				Initiated:    fi.ModTime(),
+				Initiator: s3response.Initiator{
+					ID:          string(initiator),
+					DisplayName: string(initiator),
+				},
+				Owner: s3response.Owner{
+					ID:          string(initiator),
+					DisplayName: string(initiator),
+				},
 			})
 		}
 	}
@@ -2027,11 +2285,13 @@ func (p *Posix) UploadPartCopy(ctx context.Context, upi *s3.UploadPartCopyInput)
 	}
 	vEnabled := p.isBucketVersioningEnabled(vStatus)
 
+	srcKey := backend.StorageKey(srcBucket, srcObject)
+
 	if srcVersionId != "" {
 		if !p.versioningEnabled() || !vEnabled {
 			return s3response.CopyObjectResult{}, s3err.GetAPIError(s3err.ErrInvalidVersionId)
 		}
-		vId, err := p.meta.RetrieveAttribute(nil, srcBucket, srcObject, versionIdKey)
+		vId, err := p.meta.RetrieveAttribute(nil, srcBucket, srcKey, versionIdKey)
 		if errors.Is(err, fs.ErrNotExist) {
 			return s3response.CopyObjectResult{}, s3err.GetAPIError(s3err.ErrNoSuchKey)
 		}
@@ -2041,11 +2301,11 @@ func (p *Posix) UploadPartCopy(ctx context.Context, upi *s3.UploadPartCopyInput)
 
 		if string(vId) != srcVersionId {
 			srcBucket = filepath.Join(p.versioningDir, srcBucket)
-			srcObject = filepath.Join(genObjVersionKey(srcObject), srcVersionId)
+			srcKey = filepath.Join(genObjVersionKey(srcObject), srcVersionId)
 		}
 	}
 
-	objPath := filepath.Join(srcBucket, srcObject)
+	objPath := filepath.Join(srcBucket, srcKey)
 	fi, err := os.Stat(objPath)
 	if errors.Is(err, fs.ErrNotExist) {
 		if p.versioningEnabled() && vEnabled {
@@ -2060,6 +2320,12 @@ func (p *Posix) UploadPartCopy(ctx context.Context, upi *s3.UploadPartCopyInput)
 		return s3response.CopyObjectResult{}, fmt.Errorf("stat object: %w", err)
 	}
 
+	// copying the SSE-C encrypted objects isn't supported
+	_, err = p.meta.RetrieveAttribute(nil, srcBucket, srcKey, sseCIVKey)
+	if err == nil {
+		return s3response.CopyObjectResult{}, s3err.GetAPIError(s3err.ErrSSECustomerKeyRequired)
+	}
+
 	startOffset, length, err := backend.ParseRange(fi.Size(), *upi.CopySourceRange)
 	if err != nil {
 		return s3response.CopyObjectResult{}, err
@@ -2165,7 +2431,13 @@ func (p *Posix) PutObject(ctx context.Context, po *s3.PutObjectInput) (s3respons
 		}
 	}
 
-	name := filepath.Join(*po.Bucket, *po.Key)
+	var sseCKey []byte
+	if getString(po.SSECustomerKey) != "" {
+		sseCKey, err = backend.DecodeSSECustomerKey(*po.SSECustomerKey)
+		if err != nil {
+			return s3response.PutObjectOutput{}, err
+		}
+	}
 
 	uid, gid, doChown := p.getChownIDs(acct)
 
@@ -2182,7 +2454,7 @@ func (p *Posix) PutObject(ctx context.Context, po *s3.PutObjectInput) (s3respons
 			return s3response.PutObjectOutput{}, s3err.GetAPIError(s3err.ErrDirectoryObjectContainsData)
 		}
 
-		err = backend.MkdirAll(name, uid, gid, doChown, p.newDirPerm)
+		err = p.mkdirObjAll(*po.Bucket, *po.Key, uid, gid, doChown)
 		if err != nil {
 			if errors.Is(err, syscall.EDQUOT) {
 				return s3response.PutObjectOutput{}, s3err.GetAPIError(s3err.ErrQuotaExceeded)
@@ -2205,6 +2477,8 @@ func (p *Posix) PutObject(ctx context.Context, po *s3.PutObjectInput) (s3respons
 			return s3response.PutObjectOutput{}, fmt.Errorf("set etag attr: %w", err)
 		}
 
+		p.usage.drop(*po.Bucket)
+
 		// for directory object no version is created
 		return s3response.PutObjectOutput{
 			ETag: emptyMD5,
@@ -2218,16 +2492,19 @@ func (p *Posix) PutObject(ctx context.Context, po *s3.PutObjectInput) (s3respons
 	vEnabled := p.isBucketVersioningEnabled(vStatus)
 
 	// object is file
+	storageKey := backend.StorageKey(*po.Bucket, *po.Key)
+	name := filepath.Join(*po.Bucket, storageKey)
 	d, err := os.Stat(name)
 	if err == nil && d.IsDir() {
 		return s3response.PutObjectOutput{}, s3err.GetAPIError(s3err.ErrExistingObjectIsDirectory)
 	}
+	dirObjParent := p.hasDirObjectParent(*po.Bucket, storageKey)
 
 	// if the versioninng is enabled first create the file object version
 	if p.versioningEnabled() && vStatus != "" && err == nil {
 		var isVersionIdMissing bool
 		if p.isBucketVersioningSuspended(vStatus) {
-			vIdBytes, err := p.meta.RetrieveAttribute(nil, *po.Bucket, *po.Key, versionIdKey)
+			vIdBytes, err := p.meta.RetrieveAttribute(nil, *po.Bucket, storageKey, versionIdKey)
 			if err != nil && !errors.Is(err, meta.ErrNoSuchKey) {
 				return s3response.PutObjectOutput{}, fmt.Errorf("get object versionId: %w", err)
 			}
@@ -2243,15 +2520,13 @@ func (p *Posix) PutObject(ctx context.Context, po *s3.PutObjectInput) (s3respons
 	if errors.Is(err, syscall.ENAMETOOLONG) {
 		return s3response.PutObjectOutput{}, s3err.GetAPIError(s3err.ErrKeyTooLong)
 	}
-	if errors.Is(err, syscall.ENOTDIR) {
-		return s3response.PutObjectOutput{}, s3err.GetAPIError(s3err.ErrObjectParentIsFile)
-	}
-	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+	// a file object parent is nested below to make room for the object
+	if err != nil && !errors.Is(err, fs.ErrNotExist) && !errors.Is(err, syscall.ENOTDIR) {
 		return s3response.PutObjectOutput{}, fmt.Errorf("stat object: %w", err)
 	}
 
 	f, err := p.openTmpFile(filepath.Join(*po.Bucket, metaTmpDir),
-		*po.Bucket, *po.Key, contentLength, acct, doFalloc)
+		*po.Bucket, storageKey, contentLength, acct, doFalloc)
 	if err != nil {
 		if errors.Is(err, syscall.EDQUOT) {
 			return s3response.PutObjectOutput{}, s3err.GetAPIError(s3err.ErrQuotaExceeded)
@@ -2260,9 +2535,25 @@ func (p *Posix) PutObject(ctx context.Context, po *s3.PutObjectInput) (s3respons
 	}
 	defer f.cleanup()
 
+	// the SSE-C objects are stored encrypted, and the etag
+	// is the md5 of the encrypted data as it doesn't reveal
+	// anything about the object content
+	var data io.Reader = po.Body
+	var sseCIV []byte
+	if sseCKey != nil {
+		sseCIV, err = backend.NewSSECIV()
+		if err != nil {
+			return s3response.PutObjectOutput{}, err
+		}
+		data, err = backend.NewSSECReader(po.Body, sseCKey, sseCIV, 0)
+		if err != nil {
+			return s3response.PutObjectOutput{}, err
+		}
+	}
+
 	hash := md5.New()
-	rdr := io.TeeReader(po.Body, hash)
-	_, err = io.Copy(f, rdr)
+	rdr := io.TeeReader(data, hash)
+	written, err := io.Copy(f, rdr)
 	if err != nil {
 		if errors.Is(err, syscall.EDQUOT) {
 			return s3response.PutObjectOutput{}, s3err.GetAPIError(s3err.ErrQuotaExceeded)
@@ -2270,9 +2561,9 @@ func (p *Posix) PutObject(ctx context.Context, po *s3.PutObjectInput) (s3respons
 		return s3response.PutObjectOutput{}, fmt.Errorf("write object data: %w", err)
 	}
 
-	dir := filepath.Dir(name)
-	if dir != "" {
-		err = backend.MkdirAll(dir, uid, gid, doChown, p.newDirPerm)
+	dir := filepath.Dir(storageKey)
+	if dir != "." {
+		err = p.mkdirObjAll(*po.Bucket, dir, uid, gid, doChown)
 		if err != nil {
 			return s3response.PutObjectOutput{}, s3err.GetAPIError(s3err.ErrExistingObjectIsDirectory)
 		}
@@ -2299,21 +2590,33 @@ func (p *Posix) PutObject(ctx context.Context, po *s3.PutObjectInput) (s3respons
 	}
 
 	for k, v := range po.Metadata {
-		err := p.meta.StoreAttribute(f.File(), *po.Bucket, *po.Key,
+		err := p.meta.StoreAttribute(f.File(), *po.Bucket, storageKey,
 			fmt.Sprintf("%v.%v", metaHdr, k), []byte(v))
 		if err != nil {
 			return s3response.PutObjectOutput{}, fmt.Errorf("set user attr %q: %w", k, err)
 		}
 	}
 
-	err = p.meta.StoreAttribute(f.File(), *po.Bucket, *po.Key, etagkey, []byte(etag))
+	err = p.meta.StoreAttribute(f.File(), *po.Bucket, storageKey, etagkey, []byte(etag))
 	if err != nil {
 		return s3response.PutObjectOutput{}, fmt.Errorf("set etag attr: %w", err)
 	}
 
+	if sseCKey != nil {
+		err := p.meta.StoreAttribute(f.File(), *po.Bucket, storageKey, sseCIVKey, sseCIV)
+		if err != nil {
+			return s3response.PutObjectOutput{}, fmt.Errorf("set sse-c iv attr: %w", err)
+		}
+		err = p.meta.StoreAttribute(f.File(), *po.Bucket, storageKey, sseCKeyHMACKey,
+			backend.SSECKeyHMAC(sseCKey, sseCIV))
+		if err != nil {
+			return s3response.PutObjectOutput{}, fmt.Errorf("set sse-c key hmac attr: %w", err)
+		}
+	}
+
 	ctype := getString(po.ContentType)
 	if ctype != "" {
-		err := p.meta.StoreAttribute(f.File(), *po.Bucket, *po.Key, contentTypeHdr,
+		err := p.meta.StoreAttribute(f.File(), *po.Bucket, storageKey, contentTypeHdr,
 			[]byte(*po.ContentType))
 		if err != nil {
 			return s3response.PutObjectOutput{}, fmt.Errorf("set content-type attr: %w", err)
@@ -2322,22 +2625,64 @@ func (p *Posix) PutObject(ctx context.Context, po *s3.PutObjectInput) (s3respons
 
 	cenc := getString(po.ContentEncoding)
 	if cenc != "" {
-		err := p.meta.StoreAttribute(f.File(), *po.Bucket, *po.Key, contentEncHdr,
+		err := p.meta.StoreAttribute(f.File(), *po.Bucket, storageKey, contentEncHdr,
 			[]byte(*po.ContentEncoding))
 		if err != nil {
 			return s3response.PutObjectOutput{}, fmt.Errorf("set content-encoding attr: %w", err)
 		}
 	}
 
+	for hdr, val := range map[string]string{
+		cacheControlHdr: getString(po.CacheControl),
+		contentDispHdr:  getString(po.ContentDisposition),
+	} {
+		if val == "" {
+			continue
+		}
+		err := p.meta.StoreAttribute(f.File(), *po.Bucket, storageKey, hdr, []byte(val))
+		if err != nil {
+			return s3response.PutObjectOutput{}, fmt.Errorf("set %v attr: %w", hdr, err)
+		}
+	}
+
+	// the checksum is already validated against the object data
+	for algo, sum := range map[types.ChecksumAlgorithm]string{
+		types.ChecksumAlgorithmCrc32:  getString(po.ChecksumCRC32),
+		types.ChecksumAlgorithmCrc32c: getString(po.ChecksumCRC32C),
+		types.ChecksumAlgorithmSha1:   getString(po.ChecksumSHA1),
+		types.ChecksumAlgorithmSha256: getString(po.ChecksumSHA256),
+	} {
+		if sum == "" {
+			continue
+		}
+		err := p.meta.StoreAttribute(f.File(), *po.Bucket, storageKey, checksumAttr(algo), []byte(sum))
+		if err != nil {
+			return s3response.PutObjectOutput{}, fmt.Errorf("set %v checksum attr: %w", algo, err)
+		}
+	}
+
 	if versionID != "" && versionID != nullVersionId {
-		err := p.meta.StoreAttribute(f.File(), *po.Bucket, *po.Key, versionIdKey, []byte(versionID))
+		err := p.meta.StoreAttribute(f.File(), *po.Bucket, storageKey, versionIdKey, []byte(versionID))
 		if err != nil {
 			return s3response.PutObjectOutput{}, fmt.Errorf("set versionId attr: %w", err)
 		}
 	}
 
-	err = f.link()
+	err = p.usage.update(func() error {
+		existed, oldSize := replacedObject(name)
+		err := f.link()
+		if err != nil {
+			return err
+		}
+		if dirObjParent {
+			p.usage.dropLocked(*po.Bucket)
+		} else {
+			p.trackObjectPut(*po.Bucket, existed, oldSize, written)
+		}
+		return nil
+	})
 	if errors.Is(err, syscall.EEXIST) {
+		p.usage.drop(*po.Bucket)
 		return s3response.PutObjectOutput{
 			ETag:      etag,
 			VersionID: versionID,
@@ -2411,7 +2756,8 @@ func (p *Posix) DeleteObject(ctx context.Context, input *s3.DeleteObjectInput) (
 		return nil, fmt.Errorf("stat bucket: %w", err)
 	}
 
-	objpath := filepath.Join(bucket, object)
+	storageKey := backend.StorageKey(bucket, object)
+	objpath := filepath.Join(bucket, storageKey)
 
 	vStatus, err := p.getBucketVersioningStatus(ctx, bucket)
 	if err != nil {
@@ -2446,13 +2792,13 @@ func (p *Posix) DeleteObject(ctx context.Context, input *s3.DeleteObjectInput) (
 			}
 
 			// Mark the object as a delete marker
-			err = p.meta.StoreAttribute(nil, bucket, object, deleteMarkerKey, []byte{})
+			err = p.meta.StoreAttribute(nil, bucket, storageKey, deleteMarkerKey, []byte{})
 			if err != nil {
 				return nil, fmt.Errorf("set delete marker: %w", err)
 			}
 			// Generate & set a unique versionId for the delete marker
 			versionId := ulid.Make().String()
-			err = p.meta.StoreAttribute(nil, bucket, object, versionIdKey, []byte(versionId))
+			err = p.meta.StoreAttribute(nil, bucket, storageKey, versionIdKey, []byte(versionId))
 			if err != nil {
 				return nil, fmt.Errorf("set versionId: %w", err)
 			}
@@ -2464,7 +2810,7 @@ func (p *Posix) DeleteObject(ctx context.Context, input *s3.DeleteObjectInput) (
 		} else {
 			versionPath := p.genObjVersionPath(bucket, object)
 
-			vId, err := p.meta.RetrieveAttribute(nil, bucket, object, versionIdKey)
+			vId, err := p.meta.RetrieveAttribute(nil, bucket, storageKey, versionIdKey)
 			if err != nil && !errors.Is(err, meta.ErrNoSuchKey) && !errors.Is(err, fs.ErrNotExist) {
 				return nil, fmt.Errorf("get obj versionId: %w", err)
 			}
@@ -2477,7 +2823,7 @@ func (p *Posix) DeleteObject(ctx context.Context, input *s3.DeleteObjectInput) (
 				// remove the latest version, find the latest version from the versioning
 				// directory and move to the place of the deleted object, to make it the latest
 
-				isDelMarker, err := p.isObjDeleteMarker(bucket, object)
+				isDelMarker, err := p.isObjDeleteMarker(bucket, storageKey)
 				if err != nil {
 					return nil, err
 				}
@@ -2519,7 +2865,7 @@ func (p *Posix) DeleteObject(ctx context.Context, input *s3.DeleteObjectInput) (
 					acct = auth.Account{}
 				}
 
-				f, err := p.openTmpFile(filepath.Join(bucket, metaTmpDir), bucket, object, srcObjVersion.Size(), acct, doFalloc)
+				f, err := p.openTmpFile(filepath.Join(bucket, metaTmpDir), bucket, storageKey, srcObjVersion.Size(), acct, doFalloc)
 				if err != nil {
 					return nil, fmt.Errorf("open tmp file: %w", err)
 				}
@@ -2545,7 +2891,7 @@ func (p *Posix) DeleteObject(ctx context.Context, input *s3.DeleteObjectInput) (
 						return nil, fmt.Errorf("load %v attribute", attr)
 					}
 
-					err = p.meta.StoreAttribute(nil, bucket, object, attr, data)
+					err = p.meta.StoreAttribute(nil, bucket, storageKey, attr, data)
 					if err != nil {
 						return nil, fmt.Errorf("store %v attribute", attr)
 					}
@@ -2611,7 +2957,31 @@ func (p *Posix) DeleteObject(ctx context.Context, input *s3.DeleteObjectInput) (
 		return &s3.DeleteObjectOutput{}, nil
 	}
 
-	err = os.Remove(objpath)
+	dropUsage := fi.IsDir() || p.hasDirObjectParent(bucket, storageKey)
+
+	var keepDir bool
+	err = p.usage.update(func() error {
+		// the object might have been replaced since the stat above
+		fi, err := os.Stat(objpath)
+		if err != nil {
+			return err
+		}
+		err = os.Remove(objpath)
+		if isDir && errors.Is(err, syscall.ENOTEMPTY) {
+			keepDir = true
+			p.usage.dropLocked(bucket)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if dropUsage {
+			p.usage.dropLocked(bucket)
+		} else {
+			p.trackObjectRemove(bucket, fi.Size())
+		}
+		return nil
+	})
 	if errors.Is(err, fs.ErrNotExist) {
 		return nil, s3err.GetAPIError(s3err.ErrNoSuchKey)
 	}
@@ -2619,16 +2989,109 @@ func (p *Posix) DeleteObject(ctx context.Context, input *s3.DeleteObjectInput) (
 		return nil, fmt.Errorf("delete object: %w", err)
 	}
 
-	err = p.meta.DeleteAttributes(bucket, object)
+	err = p.meta.DeleteAttributes(bucket, storageKey)
 	if err != nil {
 		return nil, fmt.Errorf("delete object attributes: %w", err)
 	}
 
-	p.removeParents(bucket, object)
+	if keepDir {
+		// the directory is still the prefix of other objects,
+		// so only the directory object attributes are removed
+		attrs, err := p.meta.ListAttributes(bucket, object)
+		if err != nil {
+			return nil, fmt.Errorf("list object attributes: %w", err)
+		}
+		for _, attr := range attrs {
+			err := p.meta.DeleteAttribute(bucket, object, attr)
+			if err != nil && !errors.Is(err, meta.ErrNoSuchKey) {
+				return nil, fmt.Errorf("delete %v attribute: %w", attr, err)
+			}
+		}
+	}
+
+	p.removeParents(bucket, storageKey)
 
 	return &s3.DeleteObjectOutput{}, nil
 }
 
+// mkdirObjAll creates the dir directory in the bucket along with any
+// necessary parents. A file object in the way is nested within the
+// directory created in its place, see backend.NestedFileObj.
+func (p *Posix) mkdirObjAll(bucket, dir string, uid, gid int, doChown bool) error {
+	var parent string
+	for _, name := range strings.Split(dir, string(filepath.Separator)) {
+		parent = filepath.Join(parent, name)
+		fi, err := os.Stat(filepath.Join(bucket, parent))
+		if err != nil {
+			// let MkdirAll create the rest or report the error
+			break
+		}
+		if fi.Mode().IsRegular() {
+			err := p.nestFileObj(bucket, parent, uid, gid, doChown)
+			if err != nil {
+				return fmt.Errorf("nest file object %q: %w", parent, err)
+			}
+		}
+	}
+
+	return backend.MkdirAll(filepath.Join(bucket, dir), uid, gid, doChown, p.newDirPerm)
+}
+
+// nestFileObj replaces the object file with a directory of the same
+// name and moves the object file within it.
+func (p *Posix) nestFileObj(bucket, object string, uid, gid int, doChown bool) error {
+	tmpDir := filepath.Join(bucket, metaTmpDir)
+	err := backend.MkdirAll(tmpDir, uid, gid, doChown, p.newDirPerm)
+	if err != nil {
+		return fmt.Errorf("make temp dir: %w", err)
+	}
+
+	objPath := filepath.Join(bucket, object)
+	tmpPath := filepath.Join(tmpDir, "nest."+ulid.Make().String())
+	err = os.Rename(objPath, tmpPath)
+	if err != nil {
+		return err
+	}
+
+	err = backend.MkdirAll(objPath, uid, gid, doChown, p.newDirPerm)
+	if err != nil {
+		os.Rename(tmpPath, objPath)
+		return err
+	}
+
+	return os.Rename(tmpPath, filepath.Join(objPath, backend.NestedFileObj))
+}
+
+// unnestFileObj moves a file object nested within the dir directory
+// back in place of the directory, once nothing else needs the directory.
+func (p *Posix) unnestFileObj(bucket, dir string) {
+	_, err := p.meta.RetrieveAttribute(nil, bucket, dir, etagkey)
+	if err == nil {
+		// the directory object is still there
+		return
+	}
+
+	dirPath := filepath.Join(bucket, dir)
+	ents, err := os.ReadDir(dirPath)
+	if err != nil || len(ents) != 1 || ents[0].Name() != backend.NestedFileObj {
+		return
+	}
+
+	tmpPath := filepath.Join(bucket, metaTmpDir, "nest."+ulid.Make().String())
+	err = os.Rename(filepath.Join(dirPath, backend.NestedFileObj), tmpPath)
+	if err != nil {
+		return
+	}
+
+	err = os.Remove(dirPath)
+	if err != nil {
+		os.Rename(tmpPath, filepath.Join(dirPath, backend.NestedFileObj))
+		return
+	}
+
+	os.Rename(tmpPath, dirPath)
+}
+
 func (p *Posix) removeParents(bucket, object string) {
 	// this will remove all parent directories that were not
 	// specifically uploaded with a put object. we detect
@@ -2653,6 +3116,7 @@ func (p *Posix) removeParents(bucket, object string) {
 
 		err = os.Remove(filepath.Join(bucket, parent))
 		if err != nil {
+			p.unnestFileObj(bucket, parent)
 			break
 		}
 
@@ -2728,7 +3192,7 @@ func (p *Posix) GetObject(_ context.Context, input *s3.GetObjectInput) (*s3.GetO
 	// NOTE: os.Stat(bucket) removed here, and moved inside the first fs.ErrNotExist handlers below
 	// if any more fs.ErrNotExist checks are added below for the file, they should also stat the bucket
 	bucket := *input.Bucket
-	object := *input.Key
+	object := backend.StorageKey(bucket, *input.Key)
 	if versionId != "" {
 		vId, err := p.meta.RetrieveAttribute(nil, bucket, object, versionIdKey)
 		if errors.Is(err, fs.ErrNotExist) {
@@ -2747,7 +3211,7 @@ func (p *Posix) GetObject(_ context.Context, input *s3.GetObjectInput) (*s3.GetO
 
 		if string(vId) != versionId {
 			bucket = filepath.Join(p.versioningDir, bucket)
-			object = filepath.Join(genObjVersionKey(object), versionId)
+			object = filepath.Join(genObjVersionKey(*input.Key), versionId)
 		}
 	}
 
@@ -2777,6 +3241,14 @@ func (p *Posix) GetObject(_ context.Context, input *s3.GetObjectInput) (*s3.GetO
 	if !strings.HasSuffix(object, "/") && fi.IsDir() {
 		return nil, s3err.GetAPIError(s3err.ErrNoSuchKey)
 	}
+	if fi.IsDir() {
+		// the parent directories of the nested objects aren't
+		// directory objects, only the explicitly put ones have etag
+		_, err := p.meta.RetrieveAttribute(nil, bucket, object, etagkey)
+		if errors.Is(err, meta.ErrNoSuchKey) {
+			return nil, s3err.GetAPIError(s3err.ErrNoSuchKey)
+		}
+	}
 
 	if p.versioningEnabled() {
 		isDelMarker, err := p.isObjDeleteMarker(bucket, object)
@@ -2798,25 +3270,18 @@ func (p *Posix) GetObject(_ context.Context, input *s3.GetObjectInput) (*s3.GetO
 		}
 	}
 
-	acceptRange := *input.Range
-	startOffset, length, err := backend.ParseRange(fi.Size(), acceptRange)
-	if err != nil {
-		return nil, err
-	}
-
 	objSize := fi.Size()
 	if fi.IsDir() {
 		// directory objects are always 0 len
 		objSize = 0
-		length = 0
 	}
 
-	if length == -1 {
-		length = objSize - startOffset
-	}
-
-	if startOffset+length > objSize {
-		length = objSize - startOffset
+	acceptRange := *input.Range
+	startOffset, length, err := backend.ParseGetObjectRange(objSize, acceptRange)
+	if err != nil {
+		return &s3.GetObjectOutput{
+			ContentRange: backend.GetStringPtr(backend.UnsatisfiableContentRange(objSize)),
+		}, err
 	}
 
 	var contentRange string
@@ -2828,9 +3293,15 @@ func (p *Posix) GetObject(_ context.Context, input *s3.GetObjectInput) (*s3.GetO
 	if fi.IsDir() {
 		userMetaData := make(map[string]string)
 
-		_, contentEncoding, _ := p.loadUserMetaData(bucket, object, userMetaData)
+		_, contentEncoding, xattrs := p.loadUserMetaData(bucket, object, userMetaData)
+		cacheControl, contentDisposition := p.loadContentHeaders(bucket, object, xattrs)
 		contentType := backend.DirContentType
 
+		_, _, err := p.checkSSECustomerKey(bucket, object, xattrs, input.SSECustomerKey)
+		if err != nil {
+			return nil, err
+		}
+
 		b, err := p.meta.RetrieveAttribute(nil, bucket, object, etagkey)
 		etag := string(b)
 		if err != nil {
@@ -2848,17 +3319,19 @@ func (p *Posix) GetObject(_ context.Context, input *s3.GetObjectInput) (*s3.GetO
 		}
 
 		return &s3.GetObjectOutput{
-			AcceptRanges:    &acceptRange,
-			ContentLength:   &length,
-			ContentEncoding: &contentEncoding,
-			ContentType:     &contentType,
-			ETag:            &etag,
-			LastModified:    backend.GetTimePtr(fi.ModTime()),
-			Metadata:        userMetaData,
-			TagCount:        tagCount,
-			ContentRange:    &contentRange,
-			StorageClass:    types.StorageClassStandard,
-			VersionId:       &versionId,
+			AcceptRanges:       &acceptRange,
+			ContentLength:      &length,
+			ContentEncoding:    &contentEncoding,
+			ContentType:        &contentType,
+			CacheControl:       &cacheControl,
+			ContentDisposition: &contentDisposition,
+			ETag:               &etag,
+			LastModified:       backend.GetTimePtr(fi.ModTime()),
+			Metadata:           userMetaData,
+			TagCount:           tagCount,
+			ContentRange:       &contentRange,
+			StorageClass:       types.StorageClassStandard,
+			VersionId:          &versionId,
 		}, nil
 	}
 
@@ -2877,6 +3350,13 @@ func (p *Posix) GetObject(_ context.Context, input *s3.GetObjectInput) (*s3.GetO
 	userMetaData := make(map[string]string)
 
 	contentType, contentEncoding, xattrs := p.loadUserMetaData(bucket, object, userMetaData)
+	cacheControl, contentDisposition := p.loadContentHeaders(bucket, object, xattrs)
+
+	sseCKey, sseCIV, err := p.checkSSECustomerKey(bucket, object, xattrs, input.SSECustomerKey)
+	if err != nil {
+		return nil, err
+	}
+	checksums := p.loadChecksums(bucket, object, xattrs)
 
 	var etag string
 	if slices.Contains(xattrs, etagkey) {
@@ -2912,19 +3392,40 @@ func (p *Posix) GetObject(_ context.Context, input *s3.GetObjectInput) (*s3.GetO
 		body = &backend.FileSectionReadCloser{R: rdr, F: f}
 	}
 
+	var sseCAlgorithm, sseCKeyMD5 *string
+	if sseCKey != nil {
+		rdr, err := backend.NewSSECReader(io.NewSectionReader(f, startOffset, length),
+			sseCKey, sseCIV, startOffset)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		body = &backend.FileSectionReadCloser{R: rdr, F: f}
+		sseCAlgorithm = backend.GetStringPtr(backend.SSECAlgorithm)
+		sseCKeyMD5 = input.SSECustomerKeyMD5
+	}
+
 	return &s3.GetObjectOutput{
-		AcceptRanges:    &acceptRange,
-		ContentLength:   &length,
-		ContentEncoding: &contentEncoding,
-		ContentType:     &contentType,
-		ETag:            &etag,
-		LastModified:    backend.GetTimePtr(fi.ModTime()),
-		Metadata:        userMetaData,
-		TagCount:        tagCount,
-		ContentRange:    &contentRange,
-		StorageClass:    types.StorageClassStandard,
-		VersionId:       &versionId,
-		Body:            body,
+		AcceptRanges:         &acceptRange,
+		ContentLength:        &length,
+		ContentEncoding:      &contentEncoding,
+		ContentType:          &contentType,
+		CacheControl:         &cacheControl,
+		ContentDisposition:   &contentDisposition,
+		ETag:                 &etag,
+		LastModified:         backend.GetTimePtr(fi.ModTime()),
+		Metadata:             userMetaData,
+		TagCount:             tagCount,
+		ContentRange:         &contentRange,
+		StorageClass:         types.StorageClassStandard,
+		VersionId:            &versionId,
+		SSECustomerAlgorithm: sseCAlgorithm,
+		SSECustomerKeyMD5:    sseCKeyMD5,
+		ChecksumCRC32:        checksums.ChecksumCRC32,
+		ChecksumCRC32C:       checksums.ChecksumCRC32C,
+		ChecksumSHA1:         checksums.ChecksumSHA1,
+		ChecksumSHA256:       checksums.ChecksumSHA256,
+		Body:                 body,
 	}, nil
 }
 
@@ -2996,6 +3497,8 @@ func (p *Posix) HeadObject(ctx context.Context, input *s3.HeadObjectInput) (*s3.
 		return nil, fmt.Errorf("stat bucket: %w", err)
 	}
 
+	object = backend.StorageKey(bucket, object)
+
 	if *input.VersionId != "" {
 		vId, err := p.meta.RetrieveAttribute(nil, bucket, object, versionIdKey)
 		if errors.Is(err, fs.ErrNotExist) {
@@ -3006,12 +3509,12 @@ func (p *Posix) HeadObject(ctx context.Context, input *s3.HeadObjectInput) (*s3.
 		}
 		if errors.Is(err, meta.ErrNoSuchKey) {
 			bucket = filepath.Join(p.versioningDir, bucket)
-			object = filepath.Join(genObjVersionKey(object), *input.VersionId)
+			object = filepath.Join(genObjVersionKey(*input.Key), *input.VersionId)
 		}
 
 		if string(vId) != *input.VersionId {
 			bucket = filepath.Join(p.versioningDir, bucket)
-			object = filepath.Join(genObjVersionKey(object), *input.VersionId)
+			object = filepath.Join(genObjVersionKey(*input.Key), *input.VersionId)
 		}
 	}
 
@@ -3036,6 +3539,14 @@ func (p *Posix) HeadObject(ctx context.Context, input *s3.HeadObjectInput) (*s3.
 	if !strings.HasSuffix(object, "/") && fi.IsDir() {
 		return nil, s3err.GetAPIError(s3err.ErrNoSuchKey)
 	}
+	if fi.IsDir() {
+		// the parent directories of the nested objects aren't
+		// directory objects, only the explicitly put ones have etag
+		_, err := p.meta.RetrieveAttribute(nil, bucket, object, etagkey)
+		if errors.Is(err, meta.ErrNoSuchKey) {
+			return nil, s3err.GetAPIError(s3err.ErrNoSuchKey)
+		}
+	}
 
 	if *input.VersionId != "" {
 		isDelMarker, err := p.isObjDeleteMarker(bucket, object)
@@ -3053,12 +3564,24 @@ func (p *Posix) HeadObject(ctx context.Context, input *s3.HeadObjectInput) (*s3.
 	}
 
 	userMetaData := make(map[string]string)
-	contentType, contentEncoding, _ := p.loadUserMetaData(bucket, object, userMetaData)
+	contentType, contentEncoding, xattrs := p.loadUserMetaData(bucket, object, userMetaData)
+	cacheControl, contentDisposition := p.loadContentHeaders(bucket, object, xattrs)
 
 	if fi.IsDir() {
 		contentType = backend.DirContentType
 	}
 
+	sseCKey, _, err := p.checkSSECustomerKey(bucket, object, xattrs, input.SSECustomerKey)
+	if err != nil {
+		return nil, err
+	}
+	var sseCAlgorithm, sseCKeyMD5 *string
+	if sseCKey != nil {
+		sseCAlgorithm = backend.GetStringPtr(backend.SSECAlgorithm)
+		sseCKeyMD5 = input.SSECustomerKeyMD5
+	}
+	checksums := p.loadChecksums(bucket, object, xattrs)
+
 	b, err := p.meta.RetrieveAttribute(nil, bucket, object, etagkey)
 	etag := string(b)
 	if err != nil {
@@ -3066,6 +3589,10 @@ func (p *Posix) HeadObject(ctx context.Context, input *s3.HeadObjectInput) (*s3.
 	}
 
 	size := fi.Size()
+	if fi.IsDir() {
+		// directory objects are always 0 len
+		size = 0
+	}
 
 	var objectLockLegalHoldStatus types.ObjectLockLegalHoldStatus
 	status, err := p.GetObjectLegalHold(ctx, bucket, object, *input.VersionId)
@@ -3094,6 +3621,8 @@ func (p *Posix) HeadObject(ctx context.Context, input *s3.HeadObjectInput) (*s3.
 		ContentLength:             &size,
 		ContentType:               &contentType,
 		ContentEncoding:           &contentEncoding,
+		CacheControl:              &cacheControl,
+		ContentDisposition:        &contentDisposition,
 		ETag:                      &etag,
 		LastModified:              backend.GetTimePtr(fi.ModTime()),
 		Metadata:                  userMetaData,
@@ -3102,6 +3631,12 @@ func (p *Posix) HeadObject(ctx context.Context, input *s3.HeadObjectInput) (*s3.
 		ObjectLockRetainUntilDate: objectLockRetainUntilDate,
 		StorageClass:              types.StorageClassStandard,
 		VersionId:                 input.VersionId,
+		SSECustomerAlgorithm:      sseCAlgorithm,
+		SSECustomerKeyMD5:         sseCKeyMD5,
+		ChecksumCRC32:             checksums.ChecksumCRC32,
+		ChecksumCRC32C:            checksums.ChecksumCRC32C,
+		ChecksumSHA1:              checksums.ChecksumSHA1,
+		ChecksumSHA256:            checksums.ChecksumSHA256,
 	}, nil
 }
 
@@ -3112,17 +3647,110 @@ func (p *Posix) GetObjectAttributes(ctx context.Context, input *s3.GetObjectAttr
 		VersionId: input.VersionId,
 	})
 	if err != nil {
-		return s3response.GetObjectAttributesResult{}, nil
+		return s3response.GetObjectAttributesResult{}, err
+	}
+
+	parts, err := p.getObjectParts(input, *data.ETag, *data.ContentLength)
+	if err != nil {
+		return s3response.GetObjectAttributesResult{}, err
+	}
+
+	var checksum *types.Checksum
+	if data.ChecksumCRC32 != nil || data.ChecksumCRC32C != nil ||
+		data.ChecksumSHA1 != nil || data.ChecksumSHA256 != nil {
+		checksum = &types.Checksum{
+			ChecksumCRC32:  data.ChecksumCRC32,
+			ChecksumCRC32C: data.ChecksumCRC32C,
+			ChecksumSHA1:   data.ChecksumSHA1,
+			ChecksumSHA256: data.ChecksumSHA256,
+		}
 	}
 
 	return s3response.GetObjectAttributesResult{
 		ETag:         data.ETag,
+		Checksum:     checksum,
 		LastModified: data.LastModified,
 		ObjectSize:   data.ContentLength,
 		StorageClass: data.StorageClass,
+		ObjectParts:  parts,
 	}, nil
 }
 
+// getObjectParts returns the parts of an object completed from a
+// multipart upload, the part count is the multipart etag suffix.
+// Objects that weren't uploaded in parts have no parts.
+func (p *Posix) getObjectParts(input *s3.GetObjectAttributesInput, etag string, size int64) (*s3response.ObjectParts, error) {
+	i := strings.LastIndex(etag, "-")
+	if i == -1 {
+		return nil, nil
+	}
+	partsCount, err := strconv.Atoi(etag[i+1:])
+	if err != nil || partsCount < 1 {
+		return nil, nil
+	}
+
+	var partNumberMarker int
+	if input.PartNumberMarker != nil && *input.PartNumberMarker != "" {
+		partNumberMarker, err = strconv.Atoi(*input.PartNumberMarker)
+		if err != nil || partNumberMarker < 0 {
+			return nil, s3err.GetAPIError(s3err.ErrInvalidPartNumberMarker)
+		}
+	}
+	maxParts := 1000
+	if input.MaxParts != nil && *input.MaxParts > 0 {
+		maxParts = int(*input.MaxParts)
+	}
+
+	result := &s3response.ObjectParts{
+		PartNumberMarker: partNumberMarker,
+		MaxParts:         maxParts,
+		TotalPartsCount:  partsCount,
+	}
+
+	bucket := *input.Bucket
+	object := backend.StorageKey(bucket, *input.Key)
+	if input.VersionId != nil && *input.VersionId != "" {
+		vId, _ := p.meta.RetrieveAttribute(nil, bucket, object, versionIdKey)
+		if string(vId) != *input.VersionId {
+			bucket = filepath.Join(p.versioningDir, bucket)
+			object = filepath.Join(genObjVersionKey(*input.Key), *input.VersionId)
+		}
+	}
+
+	b, err := p.meta.RetrieveAttribute(nil, bucket, object, partSizeKey)
+	if errors.Is(err, meta.ErrNoSuchKey) {
+		// objects completed before the part size was kept
+		// only report the part count
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get part size attr: %w", err)
+	}
+	partSize, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse part size attr: %w", err)
+	}
+
+	for pn := partNumberMarker + 1; pn <= partsCount; pn++ {
+		if len(result.Parts) == maxParts {
+			result.IsTruncated = true
+			break
+		}
+		partNumber := int32(pn)
+		psize := partSize
+		if pn == partsCount {
+			psize = size - partSize*int64(partsCount-1)
+		}
+		result.Parts = append(result.Parts, types.ObjectPart{
+			PartNumber: &partNumber,
+			Size:       &psize,
+		})
+		result.NextPartNumberMarker = pn
+	}
+
+	return result, nil
+}
+
 func (p *Posix) CopyObject(ctx context.Context, input *s3.CopyObjectInput) (*s3.CopyObjectOutput, error) {
 	if input.Bucket == nil {
 		return nil, s3err.GetAPIError(s3err.ErrInvalidBucketName)
@@ -3158,11 +3786,13 @@ func (p *Posix) CopyObject(ctx context.Context, input *s3.CopyObjectInput) (*s3.
 	}
 	vEnabled := p.isBucketVersioningEnabled(vStatus)
 
+	srcKey := backend.StorageKey(srcBucket, srcObject)
+
 	if srcVersionId != "" {
 		if !p.versioningEnabled() || !vEnabled {
 			return nil, s3err.GetAPIError(s3err.ErrInvalidVersionId)
 		}
-		vId, err := p.meta.RetrieveAttribute(nil, srcBucket, srcObject, versionIdKey)
+		vId, err := p.meta.RetrieveAttribute(nil, srcBucket, srcKey, versionIdKey)
 		if errors.Is(err, fs.ErrNotExist) {
 			return nil, s3err.GetAPIError(s3err.ErrNoSuchKey)
 		}
@@ -3172,7 +3802,7 @@ func (p *Posix) CopyObject(ctx context.Context, input *s3.CopyObjectInput) (*s3.
 
 		if string(vId) != srcVersionId {
 			srcBucket = filepath.Join(p.versioningDir, srcBucket)
-			srcObject = filepath.Join(genObjVersionKey(srcObject), srcVersionId)
+			srcKey = filepath.Join(genObjVersionKey(srcObject), srcVersionId)
 		}
 	}
 
@@ -3184,7 +3814,7 @@ func (p *Posix) CopyObject(ctx context.Context, input *s3.CopyObjectInput) (*s3.
 		return nil, fmt.Errorf("stat bucket: %w", err)
 	}
 
-	objPath := filepath.Join(srcBucket, srcObject)
+	objPath := filepath.Join(srcBucket, srcKey)
 	f, err := os.Open(objPath)
 	if errors.Is(err, fs.ErrNotExist) {
 		if p.versioningEnabled() && vEnabled {
@@ -3204,57 +3834,72 @@ func (p *Posix) CopyObject(ctx context.Context, input *s3.CopyObjectInput) (*s3.
 	if err != nil {
 		return nil, fmt.Errorf("stat object: %w", err)
 	}
-	if strings.HasSuffix(srcObject, "/") && !fi.IsDir() {
+	if strings.HasSuffix(srcKey, "/") && !fi.IsDir() {
 		return nil, s3err.GetAPIError(s3err.ErrNoSuchKey)
 	}
-	if !strings.HasSuffix(srcObject, "/") && fi.IsDir() {
+	if !strings.HasSuffix(srcKey, "/") && fi.IsDir() {
 		return nil, s3err.GetAPIError(s3err.ErrNoSuchKey)
 	}
 
 	mdmap := make(map[string]string)
-	p.loadUserMetaData(srcBucket, srcObject, mdmap)
+	cType, cEnc, srcAttrs := p.loadUserMetaData(srcBucket, srcKey, mdmap)
+	cacheControl, cDisp := p.loadContentHeaders(srcBucket, srcKey, srcAttrs)
+
+	// copying the SSE-C encrypted objects isn't supported
+	if slices.Contains(srcAttrs, sseCIVKey) {
+		return nil, s3err.GetAPIError(s3err.ErrSSECustomerKeyRequired)
+	}
 
 	var etag string
 	var version *string
 
-	dstObjdPath := filepath.Join(dstBucket, dstObject)
+	dstKey := backend.StorageKey(dstBucket, dstObject)
+	dstObjdPath := filepath.Join(dstBucket, dstKey)
 	if dstObjdPath == objPath {
 		if input.MetadataDirective == types.MetadataDirectiveCopy {
 			return &s3.CopyObjectOutput{}, s3err.GetAPIError(s3err.ErrInvalidCopyDest)
 		}
 
 		for k := range mdmap {
-			err := p.meta.DeleteAttribute(dstBucket, dstObject,
+			err := p.meta.DeleteAttribute(dstBucket, dstKey,
 				fmt.Sprintf("%v.%v", metaHdr, k))
 			if err != nil && !errors.Is(err, meta.ErrNoSuchKey) {
 				return nil, fmt.Errorf("delete user metadata: %w", err)
 			}
 		}
 		for k, v := range input.Metadata {
-			err := p.meta.StoreAttribute(nil, dstBucket, dstObject,
+			err := p.meta.StoreAttribute(nil, dstBucket, dstKey,
 				fmt.Sprintf("%v.%v", metaHdr, k), []byte(v))
 			if err != nil {
 				return nil, fmt.Errorf("set user attr %q: %w", k, err)
 			}
 		}
 
-		b, _ := p.meta.RetrieveAttribute(nil, dstBucket, dstObject, etagkey)
+		b, _ := p.meta.RetrieveAttribute(nil, dstBucket, dstKey, etagkey)
 		etag = string(b)
-		vId, _ := p.meta.RetrieveAttribute(nil, dstBucket, dstObject, versionIdKey)
+		vId, _ := p.meta.RetrieveAttribute(nil, dstBucket, dstKey, versionIdKey)
 		if errors.Is(err, fs.ErrNotExist) {
 			return nil, s3err.GetAPIError(s3err.ErrNoSuchKey)
 		}
 		version = backend.GetStringPtr(string(vId))
 	} else {
 		contentLength := fi.Size()
-		res, err := p.PutObject(ctx,
-			&s3.PutObjectInput{
-				Bucket:        &dstBucket,
-				Key:           &dstObject,
-				Body:          f,
-				ContentLength: &contentLength,
-				Metadata:      input.Metadata,
-			})
+		putInput := &s3.PutObjectInput{
+			Bucket:        &dstBucket,
+			Key:           &dstObject,
+			Body:          f,
+			ContentLength: &contentLength,
+			Metadata:      input.Metadata,
+		}
+		// the source object metadata is kept unless it's replaced
+		if input.MetadataDirective != types.MetadataDirectiveReplace {
+			putInput.Metadata = mdmap
+			putInput.ContentType = backend.GetPtrFromString(cType)
+			putInput.ContentEncoding = backend.GetPtrFromString(cEnc)
+			putInput.CacheControl = backend.GetPtrFromString(cacheControl)
+			putInput.ContentDisposition = backend.GetPtrFromString(cDisp)
+		}
+		res, err := p.PutObject(ctx, putInput)
 		if err != nil {
 			return nil, err
 		}
@@ -3277,6 +3922,164 @@ func (p *Posix) CopyObject(ctx context.Context, input *s3.CopyObjectInput) (*s3.
 	}, nil
 }
 
+// MoveObject renames the source object to the destination, which is
+// an atomic alternative to CopyObject followed by DeleteObject.
+// NotImplemented is returned for the cases, which can't be handled
+// with a single rename(2): versioned objects, directory objects and
+// cross filesystem moves.
+func (p *Posix) MoveObject(ctx context.Context, input *s3.CopyObjectInput) (*s3.CopyObjectOutput, error) {
+	if input.Bucket == nil {
+		return nil, s3err.GetAPIError(s3err.ErrInvalidBucketName)
+	}
+	if input.Key == nil {
+		return nil, s3err.GetAPIError(s3err.ErrInvalidCopyDest)
+	}
+	if input.CopySource == nil {
+		return nil, s3err.GetAPIError(s3err.ErrInvalidCopySource)
+	}
+
+	srcBucket, srcObject, srcVersionId, err := backend.ParseCopySource(*input.CopySource)
+	if err != nil {
+		return nil, err
+	}
+	dstBucket := *input.Bucket
+	dstObject := *input.Key
+
+	if srcVersionId != "" ||
+		strings.HasSuffix(srcObject, "/") || strings.HasSuffix(dstObject, "/") {
+		return nil, s3err.GetAPIError(s3err.ErrNotImplemented)
+	}
+
+	for _, bucket := range []string{srcBucket, dstBucket} {
+		_, err = os.Stat(bucket)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, s3err.GetAPIError(s3err.ErrNoSuchBucket)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("stat bucket: %w", err)
+		}
+
+		if p.versioningEnabled() {
+			vStatus, err := p.getBucketVersioningStatus(ctx, bucket)
+			if err != nil {
+				return nil, err
+			}
+			if vStatus != "" {
+				return nil, s3err.GetAPIError(s3err.ErrNotImplemented)
+			}
+		}
+	}
+
+	srcKey := backend.StorageKey(srcBucket, srcObject)
+	dstKey := backend.StorageKey(dstBucket, dstObject)
+	srcPath := filepath.Join(srcBucket, srcKey)
+	dstPath := filepath.Join(dstBucket, dstKey)
+	if srcPath == dstPath {
+		return nil, s3err.GetAPIError(s3err.ErrInvalidCopyDest)
+	}
+
+	fi, err := os.Stat(srcPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, s3err.GetAPIError(s3err.ErrNoSuchKey)
+	}
+	if errors.Is(err, syscall.ENAMETOOLONG) {
+		return nil, s3err.GetAPIError(s3err.ErrKeyTooLong)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("stat object: %w", err)
+	}
+	if fi.IsDir() {
+		return nil, s3err.GetAPIError(s3err.ErrNoSuchKey)
+	}
+
+	d, err := os.Stat(dstPath)
+	if err == nil && d.IsDir() {
+		return nil, s3err.GetAPIError(s3err.ErrExistingObjectIsDirectory)
+	}
+	dropUsage := p.hasDirObjectParent(dstBucket, dstKey) ||
+		p.hasDirObjectParent(srcBucket, srcKey)
+
+	acct, ok := ctx.Value("account").(auth.Account)
+	if !ok {
+		acct = auth.Account{}
+	}
+	uid, gid, doChown := p.getChownIDs(acct)
+
+	err = p.mkdirObjAll(dstBucket, filepath.Dir(dstKey), uid, gid, doChown)
+	if err != nil {
+		return nil, s3err.GetAPIError(s3err.ErrExistingObjectIsDirectory)
+	}
+
+	// the object attributes (etag, metadata, tags...) are
+	// stored with the file, so they are moved along with it
+	err = p.usage.update(func() error {
+		// the objects might have changed since the stats above
+		src, err := os.Stat(srcPath)
+		if err != nil {
+			return err
+		}
+		existed, oldSize := replacedObject(dstPath)
+		err = os.Rename(srcPath, dstPath)
+		if err != nil {
+			return err
+		}
+		if dropUsage {
+			p.usage.dropLocked(srcBucket)
+			p.usage.dropLocked(dstBucket)
+		} else {
+			p.trackObjectRemove(srcBucket, src.Size())
+			p.trackObjectPut(dstBucket, existed, oldSize, src.Size())
+		}
+		return nil
+	})
+	if err != nil {
+		// remove the parent directories created for the destination,
+		// the copy and delete fallback creates them as needed
+		p.removeParents(dstBucket, dstKey)
+	}
+	if errors.Is(err, syscall.EXDEV) {
+		return nil, s3err.GetAPIError(s3err.ErrNotImplemented)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("rename object: %w", err)
+	}
+
+	p.removeParents(srcBucket, srcKey)
+
+	if input.MetadataDirective == types.MetadataDirectiveReplace {
+		mdmap := make(map[string]string)
+		p.loadUserMetaData(dstBucket, dstKey, mdmap)
+
+		for k := range mdmap {
+			err := p.meta.DeleteAttribute(dstBucket, dstKey,
+				fmt.Sprintf("%v.%v", metaHdr, k))
+			if err != nil && !errors.Is(err, meta.ErrNoSuchKey) {
+				return nil, fmt.Errorf("delete user metadata: %w", err)
+			}
+		}
+		for k, v := range input.Metadata {
+			err := p.meta.StoreAttribute(nil, dstBucket, dstKey,
+				fmt.Sprintf("%v.%v", metaHdr, k), []byte(v))
+			if err != nil {
+				return nil, fmt.Errorf("set user attr %q: %w", k, err)
+			}
+		}
+	}
+
+	b, err := p.meta.RetrieveAttribute(nil, dstBucket, dstKey, etagkey)
+	if err != nil && !errors.Is(err, meta.ErrNoSuchKey) {
+		return nil, fmt.Errorf("get object etag: %w", err)
+	}
+	etag := string(b)
+
+	return &s3.CopyObjectOutput{
+		CopyObjectResult: &types.CopyObjectResult{
+			ETag:         &etag,
+			LastModified: backend.GetTimePtr(fi.ModTime()),
+		},
+	}, nil
+}
+
 func (p *Posix) ListObjects(ctx context.Context, input *s3.ListObjectsInput) (s3response.ListObjectsResult, error) {
 	if input.Bucket == nil {
 		return s3response.ListObjectsResult{}, s3err.GetAPIError(s3err.ErrInvalidBucketName)
@@ -3362,13 +4165,14 @@ func (p *Posix) fileToObj(bucket string) backend.GetObjFunc {
 		}
 
 		// If the object is a delete marker, skip
-		isDel, _ := p.isObjDeleteMarker(bucket, path)
+		storageKey := backend.EntryStorageKey(path, d)
+		isDel, _ := p.isObjDeleteMarker(bucket, storageKey)
 		if isDel {
 			return s3response.Object{}, backend.ErrSkipObj
 		}
 
 		// file object, get object info and fill out object data
-		etagBytes, err := p.meta.RetrieveAttribute(nil, bucket, path, etagkey)
+		etagBytes, err := p.meta.RetrieveAttribute(nil, bucket, storageKey, etagkey)
 		if errors.Is(err, fs.ErrNotExist) {
 			return s3response.Object{}, backend.ErrSkipObj
 		}
@@ -3558,7 +4362,12 @@ func (p *Posix) GetObjectTagging(_ context.Context, bucket, object string) (map[
 		return nil, fmt.Errorf("stat bucket: %w", err)
 	}
 
-	return p.getAttrTags(bucket, object)
+	tags, err := p.getAttrTags(bucket, backend.StorageKey(bucket, object))
+	if errors.Is(err, s3err.GetAPIError(s3err.ErrBucketTaggingNotFound)) {
+		// an object without tags has an empty tag set
+		return map[string]string{}, nil
+	}
+	return tags, err
 }
 
 func (p *Posix) getAttrTags(bucket, object string) (map[string]string, error) {
@@ -3591,6 +4400,8 @@ func (p *Posix) PutObjectTagging(_ context.Context, bucket, object string, tags
 		return fmt.Errorf("stat bucket: %w", err)
 	}
 
+	object = objStorageKey(bucket, object)
+
 	if tags == nil {
 		err = p.meta.DeleteAttribute(bucket, object, tagHdr)
 		if errors.Is(err, fs.ErrNotExist) {
@@ -3777,12 +4588,14 @@ func (p *Posix) PutObjectLegalHold(_ context.Context, bucket, object, versionId
 		statusData = []byte{0}
 	}
 
+	storageKey := objStorageKey(bucket, object)
+
 	if versionId != "" {
 		if !p.versioningEnabled() {
 			//TODO: Maybe we need to return our custom error here?
 			return s3err.GetAPIError(s3err.ErrInvalidVersionId)
 		}
-		vId, err := p.meta.RetrieveAttribute(nil, bucket, object, versionIdKey)
+		vId, err := p.meta.RetrieveAttribute(nil, bucket, storageKey, versionIdKey)
 		if errors.Is(err, fs.ErrNotExist) {
 			return s3err.GetAPIError(s3err.ErrNoSuchKey)
 		}
@@ -3792,11 +4605,11 @@ func (p *Posix) PutObjectLegalHold(_ context.Context, bucket, object, versionId
 
 		if string(vId) != versionId {
 			bucket = filepath.Join(p.versioningDir, bucket)
-			object = filepath.Join(genObjVersionKey(object), versionId)
+			storageKey = filepath.Join(genObjVersionKey(object), versionId)
 		}
 	}
 
-	err = p.meta.StoreAttribute(nil, bucket, object, objectLegalHoldKey, statusData)
+	err = p.meta.StoreAttribute(nil, bucket, storageKey, objectLegalHoldKey, statusData)
 	if errors.Is(err, fs.ErrNotExist) {
 		if versionId != "" {
 			return s3err.GetAPIError(s3err.ErrInvalidVersionId)
@@ -3820,12 +4633,14 @@ func (p *Posix) GetObjectLegalHold(_ context.Context, bucket, object, versionId
 		return nil, err
 	}
 
+	storageKey := objStorageKey(bucket, object)
+
 	if versionId != "" {
 		if !p.versioningEnabled() {
 			//TODO: Maybe we need to return our custom error here?
 			return nil, s3err.GetAPIError(s3err.ErrInvalidVersionId)
 		}
-		vId, err := p.meta.RetrieveAttribute(nil, bucket, object, versionIdKey)
+		vId, err := p.meta.RetrieveAttribute(nil, bucket, storageKey, versionIdKey)
 		if errors.Is(err, fs.ErrNotExist) {
 			return nil, s3err.GetAPIError(s3err.ErrNoSuchKey)
 		}
@@ -3835,11 +4650,11 @@ func (p *Posix) GetObjectLegalHold(_ context.Context, bucket, object, versionId
 
 		if string(vId) != versionId {
 			bucket = filepath.Join(p.versioningDir, bucket)
-			object = filepath.Join(genObjVersionKey(object), versionId)
+			storageKey = filepath.Join(genObjVersionKey(object), versionId)
 		}
 	}
 
-	data, err := p.meta.RetrieveAttribute(nil, bucket, object, objectLegalHoldKey)
+	data, err := p.meta.RetrieveAttribute(nil, bucket, storageKey, objectLegalHoldKey)
 	if errors.Is(err, fs.ErrNotExist) {
 		if versionId != "" {
 			return nil, s3err.GetAPIError(s3err.ErrInvalidVersionId)
@@ -3868,12 +4683,14 @@ func (p *Posix) PutObjectRetention(_ context.Context, bucket, object, versionId
 		return err
 	}
 
+	storageKey := objStorageKey(bucket, object)
+
 	if versionId != "" {
 		if !p.versioningEnabled() {
 			//TODO: Maybe we need to return our custom error here?
 			return s3err.GetAPIError(s3err.ErrInvalidVersionId)
 		}
-		vId, err := p.meta.RetrieveAttribute(nil, bucket, object, versionIdKey)
+		vId, err := p.meta.RetrieveAttribute(nil, bucket, storageKey, versionIdKey)
 		if errors.Is(err, fs.ErrNotExist) {
 			return s3err.GetAPIError(s3err.ErrNoSuchKey)
 		}
@@ -3883,11 +4700,11 @@ func (p *Posix) PutObjectRetention(_ context.Context, bucket, object, versionId
 
 		if string(vId) != versionId {
 			bucket = filepath.Join(p.versioningDir, bucket)
-			object = filepath.Join(genObjVersionKey(object), versionId)
+			storageKey = filepath.Join(genObjVersionKey(object), versionId)
 		}
 	}
 
-	objectLockCfg, err := p.meta.RetrieveAttribute(nil, bucket, object, objectRetentionKey)
+	objectLockCfg, err := p.meta.RetrieveAttribute(nil, bucket, storageKey, objectRetentionKey)
 	if errors.Is(err, fs.ErrNotExist) {
 		if versionId != "" {
 			return s3err.GetAPIError(s3err.ErrInvalidVersionId)
@@ -3895,7 +4712,7 @@ func (p *Posix) PutObjectRetention(_ context.Context, bucket, object, versionId
 		return s3err.GetAPIError(s3err.ErrNoSuchKey)
 	}
 	if errors.Is(err, meta.ErrNoSuchKey) {
-		err := p.meta.StoreAttribute(nil, bucket, object, objectRetentionKey, retention)
+		err := p.meta.StoreAttribute(nil, bucket, storageKey, objectRetentionKey, retention)
 		if err != nil {
 			return fmt.Errorf("set object lock config: %w", err)
 		}
@@ -3922,7 +4739,7 @@ func (p *Posix) PutObjectRetention(_ context.Context, bucket, object, versionId
 		}
 	}
 
-	err = p.meta.StoreAttribute(nil, bucket, object, objectRetentionKey, retention)
+	err = p.meta.StoreAttribute(nil, bucket, storageKey, objectRetentionKey, retention)
 	if err != nil {
 		return fmt.Errorf("set object lock config: %w", err)
 	}
@@ -3940,12 +4757,14 @@ func (p *Posix) GetObjectRetention(_ context.Context, bucket, object, versionId
 		return nil, err
 	}
 
+	storageKey := objStorageKey(bucket, object)
+
 	if versionId != "" {
 		if !p.versioningEnabled() {
 			//TODO: Maybe we need to return our custom error here?
 			return nil, s3err.GetAPIError(s3err.ErrInvalidVersionId)
 		}
-		vId, err := p.meta.RetrieveAttribute(nil, bucket, object, versionIdKey)
+		vId, err := p.meta.RetrieveAttribute(nil, bucket, storageKey, versionIdKey)
 		if errors.Is(err, fs.ErrNotExist) {
 			return nil, s3err.GetAPIError(s3err.ErrNoSuchKey)
 		}
@@ -3955,11 +4774,11 @@ func (p *Posix) GetObjectRetention(_ context.Context, bucket, object, versionId
 
 		if string(vId) != versionId {
 			bucket = filepath.Join(p.versioningDir, bucket)
-			object = filepath.Join(genObjVersionKey(object), versionId)
+			storageKey = filepath.Join(genObjVersionKey(object), versionId)
 		}
 	}
 
-	data, err := p.meta.RetrieveAttribute(nil, bucket, object, objectRetentionKey)
+	data, err := p.meta.RetrieveAttribute(nil, bucket, storageKey, objectRetentionKey)
 	if errors.Is(err, fs.ErrNotExist) {
 		if versionId != "" {
 			return nil, s3err.GetAPIError(s3err.ErrInvalidVersionId)
@@ -4022,6 +4841,80 @@ func (p *Posix) ListBucketsAndOwners(ctx context.Context) (buckets []s3response.
 	return buckets, nil
 }
 
+// GetBucketStats walks the bucket and sums up the number and
+// the total size of the current object versions
+func (p *Posix) GetBucketStats(ctx context.Context, bucket string) (s3response.BucketStats, error) {
+	_, err := os.Stat(bucket)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s3response.BucketStats{}, s3err.GetAPIError(s3err.ErrNoSuchBucket)
+	}
+	if err != nil {
+		return s3response.BucketStats{}, fmt.Errorf("stat bucket: %w", err)
+	}
+
+	// the tracker isn't used with versioning, as the object
+	// versions and delete markers are not tracked
+	if !p.versioningEnabled() {
+		if stats, ok := p.usage.get(bucket); ok {
+			return stats, nil
+		}
+		p.usage.startLoad(bucket)
+	}
+
+	stats := s3response.BucketStats{Bucket: bucket}
+	getObj := p.fileToObj(bucket)
+	fileSystem := os.DirFS(bucket)
+
+	err = fs.WalkDir(fileSystem, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if path == "." {
+			return nil
+		}
+		if d.IsDir() && d.Name() == metaTmpDir {
+			return fs.SkipDir
+		}
+		if d.IsDir() {
+			// directory object only happens if directory empty,
+			// aside from a nested file object
+			ents, err := fs.ReadDir(fileSystem, path)
+			if err != nil {
+				return fmt.Errorf("readdir %q: %w", path, err)
+			}
+			if len(ents) > 1 || len(ents) == 1 && ents[0].Name() != backend.NestedFileObj {
+				return nil
+			}
+			path += "/"
+		}
+
+		obj, err := getObj(path, d)
+		if err == backend.ErrSkipObj {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("get object %q: %w", path, err)
+		}
+
+		stats.ObjectCount++
+		stats.TotalSize += *obj.Size
+		return nil
+	})
+	if err != nil {
+		p.usage.abortLoad(bucket)
+		return s3response.BucketStats{}, fmt.Errorf("walk %v: %w", bucket, err)
+	}
+
+	if !p.versioningEnabled() {
+		p.usage.finishLoad(stats)
+	}
+
+	return stats, nil
+}
+
 func getString(str *string) string {
 	if str == nil {
 		return ""
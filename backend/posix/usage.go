@@ -0,0 +1,224 @@
+// Copyright 2023 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package posix
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/versity/versitygw/s3response"
+)
+
+// usageTracker keeps the per bucket object count and total size
+// up to date on the object writes and removals, so that the bucket
+// stats don't require walking the whole bucket on every request.
+// A bucket is loaded with a single walk on the first stats request.
+// The buckets are dropped from the tracker on the changes, whose
+// effect on the stats can't be computed cheaply (e.g. directory
+// objects), and loaded again on the next stats request.
+// The loaded buckets are walked again after usageRevalidateInterval
+// to pick up the changes made directly on the filesystem.
+type usageTracker struct {
+	sync.Mutex
+	buckets map[string]*bucketUsage
+}
+
+type bucketUsage struct {
+	// loading is set while the bucket is being walked, any change
+	// during the walk makes the walk result stale
+	loading bool
+	stale   bool
+	loaded  time.Time
+	count   int64
+	size    int64
+}
+
+const usageRevalidateInterval = 5 * time.Minute
+
+func newUsageTracker() *usageTracker {
+	return &usageTracker{
+		buckets: make(map[string]*bucketUsage),
+	}
+}
+
+// get returns the tracked bucket stats, if the bucket is loaded
+func (t *usageTracker) get(bucket string) (s3response.BucketStats, bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	u, ok := t.buckets[bucket]
+	if !ok || u.loading {
+		return s3response.BucketStats{}, false
+	}
+	if time.Since(u.loaded) > usageRevalidateInterval {
+		delete(t.buckets, bucket)
+		return s3response.BucketStats{}, false
+	}
+	return s3response.BucketStats{
+		Bucket:      bucket,
+		ObjectCount: u.count,
+		TotalSize:   u.size,
+	}, true
+}
+
+// startLoad marks the bucket as being walked
+func (t *usageTracker) startLoad(bucket string) {
+	t.Lock()
+	defer t.Unlock()
+
+	if _, ok := t.buckets[bucket]; !ok {
+		// the bucket name might reference the request buffer,
+		// which is reused after the request
+		t.buckets[strings.Clone(bucket)] = &bucketUsage{loading: true}
+	}
+}
+
+// finishLoad stores the walk result, unless the bucket has
+// changed during the walk
+func (t *usageTracker) finishLoad(stats s3response.BucketStats) {
+	t.Lock()
+	defer t.Unlock()
+
+	u, ok := t.buckets[stats.Bucket]
+	if !ok || !u.loading {
+		return
+	}
+	if u.stale {
+		delete(t.buckets, stats.Bucket)
+		return
+	}
+	u.loading = false
+	u.loaded = time.Now()
+	u.count = stats.ObjectCount
+	u.size = stats.TotalSize
+}
+
+// abortLoad removes the bucket, which failed to load
+func (t *usageTracker) abortLoad(bucket string) {
+	t.Lock()
+	defer t.Unlock()
+
+	if u, ok := t.buckets[bucket]; ok && u.loading {
+		delete(t.buckets, bucket)
+	}
+}
+
+// tracked checks if the bucket is loaded or being loaded
+func (t *usageTracker) tracked(bucket string) bool {
+	t.Lock()
+	defer t.Unlock()
+
+	_, ok := t.buckets[bucket]
+	return ok
+}
+
+// update runs the object namespace change fn holding the tracker lock,
+// fn looks up the object it replaces or removes, makes the change and
+// accounts it with addLocked or dropLocked. This way the concurrent
+// writes of the same object and the bucket loads don't interleave with
+// the accounting.
+func (t *usageTracker) update(fn func() error) error {
+	t.Lock()
+	defer t.Unlock()
+
+	return fn()
+}
+
+// addLocked applies the object count and size changes to the loaded
+// bucket, the tracker lock must be held
+func (t *usageTracker) addLocked(bucket string, count, size int64) {
+	u, ok := t.buckets[bucket]
+	if !ok {
+		return
+	}
+	if u.loading {
+		u.stale = true
+		return
+	}
+	u.count += count
+	u.size += size
+}
+
+// drop removes the bucket from the tracker
+func (t *usageTracker) drop(bucket string) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.dropLocked(bucket)
+}
+
+// dropLocked removes the bucket from the tracker,
+// the tracker lock must be held
+func (t *usageTracker) dropLocked(bucket string) {
+	u, ok := t.buckets[bucket]
+	if !ok {
+		return
+	}
+	if u.loading {
+		u.stale = true
+		return
+	}
+	delete(t.buckets, bucket)
+}
+
+// replacedObject looks up the file object at path before it is
+// replaced, it must be called from the usage tracker update
+func replacedObject(path string) (bool, int64) {
+	fi, err := os.Stat(path)
+	if err != nil || fi.IsDir() {
+		return false, 0
+	}
+	return true, fi.Size()
+}
+
+// trackObjectPut updates the bucket usage after a file object was written,
+// existed and oldSize describe the object it has replaced. It must be
+// called from the usage tracker update.
+func (p *Posix) trackObjectPut(bucket string, existed bool, oldSize, size int64) {
+	if existed {
+		p.usage.addLocked(bucket, 0, size-oldSize)
+		return
+	}
+	p.usage.addLocked(bucket, 1, size)
+}
+
+// trackObjectRemove updates the bucket usage after a file object removal,
+// it must be called from the usage tracker update
+func (p *Posix) trackObjectRemove(bucket string, size int64) {
+	p.usage.addLocked(bucket, -1, -size)
+}
+
+// hasDirObjectParent checks if any of the object parent directories
+// is a directory object. The empty directory objects are counted in
+// the bucket stats, so adding or removing files under them changes
+// the object count in a way, which isn't tracked.
+func (p *Posix) hasDirObjectParent(bucket, object string) bool {
+	if !p.usage.tracked(bucket) {
+		return false
+	}
+
+	parent := filepath.Dir(strings.TrimSuffix(object, "/"))
+	for parent != "." && parent != string(filepath.Separator) {
+		_, err := p.meta.RetrieveAttribute(nil, bucket, parent, etagkey)
+		if err == nil {
+			return true
+		}
+		parent = filepath.Dir(parent)
+	}
+	return false
+}
@@ -136,6 +136,17 @@ func (tmp *tmpfile) falloc() error {
 }
 
 func (tmp *tmpfile) link() error {
+	return tmp.linkObject(true)
+}
+
+// linkNoReplace links the temp file into the namespace only if nothing
+// exists yet at the object path. An existing object results in an error
+// matching fs.ErrExist and is left untouched.
+func (tmp *tmpfile) linkNoReplace() error {
+	return tmp.linkObject(false)
+}
+
+func (tmp *tmpfile) linkObject(replace bool) error {
 	// make sure this is cleaned up in all error cases
 	defer tmp.f.Close()
 
@@ -146,21 +157,23 @@ func (tmp *tmpfile) link() error {
 	// of last upload completed wins and is not some combination of writes
 	// from simultaneous uploads.
 	objPath := filepath.Join(tmp.bucket, tmp.objname)
-	err := os.Remove(objPath)
-	if err != nil && !errors.Is(err, fs.ErrNotExist) {
-		return fmt.Errorf("remove stale path: %w", err)
+	if replace {
+		err := os.Remove(objPath)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("remove stale path: %w", err)
+		}
 	}
 
 	dir := filepath.Dir(objPath)
 
-	err = backend.MkdirAll(dir, tmp.uid, tmp.gid, tmp.needsChown, tmp.newDirPerm)
+	err := backend.MkdirAll(dir, tmp.uid, tmp.gid, tmp.needsChown, tmp.newDirPerm)
 	if err != nil {
 		return fmt.Errorf("make parent dir: %w", err)
 	}
 
 	if !tmp.isOTmp {
 		// O_TMPFILE not suported, use fallback
-		return tmp.fallbackLink()
+		return tmp.fallbackLink(replace)
 	}
 
 	procdir, err := os.Open(procfddir)
@@ -178,7 +191,7 @@ func (tmp *tmpfile) link() error {
 	for {
 		err = unix.Linkat(int(procdir.Fd()), filepath.Base(tmp.f.Name()),
 			int(dirf.Fd()), filepath.Base(objPath), unix.AT_SYMLINK_FOLLOW)
-		if errors.Is(err, syscall.EEXIST) {
+		if errors.Is(err, syscall.EEXIST) && replace {
 			err := os.Remove(objPath)
 			if err != nil && !errors.Is(err, fs.ErrNotExist) {
 				return fmt.Errorf("remove stale path: %w", err)
@@ -200,7 +213,7 @@ func (tmp *tmpfile) link() error {
 	return nil
 }
 
-func (tmp *tmpfile) fallbackLink() error {
+func (tmp *tmpfile) fallbackLink(replace bool) error {
 	tempname := tmp.f.Name()
 	// cleanup in case anything goes wrong, if rename succeeds then
 	// this will no longer exist
@@ -215,6 +228,16 @@ func (tmp *tmpfile) fallbackLink() error {
 	}
 
 	objPath := filepath.Join(tmp.bucket, tmp.objname)
+	if !replace {
+		// rename would silently replace an existing object, so link the
+		// temp file to fail on an existing name instead
+		err = os.Link(tempname, objPath)
+		if err != nil {
+			return fmt.Errorf("link tmpfile: %w", err)
+		}
+		return nil
+	}
+
 	err = os.Rename(tempname, objPath)
 	if err != nil {
 		return fmt.Errorf("rename tmpfile: %w", err)
@@ -67,6 +67,17 @@ var (
 )
 
 func (tmp *tmpfile) link() error {
+	return tmp.linkObject(true)
+}
+
+// linkNoReplace links the temp file into the namespace only if nothing
+// exists yet at the object path. An existing object results in an error
+// matching fs.ErrExist and is left untouched.
+func (tmp *tmpfile) linkNoReplace() error {
+	return tmp.linkObject(false)
+}
+
+func (tmp *tmpfile) linkObject(replace bool) error {
 	tempname := tmp.f.Name()
 	// cleanup in case anything goes wrong, if rename succeeds then
 	// this will no longer exist
@@ -78,19 +89,31 @@ func (tmp *tmpfile) link() error {
 	// the object. This ensures the object semantics of last upload completed
 	// wins and is not some combination of writes from simultaneous uploads.
 	objPath := filepath.Join(tmp.bucket, tmp.objname)
-	err := os.Remove(objPath)
-	if err != nil && !errors.Is(err, fs.ErrNotExist) {
-		return fmt.Errorf("remove stale path: %w", err)
+	if replace {
+		err := os.Remove(objPath)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("remove stale path: %w", err)
+		}
 	}
 
 	// reset default file mode because CreateTemp uses 0600
 	tmp.f.Chmod(defaultFilePerm)
 
-	err = tmp.f.Close()
+	err := tmp.f.Close()
 	if err != nil {
 		return fmt.Errorf("close tmpfile: %w", err)
 	}
 
+	if !replace {
+		// rename would silently replace an existing object, so link the
+		// temp file to fail on an existing name instead
+		err = os.Link(tempname, objPath)
+		if err != nil {
+			return fmt.Errorf("link tmpfile: %w", err)
+		}
+		return nil
+	}
+
 	err = os.Rename(tempname, objPath)
 	if err != nil {
 		return fmt.Errorf("rename tmpfile: %w", err)
@@ -409,6 +409,9 @@ func (s *S3Proxy) GetObjectAttributes(ctx context.Context, input *s3.GetObjectAt
 			if objParts.MaxParts != nil {
 				parts.MaxParts = int(*objParts.MaxParts)
 			}
+			if objParts.TotalPartsCount != nil {
+				parts.TotalPartsCount = int(*objParts.TotalPartsCount)
+			}
 			parts.Parts = objParts.Parts
 		}
 	}
@@ -420,6 +423,7 @@ func (s *S3Proxy) GetObjectAttributes(ctx context.Context, input *s3.GetObjectAt
 		StorageClass: out.StorageClass,
 		VersionId:    out.VersionId,
 		ObjectParts:  &parts,
+		Checksum:     out.Checksum,
 	}, handleError(err)
 }
 
@@ -24,6 +24,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -89,10 +90,13 @@ const (
 	metaHdr             = "X-Amz-Meta"
 	contentTypeHdr      = "content-type"
 	contentEncHdr       = "content-encoding"
+	cacheControlHdr     = "cache-control"
+	contentDispHdr      = "content-disposition"
 	emptyMD5            = "d41d8cd98f00b204e9800998ecf8427e"
 	etagkey             = "etag"
 	objectRetentionKey  = "object-retention"
 	objectLegalHoldKey  = "object-legal-hold"
+	partSizeKey         = "part-size"
 )
 
 var (
@@ -164,6 +168,8 @@ func (s *ScoutFS) CompleteMultipartUpload(ctx context.Context, input *s3.Complet
 	if !ok {
 		acct = auth.Account{}
 	}
+	// "If-None-Match: *" requests must not replace an existing object
+	createOnly := input.IfNoneMatch != nil && *input.IfNoneMatch == "*"
 
 	if input.Bucket == nil {
 		return nil, s3err.GetAPIError(s3err.ErrInvalidBucketName)
@@ -202,10 +208,16 @@ func (s *ScoutFS) CompleteMultipartUpload(ctx context.Context, input *s3.Complet
 	last := len(parts) - 1
 	partsize := int64(0)
 	var totalsize int64
+	var prevPartNumber int32
 	for i, part := range parts {
 		if part.PartNumber == nil || *part.PartNumber < 1 {
 			return nil, s3err.GetAPIError(s3err.ErrInvalidPart)
 		}
+		// parts must be listed in ascending part number order
+		if *part.PartNumber <= prevPartNumber {
+			return nil, s3err.GetAPIError(s3err.ErrInvalidPartOrder)
+		}
+		prevPartNumber = *part.PartNumber
 
 		partObjPath := filepath.Join(objdir, uploadID, fmt.Sprintf("%v", *part.PartNumber))
 		fullPartPath := filepath.Join(bucket, partObjPath)
@@ -218,6 +230,11 @@ func (s *ScoutFS) CompleteMultipartUpload(ctx context.Context, input *s3.Complet
 			partsize = fi.Size()
 		}
 
+		// all parts except the last need to be at least the minimum size
+		if i < last && fi.Size() < backend.MinPartSize {
+			return nil, s3err.GetAPIError(s3err.ErrEntityTooSmall)
+		}
+
 		// partsize must be a multiple of the filesystem blocksize
 		// except for last part
 		if i < last && partsize%fsBlocksize != 0 {
@@ -242,7 +259,18 @@ func (s *ScoutFS) CompleteMultipartUpload(ctx context.Context, input *s3.Complet
 
 	// use totalsize=0 because we wont be writing to the file, only moving
 	// extents around.  so we dont want to fallocate this.
-	f, err := s.openTmpFile(filepath.Join(bucket, metaTmpDir), bucket, object, 0, acct)
+	storageKey := backend.StorageKey(bucket, object)
+
+	if createOnly {
+		// fail early before moving the part data, the final link
+		// below still guards against a racing put of the same key
+		_, err := os.Lstat(filepath.Join(bucket, storageKey))
+		if err == nil {
+			return nil, s3err.GetAPIError(s3err.ErrPreconditionFailed)
+		}
+	}
+
+	f, err := s.openTmpFile(filepath.Join(bucket, metaTmpDir), bucket, storageKey, 0, acct)
 	if err != nil {
 		if errors.Is(err, syscall.EDQUOT) {
 			return nil, s3err.GetAPIError(s3err.ErrQuotaExceeded)
@@ -277,7 +305,7 @@ func (s *ScoutFS) CompleteMultipartUpload(ctx context.Context, input *s3.Complet
 	upiddir := filepath.Join(objdir, uploadID)
 	cType, _ := s.loadUserMetaData(bucket, upiddir, userMetaData)
 
-	objname := filepath.Join(bucket, object)
+	objname := filepath.Join(bucket, storageKey)
 	dir := filepath.Dir(objname)
 	if dir != "" {
 		uid, gid, doChown := s.getChownIDs(acct)
@@ -288,7 +316,7 @@ func (s *ScoutFS) CompleteMultipartUpload(ctx context.Context, input *s3.Complet
 	}
 
 	for k, v := range userMetaData {
-		err = s.meta.StoreAttribute(f.File(), bucket, object, fmt.Sprintf("%v.%v", metaHdr, k), []byte(v))
+		err = s.meta.StoreAttribute(f.File(), bucket, storageKey, fmt.Sprintf("%v.%v", metaHdr, k), []byte(v))
 		if err != nil {
 			return nil, fmt.Errorf("set user attr %q: %w", k, err)
 		}
@@ -300,7 +328,7 @@ func (s *ScoutFS) CompleteMultipartUpload(ctx context.Context, input *s3.Complet
 		return nil, fmt.Errorf("get object tagging: %w", err)
 	}
 	if err == nil {
-		err := s.meta.StoreAttribute(f.File(), bucket, object, tagHdr, tagging)
+		err := s.meta.StoreAttribute(f.File(), bucket, storageKey, tagHdr, tagging)
 		if err != nil {
 			return nil, fmt.Errorf("set object tagging: %w", err)
 		}
@@ -308,7 +336,7 @@ func (s *ScoutFS) CompleteMultipartUpload(ctx context.Context, input *s3.Complet
 
 	// set content-type
 	if cType != "" {
-		err := s.meta.StoreAttribute(f.File(), bucket, object, contentTypeHdr, []byte(cType))
+		err := s.meta.StoreAttribute(f.File(), bucket, storageKey, contentTypeHdr, []byte(cType))
 		if err != nil {
 			return nil, fmt.Errorf("set object content type: %w", err)
 		}
@@ -320,7 +348,7 @@ func (s *ScoutFS) CompleteMultipartUpload(ctx context.Context, input *s3.Complet
 		return nil, fmt.Errorf("get object legal hold: %w", err)
 	}
 	if err == nil {
-		err := s.meta.StoreAttribute(f.File(), bucket, object, objectLegalHoldKey, lHold)
+		err := s.meta.StoreAttribute(f.File(), bucket, storageKey, objectLegalHoldKey, lHold)
 		if err != nil {
 			return nil, fmt.Errorf("set object legal hold: %w", err)
 		}
@@ -332,7 +360,7 @@ func (s *ScoutFS) CompleteMultipartUpload(ctx context.Context, input *s3.Complet
 		return nil, fmt.Errorf("get object retention: %w", err)
 	}
 	if err == nil {
-		err := s.meta.StoreAttribute(f.File(), bucket, object, objectRetentionKey, ret)
+		err := s.meta.StoreAttribute(f.File(), bucket, storageKey, objectRetentionKey, ret)
 		if err != nil {
 			return nil, fmt.Errorf("set object retention: %w", err)
 		}
@@ -341,12 +369,27 @@ func (s *ScoutFS) CompleteMultipartUpload(ctx context.Context, input *s3.Complet
 	// Calculate s3 compatible md5sum for complete multipart.
 	s3MD5 := backend.GetMultipartMD5(parts)
 
-	err = s.meta.StoreAttribute(f.File(), bucket, object, etagkey, []byte(s3MD5))
+	err = s.meta.StoreAttribute(f.File(), bucket, storageKey, etagkey, []byte(s3MD5))
 	if err != nil {
 		return nil, fmt.Errorf("set etag attr: %w", err)
 	}
 
-	err = f.link()
+	// all parts but the last are the same size, keep the size
+	// to be able to list the object parts
+	err = s.meta.StoreAttribute(f.File(), bucket, storageKey, partSizeKey,
+		[]byte(strconv.FormatInt(partsize, 10)))
+	if err != nil {
+		return nil, fmt.Errorf("set part size attr: %w", err)
+	}
+
+	if createOnly {
+		err = f.linkNoReplace()
+	} else {
+		err = f.link()
+	}
+	if createOnly && errors.Is(err, fs.ErrExist) {
+		return nil, s3err.GetAPIError(s3err.ErrPreconditionFailed)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("link object in namespace: %w", err)
 	}
@@ -416,6 +459,14 @@ func (s *ScoutFS) loadUserMetaData(bucket, object string, m map[string]string) (
 	return contentType, contentEncoding
 }
 
+// loadContentHeaders returns the cache-control and content-disposition
+// headers stored with the object
+func (s *ScoutFS) loadContentHeaders(bucket, object string) (string, string) {
+	cacheControl, _ := s.meta.RetrieveAttribute(nil, bucket, object, cacheControlHdr)
+	contentDisposition, _ := s.meta.RetrieveAttribute(nil, bucket, object, contentDispHdr)
+	return string(cacheControl), string(contentDisposition)
+}
+
 func isValidMeta(val string) bool {
 	if strings.HasPrefix(val, metaHdr) {
 		return true
@@ -426,6 +477,15 @@ func isValidMeta(val string) bool {
 	return false
 }
 
+// PutObject rejects the SSE-C uploads, as the scoutfs
+// object reads don't decrypt the object data
+func (s *ScoutFS) PutObject(ctx context.Context, input *s3.PutObjectInput) (s3response.PutObjectOutput, error) {
+	if input.SSECustomerKey != nil {
+		return s3response.PutObjectOutput{}, s3err.GetAPIError(s3err.ErrNotImplemented)
+	}
+	return s.Posix.PutObject(ctx, input)
+}
+
 func (s *ScoutFS) HeadObject(ctx context.Context, input *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
 	if input.Bucket == nil {
 		return nil, s3err.GetAPIError(s3err.ErrInvalidBucketName)
@@ -433,6 +493,9 @@ func (s *ScoutFS) HeadObject(ctx context.Context, input *s3.HeadObjectInput) (*s
 	if input.Key == nil {
 		return nil, s3err.GetAPIError(s3err.ErrNoSuchKey)
 	}
+	if input.SSECustomerKey != nil {
+		return nil, s3err.GetAPIError(s3err.ErrSSECustomerKeyNotApplicable)
+	}
 	bucket := *input.Bucket
 	object := *input.Key
 
@@ -487,6 +550,7 @@ func (s *ScoutFS) HeadObject(ctx context.Context, input *s3.HeadObjectInput) (*s
 		return nil, fmt.Errorf("stat bucket: %w", err)
 	}
 
+	object = backend.StorageKey(bucket, object)
 	objPath := filepath.Join(bucket, object)
 
 	fi, err := os.Stat(objPath)
@@ -502,9 +566,18 @@ func (s *ScoutFS) HeadObject(ctx context.Context, input *s3.HeadObjectInput) (*s
 	if strings.HasSuffix(object, "/") && !fi.IsDir() {
 		return nil, s3err.GetAPIError(s3err.ErrNoSuchKey)
 	}
+	if fi.IsDir() {
+		// the parent directories of the nested objects aren't
+		// directory objects, only the explicitly put ones have etag
+		_, err := s.meta.RetrieveAttribute(nil, bucket, object, etagkey)
+		if errors.Is(err, meta.ErrNoSuchKey) {
+			return nil, s3err.GetAPIError(s3err.ErrNoSuchKey)
+		}
+	}
 
 	userMetaData := make(map[string]string)
 	contentType, contentEncoding := s.loadUserMetaData(bucket, object, userMetaData)
+	cacheControl, contentDisposition := s.loadContentHeaders(bucket, object)
 
 	if fi.IsDir() {
 		// this is the media type for directories in AWS and Nextcloud
@@ -549,6 +622,10 @@ func (s *ScoutFS) HeadObject(ctx context.Context, input *s3.HeadObjectInput) (*s
 	}
 
 	contentLength := fi.Size()
+	if fi.IsDir() {
+		// directory objects are always 0 len
+		contentLength = 0
+	}
 
 	var objectLockLegalHoldStatus types.ObjectLockLegalHoldStatus
 	status, err := s.Posix.GetObjectLegalHold(ctx, bucket, object, *input.VersionId)
@@ -575,6 +652,8 @@ func (s *ScoutFS) HeadObject(ctx context.Context, input *s3.HeadObjectInput) (*s
 		ContentLength:             &contentLength,
 		ContentType:               &contentType,
 		ContentEncoding:           &contentEncoding,
+		CacheControl:              &cacheControl,
+		ContentDisposition:        &contentDisposition,
 		ETag:                      &etag,
 		LastModified:              backend.GetTimePtr(fi.ModTime()),
 		Metadata:                  userMetaData,
@@ -603,6 +682,10 @@ func (s *ScoutFS) GetObject(_ context.Context, input *s3.GetObjectInput) (*s3.Ge
 	object := *input.Key
 	acceptRange := *input.Range
 
+	if input.SSECustomerKey != nil {
+		return nil, s3err.GetAPIError(s3err.ErrSSECustomerKeyNotApplicable)
+	}
+
 	_, err := os.Stat(bucket)
 	if errors.Is(err, fs.ErrNotExist) {
 		return nil, s3err.GetAPIError(s3err.ErrNoSuchBucket)
@@ -611,6 +694,7 @@ func (s *ScoutFS) GetObject(_ context.Context, input *s3.GetObjectInput) (*s3.Ge
 		return nil, fmt.Errorf("stat bucket: %w", err)
 	}
 
+	object = backend.StorageKey(bucket, object)
 	objPath := filepath.Join(bucket, object)
 
 	fi, err := os.Stat(objPath)
@@ -627,25 +711,26 @@ func (s *ScoutFS) GetObject(_ context.Context, input *s3.GetObjectInput) (*s3.Ge
 	if strings.HasSuffix(object, "/") && !fi.IsDir() {
 		return nil, s3err.GetAPIError(s3err.ErrNoSuchKey)
 	}
-
-	startOffset, length, err := backend.ParseRange(fi.Size(), acceptRange)
-	if err != nil {
-		return nil, err
+	if fi.IsDir() {
+		// the parent directories of the nested objects aren't
+		// directory objects, only the explicitly put ones have etag
+		_, err := s.meta.RetrieveAttribute(nil, bucket, object, etagkey)
+		if errors.Is(err, meta.ErrNoSuchKey) {
+			return nil, s3err.GetAPIError(s3err.ErrNoSuchKey)
+		}
 	}
 
 	objSize := fi.Size()
 	if fi.IsDir() {
 		// directory objects are always 0 len
 		objSize = 0
-		length = 0
 	}
 
-	if length == -1 {
-		length = fi.Size() - startOffset + 1
-	}
-
-	if startOffset+length > fi.Size() {
-		return nil, s3err.GetAPIError(s3err.ErrInvalidRequest)
+	startOffset, length, err := backend.ParseGetObjectRange(objSize, acceptRange)
+	if err != nil {
+		return &s3.GetObjectOutput{
+			ContentRange: backend.GetStringPtr(backend.UnsatisfiableContentRange(objSize)),
+		}, err
 	}
 
 	var contentRange string
@@ -681,6 +766,7 @@ func (s *ScoutFS) GetObject(_ context.Context, input *s3.GetObjectInput) (*s3.Ge
 	userMetaData := make(map[string]string)
 
 	contentType, contentEncoding := s.loadUserMetaData(bucket, object, userMetaData)
+	cacheControl, contentDisposition := s.loadContentHeaders(bucket, object)
 
 	b, err := s.meta.RetrieveAttribute(nil, bucket, object, etagkey)
 	etag := string(b)
@@ -696,17 +782,19 @@ func (s *ScoutFS) GetObject(_ context.Context, input *s3.GetObjectInput) (*s3.Ge
 	tagCount := int32(len(tags))
 
 	return &s3.GetObjectOutput{
-		AcceptRanges:    &acceptRange,
-		ContentLength:   &length,
-		ContentEncoding: &contentEncoding,
-		ContentType:     &contentType,
-		ETag:            &etag,
-		LastModified:    backend.GetTimePtr(fi.ModTime()),
-		Metadata:        userMetaData,
-		TagCount:        &tagCount,
-		StorageClass:    types.StorageClassStandard,
-		ContentRange:    &contentRange,
-		Body:            &backend.FileSectionReadCloser{R: rdr, F: f},
+		AcceptRanges:       &acceptRange,
+		ContentLength:      &length,
+		ContentEncoding:    &contentEncoding,
+		ContentType:        &contentType,
+		CacheControl:       &cacheControl,
+		ContentDisposition: &contentDisposition,
+		ETag:               &etag,
+		LastModified:       backend.GetTimePtr(fi.ModTime()),
+		Metadata:           userMetaData,
+		TagCount:           &tagCount,
+		StorageClass:       types.StorageClassStandard,
+		ContentRange:       &contentRange,
+		Body:               &backend.FileSectionReadCloser{R: rdr, F: f},
 	}, nil
 }
 
@@ -833,7 +921,8 @@ func (s *ScoutFS) ListObjectsV2(ctx context.Context, input *s3.ListObjectsV2Inpu
 
 func (s *ScoutFS) fileToObj(bucket string) backend.GetObjFunc {
 	return func(path string, d fs.DirEntry) (s3response.Object, error) {
-		objPath := filepath.Join(bucket, path)
+		storageKey := backend.EntryStorageKey(path, d)
+		objPath := filepath.Join(bucket, storageKey)
 		if d.IsDir() {
 			// directory object only happens if directory empty
 			// check to see if this is a directory object by checking etag
@@ -866,7 +955,7 @@ func (s *ScoutFS) fileToObj(bucket string) backend.GetObjFunc {
 		}
 
 		// file object, get object info and fill out object data
-		b, err := s.meta.RetrieveAttribute(nil, bucket, path, etagkey)
+		b, err := s.meta.RetrieveAttribute(nil, bucket, storageKey, etagkey)
 		if errors.Is(err, fs.ErrNotExist) {
 			return s3response.Object{}, backend.ErrSkipObj
 		}
@@ -929,7 +1018,7 @@ func (s *ScoutFS) RestoreObject(_ context.Context, input *s3.RestoreObjectInput)
 		return fmt.Errorf("stat bucket: %w", err)
 	}
 
-	err = setStaging(filepath.Join(bucket, object))
+	err = setStaging(filepath.Join(bucket, backend.StorageKey(bucket, object)))
 	if errors.Is(err, fs.ErrNotExist) {
 		return s3err.GetAPIError(s3err.ErrNoSuchKey)
 	}
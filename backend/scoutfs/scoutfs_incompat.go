@@ -47,6 +47,10 @@ func (tmp *tmpfile) link() error {
 	return errNotSupported
 }
 
+func (tmp *tmpfile) linkNoReplace() error {
+	return errNotSupported
+}
+
 func (tmp *tmpfile) Write(b []byte) (int, error) {
 	return 0, errNotSupported
 }
@@ -0,0 +1,105 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package backend
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/versity/versitygw/s3err"
+)
+
+const (
+	// SSECAlgorithm is the only supported server side
+	// encryption with customer provided keys (SSE-C) algorithm
+	SSECAlgorithm = "AES256"
+
+	// SSECKeySize is the size of the decoded SSE-C key
+	SSECKeySize = 32
+)
+
+// DecodeSSECustomerKey decodes the base64 encoded SSE-C key
+func DecodeSSECustomerKey(key string) ([]byte, error) {
+	b, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || len(b) != SSECKeySize {
+		return nil, s3err.GetAPIError(s3err.ErrInvalidSSECustomerKey)
+	}
+	return b, nil
+}
+
+// NewSSECIV generates a random initialization vector
+// for the new SSE-C encrypted object data
+func NewSSECIV() ([]byte, error) {
+	iv := make([]byte, aes.BlockSize)
+	_, err := rand.Read(iv)
+	if err != nil {
+		return nil, fmt.Errorf("generate iv: %w", err)
+	}
+	return iv, nil
+}
+
+// SSECKeyHMAC returns the keyed hash stored along with the encrypted
+// object, which is used to verify the key of the following requests
+// without storing the key itself
+func SSECKeyHMAC(key, iv []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(iv)
+	return mac.Sum(nil)
+}
+
+// VerifySSECustomerKey checks the SSE-C key against the stored keyed hash
+func VerifySSECustomerKey(key, iv, keyHMAC []byte) error {
+	if !hmac.Equal(SSECKeyHMAC(key, iv), keyHMAC) {
+		return s3err.GetAPIError(s3err.ErrAccessDenied)
+	}
+	return nil
+}
+
+// NewSSECReader returns a reader encrypting or decrypting the data read
+// from r with AES-256 in the counter mode. The offset is the position of
+// the first byte read from r within the object data, which allows
+// decrypting the object ranges.
+func NewSSECReader(r io.Reader, key, iv []byte, offset int64) (io.Reader, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, s3err.GetAPIError(s3err.ErrInvalidSSECustomerKey)
+	}
+
+	// advance the counter to the block containing the offset
+	ctr := new(big.Int).SetBytes(iv)
+	ctr.Add(ctr, big.NewInt(offset/aes.BlockSize))
+	ctrBytes := ctr.Bytes()
+	if len(ctrBytes) > aes.BlockSize {
+		// the counter wraps around
+		ctrBytes = ctrBytes[len(ctrBytes)-aes.BlockSize:]
+	}
+	blockIV := make([]byte, aes.BlockSize)
+	copy(blockIV[aes.BlockSize-len(ctrBytes):], ctrBytes)
+
+	stream := cipher.NewCTR(block, blockIV)
+
+	// skip the key stream bytes before the offset within the block
+	skip := make([]byte, offset%aes.BlockSize)
+	stream.XORKeyStream(skip, skip)
+
+	return &cipher.StreamReader{S: stream, R: r}, nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package backend_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/versity/versitygw/backend"
+	"github.com/versity/versitygw/s3err"
+)
+
+func TestSSECReader(t *testing.T) {
+	key := make([]byte, backend.SSECKeySize)
+	rand.Read(key)
+	// the max iv makes the counter wrap around
+	iv := bytes.Repeat([]byte{0xff}, 16)
+
+	data := make([]byte, 1000)
+	rand.Read(data)
+
+	rdr, err := backend.NewSSECReader(bytes.NewReader(data), key, iv, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := io.ReadAll(rdr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(encrypted, data) {
+		t.Fatal("data isn't encrypted")
+	}
+
+	for _, rng := range [][2]int64{{0, 1000}, {1, 10}, {16, 32}, {17, 500}, {999, 1}} {
+		start, length := rng[0], rng[1]
+		rdr, err := backend.NewSSECReader(
+			bytes.NewReader(encrypted[start:start+length]), key, iv, start)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decrypted, err := io.ReadAll(rdr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(decrypted, data[start:start+length]) {
+			t.Errorf("range %v-%v: decrypted data mismatch", start, start+length-1)
+		}
+	}
+}
+
+func TestVerifySSECustomerKey(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, backend.SSECKeySize)
+	wrongKey := bytes.Repeat([]byte{2}, backend.SSECKeySize)
+	iv, err := backend.NewSSECIV()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyHMAC := backend.SSECKeyHMAC(key, iv)
+
+	if err := backend.VerifySSECustomerKey(key, iv, keyHMAC); err != nil {
+		t.Errorf("expected the key to match, got %v", err)
+	}
+	err = backend.VerifySSECustomerKey(wrongKey, iv, keyHMAC)
+	if !errors.Is(err, s3err.GetAPIError(s3err.ErrAccessDenied)) {
+		t.Errorf("expected %v, got %v", s3err.GetAPIError(s3err.ErrAccessDenied), err)
+	}
+}
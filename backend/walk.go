@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"path"
 	"sort"
 	"strings"
 
@@ -50,6 +51,7 @@ func Walk(ctx context.Context, fileSystem fs.FS, prefix, delimiter, marker strin
 
 	pastMax := max == 0
 	var newMarker string
+	var lastKey string
 	var truncated bool
 
 	root := "."
@@ -60,7 +62,7 @@ func Walk(ctx context.Context, fileSystem fs.FS, prefix, delimiter, marker strin
 		}
 	}
 
-	err := fs.WalkDir(fileSystem, root, func(path string, d fs.DirEntry, err error) error {
+	err := fs.WalkDir(keyOrderFS{fileSystem}, root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -76,8 +78,8 @@ func Walk(ctx context.Context, fileSystem fs.FS, prefix, delimiter, marker strin
 		}
 
 		if pastMax {
-			if len(objects) != 0 {
-				newMarker = *objects[len(objects)-1].Key
+			if lastKey != "" {
+				newMarker = lastKey
 				truncated = true
 			}
 			return fs.SkipAll
@@ -101,13 +103,22 @@ func Walk(ctx context.Context, fileSystem fs.FS, prefix, delimiter, marker strin
 			if delimiter == "/" &&
 				prefix != path+"/" &&
 				strings.HasPrefix(path+"/", prefix) {
-				cpmap[path+"/"] = struct{}{}
+				cpref := path + "/"
+				if marker != "" && cpref <= marker {
+					// the common prefix was listed before the marker
+					return fs.SkipDir
+				}
+				cpmap[cpref] = struct{}{}
+				lastKey = cpref
+				if max > 0 && (len(objects)+len(cpmap)) == int(max) {
+					pastMax = true
+				}
 				return fs.SkipDir
 			}
 
 			// TODO: can we do better here rather than a second readdir
 			// per directory?
-			ents, err := fs.ReadDir(fileSystem, path)
+			ents, err := fs.ReadDir(keyOrderFS{fileSystem}, path)
 			if err != nil {
 				return fmt.Errorf("readdir %q: %w", path, err)
 			}
@@ -123,6 +134,7 @@ func Walk(ctx context.Context, fileSystem fs.FS, prefix, delimiter, marker strin
 					return fmt.Errorf("directory to object %q: %w", path, err)
 				}
 				objects = append(objects, dirobj)
+				lastKey = path
 
 				return nil
 			}
@@ -158,6 +170,7 @@ func Walk(ctx context.Context, fileSystem fs.FS, prefix, delimiter, marker strin
 				return fmt.Errorf("file to object %q: %w", path, err)
 			}
 			objects = append(objects, obj)
+			lastKey = path
 
 			if max > 0 && (len(objects)+len(cpmap)) == int(max) {
 				pastMax = true
@@ -198,6 +211,7 @@ func Walk(ctx context.Context, fileSystem fs.FS, prefix, delimiter, marker strin
 				return fmt.Errorf("file to object %q: %w", path, err)
 			}
 			objects = append(objects, obj)
+			lastKey = path
 			if (len(objects) + len(cpmap)) == int(max) {
 				pastMax = true
 			}
@@ -220,10 +234,9 @@ func Walk(ctx context.Context, fileSystem fs.FS, prefix, delimiter, marker strin
 		}
 
 		cpmap[cpref] = struct{}{}
+		lastKey = cpref
 		if (len(objects) + len(cpmap)) == int(max) {
-			newMarker = cpref
-			truncated = true
-			return fs.SkipAll
+			pastMax = true
 		}
 
 		return nil
@@ -257,6 +270,46 @@ func Walk(ctx context.Context, fileSystem fs.FS, prefix, delimiter, marker strin
 	}, nil
 }
 
+// keyOrderFS lists the directory entries in the order of the object keys
+// they map to. A directory name is followed by the delimiter in the keys,
+// so the "a.txt" object sorts before the "a/" directory, while the file
+// names sort the other way around.
+// A file object nested within the directory of the same name is listed
+// next to that directory under its own key instead of within it.
+type keyOrderFS struct {
+	fs.FS
+}
+
+func (k keyOrderFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	ents, err := fs.ReadDir(k.FS, name)
+	keyEnts := make([]fs.DirEntry, 0, len(ents))
+	for _, ent := range ents {
+		if !ent.IsDir() {
+			if ent.Name() != NestedFileObj {
+				keyEnts = append(keyEnts, ent)
+			}
+			continue
+		}
+
+		keyEnts = append(keyEnts, ent)
+		fi, err := fs.Stat(k.FS, path.Join(name, ent.Name(), NestedFileObj))
+		if err == nil && !fi.IsDir() {
+			keyEnts = append(keyEnts, nestedFileEntry{name: ent.Name(), info: fi})
+		}
+	}
+	sort.SliceStable(keyEnts, func(i, j int) bool {
+		return entryKey(keyEnts[i]) < entryKey(keyEnts[j])
+	})
+	return keyEnts, err
+}
+
+func entryKey(d fs.DirEntry) string {
+	if d.IsDir() {
+		return d.Name() + "/"
+	}
+	return d.Name()
+}
+
 func contains(a string, strs []string) bool {
 	for _, s := range strs {
 		if s == a {
@@ -301,7 +354,7 @@ func WalkVersions(ctx context.Context, fileSystem fs.FS, prefix, delimiter, keyM
 
 	pastVersionIdMarker := versionIdMarker == ""
 
-	err := fs.WalkDir(fileSystem, ".", func(path string, d fs.DirEntry, err error) error {
+	err := fs.WalkDir(keyOrderFS{fileSystem}, ".", func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
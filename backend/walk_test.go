@@ -123,6 +123,49 @@ func TestWalk(t *testing.T) {
 	}
 }
 
+func TestWalkMarkerPagination(t *testing.T) {
+	fsys := fstest.MapFS{
+		"dir1/subdir/file.txt": {},
+		"dir1/subdir.ext":      {},
+		"dir1/subdir1.ext":     {},
+		"dir1/subdir2.ext":     {},
+	}
+
+	// "dir1/subdir.ext" sorts before the "dir1/subdir/" common prefix,
+	// the pages must follow the key order
+	pages := []struct {
+		marker   string
+		expected backend.WalkResults
+	}{
+		{"", backend.WalkResults{
+			CommonPrefixes: []types.CommonPrefix{{Prefix: backend.GetStringPtr("dir1/subdir/")}},
+			Objects:        []s3response.Object{{Key: backend.GetStringPtr("dir1/subdir.ext")}},
+			Truncated:      true,
+			NextMarker:     "dir1/subdir/",
+		}},
+		{"dir1/subdir/", backend.WalkResults{
+			Objects: []s3response.Object{
+				{Key: backend.GetStringPtr("dir1/subdir1.ext")},
+				{Key: backend.GetStringPtr("dir1/subdir2.ext")},
+			},
+		}},
+	}
+
+	for _, page := range pages {
+		res, err := backend.Walk(context.Background(), fsys, "dir1/", "/", page.marker, 2, getObj, []string{})
+		if err != nil {
+			t.Fatalf("walk: %v", err)
+		}
+
+		compareResults(res, page.expected, t)
+		if res.Truncated != page.expected.Truncated || res.NextMarker != page.expected.NextMarker {
+			t.Errorf("marker %q: expected truncated %v next marker %q, got %v %q",
+				page.marker, page.expected.Truncated, page.expected.NextMarker,
+				res.Truncated, res.NextMarker)
+		}
+	}
+}
+
 func compareResults(got, wanted backend.WalkResults, t *testing.T) {
 	if !compareCommonPrefix(got.CommonPrefixes, wanted.CommonPrefixes) {
 		t.Errorf("unexpected common prefix, got %v wanted %v",
@@ -23,7 +23,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"sort"
 	"text/tabwriter"
 	"time"
 
@@ -31,6 +33,7 @@ import (
 	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/urfave/cli/v2"
 	"github.com/versity/versitygw/auth"
+	"github.com/versity/versitygw/metrics"
 	"github.com/versity/versitygw/s3response"
 )
 
@@ -153,6 +156,24 @@ func adminCommand() *cli.Command {
 				Usage:  "Lists all the gateway buckets and owners.",
 				Action: listBuckets,
 			},
+			{
+				Name:  "bucket-stats",
+				Usage: "Shows the object count and the total size of the bucket",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "bucket",
+						Usage:    "the bucket name to get the stats of",
+						Required: true,
+						Aliases:  []string{"b"},
+					},
+				},
+				Action: getBucketStats,
+			},
+			{
+				Name:   "latency-summary",
+				Usage:  "Shows the p50/p95/p99 request latencies by action.",
+				Action: getLatencySummary,
+			},
 		},
 		Flags: []cli.Flag{
 			// TODO: create a configuration file for this
@@ -477,6 +498,58 @@ func changeBucketOwner(ctx *cli.Context) error {
 	return nil
 }
 
+func getBucketStats(ctx *cli.Context) error {
+	bucket := ctx.String("bucket")
+	req, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("%v/bucket-stats?bucket=%v", adminEndpoint, url.QueryEscape(bucket)), nil)
+	if err != nil {
+		return fmt.Errorf("failed to send the request: %w", err)
+	}
+
+	signer := v4.NewSigner()
+
+	hashedPayload := sha256.Sum256([]byte{})
+	hexPayload := hex.EncodeToString(hashedPayload[:])
+
+	req.Header.Set("X-Amz-Content-Sha256", hexPayload)
+
+	signErr := signer.SignHTTP(req.Context(), aws.Credentials{AccessKeyID: adminAccess, SecretAccessKey: adminSecret}, req, hexPayload, "s3", adminRegion, time.Now())
+	if signErr != nil {
+		return fmt.Errorf("failed to sign the request: %w", signErr)
+	}
+
+	client := initHTTPClient()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send the request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("%s", body)
+	}
+
+	var stats s3response.BucketStats
+	if err := json.Unmarshal(body, &stats); err != nil {
+		return err
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintln(w, "Bucket\tObjects\tSize")
+	fmt.Fprintln(w, "------\t-------\t----")
+	fmt.Fprintf(w, "%v\t%v\t%v\n", stats.Bucket, stats.ObjectCount, stats.TotalSize)
+	fmt.Fprintln(w)
+	w.Flush()
+
+	return nil
+}
+
 func printBuckets(buckets []s3response.Bucket) {
 	w := new(tabwriter.Writer)
 	w.Init(os.Stdout, minwidth, tabwidth, padding, padchar, flags)
@@ -533,3 +606,63 @@ func listBuckets(ctx *cli.Context) error {
 
 	return nil
 }
+
+func getLatencySummary(ctx *cli.Context) error {
+	req, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("%v/latency-summary", adminEndpoint), nil)
+	if err != nil {
+		return fmt.Errorf("failed to send the request: %w", err)
+	}
+
+	signer := v4.NewSigner()
+
+	hashedPayload := sha256.Sum256([]byte{})
+	hexPayload := hex.EncodeToString(hashedPayload[:])
+
+	req.Header.Set("X-Amz-Content-Sha256", hexPayload)
+
+	signErr := signer.SignHTTP(req.Context(), aws.Credentials{AccessKeyID: adminAccess, SecretAccessKey: adminSecret}, req, hexPayload, "s3", adminRegion, time.Now())
+	if signErr != nil {
+		return fmt.Errorf("failed to sign the request: %w", err)
+	}
+
+	client := initHTTPClient()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send the request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("%s", body)
+	}
+
+	var summary map[string]metrics.LatencySummary
+	if err := json.Unmarshal(body, &summary); err != nil {
+		return err
+	}
+
+	actions := make([]string, 0, len(summary))
+	for action := range summary {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintln(w, "Action\tCount\tP50\tP95\tP99")
+	fmt.Fprintln(w, "------\t-----\t---\t---\t---")
+	for _, action := range actions {
+		s := summary[action]
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", action, s.Count, s.P50, s.P95, s.P99)
+	}
+	fmt.Fprintln(w)
+	w.Flush()
+
+	return nil
+}
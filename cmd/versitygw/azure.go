@@ -23,6 +23,7 @@ import (
 
 var (
 	azAccount, azKey, azServiceURL, azSASToken string
+	azMD5ETags                                 bool
 )
 
 func azureCommand() *cli.Command {
@@ -60,12 +61,18 @@ func azureCommand() *cli.Command {
 				Aliases:     []string{"u"},
 				Destination: &azServiceURL,
 			},
+			&cli.BoolFlag{
+				Name:        "md5-etags",
+				Usage:       "return the md5 of the object data as the ETag (multipart \"-N\" form for multipart uploads) instead of the azure blob ETag",
+				EnvVars:     []string{"AZ_MD5_ETAGS"},
+				Destination: &azMD5ETags,
+			},
 		},
 	}
 }
 
 func runAzure(ctx *cli.Context) error {
-	be, err := azure.New(azAccount, azKey, azServiceURL, azSASToken)
+	be, err := azure.New(azAccount, azKey, azServiceURL, azSASToken, azMD5ETags)
 	if err != nil {
 		return fmt.Errorf("init azure: %w", err)
 	}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+	"github.com/versity/versitygw/backend/faultinject"
+	"github.com/versity/versitygw/backend/meta"
+	"github.com/versity/versitygw/backend/posix"
+	"github.com/versity/versitygw/tests/integration"
+)
+
+const (
+	faultdir = "faultdir"
+)
+
+func TestFaultInjection_PutObject_ENOSPC(t *testing.T) {
+	// the backend errors are collected from a local statsd server
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	path, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tempdir := filepath.Join(path, faultdir)
+	initEnv(tempdir)
+	port = "127.0.0.1:7072"
+	endpoint = "http://127.0.0.1:7072"
+	statsdServers = conn.LocalAddr().String()
+	defer func() { statsdServers = "" }()
+	statsdSampleRate = 1
+
+	err = os.RemoveAll(tempdir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Mkdir(tempdir, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempdir)
+
+	// posix changes the working directory to the backend root,
+	// restore it before removing the backend root
+	defer os.Chdir(path)
+	pbe, err := posix.New(tempdir, meta.XattrMeta{}, posix.PosixOpts{
+		NewDirPerm: 0755,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	be := faultinject.New(pbe)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- runGateway(ctx, be)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// wait for server to start
+	time.Sleep(1 * time.Second)
+
+	client := integration.NewS3Conf(
+		integration.WithAccess(awsID),
+		integration.WithSecret(awsSecret),
+		integration.WithRegion(region),
+		integration.WithEndpoint(endpoint),
+	).GetClient()
+
+	bucket, obj := "fault-bucket", "my-obj"
+	reqCtx, reqCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer reqCancel()
+
+	_, err = client.CreateBucket(reqCtx, &s3.CreateBucketInput{Bucket: &bucket})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// fail the backend write in the middle of the object data
+	be.Inject(faultinject.OpPutObject, faultinject.Fault{
+		Err:        syscall.ENOSPC,
+		AfterBytes: 100,
+	})
+
+	_, err = client.PutObject(reqCtx, &s3.PutObjectInput{
+		Bucket: &bucket,
+		Key:    &obj,
+		Body:   bytes.NewReader(make([]byte, 1024)),
+	})
+	var ae smithy.APIError
+	if !errors.As(err, &ae) {
+		t.Fatalf("expected the put object to fail with an api error, instead got %v", err)
+	}
+	if ae.ErrorCode() != "InternalError" && ae.ErrorCode() != "InsufficientStorage" {
+		t.Errorf("expected the put object error code to be InternalError, instead got %v", ae.ErrorCode())
+	}
+
+	be.Clear(faultinject.OpPutObject)
+
+	// the failed upload must not leave a partial object
+	out, err := client.ListObjectsV2(reqCtx, &s3.ListObjectsV2Input{Bucket: &bucket})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Contents) != 0 {
+		t.Errorf("expected no objects after the failed put, instead got %v", len(out.Contents))
+	}
+
+	buf := make([]byte, 65536)
+	for {
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := conn.ReadFrom(buf)
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			t.Fatal("expected the backend_error_count metric for the failed put")
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(buf[:n]), "backend_error_count") {
+			break
+		}
+	}
+}
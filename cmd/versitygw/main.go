@@ -24,6 +24,7 @@ import (
 	_ "net/http/pprof"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/urfave/cli/v2"
@@ -54,6 +55,7 @@ var (
 	pprof                                    string
 	quiet                                    bool
 	readonly                                 bool
+	maxReads, maxWrites                      int
 	iamDir                                   string
 	ldapURL, ldapBindDN, ldapPassword        string
 	ldapQueryBase, ldapObjClasses            string
@@ -72,8 +74,21 @@ var (
 	iamCacheTTL                              int
 	iamCachePrune                            int
 	metricsService                           string
+	metricsPrefix                            string
+	metricsMaxPacketSize                     int
 	statsdServers                            string
 	dogstatsServers                          string
+	prometheusAddr                           string
+	otlpEndpoint                             string
+	graphiteServers                          string
+	metricsDebug                             bool
+	statsdSampleRate                         float64
+	metricsFlushInterval                     time.Duration
+	metricsBufferSize                        int
+	metricsBucketTags                        bool
+	metricsMaxBucketTags                     int
+	metricsEMF                               bool
+	influxURL, influxToken                   string
 )
 
 var (
@@ -485,6 +500,18 @@ func initFlags() []cli.Flag {
 			EnvVars:     []string{"VGW_READ_ONLY"},
 			Destination: &readonly,
 		},
+		&cli.IntFlag{
+			Name:        "max-concurrent-reads",
+			Usage:       "max number of concurrent object read requests, 0 for unlimited",
+			EnvVars:     []string{"VGW_MAX_CONCURRENT_READS"},
+			Destination: &maxReads,
+		},
+		&cli.IntFlag{
+			Name:        "max-concurrent-writes",
+			Usage:       "max number of concurrent object write requests, 0 for unlimited",
+			EnvVars:     []string{"VGW_MAX_CONCURRENT_WRITES"},
+			Destination: &maxWrites,
+		},
 		&cli.StringFlag{
 			Name:        "metrics-service-name",
 			Usage:       "service name tag for metrics, hostname if blank",
@@ -492,20 +519,107 @@ func initFlags() []cli.Flag {
 			Aliases:     []string{"msn"},
 			Destination: &metricsService,
 		},
+		&cli.StringFlag{
+			Name:        "metrics-prefix",
+			Usage:       "prefix prepended to all of the metric keys within the versitygw namespace, e.g. 'prod' results in 'versitygw.prod.success_count'",
+			EnvVars:     []string{"VGW_METRICS_PREFIX"},
+			Destination: &metricsPrefix,
+		},
+		&cli.IntFlag{
+			Name:        "metrics-max-packet-size",
+			Usage:       "max size in bytes of the statsd/dogstatsd UDP datagrams packing multiple metrics",
+			EnvVars:     []string{"VGW_METRICS_MAX_PACKET_SIZE"},
+			Value:       1432,
+			Destination: &metricsMaxPacketSize,
+		},
 		&cli.StringFlag{
 			Name:        "metrics-statsd-servers",
-			Usage:       "StatsD server urls comma separated. e.g. 'statsd1.example.com:8125,statsd2.example.com:8125'",
+			Usage:       "StatsD server urls comma separated, prefix with 'statsd+tcp://' for TCP transport. e.g. 'statsd1.example.com:8125,statsd+tcp://statsd2.example.com:8125'",
 			EnvVars:     []string{"VGW_METRICS_STATSD_SERVERS"},
 			Aliases:     []string{"mss"},
 			Destination: &statsdServers,
 		},
+		&cli.Float64Flag{
+			Name:        "metrics-statsd-sample-rate",
+			Usage:       "StatsD and DogStatsD sampling rate between 0 and 1, 0 disables the sends",
+			EnvVars:     []string{"VGW_METRICS_STATSD_SAMPLE_RATE"},
+			Value:       1,
+			Destination: &statsdSampleRate,
+		},
 		&cli.StringFlag{
 			Name:        "metrics-dogstatsd-servers",
-			Usage:       "DogStatsD server urls comma separated. e.g. '127.0.0.1:8125,dogstats.example.com:8125'",
+			Usage:       "DogStatsD server urls comma separated, prefix with 'dogstatsd+tcp://' for TCP transport. e.g. '127.0.0.1:8125,dogstatsd+tcp://dogstats.example.com:8125'",
 			EnvVars:     []string{"VGW_METRICS_DOGSTATS_SERVERS"},
 			Aliases:     []string{"mds"},
 			Destination: &dogstatsServers,
 		},
+		&cli.StringFlag{
+			Name:        "metrics-prometheus-listen",
+			Usage:       "listen address to serve the Prometheus metrics on the /metrics path, e.g. ':9100'",
+			EnvVars:     []string{"VGW_METRICS_PROMETHEUS_LISTEN"},
+			Destination: &prometheusAddr,
+		},
+		&cli.StringFlag{
+			Name:        "metrics-otlp-endpoint",
+			Usage:       "OTLP/HTTP collector url to export the metrics to, e.g. 'http://127.0.0.1:4318'",
+			EnvVars:     []string{"VGW_METRICS_OTLP_ENDPOINT"},
+			Destination: &otlpEndpoint,
+		},
+		&cli.StringFlag{
+			Name:        "metrics-graphite-servers",
+			Usage:       "Graphite carbon server addresses comma separated, for the plaintext protocol. e.g. 'carbon1.example.com:2003,carbon2.example.com:2003'",
+			EnvVars:     []string{"VGW_METRICS_GRAPHITE_SERVERS"},
+			Destination: &graphiteServers,
+		},
+		&cli.StringFlag{
+			Name:        "metrics-influx-url",
+			Usage:       "InfluxDB write endpoint url, e.g. 'http://influxdb:8086/api/v2/write?org=org&bucket=bucket'",
+			EnvVars:     []string{"VGW_METRICS_INFLUX_URL"},
+			Destination: &influxURL,
+		},
+		&cli.StringFlag{
+			Name:        "metrics-influx-token",
+			Usage:       "InfluxDB API token",
+			EnvVars:     []string{"VGW_METRICS_INFLUX_TOKEN"},
+			Destination: &influxToken,
+		},
+		&cli.BoolFlag{
+			Name:        "metrics-emf",
+			Usage:       "write the metrics to stdout in the CloudWatch embedded metric format",
+			EnvVars:     []string{"VGW_METRICS_EMF"},
+			Destination: &metricsEMF,
+		},
+		&cli.DurationFlag{
+			Name:        "metrics-flush-interval",
+			Usage:       "interval of sending the metrics summed up, e.g. '10s', each datapoint is sent right away if zero",
+			EnvVars:     []string{"VGW_METRICS_FLUSH_INTERVAL"},
+			Destination: &metricsFlushInterval,
+		},
+		&cli.IntFlag{
+			Name:        "metrics-buffer-size",
+			Usage:       "max number of the metrics datapoints buffered before dropping new ones",
+			EnvVars:     []string{"VGW_METRICS_BUFFER_SIZE"},
+			Value:       100000,
+			Destination: &metricsBufferSize,
+		},
+		&cli.BoolFlag{
+			Name:        "metrics-bucket-tags",
+			Usage:       "add the bucket tag to the request metrics for the per bucket accounting",
+			EnvVars:     []string{"VGW_METRICS_BUCKET_TAGS"},
+			Destination: &metricsBucketTags,
+		},
+		&cli.IntFlag{
+			Name:        "metrics-max-bucket-tags",
+			Usage:       "max number of the distinct bucket tag values, the other buckets are tagged as '_other', unlimited if zero",
+			EnvVars:     []string{"VGW_METRICS_MAX_BUCKET_TAGS"},
+			Destination: &metricsMaxBucketTags,
+		},
+		&cli.BoolFlag{
+			Name:        "metrics-debug",
+			Usage:       "print all of the metrics datapoints to stdout",
+			EnvVars:     []string{"VGW_METRICS_DEBUG"},
+			Destination: &metricsDebug,
+		},
 	}
 }
 
@@ -562,6 +676,12 @@ func runGateway(ctx context.Context, be backend.Backend) error {
 	if readonly {
 		opts = append(opts, s3api.WithReadOnly())
 	}
+	if maxReads < 0 || maxWrites < 0 {
+		return fmt.Errorf("max concurrent reads/writes can't be negative")
+	}
+	if maxReads > 0 || maxWrites > 0 {
+		opts = append(opts, s3api.WithConcurrencyLimits(maxReads, maxWrites))
+	}
 
 	admApp := fiber.New(fiber.Config{
 		AppName:               "versitygw",
@@ -638,9 +758,23 @@ func runGateway(ctx context.Context, be backend.Backend) error {
 	}
 
 	metricsManager, err := metrics.NewManager(ctx, metrics.Config{
-		ServiceName:      metricsService,
-		StatsdServers:    statsdServers,
-		DogStatsdServers: dogstatsServers,
+		ServiceName:             metricsService,
+		MetricPrefix:            metricsPrefix,
+		MaxPacketSize:           metricsMaxPacketSize,
+		StatsdServers:           statsdServers,
+		DogStatsdServers:        dogstatsServers,
+		StatsdSampleRate:        statsdSampleRate,
+		PrometheusListenAddr:    prometheusAddr,
+		OTLPEndpoint:            otlpEndpoint,
+		GraphiteServers:         graphiteServers,
+		FlushInterval:           metricsFlushInterval,
+		BufferSize:              metricsBufferSize,
+		BucketTags:              metricsBucketTags,
+		MaxBucketTagCardinality: metricsMaxBucketTags,
+		InfluxURL:               influxURL,
+		InfluxToken:             influxToken,
+		EMFEnabled:              metricsEMF,
+		DebugMetrics:            metricsDebug,
 	})
 	if err != nil {
 		return fmt.Errorf("init metrics manager: %w", err)
@@ -667,7 +801,7 @@ func runGateway(ctx context.Context, be backend.Backend) error {
 		return fmt.Errorf("init gateway: %v", err)
 	}
 
-	admSrv := s3api.NewAdminServer(admApp, be, middlewares.RootUserConfig{Access: rootUserAccess, Secret: rootUserSecret}, admPort, region, iam, loggers.AdminLogger, admOpts...)
+	admSrv := s3api.NewAdminServer(admApp, be, middlewares.RootUserConfig{Access: rootUserAccess, Secret: rootUserSecret}, admPort, region, iam, loggers.AdminLogger, metricsManager, admOpts...)
 
 	if !quiet {
 		printBanner(port, admPort, certFile != "", admCertFile != "")
@@ -746,7 +880,13 @@ Loop:
 	}
 
 	if metricsManager != nil {
-		metricsManager.Close()
+		err := metricsManager.CloseWithTimeout(10 * time.Second)
+		if err != nil {
+			if saveErr == nil {
+				saveErr = err
+			}
+			fmt.Fprintf(os.Stderr, "close metrics: %v\n", err)
+		}
 	}
 
 	return saveErr
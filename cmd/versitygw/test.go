@@ -16,6 +16,8 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/urfave/cli/v2"
 	"github.com/versity/versitygw/tests/integration"
@@ -38,6 +40,17 @@ var (
 	checksumDisable   bool
 	versioningEnabled bool
 	azureTests        bool
+	md5ETags          bool
+	failFast          bool
+	smoke             bool
+	testMaxWrites     int
+	largeObjSize      int64
+	opTimeout         time.Duration
+	attempts          int
+	benchObjects      int
+	benchWorkers      int
+	timings           bool
+	outputFormat      string
 )
 
 func testCommand() *cli.Command {
@@ -79,17 +92,52 @@ func initTestFlags() []cli.Flag {
 			Aliases:     []string{"d"},
 			Destination: &debug,
 		},
+		&cli.DurationFlag{
+			Name:        "op-timeout",
+			Usage:       "timeout of each test request, raise for slow backends",
+			Value:       10 * time.Second,
+			Destination: &opTimeout,
+		},
+		&cli.IntFlag{
+			Name:        "attempts",
+			Usage:       "times to try the checks that depend on read-after-write visibility, 1 for strongly consistent backends",
+			Value:       3,
+			Destination: &attempts,
+		},
+		&cli.BoolFlag{
+			Name:        "timings",
+			Usage:       "print the P50/P90/P99 latency of each S3 operation after the run",
+			Destination: &timings,
+		},
+		&cli.StringFlag{
+			Name:        "output",
+			Usage:       "test results format: text or json, json writes the results to stdout and the progress to stderr",
+			Value:       "text",
+			Destination: &outputFormat,
+		},
 	}
 }
 
 func initTestCommands() []*cli.Command {
 	return append([]*cli.Command{
 		{
-			Name:        "full-flow",
-			Usage:       "Tests the full flow of gateway.",
-			Description: `Runs all the available tests to test the full flow of the gateway.`,
-			Action:      getAction(integration.TestFullFlow),
+			Name:  "full-flow",
+			Usage: "Tests the full flow of gateway.",
+			Description: `Runs all the available tests to test the full flow of the gateway.
+			With --smoke only a minimal liveness check is run: create a bucket,
+			put/get/delete an object and delete the bucket.`,
+			Action: func(ctx *cli.Context) error {
+				if smoke {
+					return getAction(integration.TestSmoke)(ctx)
+				}
+				return getAction(integration.TestFullFlow)(ctx)
+			},
 			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:        "smoke",
+					Usage:       "Run a fast smoke test subset instead of the full flow",
+					Destination: &smoke,
+				},
 				&cli.BoolFlag{
 					Name:        "versioning-enabled",
 					Usage:       "Test the bucket object versioning, if the versioning is enabled",
@@ -102,6 +150,16 @@ func initTestCommands() []*cli.Command {
 					Destination: &azureTests,
 					Aliases:     []string{"azure"},
 				},
+				&cli.BoolFlag{
+					Name:        "md5-etags",
+					Usage:       "Runs the md5 ETag tests in the Azure test mode, if the gateway runs with azure --md5-etags",
+					Destination: &md5ETags,
+				},
+				&cli.BoolFlag{
+					Name:        "fail-fast",
+					Usage:       "Stop running the tests after the first failure",
+					Destination: &failFast,
+				},
 			},
 		},
 		{
@@ -117,6 +175,37 @@ func initTestCommands() []*cli.Command {
 				},
 			},
 		},
+		{
+			Name:  "concurrency-limits",
+			Usage: "Tests the gateway object request concurrency limits",
+			Description: `Drives more concurrent uploads than the gateway write limit.
+			The gateway should be started with the same --max-writes value.`,
+			Action: getAction(integration.TestConcurrencyLimits),
+			Flags: []cli.Flag{
+				&cli.IntFlag{
+					Name:        "max-writes",
+					Usage:       "The gateway max concurrent object write requests",
+					Required:    true,
+					Destination: &testMaxWrites,
+				},
+			},
+		},
+		{
+			Name:  "large-object-streaming",
+			Usage: "Tests streaming a large object in and out of the gateway",
+			Description: `Uploads a generated object with multipart upload and downloads it
+			with a single GetObject, verifying the checksum on the fly. The object
+			is never held in memory by the client. Reports the throughput.`,
+			Action: getAction(integration.TestLargeObjectStreaming),
+			Flags: []cli.Flag{
+				&cli.Int64Flag{
+					Name:        "size",
+					Usage:       "The object size in bytes",
+					Value:       1024 * 1024 * 1024,
+					Destination: &largeObjSize,
+				},
+			},
+		},
 		{
 			Name:   "iam",
 			Usage:  "Tests iam service",
@@ -231,6 +320,81 @@ func initTestCommands() []*cli.Command {
 				}
 			},
 		},
+		{
+			Name:  "object-bench",
+			Usage: "Runs PutObject/GetObject throughput benchmark on the gateway",
+			Description: `Puts the number of objects of the size specified with flags into the
+			bucket and then gets them back. Reports MB/s, ops/s and the min/avg/max
+			request latency of each.`,
+			Flags: []cli.Flag{
+				&cli.IntFlag{
+					Name:        "objects",
+					Usage:       "Number of objects to put and get",
+					Value:       100,
+					Destination: &benchObjects,
+				},
+				&cli.Int64Flag{
+					Name:        "objsize",
+					Usage:       "Object size in bytes",
+					Value:       1024 * 1024,
+					Destination: &objSize,
+				},
+				&cli.IntFlag{
+					Name:        "workers",
+					Usage:       "Number of objects put/get concurrently",
+					Value:       1,
+					Destination: &benchWorkers,
+				},
+				&cli.StringFlag{
+					Name:        "bucket",
+					Usage:       "Destination bucket name to read/write data",
+					Destination: &dstBucket,
+				},
+				&cli.StringFlag{
+					Name:        "prefix",
+					Usage:       "Object name prefix",
+					Value:       "bench-",
+					Destination: &prefix,
+				},
+				&cli.Int64Flag{
+					Name:        "partSize",
+					Usage:       "Upload/download size per thread",
+					Value:       64 * 1024 * 1024,
+					Destination: &partSize,
+				},
+				&cli.IntFlag{
+					Name:        "concurrency",
+					Usage:       "Upload/download threads per object",
+					Value:       1,
+					Destination: &concurrency,
+				},
+			},
+			Action: func(ctx *cli.Context) error {
+				if dstBucket == "" {
+					return fmt.Errorf("must specify bucket")
+				}
+
+				opts := []integration.Option{
+					integration.WithAccess(awsID),
+					integration.WithSecret(awsSecret),
+					integration.WithRegion(region),
+					integration.WithEndpoint(endpoint),
+					integration.WithConcurrency(concurrency),
+					integration.WithPartSize(partSize),
+				}
+				if debug {
+					opts = append(opts, integration.WithDebug())
+				}
+
+				s3conf := integration.NewS3Conf(opts...)
+
+				err := integration.BenchmarkPutObject(s3conf, benchObjects, benchWorkers, objSize, dstBucket, prefix)
+				if err != nil {
+					return err
+				}
+				return integration.BenchmarkGetObject(s3conf, benchObjects, benchWorkers, dstBucket, prefix)
+			},
+		},
 		{
 			Name:        "throughput",
 			Usage:       "Runs throughput performance test on the gateway",
@@ -296,6 +460,8 @@ func getAction(tf testFunc) func(*cli.Context) error {
 			integration.WithSecret(awsSecret),
 			integration.WithRegion(region),
 			integration.WithEndpoint(endpoint),
+			integration.WithOpTimeout(opTimeout),
+			integration.WithAttempts(attempts),
 		}
 		if debug {
 			opts = append(opts, integration.WithDebug())
@@ -306,12 +472,36 @@ func getAction(tf testFunc) func(*cli.Context) error {
 		if azureTests {
 			opts = append(opts, integration.WithAzureMode())
 		}
+		if md5ETags {
+			opts = append(opts, integration.WithMD5ETags())
+		}
+		if testMaxWrites > 0 {
+			opts = append(opts, integration.WithMaxWrites(testMaxWrites))
+		}
+		if largeObjSize > 0 {
+			opts = append(opts, integration.WithLargeObjectSize(largeObjSize))
+		}
+		if timings {
+			opts = append(opts, integration.WithTimings())
+		}
+
+		if err := setupOutput(); err != nil {
+			return err
+		}
 
 		s := integration.NewS3Conf(opts...)
-		tf(s)
+		integration.FailFast = failFast
+		err := integration.RunTests(s, tf)
 
-		fmt.Println()
-		fmt.Println("RAN:", integration.RunCount, "PASS:", integration.PassCount, "FAIL:", integration.FailCount)
+		fmt.Fprintln(integration.LogOutput)
+		fmt.Fprintln(integration.LogOutput, "RAN:", integration.RunCount, "PASS:", integration.PassCount, "FAIL:", integration.FailCount)
+		s.PrintTimings(integration.LogOutput)
+		if err := writeResults(); err != nil {
+			return err
+		}
+		if err != nil {
+			return err
+		}
 		if integration.FailCount > 0 {
 			return fmt.Errorf("test failed with %v errors", integration.FailCount)
 		}
@@ -319,6 +509,26 @@ func getAction(tf testFunc) func(*cli.Context) error {
 	}
 }
 
+// setupOutput checks the output format and sends the progress of the
+// run to stderr when the results are written to stdout as JSON
+func setupOutput() error {
+	switch outputFormat {
+	case "text":
+	case "json":
+		integration.LogOutput = os.Stderr
+	default:
+		return fmt.Errorf("invalid output format %q, must be text or json", outputFormat)
+	}
+	return nil
+}
+
+func writeResults() error {
+	if outputFormat != "json" {
+		return nil
+	}
+	return integration.WriteResults(os.Stdout)
+}
+
 func extractIntTests() (commands []*cli.Command) {
 	tests := integration.GetIntTests()
 	for key, val := range tests {
@@ -333,6 +543,8 @@ func extractIntTests() (commands []*cli.Command) {
 					integration.WithSecret(awsSecret),
 					integration.WithRegion(region),
 					integration.WithEndpoint(endpoint),
+					integration.WithOpTimeout(opTimeout),
+					integration.WithAttempts(attempts),
 				}
 				if debug {
 					opts = append(opts, integration.WithDebug())
@@ -340,9 +552,20 @@ func extractIntTests() (commands []*cli.Command) {
 				if versioningEnabled {
 					opts = append(opts, integration.WithVersioningEnabled())
 				}
+				if timings {
+					opts = append(opts, integration.WithTimings())
+				}
+
+				if err := setupOutput(); err != nil {
+					return err
+				}
 
 				s := integration.NewS3Conf(opts...)
-				err := testFunc(s)
+				err := integration.RunTest(s, testFunc)
+				s.PrintTimings(integration.LogOutput)
+				if err := writeResults(); err != nil {
+					return err
+				}
 				return err
 			},
 			Flags: []cli.Flag{
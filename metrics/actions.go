@@ -47,6 +47,7 @@ var (
 	ActionGetObjectLockConfiguration    = "s3_GetObjectLockConfiguration"
 	ActionGetObjectRetention            = "s3_GetObjectRetention"
 	ActionGetObjectTagging              = "s3_GetObjectTagging"
+	ActionGetObjectTorrent              = "s3_GetObjectTorrent"
 	ActionHeadBucket                    = "s3_HeadBucket"
 	ActionHeadObject                    = "s3_HeadObject"
 	ActionListAllMyBuckets              = "s3_ListAllMyBuckets"
@@ -72,6 +73,9 @@ var (
 	ActionPutBucketOwnershipControls    = "s3_PutBucketOwnershipControls"
 	ActionGetBucketOwnershipControls    = "s3_GetBucketOwnershipControls"
 	ActionDeleteBucketOwnershipControls = "s3_DeleteBucketOwnershipControls"
+	ActionPutBucketAccelerate           = "s3_PutBucketAccelerateConfiguration"
+	ActionGetBucketAccelerate           = "s3_GetBucketAccelerateConfiguration"
+	ActionGetBucketLocation             = "s3_GetBucketLocation"
 )
 
 func init() {
@@ -170,6 +174,10 @@ func init() {
 		Name:    "GetObjectTagging",
 		Service: "s3",
 	}
+	ActionMap[ActionGetObjectTorrent] = Action{
+		Name:    "GetObjectTorrent",
+		Service: "s3",
+	}
 	ActionMap[ActionHeadBucket] = Action{
 		Name:    "HeadBucket",
 		Service: "s3",
@@ -258,4 +266,16 @@ func init() {
 		Name:    "UploadPartCopy",
 		Service: "s3",
 	}
+	ActionMap[ActionPutBucketAccelerate] = Action{
+		Name:    "PutBucketAccelerateConfiguration",
+		Service: "s3",
+	}
+	ActionMap[ActionGetBucketAccelerate] = Action{
+		Name:    "GetBucketAccelerateConfiguration",
+		Service: "s3",
+	}
+	ActionMap[ActionGetBucketLocation] = Action{
+		Name:    "GetBucketLocation",
+		Service: "s3",
+	}
 }
@@ -0,0 +1,68 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"strings"
+)
+
+// aggregator sums up the values of the identical datapoints,
+// with the same key and tags, between the flushes, only the last
+// value is kept for the gauges
+type aggregator struct {
+	index  map[string]int
+	points []datapoint
+}
+
+func newAggregator() *aggregator {
+	return &aggregator{index: make(map[string]int)}
+}
+
+// datapointID returns the key and tags identity of d
+func datapointID(d datapoint) string {
+	var b strings.Builder
+	b.WriteString(d.key)
+	for _, t := range d.tags {
+		b.WriteByte(0)
+		b.WriteString(t.Key)
+		b.WriteByte('=')
+		b.WriteString(t.Value)
+	}
+	return b.String()
+}
+
+func (a *aggregator) add(d datapoint) {
+	id := datapointID(d)
+	if i, ok := a.index[id]; ok {
+		if d.kind == kindGauge {
+			a.points[i].value = d.value
+		} else {
+			a.points[i].value += d.value
+		}
+		return
+	}
+	a.index[id] = len(a.points)
+	a.points = append(a.points, d)
+}
+
+// flush passes the combined datapoints to fn in the order they
+// were first added and resets the aggregator
+func (a *aggregator) flush(fn func(datapoint)) {
+	for _, d := range a.points {
+		fn(d)
+	}
+	clear(a.index)
+	a.points = a.points[:0]
+}
@@ -16,23 +16,25 @@ package metrics
 
 import (
 	"fmt"
+	"time"
 
 	dogstats "github.com/DataDog/datadog-go/v5/statsd"
 )
 
 // vgwDogStatsd metrics type
 type vgwDogStatsd struct {
-	c *dogstats.Client
+	c          *dogstats.Client
+	sampleRate float64
 }
 
-var (
-	rateSampleAlways = 1.0
-)
-
 // newDogStatsd takes a server address and returns a statsd merics
-func newDogStatsd(server string, service string) (*vgwDogStatsd, error) {
+// Multiple metrics are packed into a single payload up to the
+// max packet size. The datapoints are sampled with sampleRate,
+// 0 disables all of the sends and 1 sends everything.
+func newDogStatsd(server string, service string, maxPacketSize int, sampleRate float64) (*vgwDogStatsd, error) {
 	c, err := dogstats.New(server,
 		dogstats.WithMaxMessagesPerPayload(1000),
+		dogstats.WithMaxBytesPerPayload(maxPacketSize),
 		dogstats.WithNamespace("versitygw"),
 		dogstats.WithTags([]string{
 			"service:" + service,
@@ -40,7 +42,7 @@ func newDogStatsd(server string, service string) (*vgwDogStatsd, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &vgwDogStatsd{c: c}, nil
+	return &vgwDogStatsd{c: c, sampleRate: sampleRate}, nil
 }
 
 // Close closes statsd connections
@@ -57,9 +59,40 @@ func (t Tag) ddString() string {
 
 // Add adds value to key
 func (s *vgwDogStatsd) Add(key string, value int64, tags ...Tag) {
+	if s.sampleRate <= 0 {
+		return
+	}
+
+	stags := make([]string, len(tags))
+	for i, t := range tags {
+		stags[i] = t.ddString()
+	}
+	s.c.Count(key, value, stags, s.sampleRate)
+}
+
+// Gauge sets the gauge to value
+func (s *vgwDogStatsd) Gauge(key string, value int64, tags ...Tag) {
+	if s.sampleRate <= 0 {
+		return
+	}
+
+	stags := make([]string, len(tags))
+	for i, t := range tags {
+		stags[i] = t.ddString()
+	}
+	// the gauges are not sampled, each value replaces the previous one
+	s.c.Gauge(key, float64(value), stags, 1)
+}
+
+// Timing sends the duration in milliseconds as a distribution
+func (s *vgwDogStatsd) Timing(key string, d time.Duration, tags ...Tag) {
+	if s.sampleRate <= 0 {
+		return
+	}
+
 	stags := make([]string, len(tags))
 	for i, t := range tags {
 		stags[i] = t.ddString()
 	}
-	s.c.Count(key, value, stags, rateSampleAlways)
+	s.c.Distribution(key, float64(d)/float64(time.Millisecond), stags, s.sampleRate)
 }
@@ -0,0 +1,214 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"sync"
+	"time"
+)
+
+var (
+	// interval of writing the batched EMF records, the CloudWatch
+	// metrics have the one minute resolution by default
+	emfFlushInterval = time.Minute
+)
+
+const (
+	emfNamespace = "versitygw"
+	// max metrics of an EMF record
+	emfMaxMetrics = 100
+)
+
+// vgwEMF metrics type
+type vgwEMF struct {
+	w       io.Writer
+	service string
+
+	mu      sync.Mutex
+	records map[string]*emfRecord
+	order   []string
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// emfRecord is the batch of the datapoints with the same tags
+type emfRecord struct {
+	tags    []Tag
+	keys    []string
+	units   map[string]string
+	values  map[string]int64
+	timings map[string][]float64
+}
+
+// newEMF returns a metrics publisher writing the CloudWatch embedded
+// metric format (EMF) records to w, for the CloudWatch agent or the
+// log driver to forward them to CloudWatch. The datapoints with the
+// same tags are batched into a single record per flush interval, with
+// the tags as the dimensions. Supply service name to be used as a
+// dimension to identify the specific gateway instance, this may
+// typically be the gateway hostname.
+func newEMF(w io.Writer, service string) *vgwEMF {
+	e := &vgwEMF{
+		w:       w,
+		service: service,
+		records: make(map[string]*emfRecord),
+		done:    make(chan struct{}),
+	}
+
+	e.wg.Add(1)
+	go e.flusher()
+
+	return e
+}
+
+func (e *vgwEMF) flusher() {
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(emfFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			e.flush()
+		case <-e.done:
+			return
+		}
+	}
+}
+
+// Close writes the batched datapoints
+func (e *vgwEMF) Close() {
+	close(e.done)
+	e.wg.Wait()
+	e.flush()
+}
+
+// record returns the batch record of the tags, e.mu must be held
+func (e *vgwEMF) record(key string, unit string, tags []Tag) *emfRecord {
+	id := datapointID(datapoint{tags: tags})
+	r, ok := e.records[id]
+	if !ok {
+		r = &emfRecord{
+			tags:    tags,
+			units:   make(map[string]string),
+			values:  make(map[string]int64),
+			timings: make(map[string][]float64),
+		}
+		e.records[id] = r
+		e.order = append(e.order, id)
+	}
+	if _, ok := r.units[key]; !ok {
+		r.units[key] = unit
+		r.keys = append(r.keys, key)
+	}
+	return r
+}
+
+// Add adds value to key
+func (e *vgwEMF) Add(key string, value int64, tags ...Tag) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.record(key, "Count", tags).values[key] += value
+}
+
+// Timing adds the duration in milliseconds to the key values
+func (e *vgwEMF) Timing(key string, d time.Duration, tags ...Tag) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	r := e.record(key, "Milliseconds", tags)
+	r.timings[key] = append(r.timings[key], float64(d)/float64(time.Millisecond))
+}
+
+// Gauge sets the key gauge to value
+func (e *vgwEMF) Gauge(key string, value int64, tags ...Tag) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.record(key, "None", tags).values[key] = value
+}
+
+type emfMetric struct {
+	Name string `json:"Name"`
+	Unit string `json:"Unit"`
+}
+
+type emfDirective struct {
+	Namespace  string      `json:"Namespace"`
+	Dimensions [][]string  `json:"Dimensions"`
+	Metrics    []emfMetric `json:"Metrics"`
+}
+
+type emfMetadata struct {
+	Timestamp         int64          `json:"Timestamp"`
+	CloudWatchMetrics []emfDirective `json:"CloudWatchMetrics"`
+}
+
+// flush writes a record per tags batch and resets the batches
+func (e *vgwEMF) flush() {
+	e.mu.Lock()
+	records, order := e.records, e.order
+	e.records = make(map[string]*emfRecord)
+	e.order = nil
+	e.mu.Unlock()
+
+	now := time.Now().UnixMilli()
+	for _, id := range order {
+		r := records[id]
+
+		dims := []string{"service"}
+		for _, t := range r.tags {
+			dims = append(dims, t.Key)
+		}
+
+		// split the records over the max metrics
+		for start := 0; start < len(r.keys); start += emfMaxMetrics {
+			keys := r.keys[start:min(start+emfMaxMetrics, len(r.keys))]
+
+			root := map[string]any{"service": e.service}
+			for _, t := range r.tags {
+				root[t.Key] = t.Value
+			}
+
+			directive := emfDirective{
+				Namespace:  emfNamespace,
+				Dimensions: [][]string{dims},
+			}
+			for _, key := range keys {
+				directive.Metrics = append(directive.Metrics,
+					emfMetric{Name: key, Unit: r.units[key]})
+				if timings, ok := r.timings[key]; ok {
+					root[key] = timings
+				} else {
+					root[key] = r.values[key]
+				}
+			}
+			root["_aws"] = emfMetadata{
+				Timestamp:         now,
+				CloudWatchMetrics: []emfDirective{directive},
+			}
+
+			b, err := json.Marshal(root)
+			if err != nil {
+				log.Printf("encode EMF record: %v", err)
+				continue
+			}
+			e.w.Write(append(b, '\n'))
+		}
+	}
+}
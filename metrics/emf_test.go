@@ -0,0 +1,93 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEMF_flush(t *testing.T) {
+	var buf bytes.Buffer
+	e := newEMF(&buf, "test")
+
+	tags := []Tag{{Key: "action", Value: "PutObject"}}
+	e.Add("success_count", 1, tags...)
+	e.Add("success_count", 1, tags...)
+	e.Timing("request_latency", 5*time.Millisecond, tags...)
+	e.Timing("request_latency", 15*time.Millisecond, tags...)
+	e.Gauge("in_flight", 3)
+	e.Gauge("in_flight", 2)
+	// the batched datapoints are written on close
+	e.Close()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected a record per tags, instead got:\n%v", buf.String())
+	}
+
+	var record struct {
+		AWS struct {
+			CloudWatchMetrics []struct {
+				Namespace  string
+				Dimensions [][]string
+				Metrics    []emfMetric
+			}
+		} `json:"_aws"`
+		Service        string    `json:"service"`
+		Action         string    `json:"action"`
+		SuccessCount   int64     `json:"success_count"`
+		RequestLatency []float64 `json:"request_latency"`
+	}
+	err := json.Unmarshal([]byte(lines[0]), &record)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(record.AWS.CloudWatchMetrics) != 1 {
+		t.Fatalf("expected a metrics directive, instead got %v", lines[0])
+	}
+	directive := record.AWS.CloudWatchMetrics[0]
+	if directive.Namespace != "versitygw" {
+		t.Errorf("expected the versitygw namespace, instead got %v", directive.Namespace)
+	}
+	if !reflect.DeepEqual(directive.Dimensions, [][]string{{"service", "action"}}) {
+		t.Errorf("expected the service and action dimensions, instead got %v", directive.Dimensions)
+	}
+	expectedMetrics := []emfMetric{
+		{Name: "success_count", Unit: "Count"},
+		{Name: "request_latency", Unit: "Milliseconds"},
+	}
+	if !reflect.DeepEqual(directive.Metrics, expectedMetrics) {
+		t.Errorf("expected the metrics %v, instead got %v", expectedMetrics, directive.Metrics)
+	}
+	if record.Service != "test" || record.Action != "PutObject" {
+		t.Errorf("expected the dimension values, instead got %v", lines[0])
+	}
+	if record.SuccessCount != 2 {
+		t.Errorf("expected the success count 2, instead got %v", record.SuccessCount)
+	}
+	if !reflect.DeepEqual(record.RequestLatency, []float64{5, 15}) {
+		t.Errorf("expected the latencies [5 15], instead got %v", record.RequestLatency)
+	}
+
+	if !strings.Contains(lines[1], `"in_flight":2`) {
+		t.Errorf("expected the last gauge value, instead got %v", lines[1])
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// graphiteBatchSize is the max size of the datapoints batch written at once
+const graphiteBatchSize = 4096
+
+// vgwGraphite metrics type
+type vgwGraphite struct {
+	service string
+	w       *lineWriter
+}
+
+// newGraphite takes a carbon server address and returns a graphite
+// metrics publisher sending the plaintext protocol over a persistent
+// TCP connection, which is reconnected if it drops. Supply service
+// name to be used as the first metric path node to identify the
+// specific gateway instance, this may typically be the gateway hostname.
+func newGraphite(server string, service string) (*vgwGraphite, error) {
+	w, err := newLineWriter("tcp", server, graphiteBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("graphite: %w", err)
+	}
+
+	return &vgwGraphite{
+		// the dots would split the service name into path nodes
+		service: strings.ReplaceAll(service, ".", "_"),
+		w:       w,
+	}, nil
+}
+
+// Close flushes the buffered datapoints and closes the connection
+func (g *vgwGraphite) Close() {
+	g.w.Close()
+}
+
+// Add adds value to key, the tags are sent in the graphite
+// tagged series format, e.g. 'host.success_count;action=PutObject 1 <ts>'
+func (g *vgwGraphite) Add(key string, value int64, tags ...Tag) {
+	g.send(key, strconv.FormatInt(value, 10), tags)
+}
+
+// Timing sends the duration in milliseconds
+func (g *vgwGraphite) Timing(key string, d time.Duration, tags ...Tag) {
+	g.send(key, strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', -1, 64), tags)
+}
+
+// Gauge sends the gauge value
+func (g *vgwGraphite) Gauge(key string, value int64, tags ...Tag) {
+	g.send(key, strconv.FormatInt(value, 10), tags)
+}
+
+func (g *vgwGraphite) send(key, value string, tags []Tag) {
+	var b strings.Builder
+	b.WriteString(g.service)
+	b.WriteByte('.')
+	b.WriteString(key)
+	for _, t := range tags {
+		fmt.Fprintf(&b, ";%v=%v", t.Key, t.Value)
+	}
+	fmt.Fprintf(&b, " %v %v\n", value, time.Now().Unix())
+
+	g.w.writeLine(b.String())
+}
@@ -0,0 +1,94 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGraphite_reconnect(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	conns := make(chan net.Conn, 2)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conns <- conn
+		}
+	}()
+
+	g, err := newGraphite(ln.Addr().String(), "test.host")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	readLine := func(conn net.Conn, r *bufio.Reader) string {
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read datapoint: %v", err)
+		}
+		return line
+	}
+
+	conn := <-conns
+	g.Add("success_count", 1, Tag{Key: "action", Value: "PutObject"})
+
+	// the datapoints are flushed periodically
+	line := readLine(conn, bufio.NewReader(conn))
+	fields := strings.Fields(line)
+	if len(fields) != 3 || fields[0] != "test_host.success_count;action=PutObject" || fields[1] != "1" {
+		t.Fatalf("unexpected datapoint %q", line)
+	}
+
+	// the dropped connection is reopened
+	conn.Close()
+	deadline := time.After(10 * time.Second)
+Reconnect:
+	for {
+		g.Add("success_count", 1)
+		select {
+		case conn = <-conns:
+			break Reconnect
+		case <-deadline:
+			t.Fatal("expected the publisher to reconnect")
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+	defer conn.Close()
+
+	// close flushes the buffered datapoints
+	g.Add("final_count", 1)
+	g.Close()
+
+	r := bufio.NewReader(conn)
+	for {
+		line := readLine(conn, r)
+		if strings.HasPrefix(line, "test_host.final_count ") {
+			break
+		}
+	}
+}
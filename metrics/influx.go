@@ -0,0 +1,224 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// interval of posting the batched datapoints
+	influxFlushInterval = 10 * time.Second
+	// the failed batch writes are retried with the doubled
+	// backoff, until the retries are exhausted
+	influxRetries      = 3
+	influxRetryBackoff = time.Second
+	influxTimeout      = 10 * time.Second
+)
+
+const influxMeasurement = "versitygw"
+
+// influxEscaper escapes the tag keys and values and the field keys
+var influxEscaper = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+
+// vgwInflux metrics type
+type vgwInflux struct {
+	url     string
+	token   string
+	service string
+	client  *http.Client
+	// dropped counts the datapoints of the batches failed to write
+	dropped func(n int64)
+
+	mu      sync.Mutex
+	agg     *aggregator
+	timings []string
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newInflux takes an InfluxDB write endpoint url, e.g.
+// 'http://influxdb:8086/api/v2/write?org=org&bucket=bucket', and
+// returns a metrics publisher posting the datapoints batched per flush
+// interval in the InfluxDB line protocol. The token is sent in the
+// Authorization header if not blank. Supply service name to be used
+// as a tag to identify the specific gateway instance, this may
+// typically be the gateway hostname.
+func newInflux(endpoint, token, service string, dropped func(n int64)) (*vgwInflux, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid InfluxDB url %q, e.g. 'http://127.0.0.1:8086/api/v2/write?org=org&bucket=bucket'", endpoint)
+	}
+
+	i := &vgwInflux{
+		url:     endpoint,
+		token:   token,
+		service: service,
+		client:  &http.Client{Timeout: influxTimeout},
+		dropped: dropped,
+		agg:     newAggregator(),
+		done:    make(chan struct{}),
+	}
+
+	i.wg.Add(1)
+	go i.flusher()
+
+	return i, nil
+}
+
+func (i *vgwInflux) flusher() {
+	defer i.wg.Done()
+
+	ticker := time.NewTicker(influxFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			i.flush()
+		case <-i.done:
+			return
+		}
+	}
+}
+
+// Close posts the batched datapoints
+func (i *vgwInflux) Close() {
+	close(i.done)
+	i.wg.Wait()
+	i.flush()
+}
+
+// Add adds value to key
+func (i *vgwInflux) Add(key string, value int64, tags ...Tag) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.agg.add(datapoint{key: key, value: value, tags: tags})
+}
+
+// Gauge sets the key gauge to value
+func (i *vgwInflux) Gauge(key string, value int64, tags ...Tag) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.agg.add(datapoint{kind: kindGauge, key: key, value: value, tags: tags})
+}
+
+// Timing adds the duration in milliseconds, the timings are not
+// combined and keep the own timestamps
+func (i *vgwInflux) Timing(key string, d time.Duration, tags ...Tag) {
+	line := i.line(key,
+		strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', -1, 64),
+		tags, time.Now())
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.timings = append(i.timings, line)
+}
+
+// line formats the datapoint in the line protocol,
+// e.g. 'versitygw,service=host,action=PutObject success_count=1i <ts>'
+func (i *vgwInflux) line(key, value string, tags []Tag, ts time.Time) string {
+	var b strings.Builder
+	b.WriteString(influxMeasurement)
+	b.WriteString(",service=")
+	b.WriteString(influxEscaper.Replace(i.service))
+	for _, t := range tags {
+		if t.Value == "" {
+			// the empty tag values are not allowed
+			continue
+		}
+		fmt.Fprintf(&b, ",%v=%v", influxEscaper.Replace(t.Key), influxEscaper.Replace(t.Value))
+	}
+	fmt.Fprintf(&b, " %v=%v %v\n", influxEscaper.Replace(key), value, ts.UnixNano())
+	return b.String()
+}
+
+// flush posts the batched datapoints and resets the batch
+func (i *vgwInflux) flush() {
+	now := time.Now()
+
+	var body bytes.Buffer
+	i.mu.Lock()
+	i.agg.flush(func(d datapoint) {
+		body.WriteString(i.line(d.key, strconv.FormatInt(d.value, 10)+"i", d.tags, now))
+	})
+	for _, line := range i.timings {
+		body.WriteString(line)
+	}
+	i.timings = nil
+	i.mu.Unlock()
+
+	if body.Len() == 0 {
+		return
+	}
+
+	backoff := influxRetryBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := i.post(body.Bytes())
+		if err == nil {
+			return
+		}
+		if !retry || attempt >= influxRetries {
+			i.dropped(int64(bytes.Count(body.Bytes(), []byte{'\n'})))
+			return
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-i.done:
+			// the pending batch is posted without the
+			// backoff on close
+		}
+		backoff *= 2
+	}
+}
+
+// post writes the batch, retry reports if the failed write may
+// succeed when retried
+func (i *vgwInflux) post(body []byte) (retry bool, err error) {
+	req, err := http.NewRequest(http.MethodPost, i.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if i.token != "" {
+		req.Header.Set("Authorization", "Token "+i.token)
+	}
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	// the malformed batches are not retried
+	retry = resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode >= http.StatusInternalServerError
+	return retry, fmt.Errorf("InfluxDB write: %v", resp.Status)
+}
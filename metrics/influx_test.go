@@ -0,0 +1,123 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInflux_flush(t *testing.T) {
+	defer func(backoff time.Duration) { influxRetryBackoff = backoff }(influxRetryBackoff)
+	influxRetryBackoff = time.Millisecond
+
+	var (
+		mu       sync.Mutex
+		requests int
+		bodies   []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+
+		if r.Header.Get("Authorization") != "Token secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		// the first write fails and is retried
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	var dropped atomic.Int64
+	i, err := newInflux(srv.URL+"/api/v2/write?org=o&bucket=b", "secret", "test",
+		func(n int64) { dropped.Add(n) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tags := []Tag{{Key: "action", Value: "PutObject"}}
+	i.Add("success_count", 1, tags...)
+	i.Add("success_count", 1, tags...)
+	i.Gauge("in_flight", 1)
+	i.Timing("request_latency", 1500*time.Microsecond, tags...)
+	i.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if requests != 2 || len(bodies) != 1 {
+		t.Fatalf("expected a single retried write, instead got %v requests", requests)
+	}
+	if dropped.Load() != 0 {
+		t.Errorf("expected no dropped datapoints, instead got %v", dropped.Load())
+	}
+
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(bodies[0]), "\n") {
+		// strip the timestamps
+		lines = append(lines, line[:strings.LastIndexByte(line, ' ')])
+	}
+	expected := []string{
+		"versitygw,service=test,action=PutObject success_count=2i",
+		"versitygw,service=test in_flight=1i",
+		"versitygw,service=test,action=PutObject request_latency=1.5",
+	}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected the lines:\n%v\ninstead got:\n%v",
+			strings.Join(expected, "\n"), strings.Join(lines, "\n"))
+	}
+}
+
+func TestInflux_dropped(t *testing.T) {
+	defer func(backoff time.Duration) { influxRetryBackoff = backoff }(influxRetryBackoff)
+	influxRetryBackoff = time.Millisecond
+
+	var requests atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	var dropped atomic.Int64
+	i, err := newInflux(srv.URL, "", "test", func(n int64) { dropped.Add(n) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	i.Add("success_count", 1)
+	i.Add("bytes_written", 10)
+	i.Close()
+
+	if requests.Load() != int64(influxRetries+1) {
+		t.Errorf("expected %v write attempts, instead got %v", influxRetries+1, requests.Load())
+	}
+	if dropped.Load() != 2 {
+		t.Errorf("expected 2 dropped datapoints, instead got %v", dropped.Load())
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// number of the most recent request durations kept per action
+	latencyWindowSize = 1024
+)
+
+// LatencySummary is the latency percentiles of an action
+type LatencySummary struct {
+	Count int           `json:"count"`
+	P50   time.Duration `json:"p50"`
+	P95   time.Duration `json:"p95"`
+	P99   time.Duration `json:"p99"`
+}
+
+// latencyWindow is a fixed size sliding window of the most
+// recent durations, so the memory usage is bounded per action
+type latencyWindow struct {
+	mu      sync.Mutex
+	samples []time.Duration
+	next    int
+	full    bool
+}
+
+func newLatencyWindow(size int) *latencyWindow {
+	return &latencyWindow{
+		samples: make([]time.Duration, size),
+	}
+}
+
+func (w *latencyWindow) add(d time.Duration) {
+	w.mu.Lock()
+	w.samples[w.next] = d
+	w.next++
+	if w.next == len(w.samples) {
+		w.next = 0
+		w.full = true
+	}
+	w.mu.Unlock()
+}
+
+func (w *latencyWindow) summary() LatencySummary {
+	w.mu.Lock()
+	n := w.next
+	if w.full {
+		n = len(w.samples)
+	}
+	sorted := make([]time.Duration, n)
+	copy(sorted, w.samples[:n])
+	w.mu.Unlock()
+
+	if n == 0 {
+		return LatencySummary{}
+	}
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	return LatencySummary{
+		Count: n,
+		P50:   Percentile(sorted, 50),
+		P95:   Percentile(sorted, 95),
+		P99:   Percentile(sorted, 99),
+	}
+}
+
+// Percentile returns the nearest-rank p percentile of the sorted durations
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// latencyTracker keeps the latency windows by action
+type latencyTracker struct {
+	windows sync.Map
+}
+
+func (t *latencyTracker) add(action string, d time.Duration) {
+	w, ok := t.windows.Load(action)
+	if !ok {
+		w, _ = t.windows.LoadOrStore(action, newLatencyWindow(latencyWindowSize))
+	}
+	w.(*latencyWindow).add(d)
+}
+
+func (t *latencyTracker) summary() map[string]LatencySummary {
+	res := make(map[string]LatencySummary)
+	t.windows.Range(func(key, value any) bool {
+		res[key.(string)] = value.(*latencyWindow).summary()
+		return true
+	})
+	return res
+}
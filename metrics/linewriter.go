@@ -0,0 +1,170 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+var (
+	// interval of flushing the buffered datapoints
+	lineFlushInterval = time.Second
+	lineDialTimeout   = 5 * time.Second
+	// min interval between the reconnect attempts to not block
+	// the datapoints forwarding while the server is unavailable
+	lineRetryInterval = time.Second
+)
+
+// lineWriter batches the line based datapoints over a persistent
+// TCP connection or UDP socket, which is reconnected if it drops.
+// The batches are split on the line boundaries, so that each UDP
+// datagram holds the whole lines only.
+type lineWriter struct {
+	network string
+	server  string
+	size    int
+
+	mu       sync.Mutex
+	conn     net.Conn
+	w        *bufio.Writer
+	lastDial time.Time
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newLineWriter connects to server on network, 'tcp' or 'udp', and
+// starts flushing the buffered lines periodically. The batches are
+// at most size bytes, unless a single line is larger.
+func newLineWriter(network, server string, size int) (*lineWriter, error) {
+	t := &lineWriter{
+		network: network,
+		server:  server,
+		size:    size,
+		done:    make(chan struct{}),
+	}
+
+	err := t.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	t.wg.Add(1)
+	go t.flusher()
+
+	return t, nil
+}
+
+// connect (re)opens the connection to the server, t.mu must be held
+// for the reconnects
+func (t *lineWriter) connect() error {
+	if t.conn != nil {
+		t.conn.Close()
+		t.conn = nil
+	}
+
+	t.lastDial = time.Now()
+	conn, err := net.DialTimeout(t.network, t.server, lineDialTimeout)
+	if err != nil {
+		return fmt.Errorf("connect metrics server %v: %w", t.server, err)
+	}
+	t.conn = conn
+	t.w = bufio.NewWriterSize(conn, t.size)
+	return nil
+}
+
+// reconnect opens the dropped connection, unless the last attempt was
+// too recent, t.mu must be held
+func (t *lineWriter) reconnect() bool {
+	if t.conn != nil {
+		return true
+	}
+	if time.Since(t.lastDial) < lineRetryInterval {
+		return false
+	}
+	return t.connect() == nil
+}
+
+// flush writes the buffered lines, the connection is reopened on
+// failure and the lines of the failed write are dropped, t.mu
+// must be held
+func (t *lineWriter) flush() {
+	if !t.reconnect() {
+		return
+	}
+	if t.w.Flush() != nil {
+		t.connect()
+	}
+}
+
+func (t *lineWriter) flusher() {
+	defer t.wg.Done()
+
+	ticker := time.NewTicker(lineFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			t.mu.Lock()
+			t.flush()
+			t.mu.Unlock()
+		case <-t.done:
+			return
+		}
+	}
+}
+
+// Close flushes the buffered lines and closes the connection
+func (t *lineWriter) Close() {
+	close(t.done)
+	t.wg.Wait()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.flush()
+	if t.conn != nil {
+		t.conn.Close()
+		t.conn = nil
+	}
+}
+
+// writeLine buffers line, it is dropped while the server
+// is unavailable until the connection is restored
+func (t *lineWriter) writeLine(line string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.reconnect() {
+		return
+	}
+
+	// flush the batch before it would be split in the middle of line
+	if t.w.Buffered() > 0 && t.w.Available() < len(line) {
+		t.flush()
+		if t.conn == nil {
+			return
+		}
+	}
+
+	_, err := t.w.WriteString(line)
+	if err != nil {
+		t.connect()
+	}
+}
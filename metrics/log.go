@@ -0,0 +1,65 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// vgwLog metrics type
+type vgwLog struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// newLogPublisher returns a metrics publisher writing each datapoint as
+// a human readable line to w, to check the metrics without any metrics
+// server during the local development
+func newLogPublisher(w io.Writer) *vgwLog {
+	return &vgwLog{w: w}
+}
+
+// Close is a no-op, the writer is owned by the caller
+func (l *vgwLog) Close() {}
+
+// Add adds value to key
+func (l *vgwLog) Add(key string, value int64, tags ...Tag) {
+	l.print(key, value, tags)
+}
+
+// Timing prints the duration, e.g. '1.5ms'
+func (l *vgwLog) Timing(key string, d time.Duration, tags ...Tag) {
+	l.print(key, d, tags)
+}
+
+// Gauge prints the gauge value
+func (l *vgwLog) Gauge(key string, value int64, tags ...Tag) {
+	l.print(key, value, tags)
+}
+
+func (l *vgwLog) print(key string, value any, tags []Tag) {
+	stags := make([]string, len(tags))
+	for i, t := range tags {
+		stags[i] = t.Key + "=" + t.Value
+	}
+
+	l.mu.Lock()
+	fmt.Fprintf(l.w, "metric: %v %v [%v]\n", key, value, strings.Join(stags, " "))
+	l.mu.Unlock()
+}
@@ -0,0 +1,36 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLogPublisher_Add(t *testing.T) {
+	var buf bytes.Buffer
+	l := newLogPublisher(&buf)
+
+	l.Add("success_count", 1,
+		Tag{Key: "method", Value: "PUT"},
+		Tag{Key: "action", Value: "PutObject"})
+	l.Add("bytes_written", 512)
+
+	expected := "metric: success_count 1 [method=PUT action=PutObject]\n" +
+		"metric: bytes_written 512 []\n"
+	if buf.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, buf.String())
+	}
+}
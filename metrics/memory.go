@@ -0,0 +1,63 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"maps"
+	"sync"
+	"time"
+)
+
+// vgwMemory metrics type
+type vgwMemory struct {
+	mu     sync.Mutex
+	values map[string]int64
+}
+
+// newMemory returns a metrics publisher accumulating the datapoint
+// values by key in memory, to inspect the metrics without any
+// metrics server, e.g. in the tests
+func newMemory() *vgwMemory {
+	return &vgwMemory{values: make(map[string]int64)}
+}
+
+// Close is a no-op, the values are kept for the inspection
+func (m *vgwMemory) Close() {}
+
+// Add adds value to key
+func (m *vgwMemory) Add(key string, value int64, tags ...Tag) {
+	m.mu.Lock()
+	m.values[key] += value
+	m.mu.Unlock()
+}
+
+// Timing adds the duration in nanoseconds to key
+func (m *vgwMemory) Timing(key string, d time.Duration, tags ...Tag) {
+	m.Add(key, int64(d), tags...)
+}
+
+// Gauge sets the key gauge to value
+func (m *vgwMemory) Gauge(key string, value int64, tags ...Tag) {
+	m.mu.Lock()
+	m.values[key] = value
+	m.mu.Unlock()
+}
+
+// snapshot returns a copy of the accumulated values
+func (m *vgwMemory) snapshot() map[string]int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return maps.Clone(m.values)
+}
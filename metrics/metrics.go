@@ -18,19 +18,38 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/versity/versitygw/s3err"
 )
 
 var (
-	// max size of data items to buffer before dropping
-	// new incoming data items
+	// default max size of data items to buffer before
+	// dropping new incoming data items
 	dataItemCount = 100000
+
+	// MTU-safe UDP payload size for the statsd datagrams
+	defaultMaxPacketSize = 1432
+
+	// the bucket tag values of the requests without the bucket
+	// and of the buckets over the max bucket tag cardinality,
+	// '_' is not allowed in the bucket names
+	noBucketTag    = "_none"
+	otherBucketTag = "_other"
+
+	// max time to wait for the publishers on close
+	defaultCloseTimeout = 10 * time.Second
+
+	// interval of sending the dropped datapoints count, unless
+	// the datapoints are aggregated with the flush interval
+	droppedReportInterval = 10 * time.Second
 )
 
 // Tag is added metadata for metrics
@@ -50,20 +69,105 @@ type Manager struct {
 
 	publishers  []publisher
 	addDataChan chan datapoint
+	// memory is the in-memory publisher, if enabled
+	memory *vgwMemory
+
+	// inFlight is the count of the requests being processed, it is
+	// published on every flush once the requests are tracked
+	inFlight        atomic.Int64
+	inFlightTracked atomic.Bool
+
+	// dropped is the count of the datapoints dropped with the
+	// full channel, reportedDrops is the part already sent
+	dropped       atomic.Int64
+	reportedDrops int64
+
+	latencies latencyTracker
+
+	// bucketsMu protects buckets, the bucket tag values
+	// with the max bucket tag cardinality
+	bucketsMu sync.Mutex
+	buckets   map[string]struct{}
 }
 
 type Config struct {
-	ServiceName      string
+	ServiceName string
+	// StatsdServers and DogStatsdServers are the server addresses
+	// comma separated, sent over UDP unless the address is prefixed
+	// with 'statsd+tcp://' or 'dogstatsd+tcp://' respectively
 	StatsdServers    string
 	DogStatsdServers string
+	// StatsdSampleRate is the statsd and dogstatsd sampling rate
+	// between 0 and 1, the sampled datapoints are sent with the
+	// '|@rate' suffix. 0 disables all of the sends and 1 sends
+	// everything.
+	StatsdSampleRate float64
+	// PrometheusListenAddr is the address to serve the prometheus
+	// metrics on, e.g. ':9100', prometheus is disabled if blank
+	PrometheusListenAddr string
+	// OTLPEndpoint is the OTLP/HTTP collector url to export the
+	// metrics to, e.g. 'http://127.0.0.1:4318', OTLP is disabled
+	// if blank
+	OTLPEndpoint string
+	// GraphiteServers are the carbon server addresses comma
+	// separated, to send the graphite plaintext protocol to
+	GraphiteServers string
+	// BucketTags adds the 'bucket' tag to the request datapoints
+	// for the per bucket accounting
+	BucketTags bool
+	// MaxBucketTagCardinality limits the number of the distinct
+	// bucket tag values, the buckets over the limit are tagged
+	// as '_other', unlimited if zero
+	MaxBucketTagCardinality int
+	// BufferSize is the max number of the datapoints buffered
+	// before dropping new ones, 100000 by default if zero
+	BufferSize int
+	// FlushInterval is the interval of sending the identical
+	// datapoints summed up, to reduce the number of packets under
+	// load, each datapoint is sent right away if zero
+	FlushInterval time.Duration
+	// InfluxURL is the InfluxDB write endpoint url, e.g.
+	// 'http://influxdb:8086/api/v2/write?org=org&bucket=bucket',
+	// to post the line protocol batches to, InfluxToken is the
+	// optional API token
+	InfluxURL   string
+	InfluxToken string
+	// EMFEnabled writes the metrics to stdout in the CloudWatch
+	// embedded metric format, to be forwarded by the log agent
+	EMFEnabled bool
+	// InMemory accumulates the metrics in memory to be inspected
+	// with Manager.Snapshot, e.g. in the tests
+	InMemory bool
+	// DebugMetrics prints all of the datapoints to stdout,
+	// to check the metrics without any metrics server
+	DebugMetrics bool
+	// MetricPrefix is prepended to all of the metric keys, within
+	// the 'versitygw' namespace the publishers add to the keys,
+	// e.g. 'prod' results in 'versitygw.prod.success_count'
+	MetricPrefix string
+	// MaxPacketSize is the max size of the statsd/dogstatsd
+	// datagrams packing multiple metrics, 1432 bytes if 0
+	MaxPacketSize int
+	// KeyFilters limits the metric keys forwarded to a publisher,
+	// indexed by the statsd/dogstatsd/graphite server address, the
+	// prometheus listen address or the OTLP endpoint. Publishers
+	// without a filter forward all of the keys.
+	KeyFilters map[string]KeyFilter
 }
 
-// NewManager initializes metrics plugins and returns a new metrics manager
-func NewManager(ctx context.Context, conf Config) (*Manager, error) {
-	if len(conf.StatsdServers) == 0 && len(conf.DogStatsdServers) == 0 {
-		return nil, nil
-	}
+// KeyFilter selects the metric keys forwarded to a publisher. The keys
+// are the metric names without the MetricPrefix, e.g. 'success_count'.
+type KeyFilter struct {
+	// Allow forwards only the listed keys, all keys if empty
+	Allow []string
+	// Deny drops the listed keys, even if they are allowed
+	Deny []string
+}
 
+// NewManager initializes metrics plugins and returns a new metrics manager.
+// The request latencies are collected, even if no metrics plugins are
+// configured, so that the latency summary is always available.
+func NewManager(ctx context.Context, conf Config) (*Manager, error) {
 	if conf.ServiceName == "" {
 		hostname, err := os.Hostname()
 		if err != nil {
@@ -72,7 +176,22 @@ func NewManager(ctx context.Context, conf Config) (*Manager, error) {
 		conf.ServiceName = hostname
 	}
 
-	addDataChan := make(chan datapoint, dataItemCount)
+	if conf.MaxPacketSize <= 0 {
+		conf.MaxPacketSize = defaultMaxPacketSize
+	}
+
+	if conf.MetricPrefix != "" && !strings.HasSuffix(conf.MetricPrefix, ".") {
+		conf.MetricPrefix += "."
+	}
+
+	if conf.BufferSize < 0 {
+		return nil, fmt.Errorf("invalid metrics buffer size %v", conf.BufferSize)
+	}
+	if conf.BufferSize == 0 {
+		conf.BufferSize = dataItemCount
+	}
+
+	addDataChan := make(chan datapoint, conf.BufferSize)
 
 	mgr := &Manager{
 		addDataChan: addDataChan,
@@ -80,16 +199,41 @@ func NewManager(ctx context.Context, conf Config) (*Manager, error) {
 		config:      conf,
 	}
 
+	if conf.StatsdSampleRate < 0 || conf.StatsdSampleRate > 1 {
+		return nil, fmt.Errorf("invalid statsd sample rate %v, expected a value between 0 and 1",
+			conf.StatsdSampleRate)
+	}
+
+	for server := range conf.KeyFilters {
+		if !hasServer(conf.StatsdServers, server) &&
+			!hasServer(conf.DogStatsdServers, server) &&
+			!hasServer(conf.GraphiteServers, server) &&
+			server != conf.PrometheusListenAddr &&
+			server != conf.OTLPEndpoint &&
+			server != conf.InfluxURL {
+			return nil, fmt.Errorf("metric key filter for unknown server %q", server)
+		}
+	}
+
 	// setup statsd endpoints
 	if len(conf.StatsdServers) > 0 {
 		statsdServers := strings.Split(conf.StatsdServers, ",")
 
 		for _, server := range statsdServers {
-			statsd, err := newStatsd(server, conf.ServiceName)
+			var statsd publisher
+			var err error
+			if addr, ok := tcpServer(server, statsdTCPScheme); ok {
+				statsd, err = newStatsd("tcp", addr, conf.ServiceName,
+					conf.MaxPacketSize, conf.StatsdSampleRate, false)
+			} else {
+				statsd, err = newStatsd("udp", server, conf.ServiceName,
+					conf.MaxPacketSize, conf.StatsdSampleRate, false)
+			}
 			if err != nil {
 				return nil, err
 			}
-			mgr.publishers = append(mgr.publishers, statsd)
+			mgr.publishers = append(mgr.publishers,
+				withKeyFilter(statsd, conf.MetricPrefix, conf.KeyFilters[server]))
 		}
 	}
 
@@ -98,12 +242,80 @@ func NewManager(ctx context.Context, conf Config) (*Manager, error) {
 		dogStatsdServers := strings.Split(conf.DogStatsdServers, ",")
 
 		for _, server := range dogStatsdServers {
-			dogStatsd, err := newDogStatsd(server, conf.ServiceName)
+			var dogStatsd publisher
+			var err error
+			if addr, ok := tcpServer(server, dogStatsdTCPScheme); ok {
+				dogStatsd, err = newStatsd("tcp", addr, conf.ServiceName,
+					conf.MaxPacketSize, conf.StatsdSampleRate, true)
+			} else {
+				dogStatsd, err = newDogStatsd(server, conf.ServiceName,
+					conf.MaxPacketSize, conf.StatsdSampleRate)
+			}
 			if err != nil {
 				return nil, err
 			}
-			mgr.publishers = append(mgr.publishers, dogStatsd)
+			mgr.publishers = append(mgr.publishers,
+				withKeyFilter(dogStatsd, conf.MetricPrefix, conf.KeyFilters[server]))
+		}
+	}
+
+	// setup graphite endpoints
+	if len(conf.GraphiteServers) > 0 {
+		graphiteServers := strings.Split(conf.GraphiteServers, ",")
+
+		for _, server := range graphiteServers {
+			graphite, err := newGraphite(server, conf.ServiceName)
+			if err != nil {
+				return nil, err
+			}
+			mgr.publishers = append(mgr.publishers,
+				withKeyFilter(graphite, conf.MetricPrefix, conf.KeyFilters[server]))
+		}
+	}
+
+	// setup prometheus endpoint
+	if conf.PrometheusListenAddr != "" {
+		prom, err := newPrometheus(conf.PrometheusListenAddr, conf.ServiceName)
+		if err != nil {
+			return nil, err
+		}
+		mgr.publishers = append(mgr.publishers,
+			withKeyFilter(prom, conf.MetricPrefix, conf.KeyFilters[conf.PrometheusListenAddr]))
+	}
+
+	// setup OTLP endpoint
+	if conf.OTLPEndpoint != "" {
+		otlp, err := newOTLP(conf.OTLPEndpoint, conf.ServiceName)
+		if err != nil {
+			return nil, err
+		}
+		mgr.publishers = append(mgr.publishers,
+			withKeyFilter(otlp, conf.MetricPrefix, conf.KeyFilters[conf.OTLPEndpoint]))
+	}
+
+	// setup InfluxDB endpoint
+	if conf.InfluxURL != "" {
+		influx, err := newInflux(conf.InfluxURL, conf.InfluxToken, conf.ServiceName,
+			func(n int64) { mgr.dropped.Add(n) })
+		if err != nil {
+			return nil, err
 		}
+		mgr.publishers = append(mgr.publishers,
+			withKeyFilter(influx, conf.MetricPrefix, conf.KeyFilters[conf.InfluxURL]))
+	}
+
+	// setup CloudWatch EMF
+	if conf.EMFEnabled {
+		mgr.publishers = append(mgr.publishers, newEMF(os.Stdout, conf.ServiceName))
+	}
+
+	if conf.InMemory {
+		mgr.memory = newMemory()
+		mgr.publishers = append(mgr.publishers, mgr.memory)
+	}
+
+	if conf.DebugMetrics {
+		mgr.publishers = append(mgr.publishers, newLogPublisher(os.Stdout))
 	}
 
 	mgr.wg.Add(1)
@@ -113,12 +325,35 @@ func NewManager(ctx context.Context, conf Config) (*Manager, error) {
 }
 
 func (m *Manager) Send(ctx *fiber.Ctx, err error, action string, count int64, status int) {
+	m.SendWithBucket(ctx, err, action, "", count, status)
+}
+
+// SendWithBucket is Send with the 'bucket' tag added to the request
+// datapoints, if enabled with Config.BucketTags
+func (m *Manager) SendWithBucket(ctx *fiber.Ctx, err error, action, bucket string, count int64, status int) {
 	// In case of Authentication failures, url parsing ...
 	if action == "" {
 		action = ActionUndetected
 	}
 
 	a := ActionMap[action]
+
+	var elapsed time.Duration
+	startTime, timed := ctx.Locals("startTime").(time.Time)
+	if timed {
+		elapsed = time.Since(startTime)
+		m.latencies.add(a.Name, elapsed)
+	}
+
+	// only the latencies are collected without the metrics plugins
+	if len(m.publishers) == 0 {
+		return
+	}
+
+	if timed {
+		m.SendTimer(action, elapsed, Tag{Key: "method", Value: ctx.Method()})
+	}
+
 	reqTags := []Tag{
 		{Key: "method", Value: ctx.Method()},
 		{Key: "api", Value: a.Service},
@@ -127,12 +362,16 @@ func (m *Manager) Send(ctx *fiber.Ctx, err error, action string, count int64, st
 
 	reqStatus := status
 
+	// the errors other than the s3 api errors are the unexpected
+	// backend failures, e.g. the filesystem I/O errors
+	var backendErr bool
+	var apierr s3err.APIError
 	if err != nil {
-		var apierr s3err.APIError
 		if errors.As(err, &apierr) {
 			reqStatus = apierr.HTTPStatusCode
 		} else {
 			reqStatus = http.StatusInternalServerError
+			backendErr = true
 		}
 	}
 	if reqStatus == 0 {
@@ -143,12 +382,26 @@ func (m *Manager) Send(ctx *fiber.Ctx, err error, action string, count int64, st
 		Key:   "status",
 		Value: fmt.Sprintf("%v", reqStatus),
 	})
+	if m.config.BucketTags {
+		reqTags = append(reqTags, Tag{
+			Key:   "bucket",
+			Value: m.bucketTag(bucket),
+		})
+	}
 
 	if err != nil {
-		m.increment("failed_count", reqTags...)
+		class := StatusClassServerError
+		if !backendErr {
+			class = ErrorStatusClass(apierr)
+		}
+		m.increment("failed_count", append(reqTags[:len(reqTags):len(reqTags)],
+			Tag{Key: "status_class", Value: class})...)
 	} else {
 		m.increment("success_count", reqTags...)
 	}
+	if backendErr {
+		m.increment("backend_error_count", reqTags...)
+	}
 
 	switch action {
 	case ActionPutObject:
@@ -160,6 +413,12 @@ func (m *Manager) Send(ctx *fiber.Ctx, err error, action string, count int64, st
 		m.add("bytes_written", count, reqTags...)
 	case ActionGetObject:
 		m.add("bytes_read", count, reqTags...)
+	case ActionCopyObject:
+		// the source object is read and the copy written
+		m.add("bytes_read", count, reqTags...)
+		m.add("bytes_written", count, reqTags...)
+	case ActionListObjects, ActionListObjectsV2, ActionListObjectVersions:
+		m.add("object_count", count, reqTags...)
 	case ActionDeleteObject:
 		m.increment("object_removed_count", reqTags...)
 	case ActionDeleteObjects:
@@ -167,59 +426,360 @@ func (m *Manager) Send(ctx *fiber.Ctx, err error, action string, count int64, st
 	}
 }
 
+// LatencySummary returns the p50/p95/p99 request latencies by action,
+// computed over the most recent requests of each action
+func (m *Manager) LatencySummary() map[string]LatencySummary {
+	return m.latencies.summary()
+}
+
+// the failed request classes of the 'status_class' tag
+const (
+	StatusClassClientError = "client_error"
+	StatusClassServerError = "server_error"
+	StatusClassThrottled   = "throttled"
+)
+
+// ErrorStatusClass classifies the s3 api error as the throttled
+// request, the client error (4xx) or the server error (5xx), so that
+// the server errors can be told apart from the expected client errors
+func ErrorStatusClass(apierr s3err.APIError) string {
+	switch {
+	case apierr.Code == s3err.GetAPIError(s3err.ErrSlowDown).Code ||
+		apierr.HTTPStatusCode == http.StatusTooManyRequests:
+		return StatusClassThrottled
+	case apierr.HTTPStatusCode >= http.StatusInternalServerError:
+		return StatusClassServerError
+	default:
+		return StatusClassClientError
+	}
+}
+
+// bucketTag returns the bucket tag value, limited to the max bucket
+// tag cardinality of the distinct buckets seen first
+func (m *Manager) bucketTag(bucket string) string {
+	if bucket == "" {
+		return noBucketTag
+	}
+	if m.config.MaxBucketTagCardinality <= 0 {
+		return bucket
+	}
+
+	m.bucketsMu.Lock()
+	defer m.bucketsMu.Unlock()
+
+	if _, ok := m.buckets[bucket]; ok {
+		return bucket
+	}
+	if len(m.buckets) >= m.config.MaxBucketTagCardinality {
+		return otherBucketTag
+	}
+	if m.buckets == nil {
+		m.buckets = make(map[string]struct{})
+	}
+	m.buckets[bucket] = struct{}{}
+	return bucket
+}
+
 // increment increments the key by one
 func (m *Manager) increment(key string, tags ...Tag) {
 	m.add(key, 1, tags...)
 }
 
-// add adds value to key
-func (m *Manager) add(key string, value int64, tags ...Tag) {
-	if m.ctx.Err() != nil {
-		return
+// SendTimer sends the action duration as the 'request_latency'
+// timing datapoint tagged with the action, so that the per action
+// latency percentiles can be derived by the metrics servers
+func (m *Manager) SendTimer(action string, d time.Duration, tags ...Tag) {
+	if action == "" {
+		action = ActionUndetected
 	}
+	a := ActionMap[action]
 
-	d := datapoint{
-		key:   key,
+	m.send(datapoint{
+		kind:  kindTiming,
+		key:   m.config.MetricPrefix + "request_latency",
+		value: int64(d),
+		tags: append([]Tag{
+			{Key: "api", Value: a.Service},
+			{Key: "action", Value: a.Name},
+		}, tags...),
+	})
+}
+
+// Gauge sets the key gauge to value, unlike the counters the gauge
+// value replaces the previous one
+func (m *Manager) Gauge(key string, value int64, tags ...Tag) {
+	m.send(datapoint{
+		kind:  kindGauge,
+		key:   m.config.MetricPrefix + key,
 		value: value,
 		tags:  tags,
+	})
+}
+
+// TrackRequest counts the request in the 'in_flight' gauge
+// until the returned done func is called on its completion.
+// The gauge is published on the flush, as the values sent per
+// request could reach the forwarder out of order.
+func (m *Manager) TrackRequest() (done func()) {
+	m.inFlightTracked.Store(true)
+	m.inFlight.Add(1)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			m.inFlight.Add(-1)
+		})
+	}
+}
+
+// add adds value to key
+func (m *Manager) add(key string, value int64, tags ...Tag) {
+	m.send(datapoint{
+		key:   m.config.MetricPrefix + key,
+		value: value,
+		tags:  tags,
+	})
+}
+
+func (m *Manager) send(d datapoint) {
+	if m.ctx.Err() != nil {
+		return
 	}
 
 	select {
 	case m.addDataChan <- d:
 	default:
 		// channel full, drop the updates
+		m.dropped.Add(1)
+	}
+}
+
+// Snapshot returns the metric values accumulated by key, including
+// the metric prefix, with Config.InMemory, or nil otherwise. The
+// timings are accumulated in nanoseconds. The datapoints are
+// forwarded asynchronously, so the values sent right before may be
+// missing until the manager is closed.
+func (m *Manager) Snapshot() map[string]int64 {
+	if m.memory == nil {
+		return nil
 	}
+	return m.memory.snapshot()
+}
+
+// Dropped returns the count of the datapoints dropped since the
+// manager start, because of the full datapoints buffer
+func (m *Manager) Dropped() int64 {
+	return m.dropped.Load()
 }
 
 // Close closes metrics channels, waits for data to complete, closes all plugins
 func (m *Manager) Close() {
-	// drain the datapoint channels
-	close(m.addDataChan)
-	m.wg.Wait()
+	err := m.CloseWithTimeout(defaultCloseTimeout)
+	if err != nil {
+		log.Printf("close metrics: %v", err)
+	}
+}
 
-	// close all publishers
-	for _, p := range m.publishers {
-		p.Close()
+// CloseWithTimeout drains the datapoints to the publishers and closes
+// them, but returns after d with an error reporting the abandoned
+// datapoints count if the publishers are still flushing, e.g. with
+// an unresponsive metrics server, to not block the gateway shutdown
+func (m *Manager) CloseWithTimeout(d time.Duration) error {
+	done := make(chan struct{})
+	go func() {
+		// drain the datapoint channels
+		close(m.addDataChan)
+		m.wg.Wait()
+
+		// close all publishers
+		for _, p := range m.publishers {
+			p.Close()
+		}
+		close(done)
+	}()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("publishers flush timed out after %v, %v datapoints abandoned",
+			d, len(m.addDataChan))
 	}
 }
 
 // publisher is the interface for interacting with the metrics plugins
 type publisher interface {
 	Add(key string, value int64, tags ...Tag)
+	Timing(key string, d time.Duration, tags ...Tag)
+	Gauge(key string, value int64, tags ...Tag)
 	Close()
 }
 
+// filteredPublisher forwards only the datapoints with the
+// keys selected by the filter to the wrapped publisher
+type filteredPublisher struct {
+	publisher
+	prefix string
+	allow  map[string]struct{}
+	deny   map[string]struct{}
+}
+
+// hasServer checks if the server is in the comma separated servers list
+func hasServer(servers, server string) bool {
+	for _, s := range strings.Split(servers, ",") {
+		if s == server {
+			return true
+		}
+	}
+	return false
+}
+
+// withKeyFilter wraps the publisher with the key filter,
+// the publisher is returned as is for an empty filter
+func withKeyFilter(p publisher, prefix string, filter KeyFilter) publisher {
+	if len(filter.Allow) == 0 && len(filter.Deny) == 0 {
+		return p
+	}
+
+	f := &filteredPublisher{
+		publisher: p,
+		prefix:    prefix,
+		deny:      make(map[string]struct{}, len(filter.Deny)),
+	}
+	if len(filter.Allow) > 0 {
+		f.allow = make(map[string]struct{}, len(filter.Allow))
+		for _, key := range filter.Allow {
+			f.allow[key] = struct{}{}
+		}
+	}
+	for _, key := range filter.Deny {
+		f.deny[key] = struct{}{}
+	}
+
+	return f
+}
+
+// selected checks if the key passes the filter
+func (f *filteredPublisher) selected(key string) bool {
+	name := strings.TrimPrefix(key, f.prefix)
+	if _, ok := f.deny[name]; ok {
+		return false
+	}
+	if f.allow != nil {
+		if _, ok := f.allow[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *filteredPublisher) Add(key string, value int64, tags ...Tag) {
+	if f.selected(key) {
+		f.publisher.Add(key, value, tags...)
+	}
+}
+
+func (f *filteredPublisher) Timing(key string, d time.Duration, tags ...Tag) {
+	if f.selected(key) {
+		f.publisher.Timing(key, d, tags...)
+	}
+}
+
+func (f *filteredPublisher) Gauge(key string, value int64, tags ...Tag) {
+	if f.selected(key) {
+		f.publisher.Gauge(key, value, tags...)
+	}
+}
+
 func (m *Manager) addForwarder(addChan <-chan datapoint) {
-	for data := range addChan {
-		for _, s := range m.publishers {
+	defer m.wg.Done()
+
+	// aggregate the datapoints to send a single combined
+	// value per interval instead of each increment
+	var agg *aggregator
+	interval := droppedReportInterval
+	if m.config.FlushInterval > 0 {
+		agg = newAggregator()
+		interval = m.config.FlushInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case data, ok := <-addChan:
+			if !ok {
+				m.flush(agg)
+				return
+			}
+			// the timing samples can't be combined
+			if agg != nil && data.kind != kindTiming {
+				agg.add(data)
+			} else {
+				m.publish(data)
+			}
+		case <-ticker.C:
+			m.flush(agg)
+		}
+	}
+}
+
+// flush sends the aggregated datapoints, the in-flight requests
+// and the count of the datapoints dropped since the previous flush
+func (m *Manager) flush(agg *aggregator) {
+	if agg != nil {
+		agg.flush(m.publish)
+	}
+
+	if m.inFlightTracked.Load() {
+		m.publish(datapoint{
+			kind:  kindGauge,
+			key:   m.config.MetricPrefix + "in_flight",
+			value: m.inFlight.Load(),
+		})
+	}
+
+	dropped := m.dropped.Load()
+	if dropped > m.reportedDrops {
+		m.publish(datapoint{
+			key:   m.config.MetricPrefix + "dropped_count",
+			value: dropped - m.reportedDrops,
+		})
+		m.reportedDrops = dropped
+	}
+}
+
+func (m *Manager) publish(data datapoint) {
+	for _, s := range m.publishers {
+		switch data.kind {
+		case kindTiming:
+			s.Timing(data.key, time.Duration(data.value), data.tags...)
+		case kindGauge:
+			s.Gauge(data.key, data.value, data.tags...)
+		default:
 			s.Add(data.key, data.value, data.tags...)
 		}
 	}
-	m.wg.Done()
 }
 
 type datapoint struct {
+	kind  datapointKind
 	key   string
 	value int64
 	tags  []Tag
 }
+
+type datapointKind int
+
+const (
+	// kindCount value is added to the counter
+	kindCount datapointKind = iota
+	// kindTiming value is a duration sample in nanoseconds
+	kindTiming
+	// kindGauge value replaces the gauge value
+	kindGauge
+)
@@ -0,0 +1,629 @@
+// Copyright 2023 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+	"github.com/versity/versitygw/s3err"
+)
+
+// countingPublisher sums up the values of all the datapoints by key
+type countingPublisher struct {
+	mu     sync.Mutex
+	totals map[string]int64
+}
+
+func (c *countingPublisher) Add(key string, value int64, tags ...Tag) {
+	c.mu.Lock()
+	c.totals[key] += value
+	c.mu.Unlock()
+}
+
+func (c *countingPublisher) Timing(key string, d time.Duration, tags ...Tag) {}
+
+func (c *countingPublisher) Gauge(key string, value int64, tags ...Tag) {}
+
+func (c *countingPublisher) Close() {}
+
+func newTestManager(pub publisher) *Manager {
+	mgr := &Manager{
+		ctx:         context.Background(),
+		addDataChan: make(chan datapoint, dataItemCount),
+		publishers:  []publisher{pub},
+	}
+	mgr.wg.Add(1)
+	go mgr.addForwarder(mgr.addDataChan)
+	return mgr
+}
+
+func TestManager_Send_concurrent(t *testing.T) {
+	pub := &countingPublisher{totals: map[string]int64{}}
+	mgr := newTestManager(pub)
+
+	app := fiber.New()
+
+	const (
+		workers   = 50
+		opsPerJob = 100
+		objSize   = 1024
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := app.AcquireCtx(&fasthttp.RequestCtx{})
+			defer app.ReleaseCtx(ctx)
+
+			for j := 0; j < opsPerJob; j++ {
+				mgr.Send(ctx, nil, ActionPutObject, objSize, 0)
+				mgr.Send(ctx, nil, ActionGetObject, objSize, 0)
+				mgr.Send(ctx, nil, ActionDeleteObject, 0, 0)
+				mgr.Send(ctx, s3err.GetAPIError(s3err.ErrNoSuchKey), ActionGetObject, 0, 0)
+				mgr.Send(ctx, errors.New("input/output error"), ActionHeadObject, 0, 0)
+			}
+		}()
+	}
+	wg.Wait()
+	mgr.Close()
+
+	total := int64(workers * opsPerJob)
+	expected := map[string]int64{
+		"success_count":        3 * total,
+		"failed_count":         2 * total,
+		"backend_error_count":  total,
+		"object_created_count": total,
+		"object_removed_count": total,
+		"bytes_written":        total * objSize,
+		"bytes_read":           total * objSize,
+	}
+
+	for key, val := range expected {
+		if pub.totals[key] != val {
+			t.Errorf("expected %v to be %v, instead got %v", key, val, pub.totals[key])
+		}
+	}
+}
+
+func TestLatencyWindow_summary(t *testing.T) {
+	w := newLatencyWindow(100)
+	// durations are added in the reverse order to make sure they are sorted
+	for i := 100; i > 0; i-- {
+		w.add(time.Duration(i) * time.Millisecond)
+	}
+
+	expected := LatencySummary{
+		Count: 100,
+		P50:   50 * time.Millisecond,
+		P95:   95 * time.Millisecond,
+		P99:   99 * time.Millisecond,
+	}
+	if got := w.summary(); got != expected {
+		t.Errorf("expected the summary to be %+v, instead got %+v", expected, got)
+	}
+
+	// the window keeps only the most recent durations
+	for i := 0; i < 100; i++ {
+		w.add(time.Second)
+	}
+	if got := w.summary(); got.P50 != time.Second {
+		t.Errorf("expected the p50 to be %v, instead got %v", time.Second, got.P50)
+	}
+}
+
+func TestManager_LatencySummary(t *testing.T) {
+	mgr := newTestManager(&countingPublisher{totals: map[string]int64{}})
+	defer mgr.Close()
+
+	app := fiber.New()
+	ctx := app.AcquireCtx(&fasthttp.RequestCtx{})
+	defer app.ReleaseCtx(ctx)
+
+	const latency = 200 * time.Millisecond
+	ctx.Locals("startTime", time.Now().Add(-latency))
+	mgr.Send(ctx, nil, ActionPutObject, 0, 0)
+
+	summary, ok := mgr.LatencySummary()[ActionMap[ActionPutObject].Name]
+	if !ok {
+		t.Fatalf("expected the latency summary of %v", ActionPutObject)
+	}
+	if summary.Count != 1 {
+		t.Errorf("expected the count to be 1, instead got %v", summary.Count)
+	}
+	// allow some tolerance for the time spent in Send
+	if summary.P99 < latency || summary.P99 > latency+time.Second {
+		t.Errorf("expected the p99 to be about %v, instead got %v", latency, summary.P99)
+	}
+}
+
+func TestManager_MetricPrefix(t *testing.T) {
+	pub := &countingPublisher{totals: map[string]int64{}}
+	mgr := newTestManager(pub)
+	mgr.config.MetricPrefix = "versitygw."
+
+	app := fiber.New()
+	ctx := app.AcquireCtx(&fasthttp.RequestCtx{})
+	defer app.ReleaseCtx(ctx)
+
+	mgr.Send(ctx, nil, ActionPutObject, 10, 0)
+	mgr.Close()
+
+	expected := map[string]int64{
+		"versitygw.success_count":        1,
+		"versitygw.bytes_written":        10,
+		"versitygw.object_created_count": 1,
+	}
+
+	if len(pub.totals) != len(expected) {
+		t.Errorf("expected %v metric keys, instead got %v", len(expected), pub.totals)
+	}
+	for key, val := range expected {
+		if pub.totals[key] != val {
+			t.Errorf("expected %v to be %v, instead got %v", key, val, pub.totals[key])
+		}
+	}
+}
+
+func TestNewManager_latencies_without_publishers(t *testing.T) {
+	mgr, err := NewManager(context.Background(), Config{ServiceName: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mgr == nil {
+		t.Fatal("expected the metrics manager without the metrics plugins")
+	}
+	defer mgr.Close()
+
+	app := fiber.New()
+	ctx := app.AcquireCtx(&fasthttp.RequestCtx{})
+	defer app.ReleaseCtx(ctx)
+
+	ctx.Locals("startTime", time.Now())
+	mgr.Send(ctx, nil, ActionGetObject, 10, 0)
+
+	summary, ok := mgr.LatencySummary()[ActionMap[ActionGetObject].Name]
+	if !ok {
+		t.Fatalf("expected the latency summary of %v", ActionGetObject)
+	}
+	if summary.Count != 1 {
+		t.Errorf("expected the count to be 1, instead got %v", summary.Count)
+	}
+}
+
+func TestManager_KeyFilter(t *testing.T) {
+	allowed := &countingPublisher{totals: map[string]int64{}}
+	denied := &countingPublisher{totals: map[string]int64{}}
+	all := &countingPublisher{totals: map[string]int64{}}
+
+	mgr := newTestManager(all)
+	mgr.config.MetricPrefix = "versitygw."
+	mgr.publishers = append(mgr.publishers,
+		withKeyFilter(allowed, mgr.config.MetricPrefix, KeyFilter{
+			Allow: []string{"success_count", "failed_count", "bytes_read"},
+			Deny:  []string{"bytes_read"},
+		}),
+		withKeyFilter(denied, mgr.config.MetricPrefix, KeyFilter{
+			Deny: []string{"bytes_written"},
+		}))
+
+	app := fiber.New()
+	ctx := app.AcquireCtx(&fasthttp.RequestCtx{})
+	defer app.ReleaseCtx(ctx)
+
+	mgr.Send(ctx, nil, ActionPutObject, 10, 0)
+	mgr.Send(ctx, nil, ActionGetObject, 10, 0)
+	mgr.Close()
+
+	tests := []struct {
+		name     string
+		pub      *countingPublisher
+		expected map[string]int64
+	}{
+		{
+			name: "allowlist",
+			pub:  allowed,
+			expected: map[string]int64{
+				"versitygw.success_count": 2,
+			},
+		},
+		{
+			name: "denylist",
+			pub:  denied,
+			expected: map[string]int64{
+				"versitygw.success_count":        2,
+				"versitygw.object_created_count": 1,
+				"versitygw.bytes_read":           10,
+			},
+		},
+		{
+			name: "no-filter",
+			pub:  all,
+			expected: map[string]int64{
+				"versitygw.success_count":        2,
+				"versitygw.object_created_count": 1,
+				"versitygw.bytes_written":        10,
+				"versitygw.bytes_read":           10,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.pub.totals) != len(tt.expected) {
+				t.Errorf("expected the metric keys %v, instead got %v", tt.expected, tt.pub.totals)
+			}
+			for key, val := range tt.expected {
+				if tt.pub.totals[key] != val {
+					t.Errorf("expected %v to be %v, instead got %v", key, val, tt.pub.totals[key])
+				}
+			}
+		})
+	}
+}
+
+func TestNewManager_KeyFilter_unknown_server(t *testing.T) {
+	_, err := NewManager(context.Background(), Config{
+		ServiceName: "test",
+		KeyFilters: map[string]KeyFilter{
+			"127.0.0.1:8125": {Allow: []string{"success_count"}},
+		},
+	})
+	if err == nil {
+		t.Fatal("expected an error for the key filter of an unconfigured server")
+	}
+}
+
+func TestManager_FlushInterval(t *testing.T) {
+	var buf bytes.Buffer
+	mgr := &Manager{
+		ctx:         context.Background(),
+		config:      Config{FlushInterval: time.Hour},
+		addDataChan: make(chan datapoint, dataItemCount),
+		publishers:  []publisher{newLogPublisher(&buf)},
+	}
+	mgr.wg.Add(1)
+	go mgr.addForwarder(mgr.addDataChan)
+
+	for i := 0; i < 3; i++ {
+		mgr.add("success_count", 1, Tag{Key: "action", Value: "PutObject"})
+		mgr.add("bytes_written", 10, Tag{Key: "action", Value: "PutObject"})
+	}
+	mgr.add("success_count", 1, Tag{Key: "action", Value: "GetObject"})
+	// the remaining datapoints are sent on close
+	mgr.Close()
+
+	expected := "metric: success_count 3 [action=PutObject]\n" +
+		"metric: bytes_written 30 [action=PutObject]\n" +
+		"metric: success_count 1 [action=GetObject]\n"
+	if buf.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestManager_Dropped(t *testing.T) {
+	var buf bytes.Buffer
+	mgr := &Manager{
+		ctx:         context.Background(),
+		addDataChan: make(chan datapoint, 1),
+		publishers:  []publisher{newLogPublisher(&buf)},
+	}
+
+	// the buffer is full until the forwarder starts
+	for i := 0; i < 3; i++ {
+		mgr.add("success_count", 1)
+	}
+	if mgr.Dropped() != 2 {
+		t.Fatalf("expected 2 dropped datapoints, instead got %v", mgr.Dropped())
+	}
+
+	mgr.wg.Add(1)
+	go mgr.addForwarder(mgr.addDataChan)
+	mgr.Close()
+
+	expected := "metric: success_count 1 []\n" +
+		"metric: dropped_count 2 []\n"
+	if buf.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestNewManager_BufferSize(t *testing.T) {
+	_, err := NewManager(context.Background(), Config{
+		ServiceName: "test",
+		BufferSize:  -1,
+	})
+	if err == nil {
+		t.Fatal("expected an error for the negative buffer size")
+	}
+
+	mgr, err := NewManager(context.Background(), Config{
+		ServiceName: "test",
+		BufferSize:  10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mgr.Close()
+
+	if cap(mgr.addDataChan) != 10 {
+		t.Errorf("expected the buffer size to be 10, instead got %v", cap(mgr.addDataChan))
+	}
+}
+
+func TestManager_SendTimer(t *testing.T) {
+	var buf bytes.Buffer
+	mgr := &Manager{
+		ctx:         context.Background(),
+		config:      Config{FlushInterval: time.Hour},
+		addDataChan: make(chan datapoint, dataItemCount),
+		publishers:  []publisher{newLogPublisher(&buf)},
+	}
+	mgr.wg.Add(1)
+	go mgr.addForwarder(mgr.addDataChan)
+
+	// the timing samples are not summed up by the aggregation
+	mgr.SendTimer(ActionPutObject, 15*time.Millisecond, Tag{Key: "method", Value: "PUT"})
+	mgr.SendTimer(ActionPutObject, 5*time.Millisecond, Tag{Key: "method", Value: "PUT"})
+	mgr.Close()
+
+	expected := "metric: request_latency 15ms [api=s3 action=PutObject method=PUT]\n" +
+		"metric: request_latency 5ms [api=s3 action=PutObject method=PUT]\n"
+	if buf.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestManager_SendWithBucket(t *testing.T) {
+	var buf bytes.Buffer
+	mgr := &Manager{
+		ctx: context.Background(),
+		config: Config{
+			BucketTags:              true,
+			MaxBucketTagCardinality: 1,
+		},
+		addDataChan: make(chan datapoint, dataItemCount),
+		publishers:  []publisher{newLogPublisher(&buf)},
+	}
+	mgr.wg.Add(1)
+	go mgr.addForwarder(mgr.addDataChan)
+
+	app := fiber.New()
+	ctx := app.AcquireCtx(&fasthttp.RequestCtx{})
+	defer app.ReleaseCtx(ctx)
+
+	mgr.SendWithBucket(ctx, nil, ActionHeadObject, "bucket-a", 0, 0)
+	// over the max bucket tag cardinality
+	mgr.SendWithBucket(ctx, nil, ActionHeadObject, "bucket-b", 0, 0)
+	mgr.SendWithBucket(ctx, nil, ActionHeadObject, "bucket-a", 0, 0)
+	mgr.Send(ctx, nil, ActionHeadObject, 0, 0)
+	mgr.Close()
+
+	var buckets []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		_, bucket, ok := strings.Cut(line, "bucket=")
+		if !ok {
+			t.Fatalf("expected the bucket tag, got %q", line)
+		}
+		buckets = append(buckets, strings.TrimSuffix(bucket, "]"))
+	}
+
+	expected := []string{"bucket-a", otherBucketTag, "bucket-a", noBucketTag}
+	if strings.Join(buckets, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected the bucket tags %v, got %v", expected, buckets)
+	}
+}
+
+func TestManager_Send_action_keys(t *testing.T) {
+	app := fiber.New()
+	ctx := app.AcquireCtx(&fasthttp.RequestCtx{})
+	defer app.ReleaseCtx(ctx)
+
+	tests := []struct {
+		action string
+		count  int64
+		keys   map[string]int64
+	}{
+		{ActionPutObject, 10, map[string]int64{
+			"success_count": 1, "bytes_written": 10, "object_created_count": 1}},
+		{ActionUploadPart, 10, map[string]int64{
+			"success_count": 1, "bytes_written": 10}},
+		{ActionCompleteMultipartUpload, 0, map[string]int64{
+			"success_count": 1, "object_created_count": 1}},
+		{ActionGetObject, 10, map[string]int64{
+			"success_count": 1, "bytes_read": 10}},
+		{ActionCopyObject, 10, map[string]int64{
+			"success_count": 1, "bytes_read": 10, "bytes_written": 10}},
+		{ActionDeleteObject, 0, map[string]int64{
+			"success_count": 1, "object_removed_count": 1}},
+		{ActionDeleteObjects, 3, map[string]int64{
+			"success_count": 1, "object_removed_count": 3}},
+		{ActionListObjects, 5, map[string]int64{
+			"success_count": 1, "object_count": 5}},
+		{ActionListObjectsV2, 5, map[string]int64{
+			"success_count": 1, "object_count": 5}},
+		{ActionListObjectVersions, 5, map[string]int64{
+			"success_count": 1, "object_count": 5}},
+		{ActionHeadObject, 0, map[string]int64{
+			"success_count": 1}},
+		{ActionAbortMultipartUpload, 0, map[string]int64{
+			"success_count": 1}},
+	}
+
+	for _, tt := range tests {
+		pub := &countingPublisher{totals: map[string]int64{}}
+		mgr := newTestManager(pub)
+		mgr.Send(ctx, nil, tt.action, tt.count, 0)
+		mgr.Close()
+
+		if len(pub.totals) != len(tt.keys) {
+			t.Errorf("%v: expected the metric keys %v, instead got %v", tt.action, tt.keys, pub.totals)
+			continue
+		}
+		for key, value := range tt.keys {
+			if pub.totals[key] != value {
+				t.Errorf("%v: expected %v to be %v, instead got %v", tt.action, key, value, pub.totals[key])
+			}
+		}
+	}
+}
+
+func TestManager_Send_status_class(t *testing.T) {
+	app := fiber.New()
+	ctx := app.AcquireCtx(&fasthttp.RequestCtx{})
+	defer app.ReleaseCtx(ctx)
+
+	tests := []struct {
+		err   error
+		class string
+	}{
+		{s3err.GetAPIError(s3err.ErrNoSuchKey), StatusClassClientError},
+		{s3err.GetAPIError(s3err.ErrAccessDenied), StatusClassClientError},
+		{s3err.GetAPIError(s3err.ErrSlowDown), StatusClassThrottled},
+		{s3err.GetAPIError(s3err.ErrNotImplemented), StatusClassServerError},
+		{errors.New("input/output error"), StatusClassServerError},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		mgr := newTestManager(newLogPublisher(&buf))
+		mgr.Send(ctx, tt.err, ActionHeadObject, 0, 0)
+		mgr.Close()
+
+		line, _, _ := strings.Cut(buf.String(), "\n")
+		if !strings.HasPrefix(line, "metric: failed_count ") ||
+			!strings.HasSuffix(line, " status_class="+tt.class+"]") {
+			t.Errorf("%v: expected the failed_count with the %v status class, instead got %q",
+				tt.err, tt.class, line)
+		}
+	}
+}
+
+func TestManager_TrackRequest(t *testing.T) {
+	for _, interval := range []time.Duration{0, time.Hour} {
+		var buf bytes.Buffer
+		mgr := &Manager{
+			ctx:         context.Background(),
+			config:      Config{FlushInterval: interval},
+			addDataChan: make(chan datapoint, dataItemCount),
+			publishers:  []publisher{newLogPublisher(&buf)},
+		}
+		mgr.wg.Add(1)
+		go mgr.addForwarder(mgr.addDataChan)
+
+		done := mgr.TrackRequest()
+		// the second request is still in flight
+		mgr.TrackRequest()
+		done()
+		// the request is counted done once
+		done()
+		mgr.Close()
+
+		// the gauge is published on the flush
+		expected := "metric: in_flight 1 []\n"
+		if buf.String() != expected {
+			t.Errorf("flush interval %v: expected %q, got %q", interval, expected, buf.String())
+		}
+	}
+}
+
+// blockingPublisher blocks the datapoints until unblocked,
+// like an unresponsive metrics server
+type blockingPublisher struct {
+	unblock chan struct{}
+}
+
+func (b *blockingPublisher) Add(key string, value int64, tags ...Tag) { <-b.unblock }
+
+func (b *blockingPublisher) Timing(key string, d time.Duration, tags ...Tag) { <-b.unblock }
+
+func (b *blockingPublisher) Gauge(key string, value int64, tags ...Tag) { <-b.unblock }
+
+func (b *blockingPublisher) Close() {}
+
+func TestManager_CloseWithTimeout(t *testing.T) {
+	pub := &blockingPublisher{unblock: make(chan struct{})}
+	defer close(pub.unblock)
+	mgr := newTestManager(pub)
+
+	for i := 0; i < 10; i++ {
+		mgr.add("success_count", 1)
+	}
+
+	start := time.Now()
+	err := mgr.CloseWithTimeout(100 * time.Millisecond)
+	if err == nil {
+		t.Fatal("expected the close timeout error")
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Fatalf("expected the close to return after the timeout, instead took %v", time.Since(start))
+	}
+	// the forwarder is blocked on the first datapoint
+	if !strings.Contains(err.Error(), "9 datapoints abandoned") {
+		t.Errorf("expected 9 abandoned datapoints, instead got: %v", err)
+	}
+}
+
+func TestManager_Snapshot(t *testing.T) {
+	mgr, err := NewManager(context.Background(), Config{
+		ServiceName: "test",
+		InMemory:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiber.New()
+
+	const workers = 10
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := app.AcquireCtx(&fasthttp.RequestCtx{})
+			defer app.ReleaseCtx(ctx)
+
+			mgr.Send(ctx, nil, ActionPutObject, 10, 0)
+			// the snapshot is safe with the ongoing sends
+			mgr.Snapshot()
+		}()
+	}
+	wg.Wait()
+	mgr.Close()
+
+	expected := map[string]int64{
+		"success_count":        workers,
+		"bytes_written":        10 * workers,
+		"object_created_count": workers,
+	}
+	snapshot := mgr.Snapshot()
+	if len(snapshot) != len(expected) {
+		t.Fatalf("expected the metrics %v, instead got %v", expected, snapshot)
+	}
+	for key, value := range expected {
+		if snapshot[key] != value {
+			t.Errorf("expected %v to be %v, instead got %v", key, value, snapshot[key])
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+var (
+	// interval of the batched OTLP metric exports
+	otlpExportInterval = 10 * time.Second
+)
+
+// vgwOTLP metrics type
+type vgwOTLP struct {
+	provider *sdkmetric.MeterProvider
+	meter    metric.Meter
+
+	mu         sync.Mutex
+	counters   map[string]metric.Int64Counter
+	histograms map[string]metric.Float64Histogram
+	gauges     map[string]metric.Int64Gauge
+}
+
+// newOTLP takes an OTLP/HTTP collector url and returns an OTLP metrics
+// publisher. The datapoints are batched into periodic metric exports.
+// Supply service name to be used as the 'service.name' resource
+// attribute, this may typically be the gateway hostname.
+func newOTLP(endpoint string, service string) (*vgwOTLP, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP endpoint url %q, e.g. 'http://127.0.0.1:4318'", endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = "/v1/metrics"
+	}
+
+	exp, err := otlpmetrichttp.New(context.Background(),
+		otlpmetrichttp.WithEndpointURL(u.String()))
+	if err != nil {
+		return nil, fmt.Errorf("init OTLP exporter: %w", err)
+	}
+
+	provider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exp,
+			sdkmetric.WithInterval(otlpExportInterval))),
+		sdkmetric.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", service))),
+	)
+
+	return &vgwOTLP{
+		provider:   provider,
+		meter:      provider.Meter("versitygw"),
+		counters:   make(map[string]metric.Int64Counter),
+		histograms: make(map[string]metric.Float64Histogram),
+		gauges:     make(map[string]metric.Int64Gauge),
+	}, nil
+}
+
+// Close flushes the pending datapoints and shuts the exporter down
+func (o *vgwOTLP) Close() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	o.provider.Shutdown(ctx)
+}
+
+// Add adds value to key
+func (o *vgwOTLP) Add(key string, value int64, tags ...Tag) {
+	// the OTLP sums are monotonic
+	if value < 0 {
+		return
+	}
+
+	o.mu.Lock()
+	c, ok := o.counters[key]
+	if !ok {
+		var err error
+		c, err = o.meter.Int64Counter("versitygw." + key)
+		if err != nil {
+			o.mu.Unlock()
+			return
+		}
+		o.counters[key] = c
+	}
+	o.mu.Unlock()
+
+	c.Add(context.Background(), value, metric.WithAttributes(otlpAttrs(tags)...))
+}
+
+// Timing records the duration in seconds in the key histogram
+func (o *vgwOTLP) Timing(key string, d time.Duration, tags ...Tag) {
+	o.mu.Lock()
+	h, ok := o.histograms[key]
+	if !ok {
+		var err error
+		h, err = o.meter.Float64Histogram("versitygw."+key,
+			metric.WithUnit("s"))
+		if err != nil {
+			o.mu.Unlock()
+			return
+		}
+		o.histograms[key] = h
+	}
+	o.mu.Unlock()
+
+	h.Record(context.Background(), d.Seconds(), metric.WithAttributes(otlpAttrs(tags)...))
+}
+
+// Gauge records the key gauge value
+func (o *vgwOTLP) Gauge(key string, value int64, tags ...Tag) {
+	o.mu.Lock()
+	g, ok := o.gauges[key]
+	if !ok {
+		var err error
+		g, err = o.meter.Int64Gauge("versitygw." + key)
+		if err != nil {
+			o.mu.Unlock()
+			return
+		}
+		o.gauges[key] = g
+	}
+	o.mu.Unlock()
+
+	g.Record(context.Background(), value, metric.WithAttributes(otlpAttrs(tags)...))
+}
+
+func otlpAttrs(tags []Tag) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, len(tags))
+	for i, t := range tags {
+		attrs[i] = attribute.String(t.Key, t.Value)
+	}
+	return attrs
+}
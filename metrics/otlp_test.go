@@ -0,0 +1,106 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestOTLP_Close_flushes(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		totals = map[string]int64{}
+		action string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/metrics" {
+			t.Errorf("expected the export path to be /v1/metrics, instead got %v", r.URL.Path)
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		var req colmetricpb.ExportMetricsServiceRequest
+		if err := proto.Unmarshal(body, &req); err != nil {
+			t.Error(err)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		for _, rm := range req.ResourceMetrics {
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					for _, dp := range m.GetSum().GetDataPoints() {
+						totals[m.Name] = dp.GetAsInt()
+						for _, attr := range dp.Attributes {
+							if attr.Key == "action" {
+								action = attr.Value.GetStringValue()
+							}
+						}
+					}
+				}
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	o, err := newOTLP(srv.URL, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tags := []Tag{{Key: "action", Value: "PutObject"}}
+	o.Add("success_count", 1, tags...)
+	o.Add("success_count", 1, tags...)
+	o.Add("bytes_written", 10, tags...)
+
+	// the export interval is not reached, so the datapoints
+	// are exported by the final flush of close
+	o.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	expected := map[string]int64{
+		"versitygw.success_count": 2,
+		"versitygw.bytes_written": 10,
+	}
+	for key, val := range expected {
+		if totals[key] != val {
+			t.Errorf("expected %v to be %v, instead got %v", key, val, totals[key])
+		}
+	}
+	if action != "PutObject" {
+		t.Errorf("expected the action attribute to be PutObject, instead got %q", action)
+	}
+}
+
+func TestNewOTLP_invalid_endpoint(t *testing.T) {
+	_, err := newOTLP("127.0.0.1:4318", "test")
+	if err == nil {
+		t.Error("expected an error for the endpoint without the url scheme")
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// vgwPrometheus metrics type
+type vgwPrometheus struct {
+	registry *prometheus.Registry
+	service  string
+	ln       net.Listener
+	srv      *http.Server
+
+	mu         sync.Mutex
+	counters   map[string]*prometheus.CounterVec
+	histograms map[string]*prometheus.HistogramVec
+	gauges     map[string]*prometheus.GaugeVec
+}
+
+// newPrometheus takes a listen address and returns a prometheus metrics
+// publisher serving the counters over http on the '/metrics' path.
+// Supply service name to be used as a label to identify the specific
+// gateway instance, this may typically be the gateway hostname.
+func newPrometheus(addr string, service string) (*vgwPrometheus, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("prometheus listen %v: %w", addr, err)
+	}
+
+	p := &vgwPrometheus{
+		registry:   prometheus.NewRegistry(),
+		service:    service,
+		ln:         ln,
+		counters:   make(map[string]*prometheus.CounterVec),
+		histograms: make(map[string]*prometheus.HistogramVec),
+		gauges:     make(map[string]*prometheus.GaugeVec),
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(p.registry, promhttp.HandlerOpts{}))
+	p.srv = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		err := p.srv.Serve(ln)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("prometheus metrics server: %v", err)
+		}
+	}()
+
+	return p, nil
+}
+
+// Close shuts the metrics http server down
+func (p *vgwPrometheus) Close() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	p.srv.Shutdown(ctx)
+}
+
+// promName converts the metric key to a prometheus metric name,
+// e.g. 'success_count' results in 'versitygw_success_count'
+func promName(key string) string {
+	return "versitygw_" + promSanitize(key)
+}
+
+// promSanitize replaces the characters not allowed in the prometheus
+// metric and label names, e.g. the '.' of the metric prefix
+func promSanitize(name string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') ||
+			(r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// Add adds value to key
+func (p *vgwPrometheus) Add(key string, value int64, tags ...Tag) {
+	// the prometheus counters can't be decreased
+	if value < 0 {
+		return
+	}
+
+	labels := p.labels(tags)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	name := promName(key)
+	c, ok := p.counters[name]
+	if !ok {
+		c = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: name,
+			Help: fmt.Sprintf("versitygw %v counter", key),
+		}, labelNames(labels))
+		err := p.registry.Register(c)
+		if err != nil {
+			return
+		}
+		p.counters[name] = c
+	}
+
+	counter, err := c.GetMetricWith(labels)
+	if err != nil {
+		// the datapoints of a key have the same set of tags,
+		// drop the ones not matching the counter labels
+		return
+	}
+	counter.Add(float64(value))
+}
+
+// Timing observes the duration in seconds in the key histogram,
+// e.g. 'request_latency' results in 'versitygw_request_latency_seconds'
+func (p *vgwPrometheus) Timing(key string, d time.Duration, tags ...Tag) {
+	labels := p.labels(tags)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	name := promName(key) + "_seconds"
+	h, ok := p.histograms[name]
+	if !ok {
+		h = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    name,
+			Help:    fmt.Sprintf("versitygw %v histogram", key),
+			Buckets: prometheus.DefBuckets,
+		}, labelNames(labels))
+		err := p.registry.Register(h)
+		if err != nil {
+			return
+		}
+		p.histograms[name] = h
+	}
+
+	observer, err := h.GetMetricWith(labels)
+	if err != nil {
+		return
+	}
+	observer.Observe(d.Seconds())
+}
+
+// Gauge sets the key gauge to value
+func (p *vgwPrometheus) Gauge(key string, value int64, tags ...Tag) {
+	labels := p.labels(tags)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	name := promName(key)
+	g, ok := p.gauges[name]
+	if !ok {
+		g = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: name,
+			Help: fmt.Sprintf("versitygw %v gauge", key),
+		}, labelNames(labels))
+		err := p.registry.Register(g)
+		if err != nil {
+			return
+		}
+		p.gauges[name] = g
+	}
+
+	gauge, err := g.GetMetricWith(labels)
+	if err != nil {
+		return
+	}
+	gauge.Set(float64(value))
+}
+
+// labels returns the datapoint tags with the service as the labels
+func (p *vgwPrometheus) labels(tags []Tag) prometheus.Labels {
+	labels := prometheus.Labels{"service": p.service}
+	for _, t := range tags {
+		labels[promSanitize(t.Key)] = t.Value
+	}
+	return labels
+}
+
+// labelNames returns the sorted label names
+func labelNames(labels prometheus.Labels) []string {
+	names := make([]string, 0, len(labels))
+	for l := range labels {
+		names = append(names, l)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrometheus_Add(t *testing.T) {
+	p, err := newPrometheus("127.0.0.1:0", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tags := []Tag{
+		{Key: "action", Value: "PutObject"},
+		{Key: "status", Value: "200"},
+	}
+	p.Add("success_count", 1, tags...)
+	p.Add("success_count", 1, tags...)
+	p.Add("versitygw.bytes_written", 10, tags...)
+	// the negative values can't be added to the counters
+	p.Add("bytes_read", -1, tags...)
+	p.Timing("request_latency", 20*time.Millisecond, tags...)
+
+	resp, err := http.Get("http://" + p.ln.Addr().String() + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range []string{
+		`versitygw_success_count{action="PutObject",service="test",status="200"} 2`,
+		`versitygw_versitygw_bytes_written{action="PutObject",service="test",status="200"} 10`,
+		`versitygw_request_latency_seconds_bucket{action="PutObject",service="test",status="200",le="0.025"} 1`,
+		`versitygw_request_latency_seconds_count{action="PutObject",service="test",status="200"} 1`,
+	} {
+		if !strings.Contains(string(body), line) {
+			t.Errorf("expected the metrics to contain %q, instead got:\n%s", line, body)
+		}
+	}
+	if strings.Contains(string(body), "bytes_read") {
+		t.Errorf("expected no bytes_read metric, instead got:\n%s", body)
+	}
+
+	p.Close()
+	_, err = http.Get("http://" + p.ln.Addr().String() + "/metrics")
+	if err == nil {
+		t.Error("expected the metrics server to be shut down")
+	}
+}
@@ -15,37 +15,117 @@
 package metrics
 
 import (
-	"github.com/smira/go-statsd"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// server address schemes selecting the TCP transport,
+	// e.g. 'statsd+tcp://relay:8125'
+	statsdTCPScheme    = "statsd+tcp://"
+	dogStatsdTCPScheme = "dogstatsd+tcp://"
 )
 
 // vgwStatsd metrics type
 type vgwStatsd struct {
-	c *statsd.Client
+	w          *lineWriter
+	service    string
+	sampleRate float64
+	// dogstatsd selects the datadog tags format instead of
+	// the influxdb statsd format
+	dogstatsd bool
 }
 
 // newStatsd takes a server address and returns a statsd merics
 // Supply service name to be used as a tag to identify the spcific
-// gateway instance, this may typically be the gateway hostname
-func newStatsd(server string, service string) (*vgwStatsd, error) {
-	c := statsd.NewClient(
-		server,
-		statsd.MetricPrefix("versitygw."),
-		statsd.TagStyle(statsd.TagFormatInfluxDB),
-		statsd.DefaultTags(statsd.StringTag("service", service)),
-	)
-	return &vgwStatsd{c: c}, nil
+// gateway instance, this may typically be the gateway hostname.
+// Multiple metric lines are packed into a single UDP datagram, or
+// a single write to the persistent TCP connection if network is
+// 'tcp', up to the max packet size. The datapoints are sampled with
+// sampleRate, 0 disables all of the sends and 1 sends everything.
+func newStatsd(network, server, service string, maxPacketSize int, sampleRate float64, dogstatsd bool) (*vgwStatsd, error) {
+	w, err := newLineWriter(network, server, maxPacketSize)
+	if err != nil {
+		return nil, fmt.Errorf("statsd: %w", err)
+	}
+
+	return &vgwStatsd{
+		w:          w,
+		service:    service,
+		sampleRate: sampleRate,
+		dogstatsd:  dogstatsd,
+	}, nil
+}
+
+// tcpServer returns the server address without the scheme, if server
+// selects the TCP transport with scheme
+func tcpServer(server, scheme string) (string, bool) {
+	return strings.CutPrefix(server, scheme)
 }
 
 // Close closes statsd connections
 func (s *vgwStatsd) Close() {
-	s.c.Close()
+	s.w.Close()
 }
 
 // Add adds value to key
 func (s *vgwStatsd) Add(key string, value int64, tags ...Tag) {
-	stags := make([]statsd.Tag, len(tags))
-	for i, t := range tags {
-		stags[i] = statsd.StringTag(t.Key, t.Value)
+	s.send(key, strconv.FormatInt(value, 10), "c", tags)
+}
+
+// Timing sends the duration as a timer in milliseconds
+func (s *vgwStatsd) Timing(key string, d time.Duration, tags ...Tag) {
+	s.send(key, strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', -1, 64), "ms", tags)
+}
+
+// Gauge sets the gauge to value, the negative values are dropped
+// as the signed statsd gauge values change the gauge by the value
+func (s *vgwStatsd) Gauge(key string, value int64, tags ...Tag) {
+	if value < 0 || s.sampleRate <= 0 {
+		return
 	}
-	s.c.Incr(key, value, stags...)
+	// the gauges are not sampled, each value replaces the previous one
+	s.write(key, strconv.FormatInt(value, 10), "g", "", tags)
+}
+
+// send writes the sampled datapoint line with the statsd metric type
+func (s *vgwStatsd) send(key, value, typ string, tags []Tag) {
+	if s.sampleRate < 1 && rand.Float64() >= s.sampleRate {
+		return
+	}
+
+	var rate string
+	if s.sampleRate < 1 {
+		rate = "|@" + strconv.FormatFloat(s.sampleRate, 'g', -1, 64)
+	}
+
+	s.write(key, value, typ, rate, tags)
+}
+
+// write writes the datapoint line with the statsd metric type
+// and the sample rate suffix
+func (s *vgwStatsd) write(key, value, typ, rate string, tags []Tag) {
+	var b strings.Builder
+	b.WriteString("versitygw.")
+	b.WriteString(key)
+
+	if s.dogstatsd {
+		fmt.Fprintf(&b, ":%v|%v%v|#service:%v", value, typ, rate, s.service)
+		for _, t := range tags {
+			b.WriteByte(',')
+			b.WriteString(t.ddString())
+		}
+	} else {
+		fmt.Fprintf(&b, ",service=%v", s.service)
+		for _, t := range tags {
+			fmt.Fprintf(&b, ",%v=%v", t.Key, t.Value)
+		}
+		fmt.Fprintf(&b, ":%v|%v%v", value, typ, rate)
+	}
+	b.WriteByte('\n')
+
+	s.w.writeLine(b.String())
 }
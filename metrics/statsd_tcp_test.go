@@ -0,0 +1,91 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"context"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewManager_statsd_tcp(t *testing.T) {
+	listen := func() (net.Listener, <-chan string) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		received := make(chan string, 1)
+		go func() {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+			data, _ := io.ReadAll(conn)
+			received <- string(data)
+		}()
+		return ln, received
+	}
+
+	statsdLn, statsdData := listen()
+	defer statsdLn.Close()
+	dogLn, dogData := listen()
+	defer dogLn.Close()
+
+	mgr, err := NewManager(context.Background(), Config{
+		ServiceName:      "test",
+		StatsdServers:    statsdTCPScheme + statsdLn.Addr().String(),
+		DogStatsdServers: dogStatsdTCPScheme + dogLn.Addr().String(),
+		StatsdSampleRate: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mgr.add("success_count", 1, Tag{Key: "action", Value: "PutObject"})
+	mgr.add("bytes_written", 512)
+	// the batched datapoints are flushed on close
+	mgr.Close()
+
+	expected := map[string]struct {
+		data  <-chan string
+		lines []string
+	}{
+		"statsd": {statsdData, []string{
+			"versitygw.success_count,service=test,action=PutObject:1|c",
+			"versitygw.bytes_written,service=test:512|c",
+		}},
+		"dogstatsd": {dogData, []string{
+			"versitygw.success_count:1|c|#service:test,action:PutObject",
+			"versitygw.bytes_written:512|c|#service:test",
+		}},
+	}
+
+	for name, e := range expected {
+		select {
+		case data := <-e.data:
+			want := strings.Join(e.lines, "\n") + "\n"
+			if data != want {
+				t.Errorf("%v: expected %q, got %q", name, want, data)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("%v: expected the datapoints", name)
+		}
+	}
+}
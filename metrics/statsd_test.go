@@ -0,0 +1,159 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"errors"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatsd_MaxPacketSize(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	const (
+		maxPacketSize = 256
+		// the client send queue holds up to 10 datagrams
+		metricsCount = 20
+	)
+
+	s, err := newStatsd("udp", conn.LocalAddr().String(), "test", maxPacketSize, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// closing the client right away may cancel its connection, so the
+	// datagrams are flushed by the client periodic flush instead
+	defer s.Close()
+
+	for i := 0; i < metricsCount; i++ {
+		s.Add("success_count", 1, Tag{Key: "action", Value: "PutObject"})
+	}
+
+	var datagrams, lines int
+	buf := make([]byte, 65536)
+	for lines < metricsCount {
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := conn.ReadFrom(buf)
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n > maxPacketSize {
+			t.Errorf("expected the datagram size to be at most %v, instead got %v", maxPacketSize, n)
+		}
+		datagrams++
+		lines += len(strings.Split(strings.TrimSpace(string(buf[:n])), "\n"))
+	}
+
+	if lines != metricsCount {
+		t.Errorf("expected %v metric lines, instead got %v", metricsCount, lines)
+	}
+	if datagrams >= lines {
+		t.Errorf("expected multiple metric lines per datagram, instead got %v datagrams for %v lines", datagrams, lines)
+	}
+}
+
+func TestStatsd_SampleRate(t *testing.T) {
+	tests := []struct {
+		rate       float64
+		suffix     string
+		minLines   int
+		maxLines   int
+		datapoints int
+	}{
+		{rate: 0, datapoints: 100},
+		{rate: 0.5, suffix: "|c|@0.5", minLines: 1, maxLines: 99, datapoints: 100},
+		{rate: 1, suffix: "|c", minLines: 100, maxLines: 100, datapoints: 100},
+	}
+
+	for _, tt := range tests {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s, err := newStatsd("udp", conn.LocalAddr().String(), "test", 512, tt.rate, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < tt.datapoints; i++ {
+			s.Add("success_count", 1)
+		}
+		s.Close()
+
+		var lines int
+		buf := make([]byte, 65536)
+		for {
+			conn.SetReadDeadline(time.Now().Add(500 * time.Millisecond))
+			n, _, err := conn.ReadFrom(buf)
+			if errors.Is(err, os.ErrDeadlineExceeded) {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, line := range strings.Split(strings.TrimSpace(string(buf[:n])), "\n") {
+				if !strings.HasSuffix(line, tt.suffix) {
+					t.Errorf("rate %v: expected %q suffix, got %q", tt.rate, tt.suffix, line)
+				}
+				lines++
+			}
+		}
+		conn.Close()
+
+		if lines < tt.minLines || lines > tt.maxLines {
+			t.Errorf("rate %v: expected %v-%v metric lines, instead got %v",
+				tt.rate, tt.minLines, tt.maxLines, lines)
+		}
+	}
+}
+
+func TestStatsd_Timing(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	s, err := newStatsd("udp", conn.LocalAddr().String(), "test", 512, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Timing("request_latency", 1500*time.Microsecond, Tag{Key: "action", Value: "PutObject"})
+	s.Close()
+
+	buf := make([]byte, 65536)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "versitygw.request_latency,service=test,action=PutObject:1.5|ms\n"
+	if string(buf[:n]) != expected {
+		t.Fatalf("expected %q, got %q", expected, buf[:n])
+	}
+}
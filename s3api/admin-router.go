@@ -18,14 +18,15 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/versity/versitygw/auth"
 	"github.com/versity/versitygw/backend"
+	"github.com/versity/versitygw/metrics"
 	"github.com/versity/versitygw/s3api/controllers"
 	"github.com/versity/versitygw/s3log"
 )
 
 type S3AdminRouter struct{}
 
-func (ar *S3AdminRouter) Init(app *fiber.App, be backend.Backend, iam auth.IAMService, logger s3log.AuditLogger) {
-	controller := controllers.NewAdminController(iam, be, logger)
+func (ar *S3AdminRouter) Init(app *fiber.App, be backend.Backend, iam auth.IAMService, logger s3log.AuditLogger, mm *metrics.Manager) {
+	controller := controllers.NewAdminController(iam, be, logger, mm)
 
 	// CreateUser admin api
 	app.Patch("/create-user", controller.CreateUser)
@@ -44,4 +45,10 @@ func (ar *S3AdminRouter) Init(app *fiber.App, be backend.Backend, iam auth.IAMSe
 
 	// ListBucketsAndOwners admin api
 	app.Patch("/list-buckets", controller.ListBuckets)
+
+	// GetBucketStats admin api
+	app.Patch("/bucket-stats", controller.GetBucketStats)
+
+	// GetLatencySummary admin api
+	app.Patch("/latency-summary", controller.GetLatencySummary)
 }
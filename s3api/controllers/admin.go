@@ -24,6 +24,8 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/versity/versitygw/auth"
 	"github.com/versity/versitygw/backend"
+	"github.com/versity/versitygw/metrics"
+	"github.com/versity/versitygw/s3api/utils"
 	"github.com/versity/versitygw/s3log"
 )
 
@@ -31,10 +33,11 @@ type AdminController struct {
 	iam auth.IAMService
 	be  backend.Backend
 	l   s3log.AuditLogger
+	mm  *metrics.Manager
 }
 
-func NewAdminController(iam auth.IAMService, be backend.Backend, l s3log.AuditLogger) AdminController {
-	return AdminController{iam: iam, be: be, l: l}
+func NewAdminController(iam auth.IAMService, be backend.Backend, l s3log.AuditLogger, mm *metrics.Manager) AdminController {
+	return AdminController{iam: iam, be: be, l: l, mm: mm}
 }
 
 func (c AdminController) CreateUser(ctx *fiber.Ctx) error {
@@ -269,6 +272,66 @@ func (c AdminController) ListBuckets(ctx *fiber.Ctx) error {
 		})
 }
 
+func (c AdminController) GetBucketStats(ctx *fiber.Ctx) error {
+	acct := ctx.Locals("account").(auth.Account)
+	if acct.Role != "admin" {
+		return sendResponse(ctx, errors.New("access denied: only admin users have access to this resource"), nil,
+			&metaOptions{
+				logger: c.l,
+				status: fiber.StatusForbidden,
+				action: "admin:GetBucketStats",
+			})
+	}
+
+	bucket := ctx.Query("bucket")
+	if bucket == "" {
+		return sendResponse(ctx, errors.New("missing bucket parameter"), nil,
+			&metaOptions{
+				status: fiber.StatusBadRequest,
+				logger: c.l,
+				action: "admin:GetBucketStats",
+			})
+	}
+	if !utils.IsValidBucketName(bucket) {
+		return sendResponse(ctx, errors.New("invalid bucket name"), nil,
+			&metaOptions{
+				status: fiber.StatusBadRequest,
+				logger: c.l,
+				action: "admin:GetBucketStats",
+			})
+	}
+
+	stats, err := c.be.GetBucketStats(ctx.Context(), bucket)
+	return sendResponse(ctx, err, stats,
+		&metaOptions{
+			logger: c.l,
+			action: "admin:GetBucketStats",
+		})
+}
+
+func (c AdminController) GetLatencySummary(ctx *fiber.Ctx) error {
+	acct := ctx.Locals("account").(auth.Account)
+	if acct.Role != "admin" {
+		return sendResponse(ctx, errors.New("access denied: only admin users have access to this resource"), nil,
+			&metaOptions{
+				logger: c.l,
+				status: fiber.StatusForbidden,
+				action: "admin:GetLatencySummary",
+			})
+	}
+
+	summary := map[string]metrics.LatencySummary{}
+	if c.mm != nil {
+		summary = c.mm.LatencySummary()
+	}
+
+	return sendResponse(ctx, nil, summary,
+		&metaOptions{
+			logger: c.l,
+			action: "admin:GetLatencySummary",
+		})
+}
+
 type metaOptions struct {
 	action string
 	status int
@@ -25,6 +25,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/versity/versitygw/auth"
+	"github.com/versity/versitygw/metrics"
 	"github.com/versity/versitygw/s3response"
 )
 
@@ -595,3 +596,171 @@ func TestAdminController_ListBuckets(t *testing.T) {
 		}
 	}
 }
+
+func TestAdminController_GetBucketStats(t *testing.T) {
+	type args struct {
+		req *http.Request
+	}
+	adminController := AdminController{
+		be: &BackendMock{
+			GetBucketStatsFunc: func(contextMoqParam context.Context, bucket string) (s3response.BucketStats, error) {
+				return s3response.BucketStats{Bucket: bucket}, nil
+			},
+		},
+	}
+
+	app := fiber.New()
+
+	app.Use(func(ctx *fiber.Ctx) error {
+		ctx.Locals("account", auth.Account{Access: "admin1", Secret: "secret", Role: "admin"})
+		return ctx.Next()
+	})
+
+	app.Patch("/bucket-stats", adminController.GetBucketStats)
+
+	appRoleErr := fiber.New()
+
+	appRoleErr.Use(func(ctx *fiber.Ctx) error {
+		ctx.Locals("account", auth.Account{Access: "user1", Secret: "secret", Role: "user"})
+		return ctx.Next()
+	})
+
+	appRoleErr.Patch("/bucket-stats", adminController.GetBucketStats)
+
+	tests := []struct {
+		name       string
+		app        *fiber.App
+		args       args
+		wantErr    bool
+		statusCode int
+	}{
+		{
+			name: "Get-bucket-stats-incorrect-role",
+			app:  appRoleErr,
+			args: args{
+				req: httptest.NewRequest(http.MethodPatch, "/bucket-stats?bucket=my-bucket", nil),
+			},
+			wantErr:    false,
+			statusCode: 403,
+		},
+		{
+			name: "Get-bucket-stats-missing-bucket",
+			app:  app,
+			args: args{
+				req: httptest.NewRequest(http.MethodPatch, "/bucket-stats", nil),
+			},
+			wantErr:    false,
+			statusCode: 400,
+		},
+		{
+			name: "Get-bucket-stats-invalid-bucket-name",
+			app:  app,
+			args: args{
+				req: httptest.NewRequest(http.MethodPatch, "/bucket-stats?bucket=..", nil),
+			},
+			wantErr:    false,
+			statusCode: 400,
+		},
+		{
+			name: "Get-bucket-stats-metadata-dir",
+			app:  app,
+			args: args{
+				req: httptest.NewRequest(http.MethodPatch, "/bucket-stats?bucket=.sgwtmp", nil),
+			},
+			wantErr:    false,
+			statusCode: 400,
+		},
+		{
+			name: "Get-bucket-stats-success",
+			app:  app,
+			args: args{
+				req: httptest.NewRequest(http.MethodPatch, "/bucket-stats?bucket=my-bucket", nil),
+			},
+			wantErr:    false,
+			statusCode: 200,
+		},
+	}
+	for _, tt := range tests {
+		resp, err := tt.app.Test(tt.args.req)
+
+		if (err != nil) != tt.wantErr {
+			t.Errorf("AdminController.GetBucketStats() error = %v, wantErr %v", err, tt.wantErr)
+		}
+
+		if resp.StatusCode != tt.statusCode {
+			t.Errorf("AdminController.GetBucketStats() statusCode = %v, wantStatusCode = %v", resp.StatusCode, tt.statusCode)
+		}
+	}
+}
+
+func TestAdminController_GetLatencySummary(t *testing.T) {
+	type args struct {
+		req *http.Request
+	}
+
+	mm, err := metrics.NewManager(context.Background(), metrics.Config{ServiceName: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mm.Close()
+
+	adminController := AdminController{
+		mm: mm,
+	}
+
+	app := fiber.New()
+
+	app.Use(func(ctx *fiber.Ctx) error {
+		ctx.Locals("account", auth.Account{Access: "admin1", Secret: "secret", Role: "admin"})
+		return ctx.Next()
+	})
+
+	app.Patch("/latency-summary", adminController.GetLatencySummary)
+
+	appRoleErr := fiber.New()
+
+	appRoleErr.Use(func(ctx *fiber.Ctx) error {
+		ctx.Locals("account", auth.Account{Access: "user1", Secret: "secret", Role: "user"})
+		return ctx.Next()
+	})
+
+	appRoleErr.Patch("/latency-summary", adminController.GetLatencySummary)
+
+	tests := []struct {
+		name       string
+		app        *fiber.App
+		args       args
+		wantErr    bool
+		statusCode int
+	}{
+		{
+			name: "Get-latency-summary-incorrect-role",
+			app:  appRoleErr,
+			args: args{
+				req: httptest.NewRequest(http.MethodPatch, "/latency-summary", nil),
+			},
+			wantErr:    false,
+			statusCode: 403,
+		},
+		{
+			name: "Get-latency-summary-success",
+			app:  app,
+			args: args{
+				req: httptest.NewRequest(http.MethodPatch, "/latency-summary", nil),
+			},
+			wantErr:    false,
+			statusCode: 200,
+		},
+	}
+	for _, tt := range tests {
+		resp, err := tt.app.Test(tt.args.req)
+
+		if (err != nil) != tt.wantErr {
+			t.Errorf("AdminController.GetLatencySummary() error = %v, wantErr %v", err, tt.wantErr)
+		}
+
+		if resp.StatusCode != tt.statusCode {
+			t.Errorf("AdminController.GetLatencySummary() statusCode = %v, wantStatusCode = %v", resp.StatusCode, tt.statusCode)
+		}
+	}
+}
@@ -62,6 +62,9 @@ var _ backend.Backend = &BackendMock{}
 //			DeleteObjectsFunc: func(contextMoqParam context.Context, deleteObjectsInput *s3.DeleteObjectsInput) (s3response.DeleteResult, error) {
 //				panic("mock out the DeleteObjects method")
 //			},
+//			GetBucketAccelerateConfigurationFunc: func(contextMoqParam context.Context, bucket string) (types.BucketAccelerateStatus, error) {
+//				panic("mock out the GetBucketAccelerateConfiguration method")
+//			},
 //			GetBucketAclFunc: func(contextMoqParam context.Context, getBucketAclInput *s3.GetBucketAclInput) ([]byte, error) {
 //				panic("mock out the GetBucketAcl method")
 //			},
@@ -71,6 +74,9 @@ var _ backend.Backend = &BackendMock{}
 //			GetBucketPolicyFunc: func(contextMoqParam context.Context, bucket string) ([]byte, error) {
 //				panic("mock out the GetBucketPolicy method")
 //			},
+//			GetBucketStatsFunc: func(contextMoqParam context.Context, bucket string) (s3response.BucketStats, error) {
+//				panic("mock out the GetBucketStats method")
+//			},
 //			GetBucketTaggingFunc: func(contextMoqParam context.Context, bucket string) (map[string]string, error) {
 //				panic("mock out the GetBucketTagging method")
 //			},
@@ -125,6 +131,12 @@ var _ backend.Backend = &BackendMock{}
 //			ListPartsFunc: func(contextMoqParam context.Context, listPartsInput *s3.ListPartsInput) (s3response.ListPartsResult, error) {
 //				panic("mock out the ListParts method")
 //			},
+//			MoveObjectFunc: func(contextMoqParam context.Context, copyObjectInput *s3.CopyObjectInput) (*s3.CopyObjectOutput, error) {
+//				panic("mock out the MoveObject method")
+//			},
+//			PutBucketAccelerateConfigurationFunc: func(contextMoqParam context.Context, bucket string, status types.BucketAccelerateStatus) error {
+//				panic("mock out the PutBucketAccelerateConfiguration method")
+//			},
 //			PutBucketAclFunc: func(contextMoqParam context.Context, bucket string, data []byte) error {
 //				panic("mock out the PutBucketAcl method")
 //			},
@@ -222,6 +234,9 @@ type BackendMock struct {
 	// DeleteObjectsFunc mocks the DeleteObjects method.
 	DeleteObjectsFunc func(contextMoqParam context.Context, deleteObjectsInput *s3.DeleteObjectsInput) (s3response.DeleteResult, error)
 
+	// GetBucketAccelerateConfigurationFunc mocks the GetBucketAccelerateConfiguration method.
+	GetBucketAccelerateConfigurationFunc func(contextMoqParam context.Context, bucket string) (types.BucketAccelerateStatus, error)
+
 	// GetBucketAclFunc mocks the GetBucketAcl method.
 	GetBucketAclFunc func(contextMoqParam context.Context, getBucketAclInput *s3.GetBucketAclInput) ([]byte, error)
 
@@ -231,6 +246,9 @@ type BackendMock struct {
 	// GetBucketPolicyFunc mocks the GetBucketPolicy method.
 	GetBucketPolicyFunc func(contextMoqParam context.Context, bucket string) ([]byte, error)
 
+	// GetBucketStatsFunc mocks the GetBucketStats method.
+	GetBucketStatsFunc func(contextMoqParam context.Context, bucket string) (s3response.BucketStats, error)
+
 	// GetBucketTaggingFunc mocks the GetBucketTagging method.
 	GetBucketTaggingFunc func(contextMoqParam context.Context, bucket string) (map[string]string, error)
 
@@ -285,6 +303,12 @@ type BackendMock struct {
 	// ListPartsFunc mocks the ListParts method.
 	ListPartsFunc func(contextMoqParam context.Context, listPartsInput *s3.ListPartsInput) (s3response.ListPartsResult, error)
 
+	// MoveObjectFunc mocks the MoveObject method.
+	MoveObjectFunc func(contextMoqParam context.Context, copyObjectInput *s3.CopyObjectInput) (*s3.CopyObjectOutput, error)
+
+	// PutBucketAccelerateConfigurationFunc mocks the PutBucketAccelerateConfiguration method.
+	PutBucketAccelerateConfigurationFunc func(contextMoqParam context.Context, bucket string, status types.BucketAccelerateStatus) error
+
 	// PutBucketAclFunc mocks the PutBucketAcl method.
 	PutBucketAclFunc func(contextMoqParam context.Context, bucket string, data []byte) error
 
@@ -435,6 +459,13 @@ type BackendMock struct {
 			// DeleteObjectsInput is the deleteObjectsInput argument value.
 			DeleteObjectsInput *s3.DeleteObjectsInput
 		}
+		// GetBucketAccelerateConfiguration holds details about calls to the GetBucketAccelerateConfiguration method.
+		GetBucketAccelerateConfiguration []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// Bucket is the bucket argument value.
+			Bucket string
+		}
 		// GetBucketAcl holds details about calls to the GetBucketAcl method.
 		GetBucketAcl []struct {
 			// ContextMoqParam is the contextMoqParam argument value.
@@ -456,6 +487,13 @@ type BackendMock struct {
 			// Bucket is the bucket argument value.
 			Bucket string
 		}
+		// GetBucketStats holds details about calls to the GetBucketStats method.
+		GetBucketStats []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// Bucket is the bucket argument value.
+			Bucket string
+		}
 		// GetBucketTagging holds details about calls to the GetBucketTagging method.
 		GetBucketTagging []struct {
 			// ContextMoqParam is the contextMoqParam argument value.
@@ -592,6 +630,22 @@ type BackendMock struct {
 			// ListPartsInput is the listPartsInput argument value.
 			ListPartsInput *s3.ListPartsInput
 		}
+		// MoveObject holds details about calls to the MoveObject method.
+		MoveObject []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// CopyObjectInput is the copyObjectInput argument value.
+			CopyObjectInput *s3.CopyObjectInput
+		}
+		// PutBucketAccelerateConfiguration holds details about calls to the PutBucketAccelerateConfiguration method.
+		PutBucketAccelerateConfiguration []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// Bucket is the bucket argument value.
+			Bucket string
+			// Status is the status argument value.
+			Status types.BucketAccelerateStatus
+		}
 		// PutBucketAcl holds details about calls to the PutBucketAcl method.
 		PutBucketAcl []struct {
 			// ContextMoqParam is the contextMoqParam argument value.
@@ -734,57 +788,61 @@ type BackendMock struct {
 			UploadPartCopyInput *s3.UploadPartCopyInput
 		}
 	}
-	lockAbortMultipartUpload          sync.RWMutex
-	lockChangeBucketOwner             sync.RWMutex
-	lockCompleteMultipartUpload       sync.RWMutex
-	lockCopyObject                    sync.RWMutex
-	lockCreateBucket                  sync.RWMutex
-	lockCreateMultipartUpload         sync.RWMutex
-	lockDeleteBucket                  sync.RWMutex
-	lockDeleteBucketOwnershipControls sync.RWMutex
-	lockDeleteBucketPolicy            sync.RWMutex
-	lockDeleteBucketTagging           sync.RWMutex
-	lockDeleteObject                  sync.RWMutex
-	lockDeleteObjectTagging           sync.RWMutex
-	lockDeleteObjects                 sync.RWMutex
-	lockGetBucketAcl                  sync.RWMutex
-	lockGetBucketOwnershipControls    sync.RWMutex
-	lockGetBucketPolicy               sync.RWMutex
-	lockGetBucketTagging              sync.RWMutex
-	lockGetBucketVersioning           sync.RWMutex
-	lockGetObject                     sync.RWMutex
-	lockGetObjectAcl                  sync.RWMutex
-	lockGetObjectAttributes           sync.RWMutex
-	lockGetObjectLegalHold            sync.RWMutex
-	lockGetObjectLockConfiguration    sync.RWMutex
-	lockGetObjectRetention            sync.RWMutex
-	lockGetObjectTagging              sync.RWMutex
-	lockHeadBucket                    sync.RWMutex
-	lockHeadObject                    sync.RWMutex
-	lockListBuckets                   sync.RWMutex
-	lockListBucketsAndOwners          sync.RWMutex
-	lockListMultipartUploads          sync.RWMutex
-	lockListObjectVersions            sync.RWMutex
-	lockListObjects                   sync.RWMutex
-	lockListObjectsV2                 sync.RWMutex
-	lockListParts                     sync.RWMutex
-	lockPutBucketAcl                  sync.RWMutex
-	lockPutBucketOwnershipControls    sync.RWMutex
-	lockPutBucketPolicy               sync.RWMutex
-	lockPutBucketTagging              sync.RWMutex
-	lockPutBucketVersioning           sync.RWMutex
-	lockPutObject                     sync.RWMutex
-	lockPutObjectAcl                  sync.RWMutex
-	lockPutObjectLegalHold            sync.RWMutex
-	lockPutObjectLockConfiguration    sync.RWMutex
-	lockPutObjectRetention            sync.RWMutex
-	lockPutObjectTagging              sync.RWMutex
-	lockRestoreObject                 sync.RWMutex
-	lockSelectObjectContent           sync.RWMutex
-	lockShutdown                      sync.RWMutex
-	lockString                        sync.RWMutex
-	lockUploadPart                    sync.RWMutex
-	lockUploadPartCopy                sync.RWMutex
+	lockAbortMultipartUpload             sync.RWMutex
+	lockChangeBucketOwner                sync.RWMutex
+	lockCompleteMultipartUpload          sync.RWMutex
+	lockCopyObject                       sync.RWMutex
+	lockCreateBucket                     sync.RWMutex
+	lockCreateMultipartUpload            sync.RWMutex
+	lockDeleteBucket                     sync.RWMutex
+	lockDeleteBucketOwnershipControls    sync.RWMutex
+	lockDeleteBucketPolicy               sync.RWMutex
+	lockDeleteBucketTagging              sync.RWMutex
+	lockDeleteObject                     sync.RWMutex
+	lockDeleteObjectTagging              sync.RWMutex
+	lockDeleteObjects                    sync.RWMutex
+	lockGetBucketAccelerateConfiguration sync.RWMutex
+	lockGetBucketAcl                     sync.RWMutex
+	lockGetBucketOwnershipControls       sync.RWMutex
+	lockGetBucketPolicy                  sync.RWMutex
+	lockGetBucketStats                   sync.RWMutex
+	lockGetBucketTagging                 sync.RWMutex
+	lockGetBucketVersioning              sync.RWMutex
+	lockGetObject                        sync.RWMutex
+	lockGetObjectAcl                     sync.RWMutex
+	lockGetObjectAttributes              sync.RWMutex
+	lockGetObjectLegalHold               sync.RWMutex
+	lockGetObjectLockConfiguration       sync.RWMutex
+	lockGetObjectRetention               sync.RWMutex
+	lockGetObjectTagging                 sync.RWMutex
+	lockHeadBucket                       sync.RWMutex
+	lockHeadObject                       sync.RWMutex
+	lockListBuckets                      sync.RWMutex
+	lockListBucketsAndOwners             sync.RWMutex
+	lockListMultipartUploads             sync.RWMutex
+	lockListObjectVersions               sync.RWMutex
+	lockListObjects                      sync.RWMutex
+	lockListObjectsV2                    sync.RWMutex
+	lockListParts                        sync.RWMutex
+	lockMoveObject                       sync.RWMutex
+	lockPutBucketAccelerateConfiguration sync.RWMutex
+	lockPutBucketAcl                     sync.RWMutex
+	lockPutBucketOwnershipControls       sync.RWMutex
+	lockPutBucketPolicy                  sync.RWMutex
+	lockPutBucketTagging                 sync.RWMutex
+	lockPutBucketVersioning              sync.RWMutex
+	lockPutObject                        sync.RWMutex
+	lockPutObjectAcl                     sync.RWMutex
+	lockPutObjectLegalHold               sync.RWMutex
+	lockPutObjectLockConfiguration       sync.RWMutex
+	lockPutObjectRetention               sync.RWMutex
+	lockPutObjectTagging                 sync.RWMutex
+	lockRestoreObject                    sync.RWMutex
+	lockSelectObjectContent              sync.RWMutex
+	lockShutdown                         sync.RWMutex
+	lockString                           sync.RWMutex
+	lockUploadPart                       sync.RWMutex
+	lockUploadPartCopy                   sync.RWMutex
 }
 
 // AbortMultipartUpload calls AbortMultipartUploadFunc.
@@ -1267,6 +1325,42 @@ func (mock *BackendMock) DeleteObjectsCalls() []struct {
 	return calls
 }
 
+// GetBucketAccelerateConfiguration calls GetBucketAccelerateConfigurationFunc.
+func (mock *BackendMock) GetBucketAccelerateConfiguration(contextMoqParam context.Context, bucket string) (types.BucketAccelerateStatus, error) {
+	if mock.GetBucketAccelerateConfigurationFunc == nil {
+		panic("BackendMock.GetBucketAccelerateConfigurationFunc: method is nil but Backend.GetBucketAccelerateConfiguration was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+		Bucket          string
+	}{
+		ContextMoqParam: contextMoqParam,
+		Bucket:          bucket,
+	}
+	mock.lockGetBucketAccelerateConfiguration.Lock()
+	mock.calls.GetBucketAccelerateConfiguration = append(mock.calls.GetBucketAccelerateConfiguration, callInfo)
+	mock.lockGetBucketAccelerateConfiguration.Unlock()
+	return mock.GetBucketAccelerateConfigurationFunc(contextMoqParam, bucket)
+}
+
+// GetBucketAccelerateConfigurationCalls gets all the calls that were made to GetBucketAccelerateConfiguration.
+// Check the length with:
+//
+//	len(mockedBackend.GetBucketAccelerateConfigurationCalls())
+func (mock *BackendMock) GetBucketAccelerateConfigurationCalls() []struct {
+	ContextMoqParam context.Context
+	Bucket          string
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+		Bucket          string
+	}
+	mock.lockGetBucketAccelerateConfiguration.RLock()
+	calls = mock.calls.GetBucketAccelerateConfiguration
+	mock.lockGetBucketAccelerateConfiguration.RUnlock()
+	return calls
+}
+
 // GetBucketAcl calls GetBucketAclFunc.
 func (mock *BackendMock) GetBucketAcl(contextMoqParam context.Context, getBucketAclInput *s3.GetBucketAclInput) ([]byte, error) {
 	if mock.GetBucketAclFunc == nil {
@@ -1375,6 +1469,42 @@ func (mock *BackendMock) GetBucketPolicyCalls() []struct {
 	return calls
 }
 
+// GetBucketStats calls GetBucketStatsFunc.
+func (mock *BackendMock) GetBucketStats(contextMoqParam context.Context, bucket string) (s3response.BucketStats, error) {
+	if mock.GetBucketStatsFunc == nil {
+		panic("BackendMock.GetBucketStatsFunc: method is nil but Backend.GetBucketStats was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+		Bucket          string
+	}{
+		ContextMoqParam: contextMoqParam,
+		Bucket:          bucket,
+	}
+	mock.lockGetBucketStats.Lock()
+	mock.calls.GetBucketStats = append(mock.calls.GetBucketStats, callInfo)
+	mock.lockGetBucketStats.Unlock()
+	return mock.GetBucketStatsFunc(contextMoqParam, bucket)
+}
+
+// GetBucketStatsCalls gets all the calls that were made to GetBucketStats.
+// Check the length with:
+//
+//	len(mockedBackend.GetBucketStatsCalls())
+func (mock *BackendMock) GetBucketStatsCalls() []struct {
+	ContextMoqParam context.Context
+	Bucket          string
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+		Bucket          string
+	}
+	mock.lockGetBucketStats.RLock()
+	calls = mock.calls.GetBucketStats
+	mock.lockGetBucketStats.RUnlock()
+	return calls
+}
+
 // GetBucketTagging calls GetBucketTaggingFunc.
 func (mock *BackendMock) GetBucketTagging(contextMoqParam context.Context, bucket string) (map[string]string, error) {
 	if mock.GetBucketTaggingFunc == nil {
@@ -2043,6 +2173,82 @@ func (mock *BackendMock) ListPartsCalls() []struct {
 	return calls
 }
 
+// MoveObject calls MoveObjectFunc.
+func (mock *BackendMock) MoveObject(contextMoqParam context.Context, copyObjectInput *s3.CopyObjectInput) (*s3.CopyObjectOutput, error) {
+	if mock.MoveObjectFunc == nil {
+		panic("BackendMock.MoveObjectFunc: method is nil but Backend.MoveObject was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+		CopyObjectInput *s3.CopyObjectInput
+	}{
+		ContextMoqParam: contextMoqParam,
+		CopyObjectInput: copyObjectInput,
+	}
+	mock.lockMoveObject.Lock()
+	mock.calls.MoveObject = append(mock.calls.MoveObject, callInfo)
+	mock.lockMoveObject.Unlock()
+	return mock.MoveObjectFunc(contextMoqParam, copyObjectInput)
+}
+
+// MoveObjectCalls gets all the calls that were made to MoveObject.
+// Check the length with:
+//
+//	len(mockedBackend.MoveObjectCalls())
+func (mock *BackendMock) MoveObjectCalls() []struct {
+	ContextMoqParam context.Context
+	CopyObjectInput *s3.CopyObjectInput
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+		CopyObjectInput *s3.CopyObjectInput
+	}
+	mock.lockMoveObject.RLock()
+	calls = mock.calls.MoveObject
+	mock.lockMoveObject.RUnlock()
+	return calls
+}
+
+// PutBucketAccelerateConfiguration calls PutBucketAccelerateConfigurationFunc.
+func (mock *BackendMock) PutBucketAccelerateConfiguration(contextMoqParam context.Context, bucket string, status types.BucketAccelerateStatus) error {
+	if mock.PutBucketAccelerateConfigurationFunc == nil {
+		panic("BackendMock.PutBucketAccelerateConfigurationFunc: method is nil but Backend.PutBucketAccelerateConfiguration was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+		Bucket          string
+		Status          types.BucketAccelerateStatus
+	}{
+		ContextMoqParam: contextMoqParam,
+		Bucket:          bucket,
+		Status:          status,
+	}
+	mock.lockPutBucketAccelerateConfiguration.Lock()
+	mock.calls.PutBucketAccelerateConfiguration = append(mock.calls.PutBucketAccelerateConfiguration, callInfo)
+	mock.lockPutBucketAccelerateConfiguration.Unlock()
+	return mock.PutBucketAccelerateConfigurationFunc(contextMoqParam, bucket, status)
+}
+
+// PutBucketAccelerateConfigurationCalls gets all the calls that were made to PutBucketAccelerateConfiguration.
+// Check the length with:
+//
+//	len(mockedBackend.PutBucketAccelerateConfigurationCalls())
+func (mock *BackendMock) PutBucketAccelerateConfigurationCalls() []struct {
+	ContextMoqParam context.Context
+	Bucket          string
+	Status          types.BucketAccelerateStatus
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+		Bucket          string
+		Status          types.BucketAccelerateStatus
+	}
+	mock.lockPutBucketAccelerateConfiguration.RLock()
+	calls = mock.calls.PutBucketAccelerateConfiguration
+	mock.lockPutBucketAccelerateConfiguration.RUnlock()
+	return calls
+}
+
 // PutBucketAcl calls PutBucketAclFunc.
 func (mock *BackendMock) PutBucketAcl(contextMoqParam context.Context, bucket string, data []byte) error {
 	if mock.PutBucketAclFunc == nil {
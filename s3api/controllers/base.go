@@ -48,11 +48,15 @@ type S3ApiController struct {
 	mm       *metrics.Manager
 	debug    bool
 	readonly bool
+	// the results of PutObject requests with idempotency tokens
+	idempotency *utils.IdempotencyCache
 }
 
 const (
 	iso8601Format      = "20060102T150405Z"
 	defaultContentType = "binary/octet-stream"
+	// max number of keys in a single DeleteObjects request
+	maxDeleteObjects = 1000
 )
 
 func New(be backend.Backend, iam auth.IAMService, logger s3log.AuditLogger, evs s3event.S3EventSender, mm *metrics.Manager, debug bool, readonly bool) S3ApiController {
@@ -64,6 +68,8 @@ func New(be backend.Backend, iam auth.IAMService, logger s3log.AuditLogger, evs
 		debug:    debug,
 		readonly: readonly,
 		mm:       mm,
+
+		idempotency: utils.NewIdempotencyCache(),
 	}
 }
 
@@ -90,6 +96,15 @@ func (c S3ApiController) GetActions(ctx *fiber.Ctx) error {
 	isRoot := ctx.Locals("isRoot").(bool)
 	parsedAcl := ctx.Locals("parsedAcl").(auth.ACL)
 	versionId := ctx.Query("versionId")
+	if strings.Contains(acceptRange, ",") {
+		// S3 doesn't support multiple ranges in a request,
+		// the range is ignored and the whole object returned
+		acceptRange = ""
+	}
+	if acceptRange != "" && !backend.IsValidRange(acceptRange) {
+		// a malformed range is ignored as well
+		acceptRange = ""
+	}
 	if keyEnd != "" {
 		key = strings.Join([]string{key, keyEnd}, "/")
 	}
@@ -98,6 +113,18 @@ func (c S3ApiController) GetActions(ctx *fiber.Ctx) error {
 		key = key + "/"
 	}
 
+	// GetObjectTorrent is not supported, the request
+	// shouldn't fall through to GetObject
+	if ctx.Request().URI().QueryArgs().Has("torrent") {
+		return SendResponse(ctx, s3err.GetAPIError(s3err.ErrNotImplemented),
+			&MetaOpts{
+				Logger:      c.logger,
+				MetricsMng:  c.mm,
+				Action:      metrics.ActionGetObjectTorrent,
+				BucketOwner: parsedAcl.Owner,
+			})
+	}
+
 	if ctx.Request().URI().QueryArgs().Has("tagging") {
 		err := auth.VerifyAccess(ctx.Context(), c.be, auth.AccessOptions{
 			Readonly:      c.readonly,
@@ -407,15 +434,68 @@ func (c S3ApiController) GetActions(ctx *fiber.Ctx) error {
 			})
 	}
 
+	// the response header overrides are only allowed
+	// for the authenticated requests
+	overrides := utils.GetResponseOverrides(ctx)
+	if len(overrides) != 0 && acct.Access == "" {
+		return SendResponse(ctx, s3err.GetAPIError(s3err.ErrAnonymousResponseHeaders),
+			&MetaOpts{
+				Logger:      c.logger,
+				MetricsMng:  c.mm,
+				Action:      metrics.ActionGetObject,
+				BucketOwner: parsedAcl.Owner,
+			})
+	}
+
+	sseCKey, err := utils.ParseSSECustomerHeaders(ctx)
+	if err != nil {
+		return SendResponse(ctx, err,
+			&MetaOpts{
+				Logger:      c.logger,
+				MetricsMng:  c.mm,
+				Action:      metrics.ActionGetObject,
+				BucketOwner: parsedAcl.Owner,
+			})
+	}
+
+	// the preconditions are checked before reading the object,
+	// so that no object data is read for the failing requests
+	if conds := utils.ParseConditionalHeaders(ctx); conds.IsSet() {
+		hres, err := c.be.HeadObject(ctx.Context(), &s3.HeadObjectInput{
+			Bucket:               &bucket,
+			Key:                  &key,
+			VersionId:            &versionId,
+			SSECustomerAlgorithm: backend.GetPtrFromString(sseCKey.Algorithm),
+			SSECustomerKey:       backend.GetPtrFromString(sseCKey.Key),
+			SSECustomerKeyMD5:    backend.GetPtrFromString(sseCKey.KeyMD5),
+		})
+		// the head object errors are handled by GetObject below
+		if err == nil && hres != nil {
+			err = checkPreconditions(conds, hres)
+			if err != nil {
+				return SendResponse(ctx, err,
+					&MetaOpts{
+						Logger:      c.logger,
+						MetricsMng:  c.mm,
+						Action:      metrics.ActionGetObject,
+						BucketOwner: parsedAcl.Owner,
+					})
+			}
+		}
+	}
+
 	ctx.Locals("logResBody", false)
 	res, err := c.be.GetObject(ctx.Context(), &s3.GetObjectInput{
-		Bucket:    &bucket,
-		Key:       &key,
-		Range:     &acceptRange,
-		VersionId: &versionId,
+		Bucket:               &bucket,
+		Key:                  &key,
+		Range:                &acceptRange,
+		VersionId:            &versionId,
+		SSECustomerAlgorithm: backend.GetPtrFromString(sseCKey.Algorithm),
+		SSECustomerKey:       backend.GetPtrFromString(sseCKey.Key),
+		SSECustomerKeyMD5:    backend.GetPtrFromString(sseCKey.KeyMD5),
 	})
 	if err != nil {
-		if res != nil {
+		if res != nil && res.DeleteMarker != nil && *res.DeleteMarker {
 			utils.SetResponseHeaders(ctx, []utils.CustomHeader{
 				{
 					Key:   "x-amz-delete-marker",
@@ -427,6 +507,15 @@ func (c S3ApiController) GetActions(ctx *fiber.Ctx) error {
 				},
 			})
 		}
+		if res != nil && getstring(res.ContentRange) != "" {
+			// the unsatisfiable range errors report the object size
+			utils.SetResponseHeaders(ctx, []utils.CustomHeader{
+				{
+					Key:   "Content-Range",
+					Value: getstring(res.ContentRange),
+				},
+			})
+		}
 		return SendResponse(ctx, err,
 			&MetaOpts{
 				Logger:      c.logger,
@@ -478,6 +567,18 @@ func (c S3ApiController) GetActions(ctx *fiber.Ctx) error {
 			Value: getstring(res.ContentEncoding),
 		})
 	}
+	if getstring(res.CacheControl) != "" {
+		hdrs = append(hdrs, utils.CustomHeader{
+			Key:   "Cache-Control",
+			Value: getstring(res.CacheControl),
+		})
+	}
+	if getstring(res.ContentDisposition) != "" {
+		hdrs = append(hdrs, utils.CustomHeader{
+			Key:   "Content-Disposition",
+			Value: getstring(res.ContentDisposition),
+		})
+	}
 	if res.TagCount != nil {
 		hdrs = append(hdrs, utils.CustomHeader{
 			Key:   "x-amz-tagging-count",
@@ -490,11 +591,32 @@ func (c S3ApiController) GetActions(ctx *fiber.Ctx) error {
 			Value: string(res.StorageClass),
 		})
 	}
+	if getstring(res.SSECustomerAlgorithm) != "" {
+		hdrs = append(hdrs, utils.CustomHeader{
+			Key:   "x-amz-server-side-encryption-customer-algorithm",
+			Value: getstring(res.SSECustomerAlgorithm),
+		}, utils.CustomHeader{
+			Key:   "x-amz-server-side-encryption-customer-key-MD5",
+			Value: getstring(res.SSECustomerKeyMD5),
+		})
+	}
+	// the checksums are of the whole object, and aren't
+	// returned for the range requests
+	if ctx.Get("X-Amz-Checksum-Mode") == string(types.ChecksumModeEnabled) && acceptRange == "" {
+		hdrs = append(hdrs, checksumHeaders(types.Checksum{
+			ChecksumCRC32:  res.ChecksumCRC32,
+			ChecksumCRC32C: res.ChecksumCRC32C,
+			ChecksumSHA1:   res.ChecksumSHA1,
+			ChecksumSHA256: res.ChecksumSHA256,
+		})...)
+	}
 
 	// Set x-amz-meta-... headers
 	utils.SetMetaHeaders(ctx, res.Metadata)
 	// Set other response headers
 	utils.SetResponseHeaders(ctx, hdrs)
+	// Override the stored headers with the request response-* params
+	utils.SetResponseHeaders(ctx, overrides)
 	// Set version id header
 	if getstring(res.VersionId) != "" {
 		utils.SetResponseHeaders(ctx, []utils.CustomHeader{
@@ -511,7 +633,12 @@ func (c S3ApiController) GetActions(ctx *fiber.Ctx) error {
 	}
 
 	if res.Body != nil {
-		ctx.Response().SetBodyStream(res.Body, int(getint64(res.ContentLength)))
+		var body io.Reader = res.Body
+		// hold the concurrency limit slot, until the object is read
+		if release, ok := ctx.Locals("release-slot").(func()); ok {
+			body = utils.NewReleaseReadCloser(res.Body, release)
+		}
+		ctx.Response().SetBodyStream(body, int(getint64(res.ContentLength)))
 	}
 
 	return SendResponse(ctx, nil,
@@ -525,6 +652,59 @@ func (c S3ApiController) GetActions(ctx *fiber.Ctx) error {
 		})
 }
 
+// moveObject moves the copy source object to the destination. The backends
+// not supporting the move natively fall back to copy and delete.
+func (c S3ApiController) moveObject(ctx *fiber.Ctx, input *s3.CopyObjectInput, acct auth.Account) (*s3.CopyObjectOutput, error) {
+	srcBucket, srcObject, _, err := backend.ParseCopySource(*input.CopySource)
+	if err != nil {
+		return nil, err
+	}
+
+	// moving an object onto itself would delete the only copy
+	// of it in the copy and delete fallback
+	if srcBucket == *input.Bucket && srcObject == *input.Key {
+		return nil, s3err.GetAPIError(s3err.ErrInvalidCopyDest)
+	}
+
+	// the object lock protected source objects can't be removed
+	err = auth.CheckObjectAccess(ctx.Context(), srcBucket, acct.Access,
+		[]types.ObjectIdentifier{{Key: &srcObject}}, false, c.be)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.be.MoveObject(ctx.Context(), input)
+	if !errors.Is(err, s3err.GetAPIError(s3err.ErrNotImplemented)) {
+		return res, err
+	}
+
+	res, err = c.be.CopyObject(ctx.Context(), input)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.be.DeleteObject(ctx.Context(), &s3.DeleteObjectInput{
+		Bucket: &srcBucket,
+		Key:    &srcObject,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("delete moved source object: %w", err)
+	}
+
+	return res, nil
+}
+
+// checkPreconditions evaluates the conditional headers against the object
+func checkPreconditions(conds utils.ConditionalHeaders, obj *s3.HeadObjectOutput) error {
+	var lastModified time.Time
+	if obj.LastModified != nil {
+		lastModified = *obj.LastModified
+	}
+
+	return backend.EvaluatePreconditions(getstring(obj.ETag), lastModified,
+		conds.IfMatch, conds.IfNoneMatch, conds.IfModSince, conds.IfUnmodSince)
+}
+
 func getstring(s *string) string {
 	if s == nil {
 		return ""
@@ -640,6 +820,91 @@ func (c S3ApiController) ListActions(ctx *fiber.Ctx) error {
 			})
 	}
 
+	if ctx.Request().URI().QueryArgs().Has("accelerate") {
+		err := auth.VerifyAccess(ctx.Context(), c.be, auth.AccessOptions{
+			Readonly:      c.readonly,
+			Acl:           parsedAcl,
+			AclPermission: types.PermissionRead,
+			IsRoot:        isRoot,
+			Acc:           acct,
+			Bucket:        bucket,
+			Action:        auth.GetAccelerateConfigurationAction,
+		})
+		if err != nil {
+			return SendXMLResponse(ctx, nil, err,
+				&MetaOpts{
+					Logger:      c.logger,
+					MetricsMng:  c.mm,
+					Action:      metrics.ActionGetBucketAccelerate,
+					BucketOwner: parsedAcl.Owner,
+				})
+		}
+
+		status, err := c.be.GetBucketAccelerateConfiguration(ctx.Context(), bucket)
+		return SendXMLResponse(ctx,
+			s3response.AccelerateConfiguration{
+				Status: status,
+			}, err,
+			&MetaOpts{
+				Logger:      c.logger,
+				MetricsMng:  c.mm,
+				Action:      metrics.ActionGetBucketAccelerate,
+				BucketOwner: parsedAcl.Owner,
+			})
+	}
+
+	if ctx.Request().URI().QueryArgs().Has("location") {
+		err := auth.VerifyAccess(ctx.Context(), c.be, auth.AccessOptions{
+			Readonly:      c.readonly,
+			Acl:           parsedAcl,
+			AclPermission: types.PermissionRead,
+			IsRoot:        isRoot,
+			Acc:           acct,
+			Bucket:        bucket,
+			Action:        auth.GetBucketLocationAction,
+		})
+		if err != nil {
+			return SendXMLResponse(ctx, nil, err,
+				&MetaOpts{
+					Logger:      c.logger,
+					MetricsMng:  c.mm,
+					Action:      metrics.ActionGetBucketLocation,
+					BucketOwner: parsedAcl.Owner,
+				})
+		}
+
+		_, err = c.be.HeadBucket(ctx.Context(),
+			&s3.HeadBucketInput{
+				Bucket: &bucket,
+			})
+		if err != nil {
+			return SendXMLResponse(ctx, nil, err,
+				&MetaOpts{
+					Logger:      c.logger,
+					MetricsMng:  c.mm,
+					Action:      metrics.ActionGetBucketLocation,
+					BucketOwner: parsedAcl.Owner,
+				})
+		}
+
+		// the buckets in us-east-1 have a null location constraint
+		region := ctx.Locals("region").(string)
+		if region == "us-east-1" {
+			region = ""
+		}
+
+		return SendXMLResponse(ctx,
+			s3response.LocationConstraint{
+				Location: region,
+			}, nil,
+			&MetaOpts{
+				Logger:      c.logger,
+				MetricsMng:  c.mm,
+				Action:      metrics.ActionGetBucketLocation,
+				BucketOwner: parsedAcl.Owner,
+			})
+	}
+
 	if ctx.Request().URI().QueryArgs().Has("versioning") {
 		err := auth.VerifyAccess(ctx.Context(), c.be, auth.AccessOptions{
 			Readonly:      c.readonly,
@@ -760,6 +1025,7 @@ func (c S3ApiController) ListActions(ctx *fiber.Ctx) error {
 				MetricsMng:  c.mm,
 				Action:      metrics.ActionListObjectVersions,
 				BucketOwner: parsedAcl.Owner,
+				ObjectCount: int64(len(data.Versions) + len(data.DeleteMarkers)),
 			})
 	}
 
@@ -942,6 +1208,7 @@ func (c S3ApiController) ListActions(ctx *fiber.Ctx) error {
 				MetricsMng:  c.mm,
 				Action:      metrics.ActionListObjectsV2,
 				BucketOwner: parsedAcl.Owner,
+				ObjectCount: int64(len(res.Contents)),
 			})
 	}
 
@@ -993,6 +1260,7 @@ func (c S3ApiController) ListActions(ctx *fiber.Ctx) error {
 			MetricsMng:  c.mm,
 			Action:      metrics.ActionListObjects,
 			BucketOwner: parsedAcl.Owner,
+			ObjectCount: int64(len(res.Contents)),
 		})
 }
 
@@ -1033,7 +1301,7 @@ func (c S3ApiController) PutBucketActions(ctx *fiber.Ctx) error {
 		tags := make(map[string]string, len(bucketTagging.TagSet.Tags))
 
 		for _, tag := range bucketTagging.TagSet.Tags {
-			if len(tag.Key) > 128 || len(tag.Value) > 256 {
+			if !utils.IsValidTag(tag.Key, tag.Value) {
 				return SendResponse(ctx, s3err.GetAPIError(s3err.ErrInvalidTag),
 					&MetaOpts{
 						Logger:      c.logger,
@@ -1125,6 +1393,58 @@ func (c S3ApiController) PutBucketActions(ctx *fiber.Ctx) error {
 			})
 	}
 
+	if ctx.Request().URI().QueryArgs().Has("accelerate") {
+		parsedAcl := ctx.Locals("parsedAcl").(auth.ACL)
+		var accelerateConf types.AccelerateConfiguration
+		if err := xml.Unmarshal(ctx.Body(), &accelerateConf); err != nil {
+			return SendResponse(ctx, s3err.GetAPIError(s3err.ErrMalformedXML),
+				&MetaOpts{
+					Logger:      c.logger,
+					MetricsMng:  c.mm,
+					Action:      metrics.ActionPutBucketAccelerate,
+					BucketOwner: parsedAcl.Owner,
+				})
+		}
+
+		if accelerateConf.Status != types.BucketAccelerateStatusEnabled &&
+			accelerateConf.Status != types.BucketAccelerateStatusSuspended {
+			return SendResponse(ctx, s3err.GetAPIError(s3err.ErrMalformedXML),
+				&MetaOpts{
+					Logger:      c.logger,
+					MetricsMng:  c.mm,
+					Action:      metrics.ActionPutBucketAccelerate,
+					BucketOwner: parsedAcl.Owner,
+				})
+		}
+
+		if err := auth.VerifyAccess(ctx.Context(), c.be, auth.AccessOptions{
+			Readonly:      c.readonly,
+			Acl:           parsedAcl,
+			AclPermission: types.PermissionWrite,
+			IsRoot:        isRoot,
+			Acc:           acct,
+			Bucket:        bucket,
+			Action:        auth.PutAccelerateConfigurationAction,
+		}); err != nil {
+			return SendResponse(ctx, err,
+				&MetaOpts{
+					Logger:      c.logger,
+					MetricsMng:  c.mm,
+					Action:      metrics.ActionPutBucketAccelerate,
+					BucketOwner: parsedAcl.Owner,
+				})
+		}
+
+		err := c.be.PutBucketAccelerateConfiguration(ctx.Context(), bucket, accelerateConf.Status)
+		return SendResponse(ctx, err,
+			&MetaOpts{
+				Logger:      c.logger,
+				MetricsMng:  c.mm,
+				Action:      metrics.ActionPutBucketAccelerate,
+				BucketOwner: parsedAcl.Owner,
+			})
+	}
+
 	if ctx.Request().URI().QueryArgs().Has("versioning") {
 		parsedAcl := ctx.Locals("parsedAcl").(auth.ACL)
 		err := auth.VerifyAccess(ctx.Context(), c.be, auth.AccessOptions{
@@ -1566,6 +1886,8 @@ func (c S3ApiController) PutActions(ctx *fiber.Ctx) error {
 	isRoot := ctx.Locals("isRoot").(bool)
 	contentType := ctx.Get("Content-Type")
 	contentEncoding := ctx.Get("Content-Encoding")
+	cacheControl := ctx.Get("Cache-Control")
+	contentDisposition := ctx.Get("Content-Disposition")
 	parsedAcl := ctx.Locals("parsedAcl").(auth.ACL)
 	tagging := ctx.Get("x-amz-tagging")
 
@@ -1580,6 +1902,8 @@ func (c S3ApiController) PutActions(ctx *fiber.Ctx) error {
 	copySrcUnmodifSince := ctx.Get("X-Amz-Copy-Source-If-Unmodified-Since")
 	copySrcRange := ctx.Get("X-Amz-Copy-Source-Range")
 	directive := ctx.Get("X-Amz-Metadata-Directive")
+	// move the source object instead of copying it
+	moveSource := strings.EqualFold(ctx.Get("X-Versity-Move-Source"), "true")
 
 	// Permission headers
 	acl := ctx.Get("X-Amz-Acl")
@@ -1631,12 +1955,26 @@ func (c S3ApiController) PutActions(ctx *fiber.Ctx) error {
 				})
 		}
 
+		if len(objTagging.TagSet.Tags) > utils.MaxObjectTags {
+			if c.debug {
+				log.Printf("object tags exceed the limit: %v",
+					len(objTagging.TagSet.Tags))
+			}
+			return SendResponse(ctx, s3err.GetAPIError(s3err.ErrObjectTaggingLimited),
+				&MetaOpts{
+					Logger:      c.logger,
+					MetricsMng:  c.mm,
+					Action:      metrics.ActionPutObjectTagging,
+					BucketOwner: parsedAcl.Owner,
+				})
+		}
+
 		tags := make(map[string]string, len(objTagging.TagSet.Tags))
 
 		for _, tag := range objTagging.TagSet.Tags {
-			if len(tag.Key) > 128 || len(tag.Value) > 256 {
+			if !utils.IsValidTag(tag.Key, tag.Value) {
 				if c.debug {
-					log.Printf("invalid tag key/value len: %q %q",
+					log.Printf("invalid tag key/value: %q %q",
 						tag.Key, tag.Value)
 				}
 				return SendResponse(ctx, s3err.GetAPIError(s3err.ErrInvalidTag),
@@ -1788,6 +2126,17 @@ func (c S3ApiController) PutActions(ctx *fiber.Ctx) error {
 		ctx.Request().URI().QueryArgs().Has("partNumber") &&
 		copySource != "" {
 
+		// SSE-C is only supported for the single part uploads
+		if utils.HasSSECustomerHeaders(ctx) {
+			return SendXMLResponse(ctx, nil, s3err.GetAPIError(s3err.ErrNotImplemented),
+				&MetaOpts{
+					Logger:      c.logger,
+					MetricsMng:  c.mm,
+					Action:      metrics.ActionUploadPartCopy,
+					BucketOwner: parsedAcl.Owner,
+				})
+		}
+
 		cs := copySource
 		copySource, err := url.QueryUnescape(copySource)
 		if err != nil {
@@ -1869,6 +2218,17 @@ func (c S3ApiController) PutActions(ctx *fiber.Ctx) error {
 
 	if ctx.Request().URI().QueryArgs().Has("uploadId") &&
 		ctx.Request().URI().QueryArgs().Has("partNumber") {
+		// SSE-C is only supported for the single part uploads
+		if utils.HasSSECustomerHeaders(ctx) {
+			return SendResponse(ctx, s3err.GetAPIError(s3err.ErrNotImplemented),
+				&MetaOpts{
+					Logger:      c.logger,
+					MetricsMng:  c.mm,
+					Action:      metrics.ActionUploadPart,
+					BucketOwner: parsedAcl.Owner,
+				})
+		}
+
 		partNumber := int32(ctx.QueryInt("partNumber", -1))
 		if partNumber < 1 || partNumber > 10000 {
 			if c.debug {
@@ -2074,6 +2434,20 @@ func (c S3ApiController) PutActions(ctx *fiber.Ctx) error {
 	}
 
 	if copySource != "" {
+		// SSE-C isn't supported for the object copies
+		if utils.HasSSECustomerHeaders(ctx) {
+			return SendXMLResponse(ctx, nil, s3err.GetAPIError(s3err.ErrNotImplemented),
+				&MetaOpts{
+					Logger:      c.logger,
+					MetricsMng:  c.mm,
+					Action:      metrics.ActionCopyObject,
+					BucketOwner: parsedAcl.Owner,
+				})
+		}
+
+		// The copy source range (X-Amz-Copy-Source-Range) only applies
+		// to UploadPartCopy. As in S3, CopyObject ignores it and always
+		// copies the whole source object.
 		cs := copySource
 		copySource, err := url.QueryUnescape(copySource)
 		if err != nil {
@@ -2091,7 +2465,26 @@ func (c S3ApiController) PutActions(ctx *fiber.Ctx) error {
 				})
 		}
 
-		err = auth.VerifyObjectCopyAccess(ctx.Context(), c.be, copySource,
+		err = utils.ValidateTaggingHeader(tagging)
+		if err != nil {
+			if c.debug {
+				log.Printf("invalid object tagging %q: %v", tagging, err)
+			}
+			return SendXMLResponse(ctx, nil, err,
+				&MetaOpts{
+					Logger:      c.logger,
+					MetricsMng:  c.mm,
+					Action:      metrics.ActionCopyObject,
+					BucketOwner: parsedAcl.Owner,
+				})
+		}
+
+		verifyAccess := auth.VerifyObjectCopyAccess
+		if moveSource {
+			verifyAccess = auth.VerifyObjectMoveAccess
+		}
+
+		err = verifyAccess(ctx.Context(), c.be, copySource,
 			auth.AccessOptions{
 				Acl:           parsedAcl,
 				AclPermission: types.PermissionWrite,
@@ -2168,20 +2561,26 @@ func (c S3ApiController) PutActions(ctx *fiber.Ctx) error {
 			metaDirective = types.MetadataDirectiveReplace
 		}
 
-		res, err := c.be.CopyObject(ctx.Context(),
-			&s3.CopyObjectInput{
-				Bucket:                      &bucket,
-				Key:                         &keyStart,
-				CopySource:                  &copySource,
-				CopySourceIfMatch:           &copySrcIfMatch,
-				CopySourceIfNoneMatch:       &copySrcIfNoneMatch,
-				CopySourceIfModifiedSince:   mtime,
-				CopySourceIfUnmodifiedSince: umtime,
-				ExpectedBucketOwner:         &acct.Access,
-				Metadata:                    metadata,
-				MetadataDirective:           metaDirective,
-				StorageClass:                types.StorageClass(storageClass),
-			})
+		input := &s3.CopyObjectInput{
+			Bucket:                      &bucket,
+			Key:                         &keyStart,
+			CopySource:                  &copySource,
+			CopySourceIfMatch:           &copySrcIfMatch,
+			CopySourceIfNoneMatch:       &copySrcIfNoneMatch,
+			CopySourceIfModifiedSince:   mtime,
+			CopySourceIfUnmodifiedSince: umtime,
+			ExpectedBucketOwner:         &acct.Access,
+			Metadata:                    metadata,
+			MetadataDirective:           metaDirective,
+			StorageClass:                types.StorageClass(storageClass),
+		}
+
+		var res *s3.CopyObjectOutput
+		if moveSource {
+			res, err = c.moveObject(ctx, input, acct)
+		} else {
+			res, err = c.be.CopyObject(ctx.Context(), input)
+		}
 		if err == nil {
 			hdrs := []utils.CustomHeader{}
 			if getstring(res.VersionId) != "" {
@@ -2200,14 +2599,15 @@ func (c S3ApiController) PutActions(ctx *fiber.Ctx) error {
 
 			return SendXMLResponse(ctx, res.CopyObjectResult, err,
 				&MetaOpts{
-					Logger:      c.logger,
-					MetricsMng:  c.mm,
-					EvSender:    c.evSender,
-					Action:      metrics.ActionCopyObject,
-					BucketOwner: parsedAcl.Owner,
-					ObjectETag:  res.CopyObjectResult.ETag,
-					VersionId:   res.VersionId,
-					EventName:   s3event.EventObjectCreatedCopy,
+					Logger:        c.logger,
+					MetricsMng:    c.mm,
+					EvSender:      c.evSender,
+					ContentLength: c.copiedSize(ctx, bucket, keyStart, getstring(res.VersionId)),
+					Action:        metrics.ActionCopyObject,
+					BucketOwner:   parsedAcl.Owner,
+					ObjectETag:    res.CopyObjectResult.ETag,
+					VersionId:     res.VersionId,
+					EventName:     s3event.EventObjectCreatedCopy,
 				})
 		} else {
 			return SendXMLResponse(ctx, res, err,
@@ -2243,6 +2643,51 @@ func (c S3ApiController) PutActions(ctx *fiber.Ctx) error {
 			})
 	}
 
+	err = utils.ValidateTaggingHeader(tagging)
+	if err != nil {
+		if c.debug {
+			log.Printf("invalid object tagging %q: %v", tagging, err)
+		}
+		return SendResponse(ctx, err,
+			&MetaOpts{
+				Logger:      c.logger,
+				MetricsMng:  c.mm,
+				Action:      metrics.ActionPutObject,
+				BucketOwner: parsedAcl.Owner,
+			})
+	}
+
+	// a retried request with the same idempotency token gets the
+	// prior result, without re-writing the object or sending the
+	// metrics and events again. The result is only replayed while
+	// the object written by the prior request is still in place.
+	idempotencyToken := ctx.Get(utils.IdempotencyTokenHeader)
+	idempotencyKey := utils.IdempotencyKey(idempotencyToken, acct.Access, bucket, keyStart)
+	if idempotencyToken != "" {
+		if res, ok := c.idempotency.Get(idempotencyKey); ok && c.isCurrentObject(ctx, bucket, keyStart, res.ETag) {
+			hdrs := []utils.CustomHeader{
+				{
+					Key:   "ETag",
+					Value: res.ETag,
+				},
+			}
+			if res.VersionID != "" {
+				hdrs = append(hdrs, utils.CustomHeader{
+					Key:   "x-amz-version-id",
+					Value: res.VersionID,
+				})
+			}
+			utils.SetResponseHeaders(ctx, hdrs)
+
+			return SendResponse(ctx, nil,
+				&MetaOpts{
+					Logger:      c.logger,
+					Action:      metrics.ActionPutObject,
+					BucketOwner: parsedAcl.Owner,
+				})
+		}
+	}
+
 	err = auth.CheckObjectAccess(ctx.Context(), bucket, acct.Access, []types.ObjectIdentifier{{Key: &keyStart}}, true, c.be)
 	if err != nil {
 		return SendResponse(ctx, err,
@@ -2280,6 +2725,31 @@ func (c S3ApiController) PutActions(ctx *fiber.Ctx) error {
 			})
 	}
 
+	sseCKey, err := utils.ParseSSECustomerHeaders(ctx)
+	if err != nil {
+		return SendResponse(ctx, err,
+			&MetaOpts{
+				Logger:      c.logger,
+				MetricsMng:  c.mm,
+				Action:      metrics.ActionPutObject,
+				BucketOwner: parsedAcl.Owner,
+			})
+	}
+
+	// the checksum is validated against the object data in the
+	// VerifyChecksumBody middleware, and stored by the backend
+	checksumAlgo, checksum, err := utils.ParseChecksumHeaders(ctx)
+	if err != nil {
+		return SendResponse(ctx, err,
+			&MetaOpts{
+				Logger:      c.logger,
+				MetricsMng:  c.mm,
+				Action:      metrics.ActionPutObject,
+				BucketOwner: parsedAcl.Owner,
+			})
+	}
+	checksums := objectChecksums(checksumAlgo, checksum)
+
 	var body io.Reader
 	bodyi := ctx.Locals("body-reader")
 	if bodyi != nil {
@@ -2296,12 +2766,21 @@ func (c S3ApiController) PutActions(ctx *fiber.Ctx) error {
 			ContentLength:             &contentLength,
 			ContentType:               &contentType,
 			ContentEncoding:           &contentEncoding,
+			CacheControl:              &cacheControl,
+			ContentDisposition:        &contentDisposition,
 			Metadata:                  metadata,
 			Body:                      body,
 			Tagging:                   &tagging,
 			ObjectLockRetainUntilDate: &objLock.RetainUntilDate,
 			ObjectLockMode:            objLock.ObjectLockMode,
 			ObjectLockLegalHoldStatus: objLock.LegalHoldStatus,
+			SSECustomerAlgorithm:      backend.GetPtrFromString(sseCKey.Algorithm),
+			SSECustomerKey:            backend.GetPtrFromString(sseCKey.Key),
+			SSECustomerKeyMD5:         backend.GetPtrFromString(sseCKey.KeyMD5),
+			ChecksumCRC32:             checksums.ChecksumCRC32,
+			ChecksumCRC32C:            checksums.ChecksumCRC32C,
+			ChecksumSHA1:              checksums.ChecksumSHA1,
+			ChecksumSHA256:            checksums.ChecksumSHA256,
 		})
 	if err != nil {
 		return SendResponse(ctx, err,
@@ -2329,9 +2808,26 @@ func (c S3ApiController) PutActions(ctx *fiber.Ctx) error {
 			Value: res.VersionID,
 		})
 	}
+	if sseCKey.IsSet() {
+		hdrs = append(hdrs, utils.CustomHeader{
+			Key:   "x-amz-server-side-encryption-customer-algorithm",
+			Value: sseCKey.Algorithm,
+		}, utils.CustomHeader{
+			Key:   "x-amz-server-side-encryption-customer-key-MD5",
+			Value: sseCKey.KeyMD5,
+		})
+	}
+	hdrs = append(hdrs, checksumHeaders(checksums)...)
 
 	utils.SetResponseHeaders(ctx, hdrs)
 
+	if idempotencyToken != "" {
+		c.idempotency.Set(idempotencyKey, utils.PutObjectResult{
+			ETag:      res.ETag,
+			VersionID: res.VersionID,
+		})
+	}
+
 	return SendResponse(ctx, nil,
 		&MetaOpts{
 			Logger:        c.logger,
@@ -2346,6 +2842,21 @@ func (c S3ApiController) PutActions(ctx *fiber.Ctx) error {
 		})
 }
 
+// isCurrentObject checks if the object still has the given etag
+func (c S3ApiController) isCurrentObject(ctx *fiber.Ctx, bucket, object, etag string) bool {
+	var versionId string
+	res, err := c.be.HeadObject(ctx.Context(), &s3.HeadObjectInput{
+		Bucket:    &bucket,
+		Key:       &object,
+		VersionId: &versionId,
+	})
+	if err != nil || res.ETag == nil {
+		return false
+	}
+
+	return *res.ETag == etag
+}
+
 func (c S3ApiController) DeleteBucket(ctx *fiber.Ctx) error {
 	bucket := ctx.Params("bucket")
 	acct := ctx.Locals("account").(auth.Account)
@@ -2501,6 +3012,19 @@ func (c S3ApiController) DeleteObjects(ctx *fiber.Ctx) error {
 			})
 	}
 
+	if len(dObj.Objects) > maxDeleteObjects {
+		if c.debug {
+			log.Printf("delete objects keys count exceeds the limit: %v", len(dObj.Objects))
+		}
+		return SendResponse(ctx, s3err.GetAPIError(s3err.ErrMalformedXML),
+			&MetaOpts{
+				Logger:      c.logger,
+				MetricsMng:  c.mm,
+				Action:      metrics.ActionDeleteObjects,
+				BucketOwner: parsedAcl.Owner,
+			})
+	}
+
 	err = auth.VerifyAccess(ctx.Context(), c.be,
 		auth.AccessOptions{
 			Readonly:      c.readonly,
@@ -2540,8 +3064,13 @@ func (c S3ApiController) DeleteObjects(ctx *fiber.Ctx) error {
 			Bucket: &bucket,
 			Delete: &types.Delete{
 				Objects: dObj.Objects,
+				Quiet:   &dObj.Quiet,
 			},
 		})
+	if err == nil && dObj.Quiet {
+		// quiet mode only reports the keys that failed to delete
+		res.Deleted = nil
+	}
 	return SendXMLResponse(ctx, res, err,
 		&MetaOpts{
 			Logger:      c.logger,
@@ -2846,12 +3375,26 @@ func (c S3ApiController) HeadObject(ctx *fiber.Ctx) error {
 			})
 	}
 
+	sseCKey, err := utils.ParseSSECustomerHeaders(ctx)
+	if err != nil {
+		return SendResponse(ctx, err,
+			&MetaOpts{
+				Logger:      c.logger,
+				MetricsMng:  c.mm,
+				Action:      metrics.ActionHeadObject,
+				BucketOwner: parsedAcl.Owner,
+			})
+	}
+
 	res, err := c.be.HeadObject(ctx.Context(),
 		&s3.HeadObjectInput{
-			Bucket:     &bucket,
-			Key:        &key,
-			PartNumber: partNumber,
-			VersionId:  &versionId,
+			Bucket:               &bucket,
+			Key:                  &key,
+			PartNumber:           partNumber,
+			VersionId:            &versionId,
+			SSECustomerAlgorithm: backend.GetPtrFromString(sseCKey.Algorithm),
+			SSECustomerKey:       backend.GetPtrFromString(sseCKey.Key),
+			SSECustomerKeyMD5:    backend.GetPtrFromString(sseCKey.KeyMD5),
 		})
 	if err != nil {
 		if res != nil {
@@ -2884,6 +3427,19 @@ func (c S3ApiController) HeadObject(ctx *fiber.Ctx) error {
 			})
 	}
 
+	if conds := utils.ParseConditionalHeaders(ctx); conds.IsSet() {
+		err = checkPreconditions(conds, res)
+		if err != nil {
+			return SendResponse(ctx, err,
+				&MetaOpts{
+					Logger:      c.logger,
+					MetricsMng:  c.mm,
+					Action:      metrics.ActionHeadObject,
+					BucketOwner: parsedAcl.Owner,
+				})
+		}
+	}
+
 	utils.SetMetaHeaders(ctx, res.Metadata)
 	headers := []utils.CustomHeader{
 		{
@@ -2937,12 +3493,41 @@ func (c S3ApiController) HeadObject(ctx *fiber.Ctx) error {
 			Value: getstring(res.ContentEncoding),
 		})
 	}
+	if getstring(res.CacheControl) != "" {
+		headers = append(headers, utils.CustomHeader{
+			Key:   "Cache-Control",
+			Value: getstring(res.CacheControl),
+		})
+	}
+	if getstring(res.ContentDisposition) != "" {
+		headers = append(headers, utils.CustomHeader{
+			Key:   "Content-Disposition",
+			Value: getstring(res.ContentDisposition),
+		})
+	}
 	if res.StorageClass != "" {
 		headers = append(headers, utils.CustomHeader{
 			Key:   "x-amz-storage-class",
 			Value: string(res.StorageClass),
 		})
 	}
+	if getstring(res.SSECustomerAlgorithm) != "" {
+		headers = append(headers, utils.CustomHeader{
+			Key:   "x-amz-server-side-encryption-customer-algorithm",
+			Value: getstring(res.SSECustomerAlgorithm),
+		}, utils.CustomHeader{
+			Key:   "x-amz-server-side-encryption-customer-key-MD5",
+			Value: getstring(res.SSECustomerKeyMD5),
+		})
+	}
+	if ctx.Get("X-Amz-Checksum-Mode") == string(types.ChecksumModeEnabled) {
+		headers = append(headers, checksumHeaders(types.Checksum{
+			ChecksumCRC32:  res.ChecksumCRC32,
+			ChecksumCRC32C: res.ChecksumCRC32C,
+			ChecksumSHA1:   res.ChecksumSHA1,
+			ChecksumSHA256: res.ChecksumSHA256,
+		})...)
+	}
 
 	contentType := getstring(res.ContentType)
 	if contentType == "" {
@@ -2981,6 +3566,8 @@ func (c S3ApiController) CreateActions(ctx *fiber.Ctx) error {
 	parsedAcl := ctx.Locals("parsedAcl").(auth.ACL)
 	contentType := ctx.Get("Content-Type")
 	contentEncoding := ctx.Get("Content-Encoding")
+	cacheControl := ctx.Get("Cache-Control")
+	contentDisposition := ctx.Get("Content-Disposition")
 	tagging := ctx.Get("X-Amz-Tagging")
 
 	if keyEnd != "" {
@@ -3139,6 +3726,22 @@ func (c S3ApiController) CreateActions(ctx *fiber.Ctx) error {
 				})
 		}
 
+		// "If-None-Match: *" makes the complete a create-only operation,
+		// no other conditional value is supported for this action
+		ifNoneMatch := ctx.Get("If-None-Match")
+		if ifNoneMatch != "" {
+			if ifNoneMatch != "*" {
+				return SendXMLResponse(ctx, nil,
+					s3err.GetAPIError(s3err.ErrNotImplemented),
+					&MetaOpts{
+						Logger:      c.logger,
+						MetricsMng:  c.mm,
+						Action:      metrics.ActionCompleteMultipartUpload,
+						BucketOwner: parsedAcl.Owner,
+					})
+			}
+		}
+
 		res, err := c.be.CompleteMultipartUpload(ctx.Context(),
 			&s3.CompleteMultipartUploadInput{
 				Bucket:   &bucket,
@@ -3147,6 +3750,7 @@ func (c S3ApiController) CreateActions(ctx *fiber.Ctx) error {
 				MultipartUpload: &types.CompletedMultipartUpload{
 					Parts: data.Parts,
 				},
+				IfNoneMatch: backend.GetPtrFromString(ifNoneMatch),
 			})
 		if err == nil {
 			if getstring(res.VersionId) != "" {
@@ -3199,6 +3803,17 @@ func (c S3ApiController) CreateActions(ctx *fiber.Ctx) error {
 			})
 	}
 
+	// SSE-C is only supported for the single part uploads
+	if utils.HasSSECustomerHeaders(ctx) {
+		return SendXMLResponse(ctx, nil, s3err.GetAPIError(s3err.ErrNotImplemented),
+			&MetaOpts{
+				Logger:      c.logger,
+				MetricsMng:  c.mm,
+				Action:      metrics.ActionCreateMultipartUpload,
+				BucketOwner: parsedAcl.Owner,
+			})
+	}
+
 	objLockState, err := utils.ParsObjectLockHdrs(ctx)
 	if err != nil {
 		return SendXMLResponse(ctx, nil, err,
@@ -3210,6 +3825,20 @@ func (c S3ApiController) CreateActions(ctx *fiber.Ctx) error {
 			})
 	}
 
+	err = utils.ValidateTaggingHeader(tagging)
+	if err != nil {
+		if c.debug {
+			log.Printf("invalid object tagging %q: %v", tagging, err)
+		}
+		return SendXMLResponse(ctx, nil, err,
+			&MetaOpts{
+				Logger:      c.logger,
+				MetricsMng:  c.mm,
+				Action:      metrics.ActionCreateMultipartUpload,
+				BucketOwner: parsedAcl.Owner,
+			})
+	}
+
 	metadata := utils.GetUserMetaData(&ctx.Request().Header)
 
 	res, err := c.be.CreateMultipartUpload(ctx.Context(),
@@ -3219,6 +3848,8 @@ func (c S3ApiController) CreateActions(ctx *fiber.Ctx) error {
 			Tagging:                   &tagging,
 			ContentType:               &contentType,
 			ContentEncoding:           &contentEncoding,
+			CacheControl:              &cacheControl,
+			ContentDisposition:        &contentDisposition,
 			ObjectLockRetainUntilDate: &objLockState.RetainUntilDate,
 			ObjectLockMode:            objLockState.ObjectLockMode,
 			ObjectLockLegalHoldStatus: objLockState.LegalHoldStatus,
@@ -3233,6 +3864,60 @@ func (c S3ApiController) CreateActions(ctx *fiber.Ctx) error {
 		})
 }
 
+// copiedSize returns the copied object size for the metrics byte
+// counts, as the copy result doesn't include the object size
+func (c S3ApiController) copiedSize(ctx *fiber.Ctx, bucket, key, versionId string) int64 {
+	if c.mm == nil {
+		return 0
+	}
+
+	res, err := c.be.HeadObject(ctx.Context(), &s3.HeadObjectInput{
+		Bucket:    &bucket,
+		Key:       &key,
+		VersionId: &versionId,
+	})
+	if err != nil || res.ContentLength == nil {
+		return 0
+	}
+	return *res.ContentLength
+}
+
+// objectChecksums returns the object checksums with
+// the checksum of the given algorithm set
+func objectChecksums(algo types.ChecksumAlgorithm, sum string) types.Checksum {
+	var checksums types.Checksum
+	switch algo {
+	case types.ChecksumAlgorithmCrc32:
+		checksums.ChecksumCRC32 = &sum
+	case types.ChecksumAlgorithmCrc32c:
+		checksums.ChecksumCRC32C = &sum
+	case types.ChecksumAlgorithmSha1:
+		checksums.ChecksumSHA1 = &sum
+	case types.ChecksumAlgorithmSha256:
+		checksums.ChecksumSHA256 = &sum
+	}
+	return checksums
+}
+
+// checksumHeaders returns the x-amz-checksum-* headers of the object checksums
+func checksumHeaders(checksums types.Checksum) []utils.CustomHeader {
+	var hdrs []utils.CustomHeader
+	for hdr, sum := range map[string]*string{
+		"x-amz-checksum-crc32":  checksums.ChecksumCRC32,
+		"x-amz-checksum-crc32c": checksums.ChecksumCRC32C,
+		"x-amz-checksum-sha1":   checksums.ChecksumSHA1,
+		"x-amz-checksum-sha256": checksums.ChecksumSHA256,
+	} {
+		if getstring(sum) != "" {
+			hdrs = append(hdrs, utils.CustomHeader{
+				Key:   hdr,
+				Value: *sum,
+			})
+		}
+	}
+	return hdrs
+}
+
 type MetaOpts struct {
 	Logger        s3log.AuditLogger
 	EvSender      s3event.S3EventSender
@@ -3258,9 +3943,9 @@ func SendResponse(ctx *fiber.Ctx, err error, l *MetaOpts) error {
 	}
 	if l.MetricsMng != nil {
 		if l.ObjectCount > 0 {
-			l.MetricsMng.Send(ctx, err, l.Action, l.ObjectCount, l.Status)
+			l.MetricsMng.SendWithBucket(ctx, err, l.Action, ctx.Params("bucket"), l.ObjectCount, l.Status)
 		} else {
-			l.MetricsMng.Send(ctx, err, l.Action, l.ContentLength, l.Status)
+			l.MetricsMng.SendWithBucket(ctx, err, l.Action, ctx.Params("bucket"), l.ContentLength, l.Status)
 		}
 	}
 	if err != nil {
@@ -3307,9 +3992,9 @@ const (
 func SendXMLResponse(ctx *fiber.Ctx, resp any, err error, l *MetaOpts) error {
 	if l.MetricsMng != nil {
 		if l.ObjectCount > 0 {
-			l.MetricsMng.Send(ctx, err, l.Action, l.ObjectCount, l.Status)
+			l.MetricsMng.SendWithBucket(ctx, err, l.Action, ctx.Params("bucket"), l.ObjectCount, l.Status)
 		} else {
-			l.MetricsMng.Send(ctx, err, l.Action, l.ContentLength, l.Status)
+			l.MetricsMng.SendWithBucket(ctx, err, l.Action, ctx.Params("bucket"), l.ContentLength, l.Status)
 		}
 	}
 	if err != nil {
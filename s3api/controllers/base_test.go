@@ -32,6 +32,7 @@ import (
 	"github.com/valyala/fasthttp"
 	"github.com/versity/versitygw/auth"
 	"github.com/versity/versitygw/backend"
+	"github.com/versity/versitygw/s3api/utils"
 	"github.com/versity/versitygw/s3err"
 	"github.com/versity/versitygw/s3response"
 )
@@ -69,8 +70,9 @@ func TestNew(t *testing.T) {
 				iam: &auth.IAMServiceInternal{},
 			},
 			want: S3ApiController{
-				be:  be,
-				iam: &auth.IAMServiceInternal{},
+				be:          be,
+				iam:         &auth.IAMServiceInternal{},
+				idempotency: utils.NewIdempotencyCache(),
 			},
 		},
 	}
@@ -397,6 +399,9 @@ func TestS3ApiController_ListActions(t *testing.T) {
 			GetBucketOwnershipControlsFunc: func(contextMoqParam context.Context, bucket string) (types.ObjectOwnership, error) {
 				return types.ObjectOwnershipBucketOwnerEnforced, nil
 			},
+			HeadBucketFunc: func(contextMoqParam context.Context, headBucketInput *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
+				return &s3.HeadBucketOutput{}, nil
+			},
 		},
 	}
 
@@ -405,6 +410,7 @@ func TestS3ApiController_ListActions(t *testing.T) {
 		ctx.Locals("isRoot", true)
 		ctx.Locals("isDebug", false)
 		ctx.Locals("parsedAcl", auth.ACL{})
+		ctx.Locals("region", "us-east-1")
 		return ctx.Next()
 	})
 
@@ -422,6 +428,9 @@ func TestS3ApiController_ListActions(t *testing.T) {
 			GetBucketTaggingFunc: func(contextMoqParam context.Context, bucket string) (map[string]string, error) {
 				return nil, s3err.GetAPIError(s3err.ErrNoSuchBucket)
 			},
+			HeadBucketFunc: func(contextMoqParam context.Context, headBucketInput *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
+				return nil, s3err.GetAPIError(s3err.ErrNoSuchBucket)
+			},
 		},
 	}
 	appError := fiber.New()
@@ -430,6 +439,7 @@ func TestS3ApiController_ListActions(t *testing.T) {
 		ctx.Locals("isRoot", true)
 		ctx.Locals("isDebug", false)
 		ctx.Locals("parsedAcl", auth.ACL{})
+		ctx.Locals("region", "us-east-1")
 		return ctx.Next()
 	})
 	appError.Get("/:bucket", s3ApiControllerError.ListActions)
@@ -450,6 +460,24 @@ func TestS3ApiController_ListActions(t *testing.T) {
 			wantErr:    false,
 			statusCode: 404,
 		},
+		{
+			name: "Get-bucket-location-non-existing-bucket",
+			app:  appError,
+			args: args{
+				req: httptest.NewRequest(http.MethodGet, "/my-bucket?location", nil),
+			},
+			wantErr:    false,
+			statusCode: 404,
+		},
+		{
+			name: "Get-bucket-location-success",
+			app:  app,
+			args: args{
+				req: httptest.NewRequest(http.MethodGet, "/my-bucket?location", nil),
+			},
+			wantErr:    false,
+			statusCode: 200,
+		},
 		{
 			name: "Get-bucket-ownership-control-success",
 			app:  app,
@@ -1333,6 +1361,9 @@ func TestS3ApiController_DeleteObjects(t *testing.T) {
 	request := httptest.NewRequest(http.MethodPost, "/my-bucket", strings.NewReader(xmlBody))
 	request.Header.Set("Content-Type", "application/xml")
 
+	// More than 1000 keys in a single request
+	tooManyKeysBody := "<Delete>" + strings.Repeat("<Object><Key>key</Key></Object>", 1001) + "</Delete>"
+
 	tests := []struct {
 		name       string
 		app        *fiber.App
@@ -1340,6 +1371,15 @@ func TestS3ApiController_DeleteObjects(t *testing.T) {
 		wantErr    bool
 		statusCode int
 	}{
+		{
+			name: "Delete-Objects-too-many-keys",
+			app:  app,
+			args: args{
+				req: httptest.NewRequest(http.MethodPost, "/my-bucket", strings.NewReader(tooManyKeysBody)),
+			},
+			wantErr:    false,
+			statusCode: 400,
+		},
 		{
 			name: "Delete-Objects-success",
 			app:  app,
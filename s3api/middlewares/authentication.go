@@ -53,6 +53,11 @@ func VerifyV4Signature(root RootUserConfig, iam auth.IAMService, logger s3log.Au
 
 		ctx.Locals("region", region)
 		ctx.Locals("startTime", time.Now())
+		if isAnonymousRequest(ctx) {
+			// the anonymous request isn't allowed by the bucket policy
+			return sendResponse(ctx, s3err.GetAPIError(s3err.ErrAccessDenied), logger, mm)
+		}
+
 		authorization := ctx.Get("Authorization")
 		if authorization == "" {
 			return sendResponse(ctx, s3err.GetAPIError(s3err.ErrAuthHeaderEmpty), logger, mm)
@@ -168,3 +173,30 @@ func (a accounts) getAccount(access string) (auth.Account, error) {
 func sendResponse(ctx *fiber.Ctx, err error, logger s3log.AuditLogger, mm *metrics.Manager) error {
 	return controllers.SendResponse(ctx, err, &controllers.MetaOpts{Logger: logger, MetricsMng: mm})
 }
+
+// presignedAuthQueryParams are the query arguments, any of which makes
+// the request a presigned URL request
+var presignedAuthQueryParams = []string{
+	"X-Amz-Algorithm",
+	"X-Amz-Credential",
+	"X-Amz-Signature",
+}
+
+// isPresignedRequest reports whether the request carries presigned URL
+// query authentication, complete or not.
+func isPresignedRequest(ctx *fiber.Ctx) bool {
+	for _, param := range presignedAuthQueryParams {
+		if ctx.Query(param) != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isAnonymousRequest reports whether the request carries no credentials
+// at all: neither an Authorization header nor presigned URL query
+// authentication.
+func isAnonymousRequest(ctx *fiber.Ctx) bool {
+	return ctx.Get("Authorization") == "" && !isPresignedRequest(ctx)
+}
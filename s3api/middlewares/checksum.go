@@ -0,0 +1,53 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package middlewares
+
+import (
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/versity/versitygw/s3api/controllers"
+	"github.com/versity/versitygw/s3api/utils"
+	"github.com/versity/versitygw/s3log"
+)
+
+// VerifyChecksumBody validates the object data against the additional
+// checksum header (x-amz-checksum-crc32, -crc32c, -sha1 or -sha256)
+// of the object and part uploads
+func VerifyChecksumBody(logger s3log.AuditLogger) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if !utils.IsBigDataAction(ctx) {
+			return ctx.Next()
+		}
+
+		algo, sum, err := utils.ParseChecksumHeaders(ctx)
+		if err != nil {
+			return controllers.SendResponse(ctx, err, &controllers.MetaOpts{Logger: logger})
+		}
+		if algo == "" {
+			return ctx.Next()
+		}
+
+		wrapBodyReader(ctx, func(r io.Reader) io.Reader {
+			r, err = utils.NewHashReader(r, sum, utils.ChecksumHashType(algo))
+			return r
+		})
+		if err != nil {
+			return controllers.SendResponse(ctx, err, &controllers.MetaOpts{Logger: logger})
+		}
+
+		return ctx.Next()
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package middlewares
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/versity/versitygw/metrics"
+	"github.com/versity/versitygw/s3api/utils"
+	"github.com/versity/versitygw/s3err"
+	"github.com/versity/versitygw/s3log"
+)
+
+// LimitConcurrency limits the number of concurrent object read (GET, HEAD)
+// and write (PUT, POST, DELETE) requests handed to the backend.
+// Requests exceeding the limits are rejected with SlowDown, so that the
+// clients back off and retry instead of exhausting the backend resources.
+// A limit of 0 means unlimited.
+func LimitConcurrency(maxReads, maxWrites int, logger s3log.AuditLogger, mm *metrics.Manager) fiber.Handler {
+	reads, writes := newLimiter(maxReads), newLimiter(maxWrites)
+
+	return func(ctx *fiber.Ctx) error {
+		// only the object level requests are limited
+		path := ctx.Path()
+		if path == "/" || singlePath.MatchString(path) {
+			return ctx.Next()
+		}
+
+		l := writes
+		if ctx.Method() == http.MethodGet || ctx.Method() == http.MethodHead {
+			l = reads
+		}
+
+		if !l.acquire() {
+			return sendResponse(ctx, s3err.GetAPIError(s3err.ErrSlowDown), logger, mm)
+		}
+
+		var once sync.Once
+		release := func() { once.Do(l.release) }
+		// the streamed response bodies (GetObject) are read after the
+		// handler returns, so the slot is held until the body is closed
+		ctx.Locals("release-slot", release)
+
+		err := ctx.Next()
+		if _, ok := ctx.Response().BodyStream().(*utils.ReleaseReadCloser); !ok {
+			release()
+		}
+
+		return err
+	}
+}
+
+// limiter is a non-blocking counting semaphore, nil limiter is unlimited
+type limiter chan struct{}
+
+func newLimiter(max int) limiter {
+	if max <= 0 {
+		return nil
+	}
+	return make(limiter, max)
+}
+
+func (l limiter) acquire() bool {
+	if l == nil {
+		return true
+	}
+	select {
+	case l <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (l limiter) release() {
+	if l != nil {
+		<-l
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2023 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package middlewares
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/versity/versitygw/s3api/utils"
+)
+
+func TestLimitConcurrency(t *testing.T) {
+	const maxWrites = 2
+
+	entered := make(chan struct{})
+	unblock := make(chan struct{})
+
+	app := fiber.New()
+	app.Use(LimitConcurrency(0, maxWrites, nil, nil))
+	app.Put("/:bucket/:key", func(ctx *fiber.Ctx) error {
+		entered <- struct{}{}
+		<-unblock
+		return ctx.SendStatus(http.StatusOK)
+	})
+	app.Get("/:bucket/:key", func(ctx *fiber.Ctx) error {
+		return ctx.SendStatus(http.StatusOK)
+	})
+
+	var wg sync.WaitGroup
+	statuses := make(chan int, maxWrites)
+	for i := 0; i < maxWrites; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := app.Test(httptest.NewRequest(http.MethodPut, "/bucket/obj", nil), -1)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			statuses <- resp.StatusCode
+		}()
+		<-entered
+	}
+
+	// the write limit is reached: the next write is throttled
+	resp, err := app.Test(httptest.NewRequest(http.MethodPut, "/bucket/obj", nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected the status to be %v, instead got %v", http.StatusServiceUnavailable, resp.StatusCode)
+	}
+
+	// reads are not limited
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/bucket/obj", nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected the status to be %v, instead got %v", http.StatusOK, resp.StatusCode)
+	}
+
+	close(unblock)
+	wg.Wait()
+	close(statuses)
+	for status := range statuses {
+		if status != http.StatusOK {
+			t.Errorf("expected the status to be %v, instead got %v", http.StatusOK, status)
+		}
+	}
+
+	// the retry succeeds, once the in flight writes complete
+	go func() { <-entered }()
+	resp, err = app.Test(httptest.NewRequest(http.MethodPut, "/bucket/obj", nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected the status to be %v, instead got %v", http.StatusOK, resp.StatusCode)
+	}
+}
+
+func TestLimitConcurrency_streamed_body(t *testing.T) {
+	streams := make(chan *io.PipeWriter, 1)
+
+	app := fiber.New()
+	app.Use(LimitConcurrency(1, 0, nil, nil))
+	app.Get("/:bucket/:key", func(ctx *fiber.Ctx) error {
+		if ctx.Params("key") != "stream" {
+			return ctx.SendStatus(http.StatusOK)
+		}
+		pr, pw := io.Pipe()
+		release := ctx.Locals("release-slot").(func())
+		ctx.Response().SetBodyStream(utils.NewReleaseReadCloser(pr, release), -1)
+		streams <- pw
+		return nil
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/bucket/stream", nil), -1)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("expected the status to be %v, instead got %v", http.StatusOK, resp.StatusCode)
+		}
+	}()
+	pw := <-streams
+
+	// the handler has returned, but the body is still being read
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/bucket/obj", nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected the status to be %v, instead got %v", http.StatusServiceUnavailable, resp.StatusCode)
+	}
+
+	pw.Close()
+	<-done
+
+	// the slot is released, once the body is closed
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/bucket/obj", nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected the status to be %v, instead got %v", http.StatusOK, resp.StatusCode)
+	}
+}
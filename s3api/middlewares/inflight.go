@@ -0,0 +1,35 @@
+// Copyright 2023 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package middlewares
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/versity/versitygw/metrics"
+)
+
+// TrackInFlight counts the requests being processed in the 'in_flight'
+// metrics gauge, the request is done once the handlers return
+func TrackInFlight(mm *metrics.Manager) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if mm == nil {
+			return ctx.Next()
+		}
+
+		done := mm.TrackRequest()
+		defer done()
+
+		return ctx.Next()
+	}
+}
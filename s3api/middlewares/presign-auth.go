@@ -30,7 +30,7 @@ func VerifyPresignedV4Signature(root RootUserConfig, iam auth.IAMService, logger
 	acct := accounts{root: root, iam: iam}
 
 	return func(ctx *fiber.Ctx) error {
-		if ctx.Query("X-Amz-Signature") == "" {
+		if !isPresignedRequest(ctx) {
 			return ctx.Next()
 		}
 
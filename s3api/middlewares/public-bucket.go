@@ -0,0 +1,133 @@
+// Copyright 2023 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/versity/versitygw/auth"
+	"github.com/versity/versitygw/backend"
+)
+
+// bucketSubresources are the bucket level query arguments, which
+// turn a bucket GET request into something other than ListObjects(V2)
+var bucketSubresources = []string{
+	"acl",
+	"tagging",
+	"versioning",
+	"policy",
+	"object-lock",
+	"ownershipControls",
+	"versions",
+	"uploads",
+	"accelerate",
+	"location",
+}
+
+// objectSubresources are the object level query arguments, which
+// turn an object GET/HEAD request into something other than
+// GetObject/HeadObject
+var objectSubresources = []string{
+	"acl",
+	"tagging",
+	"retention",
+	"legal-hold",
+	"attributes",
+	"uploadId",
+	"torrent",
+}
+
+// AuthorizePublicBucketAccess allows unauthenticated ListObjects(V2)
+// requests, if the bucket policy grants s3:ListBucket to all users("*"),
+// and unauthenticated GetObject/HeadObject requests, if it grants
+// s3:GetObject to all users.
+// Any other unauthenticated request is passed to the signature verification.
+func AuthorizePublicBucketAccess(be backend.Backend, region string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		// If account is set in context locals, it means it was presigned url case
+		_, ok := ctx.Locals("account").(auth.Account)
+		if ok {
+			return ctx.Next()
+		}
+		if !isAnonymousRequest(ctx) {
+			return ctx.Next()
+		}
+
+		path := strings.TrimPrefix(ctx.Path(), "/")
+		bucket, object, _ := strings.Cut(path, "/")
+
+		var action auth.Action
+		switch {
+		case isListBucketRequest(ctx):
+			action = auth.ListBucketAction
+			object = ""
+		case isGetObjectRequest(ctx, object):
+			action = auth.GetObjectAction
+		default:
+			return ctx.Next()
+		}
+
+		ctx.Locals("region", region)
+		ctx.Locals("startTime", time.Now())
+
+		policy, err := be.GetBucketPolicy(ctx.Context(), bucket)
+		if err != nil {
+			return ctx.Next()
+		}
+
+		err = auth.VerifyPublicBucketPolicy(policy, bucket, object, action)
+		if err != nil {
+			return ctx.Next()
+		}
+
+		ctx.Locals("isRoot", false)
+		ctx.Locals("account", auth.Account{})
+
+		return ctx.Next()
+	}
+}
+
+func isListBucketRequest(ctx *fiber.Ctx) bool {
+	if ctx.Method() != http.MethodGet || !singlePath.MatchString(ctx.Path()) {
+		return false
+	}
+
+	return !hasSubresource(ctx, bucketSubresources)
+}
+
+func isGetObjectRequest(ctx *fiber.Ctx, object string) bool {
+	if ctx.Method() != http.MethodGet && ctx.Method() != http.MethodHead {
+		return false
+	}
+	if object == "" {
+		return false
+	}
+
+	return !hasSubresource(ctx, objectSubresources)
+}
+
+func hasSubresource(ctx *fiber.Ctx, subresources []string) bool {
+	args := ctx.Request().URI().QueryArgs()
+	for _, sub := range subresources {
+		if args.Has(sub) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -32,7 +32,7 @@ func (sa *S3ApiRouter) Init(app *fiber.App, be backend.Backend, iam auth.IAMServ
 	s3ApiController := controllers.New(be, iam, logger, evs, mm, debug, readonly)
 
 	if sa.WithAdmSrv {
-		adminController := controllers.NewAdminController(iam, be, aLogger)
+		adminController := controllers.NewAdminController(iam, be, aLogger, mm)
 
 		// CreateUser admin api
 		app.Patch("/create-user", adminController.CreateUser)
@@ -51,6 +51,12 @@ func (sa *S3ApiRouter) Init(app *fiber.App, be backend.Backend, iam auth.IAMServ
 
 		// ListBucketsAndOwners admin api
 		app.Patch("/list-buckets", adminController.ListBuckets)
+
+		// GetBucketStats admin api
+		app.Patch("/bucket-stats", adminController.GetBucketStats)
+
+		// GetLatencySummary admin api
+		app.Patch("/latency-summary", adminController.GetLatencySummary)
 	}
 
 	// ListBuckets action
@@ -67,6 +73,7 @@ func (sa *S3ApiRouter) Init(app *fiber.App, be backend.Backend, iam auth.IAMServ
 	app.Head("/:bucket", s3ApiController.HeadBucket)
 
 	// GetBucketAcl action
+	// GetBucketLocation action
 	// ListMultipartUploads action
 	// ListObjects action
 	// ListObjectsV2 action
@@ -38,6 +38,9 @@ type S3ApiServer struct {
 	debug    bool
 	readonly bool
 	health   string
+
+	maxReads  int
+	maxWrites int
 }
 
 func New(
@@ -75,15 +78,21 @@ func New(
 			return ctx.SendStatus(http.StatusOK)
 		})
 	}
+	app.Use(middlewares.TrackInFlight(mm))
 	app.Use(middlewares.DecodeURL(l, mm))
 	app.Use(middlewares.RequestLogger(server.debug))
 
 	// Authentication middlewares
 	app.Use(middlewares.VerifyPresignedV4Signature(root, iam, l, mm, region, server.debug))
+	app.Use(middlewares.AuthorizePublicBucketAccess(be, region))
 	app.Use(middlewares.VerifyV4Signature(root, iam, l, mm, region, server.debug))
 	app.Use(middlewares.ProcessChunkedBody(root, iam, l, mm, region))
 	app.Use(middlewares.VerifyMD5Body(l))
+	app.Use(middlewares.VerifyChecksumBody(l))
 	app.Use(middlewares.AclParser(be, l, server.readonly))
+	if server.maxReads > 0 || server.maxWrites > 0 {
+		app.Use(middlewares.LimitConcurrency(server.maxReads, server.maxWrites, l, mm))
+	}
 
 	server.router.Init(app, be, iam, l, adminLogger, evs, mm, server.debug, server.readonly)
 
@@ -122,6 +131,15 @@ func WithReadOnly() Option {
 	return func(s *S3ApiServer) { s.readonly = true }
 }
 
+// WithConcurrencyLimits sets the max number of concurrent object
+// read and write requests, 0 means unlimited
+func WithConcurrencyLimits(maxReads, maxWrites int) Option {
+	return func(s *S3ApiServer) {
+		s.maxReads = maxReads
+		s.maxWrites = maxWrites
+	}
+}
+
 func (sa *S3ApiServer) Serve() (err error) {
 	if sa.cert != nil {
 		return sa.app.ListenTLSWithCertificate(sa.port, *sa.cert)
@@ -16,11 +16,13 @@ package utils
 
 import (
 	"crypto/md5"
+	"crypto/sha1"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
 	"errors"
 	"hash"
+	"hash/crc32"
 	"io"
 
 	"github.com/versity/versitygw/s3err"
@@ -36,6 +38,18 @@ const (
 	HashTypeSha256 = "sha256"
 	// HashTypeNone is a no-op checksum for the data stream
 	HashTypeNone = "none"
+	// HashTypeCRC32 generates the base64 encoded CRC32
+	// additional checksum for the data stream
+	HashTypeCRC32 = "crc32"
+	// HashTypeCRC32C generates the base64 encoded CRC32C
+	// additional checksum for the data stream
+	HashTypeCRC32C = "crc32c"
+	// HashTypeSha1 generates the base64 encoded SHA1
+	// additional checksum for the data stream
+	HashTypeSha1 = "sha1"
+	// HashTypeSha256Checksum generates the base64 encoded SHA256
+	// additional checksum for the data stream
+	HashTypeSha256Checksum = "sha256-checksum"
 )
 
 // HashReader is an io.Reader that calculates the checksum
@@ -66,6 +80,14 @@ func NewHashReader(r io.Reader, expectedSum string, ht HashType) (*HashReader, e
 		hash = sha256.New()
 	case HashTypeNone:
 		hash = noop{}
+	case HashTypeCRC32:
+		hash = crc32.NewIEEE()
+	case HashTypeCRC32C:
+		hash = crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	case HashTypeSha1:
+		hash = sha1.New()
+	case HashTypeSha256Checksum:
+		hash = sha256.New()
 	default:
 		return nil, errInvalidHashType
 	}
@@ -97,6 +119,11 @@ func (hr *HashReader) Read(p []byte) (int, error) {
 			if sum != hr.sum {
 				return n, s3err.GetAPIError(s3err.ErrContentSHA256Mismatch)
 			}
+		case HashTypeCRC32, HashTypeCRC32C, HashTypeSha1, HashTypeSha256Checksum:
+			sum := base64.StdEncoding.EncodeToString(hr.hash.Sum(nil))
+			if sum != hr.sum {
+				return n, s3err.GetAPIError(s3err.ErrBadDigest)
+			}
 		default:
 			return n, errInvalidHashType
 		}
@@ -111,6 +138,8 @@ func (hr *HashReader) Sum() string {
 		return Md5SumString(hr.hash.Sum(nil))
 	case HashTypeSha256:
 		return hex.EncodeToString(hr.hash.Sum(nil))
+	case HashTypeCRC32, HashTypeCRC32C, HashTypeSha1, HashTypeSha256Checksum:
+		return base64.StdEncoding.EncodeToString(hr.hash.Sum(nil))
 	default:
 		return ""
 	}
@@ -0,0 +1,114 @@
+// Copyright 2023 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utils
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// IdempotencyTokenHeader is the client supplied token, which makes
+	// PutObject retries return the prior result instead of re-writing
+	IdempotencyTokenHeader = "X-Versity-Idempotency-Token"
+
+	idempotencyTokenTTL     = 15 * time.Minute
+	maxIdempotencyCacheSize = 10000
+)
+
+// PutObjectResult is the replayed result of a succeeded PutObject request
+type PutObjectResult struct {
+	ETag      string
+	VersionID string
+	expires   time.Time
+}
+
+// IdempotencyCache stores the successful PutObject results for a short
+// period of time. The entries are keyed by the token, the account access
+// key and the object path, so the token can't be used by other accounts.
+type IdempotencyCache struct {
+	sync.Mutex
+	ttl   time.Duration
+	items map[string]PutObjectResult
+}
+
+func NewIdempotencyCache() *IdempotencyCache {
+	return &IdempotencyCache{
+		ttl:   idempotencyTokenTTL,
+		items: make(map[string]PutObjectResult),
+	}
+}
+
+// IdempotencyKey returns the cache key of the PutObject request. Every
+// field is length prefixed, as the token and the object key may contain
+// any separator, so distinct requests can't map to the same key.
+func IdempotencyKey(token, access, bucket, object string) string {
+	var b strings.Builder
+	for _, field := range []string{token, access, bucket, object} {
+		b.WriteString(strconv.Itoa(len(field)))
+		b.WriteByte(':')
+		b.WriteString(field)
+	}
+	return b.String()
+}
+
+// Get returns the cached result, if it hasn't expired yet
+func (c *IdempotencyCache) Get(key string) (PutObjectResult, bool) {
+	if c == nil {
+		return PutObjectResult{}, false
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	res, ok := c.items[key]
+	if !ok {
+		return PutObjectResult{}, false
+	}
+	if time.Now().After(res.expires) {
+		delete(c.items, key)
+		return PutObjectResult{}, false
+	}
+	return res, true
+}
+
+// Set caches the PutObject result. The expired entries are evicted
+// once the cache is full, and the result isn't cached if there are
+// still no free slots.
+func (c *IdempotencyCache) Set(key string, res PutObjectResult) {
+	if c == nil {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	if len(c.items) >= maxIdempotencyCacheSize {
+		now := time.Now()
+		for k, v := range c.items {
+			if now.After(v.expires) {
+				delete(c.items, k)
+			}
+		}
+		if len(c.items) >= maxIdempotencyCacheSize {
+			return
+		}
+	}
+
+	res.expires = time.Now().Add(c.ttl)
+	c.items[key] = res
+}
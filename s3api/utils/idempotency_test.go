@@ -0,0 +1,71 @@
+// Copyright 2023 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIdempotencyCache(t *testing.T) {
+	cache := NewIdempotencyCache()
+
+	key := IdempotencyKey("token", "user1", "bucket", "obj")
+	cache.Set(key, PutObjectResult{ETag: "etag"})
+
+	res, ok := cache.Get(key)
+	if !ok {
+		t.Fatalf("expected the result to be cached")
+	}
+	if res.ETag != "etag" {
+		t.Errorf("expected the etag to be %v, instead got %v", "etag", res.ETag)
+	}
+
+	// the same token used by another account or for another object
+	// must not return the cached result
+	for _, other := range []string{
+		IdempotencyKey("token", "user2", "bucket", "obj"),
+		IdempotencyKey("token", "user1", "bucket", "other-obj"),
+		IdempotencyKey("other-token", "user1", "bucket", "obj"),
+	} {
+		if _, ok := cache.Get(other); ok {
+			t.Errorf("expected no cached result for %q", other)
+		}
+	}
+
+	// the fields containing the separator don't collide
+	for _, pair := range [][2]string{
+		{IdempotencyKey("token", "user1", "bucket", "dir:obj"), IdempotencyKey("token", "user1", "bucket:dir", "obj")},
+		{IdempotencyKey("tok:en", "user1", "bucket", "obj"), IdempotencyKey("tok", "en:user1", "bucket", "obj")},
+	} {
+		if pair[0] == pair[1] {
+			t.Errorf("expected distinct keys, both are %q", pair[0])
+		}
+	}
+
+	// expired results are evicted
+	cache.ttl = -time.Second
+	cache.Set(key, PutObjectResult{ETag: "etag"})
+	if _, ok := cache.Get(key); ok {
+		t.Errorf("expected the expired result to be evicted")
+	}
+
+	// nil cache is a no-op
+	var nilCache *IdempotencyCache
+	nilCache.Set(key, PutObjectResult{})
+	if _, ok := nilCache.Get(key); ok {
+		t.Errorf("expected no cached result in a nil cache")
+	}
+}
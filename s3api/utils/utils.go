@@ -16,6 +16,8 @@ package utils
 
 import (
 	"bytes"
+	"crypto/md5"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
@@ -24,6 +26,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
@@ -37,6 +40,12 @@ import (
 var (
 	bucketNameRegexp   = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]+[a-z0-9]$`)
 	bucketNameIpRegexp = regexp.MustCompile(`^(?:[0-9]{1,3}\.){3}[0-9]{1,3}$`)
+	tagRegexp          = regexp.MustCompile(`^[\p{L}\p{Z}\p{N}_.:/=+\-@]*$`)
+)
+
+const (
+	// MaxObjectTags is the max number of tags an object can have
+	MaxObjectTags = 10
 )
 
 const (
@@ -196,6 +205,38 @@ func SetResponseHeaders(ctx *fiber.Ctx, headers []CustomHeader) {
 	}
 }
 
+// responseOverrides maps the GetObject query params to the
+// response headers they override
+var responseOverrides = []struct {
+	param  string
+	header string
+}{
+	{"response-cache-control", "Cache-Control"},
+	{"response-content-disposition", "Content-Disposition"},
+	{"response-content-encoding", "Content-Encoding"},
+	{"response-content-language", "Content-Language"},
+	{"response-content-type", "Content-Type"},
+	{"response-expires", "Expires"},
+}
+
+// GetResponseOverrides returns the response headers overridden
+// with the response-* query params of the GetObject request
+func GetResponseOverrides(ctx *fiber.Ctx) []CustomHeader {
+	var hdrs []CustomHeader
+	for _, o := range responseOverrides {
+		val := ctx.Query(o.param)
+		if val == "" {
+			continue
+		}
+		hdrs = append(hdrs, CustomHeader{
+			Key:   o.header,
+			Value: val,
+		})
+	}
+
+	return hdrs
+}
+
 func IsValidBucketName(bucket string) bool {
 	if len(bucket) < 3 || len(bucket) > 63 {
 		return false
@@ -205,6 +246,10 @@ func IsValidBucketName(bucket string) bool {
 	if !bucketNameRegexp.MatchString(bucket) {
 		return false
 	}
+	// Checks not to contain two adjacent periods
+	if strings.Contains(bucket, "..") {
+		return false
+	}
 	// Checks not to be a valid IP address
 	if bucketNameIpRegexp.MatchString(bucket) {
 		return false
@@ -258,6 +303,9 @@ func FilterObjectAttributes(attrs map[types.ObjectAttributes]struct{}, output s3
 	if _, ok := attrs[types.ObjectAttributesEtag]; !ok {
 		output.ETag = nil
 	}
+	if _, ok := attrs[types.ObjectAttributesChecksum]; !ok {
+		output.Checksum = nil
+	}
 	if _, ok := attrs[types.ObjectAttributesObjectParts]; !ok {
 		output.ObjectParts = nil
 	}
@@ -333,6 +381,200 @@ func ParsObjectLockHdrs(ctx *fiber.Ctx) (*objLockCfg, error) {
 	}, nil
 }
 
+type ConditionalHeaders struct {
+	IfMatch      string
+	IfNoneMatch  string
+	IfModSince   *time.Time
+	IfUnmodSince *time.Time
+}
+
+// IsSet returns true if any of the conditional headers is specified
+func (c ConditionalHeaders) IsSet() bool {
+	return c.IfMatch != "" || c.IfNoneMatch != "" ||
+		c.IfModSince != nil || c.IfUnmodSince != nil
+}
+
+// ParseConditionalHeaders parses the If-Match, If-None-Match,
+// If-Modified-Since and If-Unmodified-Since request headers.
+// Invalid dates are ignored.
+func ParseConditionalHeaders(ctx *fiber.Ctx) ConditionalHeaders {
+	parseDate := func(hdr string) *time.Time {
+		date, err := http.ParseTime(ctx.Get(hdr))
+		if err != nil {
+			return nil
+		}
+		return &date
+	}
+
+	return ConditionalHeaders{
+		IfMatch:      ctx.Get("If-Match"),
+		IfNoneMatch:  ctx.Get("If-None-Match"),
+		IfModSince:   parseDate("If-Modified-Since"),
+		IfUnmodSince: parseDate("If-Unmodified-Since"),
+	}
+}
+
+// SSECustomerKey is the server side encryption with
+// customer provided keys (SSE-C) request headers
+type SSECustomerKey struct {
+	Algorithm string
+	Key       string
+	KeyMD5    string
+}
+
+// IsSet returns true if the SSE-C headers are specified
+func (k SSECustomerKey) IsSet() bool {
+	return k.Algorithm != ""
+}
+
+// ParseSSECustomerHeaders parses and validates the SSE-C request headers.
+// The key is the base64 encoded 256 bit key, and the key MD5 the base64
+// encoded MD5 digest of the decoded key. The headers are only accepted
+// on TLS connections.
+func ParseSSECustomerHeaders(ctx *fiber.Ctx) (SSECustomerKey, error) {
+	k := SSECustomerKey{
+		Algorithm: ctx.Get("X-Amz-Server-Side-Encryption-Customer-Algorithm"),
+		Key:       ctx.Get("X-Amz-Server-Side-Encryption-Customer-Key"),
+		KeyMD5:    ctx.Get("X-Amz-Server-Side-Encryption-Customer-Key-Md5"),
+	}
+
+	if k.Algorithm == "" && k.Key == "" && k.KeyMD5 == "" {
+		return k, nil
+	}
+	// the customer key is sent in plain text, so it's refused
+	// over a connection without TLS
+	if !ctx.Context().IsTLS() {
+		return SSECustomerKey{}, s3err.GetAPIError(s3err.ErrSSECustomerKeyInsecure)
+	}
+
+	if k.Algorithm == "" {
+		return SSECustomerKey{}, s3err.GetAPIError(s3err.ErrMissingSSECustomerAlgorithm)
+	}
+	if k.Algorithm != "AES256" {
+		return SSECustomerKey{}, s3err.GetAPIError(s3err.ErrInvalidSSECustomerAlgorithm)
+	}
+	if k.Key == "" {
+		return SSECustomerKey{}, s3err.GetAPIError(s3err.ErrMissingSSECustomerKey)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(k.Key)
+	if err != nil || len(key) != 32 {
+		return SSECustomerKey{}, s3err.GetAPIError(s3err.ErrInvalidSSECustomerKey)
+	}
+
+	sum := md5.Sum(key)
+	if k.KeyMD5 != base64.StdEncoding.EncodeToString(sum[:]) {
+		return SSECustomerKey{}, s3err.GetAPIError(s3err.ErrSSECustomerKeyMD5Mismatch)
+	}
+
+	return k, nil
+}
+
+// HasSSECustomerHeaders returns true if any of the SSE-C
+// or the copy source SSE-C request headers is specified
+func HasSSECustomerHeaders(ctx *fiber.Ctx) bool {
+	found := false
+	ctx.Request().Header.VisitAll(func(key, _ []byte) {
+		hdr := strings.ToLower(string(key))
+		if strings.HasPrefix(hdr, "x-amz-server-side-encryption-customer-") ||
+			strings.HasPrefix(hdr, "x-amz-copy-source-server-side-encryption-customer-") {
+			found = true
+		}
+	})
+	return found
+}
+
+// checksumSizes are the decoded sizes of the additional checksums
+var checksumSizes = map[types.ChecksumAlgorithm]int{
+	types.ChecksumAlgorithmCrc32:  4,
+	types.ChecksumAlgorithmCrc32c: 4,
+	types.ChecksumAlgorithmSha1:   20,
+	types.ChecksumAlgorithmSha256: 32,
+}
+
+// ParseChecksumHeaders parses the additional checksum request headers:
+// x-amz-checksum-crc32, -crc32c, -sha1 and -sha256. It returns the
+// checksum algorithm and the base64 encoded checksum, the algorithm
+// is empty if no checksum is specified.
+func ParseChecksumHeaders(ctx *fiber.Ctx) (types.ChecksumAlgorithm, string, error) {
+	var algo types.ChecksumAlgorithm
+	var sum string
+	for _, a := range []types.ChecksumAlgorithm{
+		types.ChecksumAlgorithmCrc32,
+		types.ChecksumAlgorithmCrc32c,
+		types.ChecksumAlgorithmSha1,
+		types.ChecksumAlgorithmSha256,
+	} {
+		val := ctx.Get("X-Amz-Checksum-" + string(a))
+		if val == "" {
+			continue
+		}
+		if algo != "" {
+			return "", "", s3err.GetAPIError(s3err.ErrMultipleChecksumHeaders)
+		}
+		b, err := base64.StdEncoding.DecodeString(val)
+		if err != nil || len(b) != checksumSizes[a] {
+			return "", "", s3err.GetAPIError(s3err.ErrInvalidChecksumHeader)
+		}
+		algo, sum = a, val
+	}
+
+	return algo, sum, nil
+}
+
+// ChecksumHashType returns the data stream hash
+// type of the additional checksum algorithm
+func ChecksumHashType(algo types.ChecksumAlgorithm) HashType {
+	switch algo {
+	case types.ChecksumAlgorithmCrc32:
+		return HashTypeCRC32
+	case types.ChecksumAlgorithmCrc32c:
+		return HashTypeCRC32C
+	case types.ChecksumAlgorithmSha1:
+		return HashTypeSha1
+	case types.ChecksumAlgorithmSha256:
+		return HashTypeSha256Checksum
+	default:
+		return HashTypeNone
+	}
+}
+
+// IsValidTag checks the tag key/value lengths and character sets:
+// letters, numbers, spaces and + - = . _ : / @ are allowed
+func IsValidTag(key, value string) bool {
+	if len(key) == 0 || len(key) > 128 || len(value) > 256 {
+		return false
+	}
+
+	return tagRegexp.MatchString(key) && tagRegexp.MatchString(value)
+}
+
+// ValidateTaggingHeader validates the url encoded X-Amz-Tagging header
+// with the same tag count and key/value restrictions as PutObjectTagging
+func ValidateTaggingHeader(tagging string) error {
+	if tagging == "" {
+		return nil
+	}
+
+	tags, err := url.ParseQuery(tagging)
+	if err != nil {
+		return s3err.GetAPIError(s3err.ErrInvalidTag)
+	}
+
+	for key, values := range tags {
+		// duplicate tag keys aren't allowed
+		if len(values) != 1 || !IsValidTag(key, values[0]) {
+			return s3err.GetAPIError(s3err.ErrInvalidTag)
+		}
+	}
+
+	if len(tags) > MaxObjectTags {
+		return s3err.GetAPIError(s3err.ErrObjectTaggingLimited)
+	}
+
+	return nil
+}
+
 func IsValidOwnership(val types.ObjectOwnership) bool {
 	switch val {
 	case types.ObjectOwnershipBucketOwnerEnforced:
@@ -416,3 +658,26 @@ func shouldEscape(c byte) bool {
 
 	return true
 }
+
+// ReleaseReadCloser calls the release function once the wrapped body
+// is closed. It's used to hold resources (e.g. concurrency limit slots)
+// for the streamed response bodies, which are read after the request
+// handler returns.
+type ReleaseReadCloser struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func NewReleaseReadCloser(body io.ReadCloser, release func()) *ReleaseReadCloser {
+	return &ReleaseReadCloser{
+		ReadCloser: body,
+		release:    release,
+	}
+}
+
+func (r *ReleaseReadCloser) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(r.release)
+	return err
+}
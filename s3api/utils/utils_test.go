@@ -16,13 +16,19 @@ package utils
 
 import (
 	"bytes"
+	"crypto/md5"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/gofiber/fiber/v2"
 	"github.com/valyala/fasthttp"
+	"github.com/versity/versitygw/s3err"
 	"github.com/versity/versitygw/s3response"
 )
 
@@ -207,6 +213,13 @@ func TestIsValidBucketName(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "IsValidBucketName-adjacent-dots",
+			args: args{
+				bucket: "my..bucket",
+			},
+			want: false,
+		},
 		{
 			name: "IsValidBucketName-valid-bucket-name",
 			args: args{
@@ -504,3 +517,281 @@ func Test_escapePath(t *testing.T) {
 		})
 	}
 }
+
+func TestIsValidTag(t *testing.T) {
+	type args struct {
+		key   string
+		value string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "valid-tag",
+			args: args{
+				key:   "key +-=._:/@1",
+				value: "value +-=._:/@1",
+			},
+			want: true,
+		},
+		{
+			name: "valid-empty-value",
+			args: args{
+				key:   "key",
+				value: "",
+			},
+			want: true,
+		},
+		{
+			name: "empty-key",
+			args: args{
+				key:   "",
+				value: "value",
+			},
+			want: false,
+		},
+		{
+			name: "too-long-key",
+			args: args{
+				key:   strings.Repeat("k", 129),
+				value: "value",
+			},
+			want: false,
+		},
+		{
+			name: "too-long-value",
+			args: args{
+				key:   "key",
+				value: strings.Repeat("v", 257),
+			},
+			want: false,
+		},
+		{
+			name: "invalid-key-chars",
+			args: args{
+				key:   "key$",
+				value: "value",
+			},
+			want: false,
+		},
+		{
+			name: "invalid-value-chars",
+			args: args{
+				key:   "key",
+				value: "value#",
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidTag(tt.args.key, tt.args.value); got != tt.want {
+				t.Errorf("IsValidTag() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateTaggingHeader(t *testing.T) {
+	tags := make([]string, 0, MaxObjectTags+1)
+	for i := 0; i <= MaxObjectTags; i++ {
+		tags = append(tags, fmt.Sprintf("key%v=val%v", i, i))
+	}
+
+	tests := []struct {
+		name    string
+		tagging string
+		err     error
+	}{
+		{
+			name:    "empty-header",
+			tagging: "",
+			err:     nil,
+		},
+		{
+			name:    "valid-tags",
+			tagging: "key1=val1&key%202=val%202",
+			err:     nil,
+		},
+		{
+			name:    "max-tags",
+			tagging: strings.Join(tags[:MaxObjectTags], "&"),
+			err:     nil,
+		},
+		{
+			name:    "too-many-tags",
+			tagging: strings.Join(tags, "&"),
+			err:     s3err.GetAPIError(s3err.ErrObjectTaggingLimited),
+		},
+		{
+			name:    "duplicate-keys",
+			tagging: "key=val1&key=val2",
+			err:     s3err.GetAPIError(s3err.ErrInvalidTag),
+		},
+		{
+			name:    "invalid-key-chars",
+			tagging: "key%24=val",
+			err:     s3err.GetAPIError(s3err.ErrInvalidTag),
+		},
+		{
+			name:    "too-long-value",
+			tagging: "key=" + strings.Repeat("v", 257),
+			err:     s3err.GetAPIError(s3err.ErrInvalidTag),
+		},
+		{
+			name:    "invalid-encoding",
+			tagging: "key=%zz",
+			err:     s3err.GetAPIError(s3err.ErrInvalidTag),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTaggingHeader(tt.tagging)
+			if !reflect.DeepEqual(err, tt.err) {
+				t.Errorf("ValidateTaggingHeader() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestGetResponseOverrides(t *testing.T) {
+	app := fiber.New()
+
+	tests := []struct {
+		name  string
+		query string
+		want  []CustomHeader
+	}{
+		{
+			name:  "no-overrides",
+			query: "versionId=1",
+			want:  nil,
+		},
+		{
+			name:  "content-type-and-disposition",
+			query: "response-content-type=application%2Fjson&response-content-disposition=attachment",
+			want: []CustomHeader{
+				{Key: "Content-Disposition", Value: "attachment"},
+				{Key: "Content-Type", Value: "application/json"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := app.AcquireCtx(&fasthttp.RequestCtx{})
+			defer app.ReleaseCtx(ctx)
+			ctx.Request().SetRequestURI("/bucket/object?" + tt.query)
+
+			if got := GetResponseOverrides(ctx); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetResponseOverrides() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSSECustomerHeaders(t *testing.T) {
+	app := fiber.New()
+
+	key := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	sum := md5.Sum([]byte("0123456789abcdef0123456789abcdef"))
+	keyMD5 := base64.StdEncoding.EncodeToString(sum[:])
+	shortKey := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef"))
+
+	tests := []struct {
+		name      string
+		algorithm string
+		key       string
+		keyMD5    string
+		err       error
+	}{
+		{"no-headers", "", "", "", nil},
+		{"success", "AES256", key, keyMD5, nil},
+		{"missing-algorithm", "", key, keyMD5, s3err.GetAPIError(s3err.ErrMissingSSECustomerAlgorithm)},
+		{"invalid-algorithm", "aws:kms", key, keyMD5, s3err.GetAPIError(s3err.ErrInvalidSSECustomerAlgorithm)},
+		{"missing-key", "AES256", "", keyMD5, s3err.GetAPIError(s3err.ErrMissingSSECustomerKey)},
+		{"short-key", "AES256", shortKey, keyMD5, s3err.GetAPIError(s3err.ErrInvalidSSECustomerKey)},
+		{"invalid-base64-key", "AES256", "invalid key", keyMD5, s3err.GetAPIError(s3err.ErrInvalidSSECustomerKey)},
+		{"missing-key-md5", "AES256", key, "", s3err.GetAPIError(s3err.ErrSSECustomerKeyMD5Mismatch)},
+		{"key-md5-mismatch", "AES256", key, shortKey, s3err.GetAPIError(s3err.ErrSSECustomerKeyMD5Mismatch)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fctx := &fasthttp.RequestCtx{}
+			fctx.Init2(&tls.Conn{}, nil, false)
+			ctx := app.AcquireCtx(fctx)
+			defer app.ReleaseCtx(ctx)
+			for hdr, val := range map[string]string{
+				"X-Amz-Server-Side-Encryption-Customer-Algorithm": tt.algorithm,
+				"X-Amz-Server-Side-Encryption-Customer-Key":       tt.key,
+				"X-Amz-Server-Side-Encryption-Customer-Key-MD5":   tt.keyMD5,
+			} {
+				if val != "" {
+					ctx.Request().Header.Set(hdr, val)
+				}
+			}
+
+			got, err := ParseSSECustomerHeaders(ctx)
+			if !reflect.DeepEqual(err, tt.err) {
+				t.Fatalf("ParseSSECustomerHeaders() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && got.IsSet() != (tt.algorithm != "") {
+				t.Errorf("ParseSSECustomerHeaders() IsSet = %v, want %v", got.IsSet(), tt.algorithm != "")
+			}
+		})
+	}
+
+	t.Run("insecure-connection", func(t *testing.T) {
+		ctx := app.AcquireCtx(&fasthttp.RequestCtx{})
+		defer app.ReleaseCtx(ctx)
+		ctx.Request().Header.Set("X-Amz-Server-Side-Encryption-Customer-Algorithm", "AES256")
+		ctx.Request().Header.Set("X-Amz-Server-Side-Encryption-Customer-Key", key)
+		ctx.Request().Header.Set("X-Amz-Server-Side-Encryption-Customer-Key-MD5", keyMD5)
+
+		_, err := ParseSSECustomerHeaders(ctx)
+		want := s3err.GetAPIError(s3err.ErrSSECustomerKeyInsecure)
+		if !reflect.DeepEqual(err, want) {
+			t.Fatalf("ParseSSECustomerHeaders() error = %v, want %v", err, want)
+		}
+	})
+}
+
+func TestParseChecksumHeaders(t *testing.T) {
+	app := fiber.New()
+
+	crc32c := base64.StdEncoding.EncodeToString([]byte{1, 2, 3, 4})
+	sha256 := base64.StdEncoding.EncodeToString(make([]byte, 32))
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		algo    types.ChecksumAlgorithm
+		sum     string
+		err     error
+	}{
+		{"no-checksum", nil, "", "", nil},
+		{"crc32c", map[string]string{"X-Amz-Checksum-Crc32c": crc32c}, types.ChecksumAlgorithmCrc32c, crc32c, nil},
+		{"sha256", map[string]string{"X-Amz-Checksum-Sha256": sha256}, types.ChecksumAlgorithmSha256, sha256, nil},
+		{"invalid-base64", map[string]string{"X-Amz-Checksum-Crc32": "invalid"}, "", "", s3err.GetAPIError(s3err.ErrInvalidChecksumHeader)},
+		{"invalid-size", map[string]string{"X-Amz-Checksum-Sha1": crc32c}, "", "", s3err.GetAPIError(s3err.ErrInvalidChecksumHeader)},
+		{"multiple-checksums", map[string]string{"X-Amz-Checksum-Crc32c": crc32c, "X-Amz-Checksum-Sha256": sha256}, "", "", s3err.GetAPIError(s3err.ErrMultipleChecksumHeaders)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := app.AcquireCtx(&fasthttp.RequestCtx{})
+			defer app.ReleaseCtx(ctx)
+			for hdr, val := range tt.headers {
+				ctx.Request().Header.Set(hdr, val)
+			}
+
+			algo, sum, err := ParseChecksumHeaders(ctx)
+			if !reflect.DeepEqual(err, tt.err) {
+				t.Fatalf("ParseChecksumHeaders() error = %v, want %v", err, tt.err)
+			}
+			if algo != tt.algo || sum != tt.sum {
+				t.Errorf("ParseChecksumHeaders() = %v, %v, want %v, %v", algo, sum, tt.algo, tt.sum)
+			}
+		})
+	}
+}
@@ -110,6 +110,7 @@ const (
 	ErrAuthNotSetup
 	ErrNotImplemented
 	ErrPreconditionFailed
+	ErrNotModified
 	ErrInvalidObjectState
 	ErrInvalidRange
 	ErrInvalidURI
@@ -136,6 +137,21 @@ const (
 	ErrInvalidVersionId
 	ErrNoSuchVersion
 	ErrSuspendedVersioningNotAllowed
+	ErrSlowDown
+	ErrObjectTaggingLimited
+	ErrAnonymousResponseHeaders
+	ErrInvalidPartOrder
+	ErrInvalidSSECustomerAlgorithm
+	ErrMissingSSECustomerAlgorithm
+	ErrMissingSSECustomerKey
+	ErrInvalidSSECustomerKey
+	ErrSSECustomerKeyMD5Mismatch
+	ErrSSECustomerKeyRequired
+	ErrSSECustomerKeyNotApplicable
+	ErrSSECustomerKeyInsecure
+	ErrBadDigest
+	ErrInvalidChecksumHeader
+	ErrMultipleChecksumHeaders
 
 	// Non-AWS errors
 	ErrExistingObjectIsDirectory
@@ -421,6 +437,11 @@ var errorCodeResponse = map[ErrorCode]APIError{
 		Description:    "At least one of the pre-conditions you specified did not hold.",
 		HTTPStatusCode: http.StatusPreconditionFailed,
 	},
+	ErrNotModified: {
+		Code:           "NotModified",
+		Description:    "Not Modified",
+		HTTPStatusCode: http.StatusNotModified,
+	},
 	ErrInvalidObjectState: {
 		Code:           "InvalidObjectState",
 		Description:    "The operation is not valid for the current state of the object.",
@@ -551,6 +572,81 @@ var errorCodeResponse = map[ErrorCode]APIError{
 		Description:    "An Object Lock configuration is present on this bucket, so the versioning state cannot be changed.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrSlowDown: {
+		Code:           "SlowDown",
+		Description:    "Please reduce your request rate.",
+		HTTPStatusCode: http.StatusServiceUnavailable,
+	},
+	ErrObjectTaggingLimited: {
+		Code:           "BadRequest",
+		Description:    "Object tags cannot be greater than 10",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAnonymousResponseHeaders: {
+		Code:           "InvalidRequest",
+		Description:    "Request specific response headers cannot be used for anonymous GET requests.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidPartOrder: {
+		Code:           "InvalidPartOrder",
+		Description:    "The list of parts was not in ascending order. Parts must be ordered by part number.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidSSECustomerAlgorithm: {
+		Code:           "InvalidEncryptionAlgorithmError",
+		Description:    "The Encryption request you specified is not valid. Supported value: AES256.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrMissingSSECustomerAlgorithm: {
+		Code:           "InvalidArgument",
+		Description:    "Requests specifying Server Side Encryption with Customer provided keys must provide a valid encryption algorithm.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrMissingSSECustomerKey: {
+		Code:           "InvalidArgument",
+		Description:    "Requests specifying Server Side Encryption with Customer provided keys must provide an appropriate secret key.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidSSECustomerKey: {
+		Code:           "InvalidArgument",
+		Description:    "The secret key was invalid for the specified algorithm.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrSSECustomerKeyMD5Mismatch: {
+		Code:           "InvalidArgument",
+		Description:    "The calculated MD5 hash of the key did not match the hash that was provided.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrSSECustomerKeyRequired: {
+		Code:           "InvalidRequest",
+		Description:    "The object was stored using a form of Server Side Encryption. The correct parameters must be provided to retrieve the object.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrSSECustomerKeyNotApplicable: {
+		Code:           "InvalidRequest",
+		Description:    "The encryption parameters are not applicable to this object.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrSSECustomerKeyInsecure: {
+		Code:           "InvalidRequest",
+		Description:    "Requests specifying Server Side Encryption with Customer provided keys must be made over a secure connection.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrBadDigest: {
+		Code:           "BadDigest",
+		Description:    "The checksum you specified did not match the calculated checksum.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidChecksumHeader: {
+		Code:           "InvalidRequest",
+		Description:    "Value for x-amz-checksum header is invalid.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrMultipleChecksumHeaders: {
+		Code:           "InvalidRequest",
+		Description:    "Expecting a single x-amz-checksum- header. Multiple checksum Types are not allowed.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 
 	// non aws errors
 	ErrExistingObjectIsDirectory: {
@@ -80,6 +80,7 @@ type ListPartsResult struct {
 
 type GetObjectAttributesResult struct {
 	ETag         *string
+	Checksum     *types.Checksum
 	LastModified *time.Time
 	ObjectSize   *int64
 	StorageClass types.StorageClass
@@ -109,6 +110,7 @@ type ObjectParts struct {
 	NextPartNumberMarker int
 	MaxParts             int
 	IsTruncated          bool
+	TotalPartsCount      int                `xml:"PartsCount"`
 	Parts                []types.ObjectPart `xml:"Part"`
 }
 
@@ -249,6 +251,7 @@ type TaggingInput struct {
 
 type DeleteObjects struct {
 	Objects []types.ObjectIdentifier `xml:"Object"`
+	Quiet   bool
 }
 
 type DeleteResult struct {
@@ -277,6 +280,12 @@ type Bucket struct {
 	Owner string `json:"owner"`
 }
 
+type BucketStats struct {
+	Bucket      string `json:"bucket"`
+	ObjectCount int64  `json:"objectCount"`
+	TotalSize   int64  `json:"totalSize"`
+}
+
 type ListAllMyBucketsResult struct {
 	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListAllMyBucketsResult" json:"-"`
 	Owner   CanonicalUser
@@ -389,6 +398,18 @@ type ListVersionsResult struct {
 	Versions            []types.ObjectVersion `xml:"Version"`
 }
 
+type AccelerateConfiguration struct {
+	XMLName xml.Name                     `xml:"http://s3.amazonaws.com/doc/2006-03-01/ AccelerateConfiguration" json:"-"`
+	Status  types.BucketAccelerateStatus `xml:"Status,omitempty"`
+}
+
+// LocationConstraint is the GetBucketLocation response,
+// which is empty for the buckets in us-east-1
+type LocationConstraint struct {
+	XMLName  xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ LocationConstraint" json:"-"`
+	Location string   `xml:",chardata"`
+}
+
 type GetBucketVersioningOutput struct {
 	XMLName   xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ VersioningConfiguration" json:"-"`
 	MFADelete *types.MFADeleteStatus
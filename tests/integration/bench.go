@@ -122,7 +122,7 @@ func TestDownload(s *S3Conf, files int, objSize int64, bucket, prefix string) er
 }
 
 func TestReqPerSec(s *S3Conf, totalReqs int, bucket string) error {
-	client := s3.NewFromConfig(s.Config())
+	client := s.GetClient()
 	var wg sync.WaitGroup
 	var resErr error
 
@@ -154,3 +154,88 @@ func TestReqPerSec(s *S3Conf, totalReqs int, bucket string) error {
 	passF("Success\nTotal Requests: %d,\nConcurrency Level: %d,\nTime Taken: %s,\nRequests Per Second: %dreq/sec", totalReqs, s.Concurrency, elapsedTime, rps)
 	return nil
 }
+
+// BenchmarkPutObject uploads count objects of objSize bytes named
+// prefix0..prefixN with the given number of concurrent workers, and
+// reports the throughput and the request latencies
+func BenchmarkPutObject(s *S3Conf, count, workers int, objSize int64, bucket, prefix string) error {
+	if objSize == 0 {
+		return fmt.Errorf("must specify object size for upload")
+	}
+	if objSize > (int64(10000) * s.PartSize) {
+		return fmt.Errorf("object size can not exceed 10000 * chunksize")
+	}
+
+	return runBenchmark("PutObject", count, workers, func(i int) (int64, error) {
+		r := NewDataReader(int(objSize), int(s.PartSize))
+		err := s.UploadData(r, bucket, fmt.Sprintf("%v%v", prefix, i))
+		return objSize, err
+	})
+}
+
+// BenchmarkGetObject downloads the count objects written by
+// BenchmarkPutObject with the given number of concurrent workers,
+// and reports the throughput and the request latencies
+func BenchmarkGetObject(s *S3Conf, count, workers int, bucket, prefix string) error {
+	return runBenchmark("GetObject", count, workers, func(i int) (int64, error) {
+		return s.DownloadData(NewNullWriter(), bucket, fmt.Sprintf("%v%v", prefix, i))
+	})
+}
+
+// runBenchmark calls op for 0..count-1 from the workers and prints the
+// summary of the run
+func runBenchmark(name string, count, workers int, op func(i int) (int64, error)) error {
+	if count < 1 {
+		return fmt.Errorf("must specify a positive object count")
+	}
+	workers = min(max(workers, 1), count)
+
+	runF("benchmark: %v", name)
+
+	results := make([]prefResult, count)
+	ops := make(chan int)
+	var wg sync.WaitGroup
+	start := time.Now()
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range ops {
+				start := time.Now()
+				n, err := op(i)
+				results[i] = prefResult{elapsed: time.Since(start), size: n, err: err}
+			}
+		}()
+	}
+	for i := 0; i < count; i++ {
+		ops <- i
+	}
+	close(ops)
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	var tot int64
+	var sum time.Duration
+	minLat, maxLat := time.Duration(math.MaxInt64), time.Duration(0)
+	for i, res := range results {
+		if res.err != nil {
+			failF("benchmark: %v: %v: %v", name, i, res.err)
+			return fmt.Errorf("%v %v: %w", name, i, res.err)
+		}
+		tot += res.size
+		sum += res.elapsed
+		if res.elapsed < minLat {
+			minLat = res.elapsed
+		}
+		if res.elapsed > maxLat {
+			maxLat = res.elapsed
+		}
+	}
+
+	passF("benchmark: %v: %v objects, %v bytes in %v (%.2f MB/s, %.2f ops/s), latency min %v avg %v max %v",
+		name, count, tot, elapsed,
+		float64(tot)/elapsed.Seconds()/1048576, float64(count)/elapsed.Seconds(),
+		minLat, sum/time.Duration(count), maxLat)
+
+	return nil
+}
@@ -17,38 +17,91 @@ package integration
 import (
 	"crypto/rand"
 	"crypto/sha256"
+	"errors"
 	"hash"
 	"io"
+	rnd "math/rand"
 )
 
+// RReader generates random test data. The data repeats a random
+// buffer, so the byte at an offset doesn't depend on how the data is
+// read, which lets Seek reposition the reader to re-read parts.
 type RReader struct {
-	buf      []byte
-	dataleft int
-	hash     hash.Hash
+	buf  []byte
+	size int
+	off  int
+	// hashed is the length of the data prefix written to hash
+	hashed int
+	hash   hash.Hash
 }
 
 func NewDataReader(totalsize, bufsize int) *RReader {
 	b := make([]byte, bufsize)
 	rand.Read(b)
+	return newRReader(b, totalsize)
+}
+
+// NewSeededDataReader returns a reader of the data generated from the
+// seed, the same seed reproduces the same data
+func NewSeededDataReader(totalsize, bufsize int, seed int64) *RReader {
+	b := make([]byte, bufsize)
+	rnd.New(rnd.NewSource(seed)).Read(b)
+	return newRReader(b, totalsize)
+}
+
+func newRReader(buf []byte, size int) *RReader {
 	return &RReader{
-		buf:      b,
-		dataleft: totalsize,
-		hash:     sha256.New(),
+		buf:  buf,
+		size: size,
+		hash: sha256.New(),
 	}
 }
 
 func (r *RReader) Read(p []byte) (int, error) {
-	n := min(len(p), len(r.buf), r.dataleft)
-	r.dataleft -= n
-	err := error(nil)
-	if n == 0 {
-		err = io.EOF
+	if r.off >= r.size {
+		return 0, io.EOF
 	}
-	r.hash.Write(r.buf[:n])
-	return copy(p, r.buf[:n]), err
+
+	start := r.off % len(r.buf)
+	n := copy(p[:min(len(p), r.size-r.off)], r.buf[start:])
+	if r.off <= r.hashed && r.off+n > r.hashed {
+		r.hash.Write(p[r.hashed-r.off : n])
+		r.hashed = r.off + n
+	}
+	r.off += n
+	return n, nil
 }
 
+// Seek implements io.Seeker, seeking past the end of the data is
+// allowed and the following reads return io.EOF
+func (r *RReader) Seek(offset int64, whence int) (int64, error) {
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = int64(r.off) + offset
+	case io.SeekEnd:
+		abs = int64(r.size) + offset
+	default:
+		return 0, errors.New("RReader.Seek: invalid whence")
+	}
+	if abs < 0 {
+		return 0, errors.New("RReader.Seek: negative position")
+	}
+	r.off = int(abs)
+	return abs, nil
+}
+
+// Sum returns the sha256 of the whole data, however it was read. The
+// data not read yet, or skipped with Seek, is generated for the sum.
 func (r *RReader) Sum() []byte {
+	for r.hashed < r.size {
+		start := r.hashed % len(r.buf)
+		n := min(len(r.buf)-start, r.size-r.hashed)
+		r.hash.Write(r.buf[start : start+n])
+		r.hashed += n
+	}
 	return r.hash.Sum(nil)
 }
 
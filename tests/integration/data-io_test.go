@@ -0,0 +1,131 @@
+// Copyright 2023 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package integration
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"testing"
+)
+
+func TestRReader_Seek(t *testing.T) {
+	const size = 1000
+	r := NewDataReader(size, 64)
+
+	// odd sized reads so that the reads don't line up with the buffer
+	var data []byte
+	p := make([]byte, 37)
+	for {
+		n, err := r.Read(p)
+		data = append(data, p[:n]...)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(data) != size {
+		t.Fatalf("expected %v bytes, got %v", size, len(data))
+	}
+	sum := sha256.Sum256(data)
+	if !bytes.Equal(r.Sum(), sum[:]) {
+		t.Errorf("expected the sum of the read data")
+	}
+
+	off, err := r.Seek(-300, io.SeekEnd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if off != size-300 {
+		t.Errorf("expected offset %v, got %v", size-300, off)
+	}
+	part := make([]byte, 100)
+	if _, err := io.ReadFull(r, part); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(part, data[off:off+100]) {
+		t.Errorf("expected the data re-read after seek to match")
+	}
+	if !bytes.Equal(r.Sum(), sum[:]) {
+		t.Errorf("expected the sum to not change after re-reading")
+	}
+
+	if _, err := r.Seek(-1, io.SeekStart); err == nil {
+		t.Errorf("expected negative position error")
+	}
+	if _, err := r.Seek(size+10, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Read(part); err != io.EOF {
+		t.Errorf("expected EOF past the end, got %v", err)
+	}
+}
+
+func TestRReader_Sum_skipped(t *testing.T) {
+	r := NewDataReader(500, 64)
+
+	// read the head, skip the middle and read the tail
+	head := make([]byte, 100)
+	if _, err := io.ReadFull(r, head); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Seek(400, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(r); err != nil {
+		t.Fatal(err)
+	}
+	skipped := r.Sum()
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(data)
+	if !bytes.Equal(skipped, sum[:]) {
+		t.Errorf("expected the sum of the whole data with skipped parts")
+	}
+}
+
+func TestNewSeededDataReader(t *testing.T) {
+	read := func(seed int64) ([]byte, []byte) {
+		r := NewSeededDataReader(300, 64, seed)
+		data, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data, r.Sum()
+	}
+
+	data1, sum1 := read(42)
+	data2, sum2 := read(42)
+	if !bytes.Equal(data1, data2) || !bytes.Equal(sum1, sum2) {
+		t.Errorf("expected the same seed to reproduce the data")
+	}
+	sum := sha256.Sum256(data1)
+	if !bytes.Equal(sum1, sum[:]) {
+		t.Errorf("expected the sum of the seeded data")
+	}
+
+	data3, _ := read(43)
+	if bytes.Equal(data1, data3) {
+		t.Errorf("expected another seed to generate other data")
+	}
+}
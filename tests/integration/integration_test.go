@@ -0,0 +1,146 @@
+// Copyright 2024 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package integration
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// conf is the gateway configuration injected from the environment,
+// nil when no endpoint is set and the integration tests are skipped
+var conf *S3Conf
+
+// parallel runs the tests that don't depend on the gateway wide
+// state in parallel
+var parallel bool
+
+// TestMain configures the suite from the environment:
+//
+//	VGW_TEST_ENDPOINT       gateway endpoint, e.g. http://127.0.0.1:7070
+//	AWS_ACCESS_KEY_ID       root access key
+//	AWS_SECRET_ACCESS_KEY   root secret key
+//	AWS_REGION              signing region, us-east-1 by default
+//	VGW_TEST_VERSIONING     the gateway runs with versioning enabled
+//	VGW_TEST_AZURE          the gateway runs with the azure backend
+//	VGW_TEST_MD5_ETAGS      the azure gateway computes md5 ETags
+//	VGW_TEST_BINARY         versitygw binary for the admin commands
+//	VGW_TEST_PARALLEL       run the tests in parallel
+//	VGW_TEST_OP_TIMEOUT     timeout of each request, 10s by default
+//	VGW_TEST_ATTEMPTS       read-after-write check attempts, 3 by default
+//	VGW_TEST_TIMINGS        print the latency percentiles of each operation
+//
+// The full flow runs longer than the default go test timeout:
+//
+//	go test ./tests/integration -timeout 0 -run 'TestIntegration/PutObject_'
+func TestMain(m *testing.M) {
+	if endpoint := os.Getenv("VGW_TEST_ENDPOINT"); endpoint != "" {
+		opts := []Option{
+			WithEndpoint(endpoint),
+			WithAccess(os.Getenv("AWS_ACCESS_KEY_ID")),
+			WithSecret(os.Getenv("AWS_SECRET_ACCESS_KEY")),
+			WithRegion(envDefault("AWS_REGION", "us-east-1")),
+		}
+		flags := []struct {
+			env string
+			opt Option
+		}{
+			{"VGW_TEST_VERSIONING", WithVersioningEnabled()},
+			{"VGW_TEST_AZURE", WithAzureMode()},
+			{"VGW_TEST_MD5_ETAGS", WithMD5ETags()},
+			{"VGW_TEST_TIMINGS", WithTimings()},
+		}
+		for _, f := range flags {
+			ok, err := envBool(f.env)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			if ok {
+				opts = append(opts, f.opt)
+			}
+		}
+		if v := os.Getenv("VGW_TEST_OP_TIMEOUT"); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "invalid VGW_TEST_OP_TIMEOUT: %v\n", err)
+				os.Exit(2)
+			}
+			opts = append(opts, WithOpTimeout(d))
+		}
+		if v := os.Getenv("VGW_TEST_ATTEMPTS"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "invalid VGW_TEST_ATTEMPTS: %v\n", err)
+				os.Exit(2)
+			}
+			opts = append(opts, WithAttempts(n))
+		}
+		conf = NewS3Conf(opts...)
+		gatewayBinary = envDefault("VGW_TEST_BINARY", gatewayBinary)
+
+		p, err := envBool("VGW_TEST_PARALLEL")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		parallel = p
+	}
+
+	os.Exit(m.Run())
+}
+
+// TestIntegration runs the full flow with every test as a subtest
+func TestIntegration(t *testing.T) {
+	if conf == nil {
+		t.Skip("VGW_TEST_ENDPOINT is not set")
+	}
+
+	s := *conf
+	t.Cleanup(func() { s.PrintTimings(os.Stdout) })
+	s.runner = func(name string, serial bool, fn func(*S3Conf) error) {
+		t.Run(name, func(t *testing.T) {
+			if parallel && !serial {
+				t.Parallel()
+			}
+			if err := fn(&s); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	TestFullFlow(&s)
+}
+
+func envDefault(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+func envBool(key string) (bool, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("invalid %v: %w", key, err)
+	}
+	return b, nil
+}
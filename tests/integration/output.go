@@ -14,7 +14,18 @@
 
 package integration
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
 
 var (
 	colorReset = "\033[0m"
@@ -27,19 +38,146 @@ var (
 	RunCount  = 0
 	PassCount = 0
 	FailCount = 0
+
+	// countMu guards the counters when the tests run in parallel
+	countMu sync.Mutex
+)
+
+var (
+	// LogOutput receives the human readable progress of the run
+	LogOutput io.Writer = os.Stdout
+
+	// FailFast aborts the test run after the first failure
+	FailFast = false
+
+	errFailFast = errors.New("test run aborted after first failure")
 )
 
+// runF aborts the run in the fail fast mode, if any of the previous
+// tests has failed. Aborting before the next test starts, instead of
+// in failF, lets the failed test clean up its buckets and users.
 func runF(format string, a ...interface{}) {
+	countMu.Lock()
+	if FailFast && FailCount > 0 {
+		countMu.Unlock()
+		panic(errFailFast)
+	}
 	RunCount++
-	fmt.Printf(colorCyan+"RUN  "+colorReset+format+"\n", a...)
+	countMu.Unlock()
+	fmt.Fprintf(LogOutput, colorCyan+"RUN  "+colorReset+format+"\n", a...)
 }
 
 func failF(format string, a ...interface{}) {
+	countMu.Lock()
 	FailCount++
-	fmt.Printf(colorRed+"FAIL "+colorReset+format+"\n", a...)
+	countMu.Unlock()
+	fmt.Fprintf(LogOutput, colorRed+"FAIL "+colorReset+format+"\n", a...)
 }
 
 func passF(format string, a ...interface{}) {
+	countMu.Lock()
 	PassCount++
-	fmt.Printf(colorGreen+"PASS "+colorReset+format+"\n", a...)
+	countMu.Unlock()
+	fmt.Fprintf(LogOutput, colorGreen+"PASS "+colorReset+format+"\n", a...)
+}
+
+// RunTests runs the test group and stops at the first failure
+// when FailFast is set
+func RunTests(s *S3Conf, tf func(*S3Conf)) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if r != errFailFast {
+				panic(r)
+			}
+			err = errFailFast
+		}
+	}()
+
+	tf(s)
+	return nil
+}
+
+// Result is the outcome of a single test of the run
+type Result struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	// Elapsed is the test duration in seconds
+	Elapsed float64 `json:"elapsed"`
+	Error   string  `json:"error,omitempty"`
+}
+
+const (
+	StatusPass = "pass"
+	StatusFail = "fail"
+)
+
+// results collects the outcome of every test run through S3Conf.run,
+// guarded by countMu
+var results []Result
+
+func addResult(name string, elapsed time.Duration, err error) {
+	r := Result{
+		Name:    name,
+		Status:  StatusPass,
+		Elapsed: elapsed.Seconds(),
+	}
+	if err != nil {
+		r.Status = StatusFail
+		r.Error = err.Error()
+	}
+
+	countMu.Lock()
+	results = append(results, r)
+	countMu.Unlock()
+}
+
+// WriteResults writes the results of the tests run so far as a JSON
+// array
+func WriteResults(w io.Writer) error {
+	countMu.Lock()
+	defer countMu.Unlock()
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if results == nil {
+		return enc.Encode([]Result{})
+	}
+	return enc.Encode(results)
+}
+
+// RunTest runs a single test and records its result
+func RunTest(s *S3Conf, fn func(*S3Conf) error) error {
+	start := time.Now()
+	err := fn(s)
+	addResult(funcName(fn), time.Since(start), err)
+	return err
+}
+
+// run runs a single test of a group with the configured runner
+func (c *S3Conf) run(fn func(*S3Conf) error) {
+	c.runTest(fn, false)
+}
+
+// runSerial runs a single test, which creates users, changes the
+// bucket owners or lists all the buckets, so the runner must not
+// run it in parallel with the other tests
+func (c *S3Conf) runSerial(fn func(*S3Conf) error) {
+	c.runTest(fn, true)
+}
+
+func (c *S3Conf) runTest(fn func(*S3Conf) error, serial bool) {
+	if c.runner == nil {
+		RunTest(c, fn)
+		return
+	}
+	c.runner(funcName(fn), serial, func(s *S3Conf) error {
+		return RunTest(s, fn)
+	})
+}
+
+// funcName returns the name of the test function without the
+// package path
+func funcName(fn func(*S3Conf) error) string {
+	name := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+	return name[strings.LastIndex(name, ".")+1:]
 }
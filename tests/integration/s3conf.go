@@ -20,6 +20,8 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
@@ -42,10 +44,29 @@ type S3Conf struct {
 	debug             bool
 	versioningEnabled bool
 	azureTests        bool
+	md5ETags          bool
+	maxWrites         int
+	largeObjSize      int64
+	runner            func(name string, serial bool, fn func(*S3Conf) error)
+
+	// OpTimeout bounds every request of the tests, 10 seconds when unset
+	OpTimeout time.Duration
+	// Attempts is how many times the assertions that depend on
+	// read-after-write visibility are tried, 3 when unset. Strongly
+	// consistent backends can set it to 1.
+	Attempts int
+	// Timings records the duration of every S3 operation for
+	// PrintTimings
+	Timings bool
+	timings *timings
+	clients *clientCache
 }
 
 func NewS3Conf(opts ...Option) *S3Conf {
-	s := &S3Conf{}
+	s := &S3Conf{
+		timings: newTimings(),
+		clients: &clientCache{clients: make(map[clientKey]*s3.Client)},
+	}
 
 	for _, opt := range opts {
 		opt(s)
@@ -88,6 +109,54 @@ func WithVersioningEnabled() Option {
 func WithAzureMode() Option {
 	return func(s *S3Conf) { s.azureTests = true }
 }
+func WithMD5ETags() Option {
+	return func(s *S3Conf) { s.md5ETags = true }
+}
+func WithMaxWrites(n int) Option {
+	return func(s *S3Conf) { s.maxWrites = n }
+}
+func WithLargeObjectSize(size int64) Option {
+	return func(s *S3Conf) { s.largeObjSize = size }
+}
+
+func WithOpTimeout(d time.Duration) Option {
+	return func(s *S3Conf) { s.OpTimeout = d }
+}
+
+func WithAttempts(n int) Option {
+	return func(s *S3Conf) { s.Attempts = n }
+}
+
+func WithTimings() Option {
+	return func(s *S3Conf) { s.Timings = true }
+}
+
+// WithRunner sets the function the test groups run each test with,
+// by default the tests are called directly. serial is set for the
+// tests, which depend on the gateway wide state.
+func WithRunner(r func(name string, serial bool, fn func(*S3Conf) error)) Option {
+	return func(s *S3Conf) { s.runner = r }
+}
+
+// defaultOpTimeout is the request timeout when OpTimeout is unset
+const defaultOpTimeout = 10 * time.Second
+
+func (c *S3Conf) opTimeout() time.Duration {
+	if c.OpTimeout <= 0 {
+		return defaultOpTimeout
+	}
+	return c.OpTimeout
+}
+
+// defaultAttempts is the visibility attempt count when Attempts is unset
+const defaultAttempts = 3
+
+func (c *S3Conf) attempts() int {
+	if c.Attempts <= 0 {
+		return defaultAttempts
+	}
+	return c.Attempts
+}
 
 func (c *S3Conf) getCreds() credentials.StaticCredentialsProvider {
 	// TODO support token/IAM
@@ -101,8 +170,35 @@ func (c *S3Conf) getCreds() credentials.StaticCredentialsProvider {
 	return credentials.NewStaticCredentialsProvider(c.awsID, c.awsSecret, "")
 }
 
+// clientCache holds the S3 clients of a run, shared by the copies of
+// the config made for the other users and regions
+type clientCache struct {
+	mu      sync.Mutex
+	clients map[clientKey]*s3.Client
+}
+
+// clientKey is the part of the config the tests change on its copies
+type clientKey struct {
+	access, secret, region, endpoint string
+}
+
+// GetClient returns the S3 client of the config credentials. It is
+// created once per run and is safe for concurrent use, so the tests
+// share its connection pool.
 func (c *S3Conf) GetClient() *s3.Client {
-	return s3.NewFromConfig(c.Config())
+	if c.clients == nil {
+		return s3.NewFromConfig(c.Config())
+	}
+
+	key := clientKey{c.awsID, c.awsSecret, c.awsRegion, c.endpoint}
+	c.clients.mu.Lock()
+	defer c.clients.mu.Unlock()
+	client, ok := c.clients.clients[key]
+	if !ok {
+		client = s3.NewFromConfig(c.Config())
+		c.clients.clients[key] = client
+	}
+	return client
 }
 
 func (c *S3Conf) Config() aws.Config {
@@ -123,6 +219,11 @@ func (c *S3Conf) Config() aws.Config {
 			config.WithAPIOptions([]func(*middleware.Stack) error{v4.SwapComputePayloadSHA256ForUnsignedPayloadMiddleware}))
 	}
 
+	if c.Timings {
+		opts = append(opts,
+			config.WithAPIOptions([]func(*middleware.Stack) error{c.timings.middleware}))
+	}
+
 	if c.debug {
 		opts = append(opts,
 			config.WithClientLogMode(aws.LogSigning|aws.LogRetries|aws.LogRequest|aws.LogResponse|aws.LogRequestEventMessage|aws.LogResponseEventMessage))
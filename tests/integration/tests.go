@@ -17,22 +17,33 @@ package integration
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
 	"crypto/rand"
+	"crypto/sha1"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"hash"
+	"hash/crc32"
 	"io"
 	"net/http"
 	"net/url"
+	"reflect"
 	"regexp"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 	"github.com/versity/versitygw/backend"
 	"github.com/versity/versitygw/s3err"
 	"github.com/versity/versitygw/s3response"
@@ -40,7 +51,6 @@ import (
 )
 
 var (
-	shortTimeout  = 10 * time.Second
 	iso8601Format = "20060102T150405Z"
 	nullVersionId = "null"
 )
@@ -57,7 +67,7 @@ func Authentication_empty_auth_header(s *S3Conf) error {
 	}, func(req *http.Request) error {
 		req.Header.Set("Authorization", "")
 		client := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		resp, err := client.Do(req)
@@ -65,7 +75,9 @@ func Authentication_empty_auth_header(s *S3Conf) error {
 			return err
 		}
 		defer resp.Body.Close()
-		if err := checkAuthErr(resp, s3err.GetAPIError(s3err.ErrAuthHeaderEmpty)); err != nil {
+		// without an Authorization header or presigned query authentication
+		// the request is anonymous, whatever other headers it carries
+		if err := checkAuthErr(resp, s3err.GetAPIError(s3err.ErrAccessDenied)); err != nil {
 			return err
 		}
 
@@ -85,7 +97,7 @@ func Authentication_invalid_auth_header(s *S3Conf) error {
 	}, func(req *http.Request) error {
 		req.Header.Set("Authorization", "invalid header")
 		client := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		resp, err := client.Do(req)
@@ -116,7 +128,7 @@ func Authentication_unsupported_signature_version(s *S3Conf) error {
 		req.Header.Set("Authorization", authHdr)
 
 		client := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		resp, err := client.Do(req)
@@ -148,7 +160,7 @@ func Authentication_malformed_credentials(s *S3Conf) error {
 		req.Header.Set("Authorization", hdr)
 
 		client := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		resp, err := client.Do(req)
@@ -180,7 +192,7 @@ func Authentication_malformed_credentials_invalid_parts(s *S3Conf) error {
 		req.Header.Set("Authorization", hdr)
 
 		client := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		resp, err := client.Do(req)
@@ -212,7 +224,7 @@ func Authentication_credentials_terminated_string(s *S3Conf) error {
 		req.Header.Set("Authorization", hdr)
 
 		client := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		resp, err := client.Do(req)
@@ -239,7 +251,7 @@ func Authentication_credentials_incorrect_service(s *S3Conf) error {
 		date:     time.Now(),
 	}, func(req *http.Request) error {
 		client := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		resp, err := client.Do(req)
@@ -272,7 +284,7 @@ func Authentication_credentials_incorrect_region(s *S3Conf) error {
 		date:     time.Now(),
 	}, func(req *http.Request) error {
 		client := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 		apiErr := s3err.APIError{
 			Code:           "SignatureDoesNotMatch",
@@ -309,7 +321,7 @@ func Authentication_credentials_invalid_date(s *S3Conf) error {
 		req.Header.Set("Authorization", hdr)
 
 		client := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		resp, err := client.Do(req)
@@ -336,7 +348,7 @@ func Authentication_credentials_future_date(s *S3Conf) error {
 		date:     time.Now().Add(time.Duration(5) * 24 * time.Hour),
 	}, func(req *http.Request) error {
 		client := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		resp, err := client.Do(req)
@@ -377,7 +389,7 @@ func Authentication_credentials_past_date(s *S3Conf) error {
 		date:     time.Now().Add(time.Duration(-5) * 24 * time.Hour),
 	}, func(req *http.Request) error {
 		client := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		resp, err := client.Do(req)
@@ -423,7 +435,7 @@ func Authentication_credentials_non_existing_access_key(s *S3Conf) error {
 		req.Header.Set("Authorization", hdr)
 
 		client := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		resp, err := client.Do(req)
@@ -455,7 +467,7 @@ func Authentication_invalid_signed_headers(s *S3Conf) error {
 		req.Header.Set("Authorization", hdr)
 
 		client := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		resp, err := client.Do(req)
@@ -482,7 +494,7 @@ func Authentication_missing_date_header(s *S3Conf) error {
 		date:     time.Now(),
 	}, func(req *http.Request) error {
 		client := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 		req.Header.Set("X-Amz-Date", "")
 
@@ -510,7 +522,7 @@ func Authentication_invalid_date_header(s *S3Conf) error {
 		date:     time.Now(),
 	}, func(req *http.Request) error {
 		client := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 		req.Header.Set("X-Amz-Date", "03032006")
 
@@ -538,7 +550,7 @@ func Authentication_date_mismatch(s *S3Conf) error {
 		date:     time.Now(),
 	}, func(req *http.Request) error {
 		client := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 		req.Header.Set("X-Amz-Date", "20220830T095525Z")
 
@@ -566,7 +578,7 @@ func Authentication_incorrect_payload_hash(s *S3Conf) error {
 		date:     time.Now(),
 	}, func(req *http.Request) error {
 		client := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 		req.Header.Set("X-Amz-Content-Sha256", "7sa6df576dsa5f675sad67f")
 
@@ -594,7 +606,7 @@ func Authentication_incorrect_md5(s *S3Conf) error {
 		date:     time.Now(),
 	}, func(req *http.Request) error {
 		client := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		req.Header.Set("Content-Md5", "sadfasdf87sad6f87==")
@@ -625,7 +637,7 @@ func Authentication_signature_error_incorrect_secret_key(s *S3Conf) error {
 		date:     time.Now(),
 	}, func(req *http.Request) error {
 		client := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		resp, err := client.Do(req)
@@ -641,10 +653,90 @@ func Authentication_signature_error_incorrect_secret_key(s *S3Conf) error {
 	})
 }
 
+func Authentication_session_token(s *S3Conf) error {
+	testName := "Authentication_session_token"
+	return actionHandler(s, testName, func(_ *s3.Client, bucket string) error {
+		obj, token := "my-obj", "my-session-token"
+
+		// temporary credentials sign the requests with the session token
+		cfg := s.Config()
+		cfg.Credentials = credentials.NewStaticCredentialsProvider(s.awsID, s.awsSecret, token)
+		client := s3.NewFromConfig(cfg)
+
+		r, err := putObjectWithData(s, 100, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		}, client)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+		bdy, err := io.ReadAll(out.Body)
+		out.Body.Close()
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(bdy, r.data) {
+			return fmt.Errorf("invalid object data")
+		}
+
+		// presigned urls carry the session token in the query
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		v4req, err := s3.NewPresignClient(client).PresignGetObject(ctx, &s3.GetObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+		if !strings.Contains(v4req.URL, "X-Amz-Security-Token") {
+			return fmt.Errorf("expected the presigned url to contain the session token, instead got %v", v4req.URL)
+		}
+
+		httpClient := http.Client{
+			Timeout: s.opTimeout(),
+		}
+		resp, err := httpClient.Get(v4req.URL)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("expected the presigned get object response status to be %v, instead got %v", http.StatusOK, resp.StatusCode)
+		}
+
+		// the session token is part of the signature
+		req, err := createSignedReq(http.MethodGet, s.endpoint, fmt.Sprintf("%v/%v", bucket, obj), s.awsID, s.awsSecret,
+			"s3", s.awsRegion, nil, time.Now(), map[string]string{"X-Amz-Security-Token": token})
+		if err != nil {
+			return err
+		}
+		req.Header.Set("X-Amz-Security-Token", "tampered-session-token")
+
+		resp, err = httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		return checkAuthErr(resp, s3err.GetAPIError(s3err.ErrSignatureDoesNotMatch))
+	})
+}
+
 func PresignedAuth_missing_algo_query_param(s *S3Conf) error {
 	testName := "PresignedAuth_missing_algo_query_param"
 	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		v4req, err := client.PresignDeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: getPtr("my-bucket")})
 		cancel()
 		if err != nil {
@@ -652,7 +744,7 @@ func PresignedAuth_missing_algo_query_param(s *S3Conf) error {
 		}
 
 		httpClient := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		urlParsed, err := url.Parse(v4req.URL)
@@ -682,10 +774,51 @@ func PresignedAuth_missing_algo_query_param(s *S3Conf) error {
 	})
 }
 
+func PresignedAuth_missing_signature_query_param(s *S3Conf) error {
+	testName := "PresignedAuth_missing_signature_query_param"
+	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) error {
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		v4req, err := client.PresignDeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: getPtr("my-bucket")})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		httpClient := http.Client{
+			Timeout: s.opTimeout(),
+		}
+
+		urlParsed, err := url.Parse(v4req.URL)
+		if err != nil {
+			return err
+		}
+
+		queries := urlParsed.Query()
+		queries.Del("X-Amz-Signature")
+		urlParsed.RawQuery = queries.Encode()
+
+		req, err := http.NewRequest(v4req.Method, urlParsed.String(), nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+
+		if err := checkAuthErr(resp, s3err.GetAPIError(s3err.ErrInvalidQueryParams)); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
 func PresignedAuth_unsupported_algorithm(s *S3Conf) error {
 	testName := "PresignedAuth_unsupported_algorithm"
 	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		v4req, err := client.PresignDeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: getPtr("my-bucket")})
 		cancel()
 		if err != nil {
@@ -693,7 +826,7 @@ func PresignedAuth_unsupported_algorithm(s *S3Conf) error {
 		}
 
 		httpClient := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		uri := strings.Replace(v4req.URL, "AWS4-HMAC-SHA256", "AWS4-SHA256", 1)
@@ -719,7 +852,7 @@ func PresignedAuth_unsupported_algorithm(s *S3Conf) error {
 func PresignedAuth_missing_credentials_query_param(s *S3Conf) error {
 	testName := "PresignedAuth_missing_credentials_query_param"
 	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		v4req, err := client.PresignDeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: getPtr("my-bucket")})
 		cancel()
 		if err != nil {
@@ -727,7 +860,7 @@ func PresignedAuth_missing_credentials_query_param(s *S3Conf) error {
 		}
 
 		httpClient := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		urlParsed, err := url.Parse(v4req.URL)
@@ -760,7 +893,7 @@ func PresignedAuth_missing_credentials_query_param(s *S3Conf) error {
 func PresignedAuth_malformed_creds_invalid_parts(s *S3Conf) error {
 	testName := "PresignedAuth_malformed_creds_invalid_parts"
 	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		v4req, err := client.PresignDeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: getPtr("my-bucket")})
 		cancel()
 		if err != nil {
@@ -768,7 +901,7 @@ func PresignedAuth_malformed_creds_invalid_parts(s *S3Conf) error {
 		}
 
 		httpClient := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		urlParsed, err := url.Parse(v4req.URL)
@@ -801,7 +934,7 @@ func PresignedAuth_malformed_creds_invalid_parts(s *S3Conf) error {
 func PresignedAuth_creds_invalid_terminator(s *S3Conf) error {
 	testName := "PresignedAuth_creds_invalid_terminator"
 	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		v4req, err := client.PresignDeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: getPtr("my-bucket")})
 		cancel()
 		if err != nil {
@@ -809,7 +942,7 @@ func PresignedAuth_creds_invalid_terminator(s *S3Conf) error {
 		}
 
 		httpClient := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		uri, err := changeAuthCred(v4req.URL, "aws5_request", credTerminator)
@@ -838,7 +971,7 @@ func PresignedAuth_creds_invalid_terminator(s *S3Conf) error {
 func PresignedAuth_creds_incorrect_service(s *S3Conf) error {
 	testName := "PresignedAuth_creds_incorrect_service"
 	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		v4req, err := client.PresignDeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: getPtr("my-bucket")})
 		cancel()
 		if err != nil {
@@ -846,7 +979,7 @@ func PresignedAuth_creds_incorrect_service(s *S3Conf) error {
 		}
 
 		httpClient := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		uri, err := changeAuthCred(v4req.URL, "sns", credService)
@@ -883,7 +1016,7 @@ func PresignedAuth_creds_incorrect_region(s *S3Conf) error {
 	}
 
 	return presignedAuthHandler(&cfg, testName, func(client *s3.PresignClient) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		v4req, err := client.PresignDeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: getPtr("my-bucket")})
 		cancel()
 		if err != nil {
@@ -891,7 +1024,7 @@ func PresignedAuth_creds_incorrect_region(s *S3Conf) error {
 		}
 
 		httpClient := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		req, err := http.NewRequest(v4req.Method, v4req.URL, nil)
@@ -919,7 +1052,7 @@ func PresignedAuth_creds_incorrect_region(s *S3Conf) error {
 func PresignedAuth_creds_invalid_date(s *S3Conf) error {
 	testName := "PresignedAuth_creds_invalid_date"
 	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		v4req, err := client.PresignDeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: getPtr("my-bucket")})
 		cancel()
 		if err != nil {
@@ -927,7 +1060,7 @@ func PresignedAuth_creds_invalid_date(s *S3Conf) error {
 		}
 
 		httpClient := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		uri, err := changeAuthCred(v4req.URL, "32234Z34", credDate)
@@ -956,7 +1089,7 @@ func PresignedAuth_creds_invalid_date(s *S3Conf) error {
 func PresignedAuth_non_existing_access_key_id(s *S3Conf) error {
 	testName := "PresignedAuth_non_existing_access_key_id"
 	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		v4req, err := client.PresignDeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: getPtr("my-bucket")})
 		cancel()
 		if err != nil {
@@ -964,7 +1097,7 @@ func PresignedAuth_non_existing_access_key_id(s *S3Conf) error {
 		}
 
 		httpClient := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		uri, err := changeAuthCred(v4req.URL, "a_rarely_existing_access_key_id890asd6f807as6ydf870say", credAccess)
@@ -993,7 +1126,7 @@ func PresignedAuth_non_existing_access_key_id(s *S3Conf) error {
 func PresignedAuth_missing_date_query(s *S3Conf) error {
 	testName := "PresignedAuth_missing_date_query"
 	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		v4req, err := client.PresignDeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: getPtr("my-bucket")})
 		cancel()
 		if err != nil {
@@ -1001,7 +1134,7 @@ func PresignedAuth_missing_date_query(s *S3Conf) error {
 		}
 
 		httpClient := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		urlParsed, err := url.Parse(v4req.URL)
@@ -1034,7 +1167,7 @@ func PresignedAuth_missing_date_query(s *S3Conf) error {
 func PresignedAuth_dates_mismatch(s *S3Conf) error {
 	testName := "PresignedAuth_dates_mismatch"
 	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		v4req, err := client.PresignDeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: getPtr("my-bucket")})
 		cancel()
 		if err != nil {
@@ -1042,7 +1175,7 @@ func PresignedAuth_dates_mismatch(s *S3Conf) error {
 		}
 
 		httpClient := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		uri, err := changeAuthCred(v4req.URL, "20060102", credDate)
@@ -1071,7 +1204,7 @@ func PresignedAuth_dates_mismatch(s *S3Conf) error {
 func PresignedAuth_missing_signed_headers_query_param(s *S3Conf) error {
 	testName := "PresignedAuth_missing_signed_headers_query_param"
 	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		v4req, err := client.PresignDeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: getPtr("my-bucket")})
 		cancel()
 		if err != nil {
@@ -1079,7 +1212,7 @@ func PresignedAuth_missing_signed_headers_query_param(s *S3Conf) error {
 		}
 
 		httpClient := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		urlParsed, err := url.Parse(v4req.URL)
@@ -1112,7 +1245,7 @@ func PresignedAuth_missing_signed_headers_query_param(s *S3Conf) error {
 func PresignedAuth_missing_expiration_query_param(s *S3Conf) error {
 	testName := "PresignedAuth_missing_expiration_query_param"
 	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		v4req, err := client.PresignDeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: getPtr("my-bucket")})
 		cancel()
 		if err != nil {
@@ -1120,7 +1253,7 @@ func PresignedAuth_missing_expiration_query_param(s *S3Conf) error {
 		}
 
 		httpClient := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		urlParsed, err := url.Parse(v4req.URL)
@@ -1153,7 +1286,7 @@ func PresignedAuth_missing_expiration_query_param(s *S3Conf) error {
 func PresignedAuth_invalid_expiration_query_param(s *S3Conf) error {
 	testName := "PresignedAuth_invalid_expiration_query_param"
 	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		v4req, err := client.PresignDeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: getPtr("my-bucket")})
 		cancel()
 		if err != nil {
@@ -1161,7 +1294,7 @@ func PresignedAuth_invalid_expiration_query_param(s *S3Conf) error {
 		}
 
 		httpClient := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		urlParsed, err := url.Parse(v4req.URL)
@@ -1194,7 +1327,7 @@ func PresignedAuth_invalid_expiration_query_param(s *S3Conf) error {
 func PresignedAuth_negative_expiration_query_param(s *S3Conf) error {
 	testName := "PresignedAuth_negative_expiration_query_param"
 	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		v4req, err := client.PresignDeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: getPtr("my-bucket")})
 		cancel()
 		if err != nil {
@@ -1202,7 +1335,7 @@ func PresignedAuth_negative_expiration_query_param(s *S3Conf) error {
 		}
 
 		httpClient := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		urlParsed, err := url.Parse(v4req.URL)
@@ -1235,7 +1368,7 @@ func PresignedAuth_negative_expiration_query_param(s *S3Conf) error {
 func PresignedAuth_exceeding_expiration_query_param(s *S3Conf) error {
 	testName := "PresignedAuth_exceeding_expiration_query_param"
 	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		v4req, err := client.PresignDeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: getPtr("my-bucket")})
 		cancel()
 		if err != nil {
@@ -1243,7 +1376,7 @@ func PresignedAuth_exceeding_expiration_query_param(s *S3Conf) error {
 		}
 
 		httpClient := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		urlParsed, err := url.Parse(v4req.URL)
@@ -1276,7 +1409,7 @@ func PresignedAuth_exceeding_expiration_query_param(s *S3Conf) error {
 func PresignedAuth_expired_request(s *S3Conf) error {
 	testName := "PresignedAuth_expired_request"
 	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		v4req, err := client.PresignDeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: getPtr("my-bucket")})
 		cancel()
 		if err != nil {
@@ -1284,7 +1417,7 @@ func PresignedAuth_expired_request(s *S3Conf) error {
 		}
 
 		httpClient := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		urlParsed, err := url.Parse(v4req.URL)
@@ -1326,7 +1459,7 @@ func PresignedAuth_incorrect_secret_key(s *S3Conf) error {
 	cfg := *s
 	cfg.awsSecret += "x"
 	return presignedAuthHandler(&cfg, testName, func(client *s3.PresignClient) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		v4req, err := client.PresignDeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: getPtr("my-bucket")})
 		cancel()
 		if err != nil {
@@ -1334,7 +1467,7 @@ func PresignedAuth_incorrect_secret_key(s *S3Conf) error {
 		}
 
 		httpClient := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		req, err := http.NewRequest(v4req.Method, v4req.URL, nil)
@@ -1357,13 +1490,14 @@ func PresignedAuth_incorrect_secret_key(s *S3Conf) error {
 
 func PresignedAuth_PutObject_success(s *S3Conf) error {
 	testName := "PresignedAuth_PutObject_success"
-	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) error {
+	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) (err error) {
 		bucket := getBucketName()
-		err := setup(s, bucket)
+		err = setup(s, bucket)
 		if err != nil {
 			return err
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		defer teardownAfter(s, bucket, &err)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		v4req, err := client.PresignPutObject(ctx, &s3.PutObjectInput{Bucket: &bucket, Key: getPtr("my-obj")})
 		cancel()
 		if err != nil {
@@ -1371,7 +1505,7 @@ func PresignedAuth_PutObject_success(s *S3Conf) error {
 		}
 
 		httpClient := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		req, err := http.NewRequest(http.MethodPut, v4req.URL, nil)
@@ -1388,28 +1522,24 @@ func PresignedAuth_PutObject_success(s *S3Conf) error {
 			return fmt.Errorf("expected my-obj to be successfully uploaded and get 200 response status, instead got %v", resp.StatusCode)
 		}
 
-		err = teardown(s, bucket)
-		if err != nil {
-			return err
-		}
-
 		return nil
 	})
 }
 
 func PresignedAuth_Put_GetObject_with_data(s *S3Conf) error {
 	testName := "PresignedAuth_Put_GetObject_with_data"
-	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) error {
+	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) (err error) {
 		bucket, obj := getBucketName(), "my-obj"
-		err := setup(s, bucket)
+		err = setup(s, bucket)
 		if err != nil {
 			return err
 		}
+		defer teardownAfter(s, bucket, &err)
 
 		data := "Hello world"
 		body := strings.NewReader(data)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		v4req, err := client.PresignPutObject(ctx, &s3.PutObjectInput{Bucket: &bucket, Key: &obj, Body: body})
 		cancel()
 		if err != nil {
@@ -1417,7 +1547,7 @@ func PresignedAuth_Put_GetObject_with_data(s *S3Conf) error {
 		}
 
 		httpClient := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		req, err := http.NewRequest(v4req.Method, v4req.URL, body)
@@ -1436,7 +1566,7 @@ func PresignedAuth_Put_GetObject_with_data(s *S3Conf) error {
 			return fmt.Errorf("expected my-obj to be successfully uploaded and get %v response status, instead got %v", http.StatusOK, resp.StatusCode)
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		v4GetReq, err := client.PresignGetObject(ctx, &s3.GetObjectInput{Bucket: &bucket, Key: &obj})
 		cancel()
 		if err != nil {
@@ -1467,25 +1597,21 @@ func PresignedAuth_Put_GetObject_with_data(s *S3Conf) error {
 			return fmt.Errorf("expected get object response body to be %v, instead got %s", data, respBody)
 		}
 
-		err = teardown(s, bucket)
-		if err != nil {
-			return err
-		}
-
 		return nil
 	})
 }
 
 func PresignedAuth_Put_GetObject_with_UTF8_chars(s *S3Conf) error {
 	testName := "PresignedAuth_Put_GetObject_with_data"
-	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) error {
+	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) (err error) {
 		bucket, obj := getBucketName(), "my-$%^&*;"
-		err := setup(s, bucket)
+		err = setup(s, bucket)
 		if err != nil {
 			return err
 		}
+		defer teardownAfter(s, bucket, &err)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		v4req, err := client.PresignPutObject(ctx, &s3.PutObjectInput{Bucket: &bucket, Key: &obj})
 		cancel()
 		if err != nil {
@@ -1493,7 +1619,7 @@ func PresignedAuth_Put_GetObject_with_UTF8_chars(s *S3Conf) error {
 		}
 
 		httpClient := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		req, err := http.NewRequest(v4req.Method, v4req.URL, nil)
@@ -1512,7 +1638,7 @@ func PresignedAuth_Put_GetObject_with_UTF8_chars(s *S3Conf) error {
 			return fmt.Errorf("expected my-obj to be successfully uploaded and get %v response status, instead got %v", http.StatusOK, resp.StatusCode)
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		v4GetReq, err := client.PresignGetObject(ctx, &s3.GetObjectInput{Bucket: &bucket, Key: &obj})
 		cancel()
 		if err != nil {
@@ -1533,32 +1659,119 @@ func PresignedAuth_Put_GetObject_with_UTF8_chars(s *S3Conf) error {
 			return fmt.Errorf("expected get object response status to be %v, instead got %v", http.StatusOK, resp.StatusCode)
 		}
 
-		err = teardown(s, bucket)
+		return nil
+	})
+}
+
+func PresignedAuth_GetObject_expired_url(s *S3Conf) error {
+	testName := "PresignedAuth_GetObject_expired_url"
+	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) (err error) {
+		bucket, obj := getBucketName(), "my-obj"
+		err = setup(s, bucket)
 		if err != nil {
 			return err
 		}
+		defer teardownAfter(s, bucket, &err)
 
-		return nil
+		_, err = putObjects(s, s.GetClient(), []string{obj}, bucket)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		v4req, err := client.PresignGetObject(ctx, &s3.GetObjectInput{Bucket: &bucket, Key: &obj}, s3.WithPresignExpires(time.Second))
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		// wait for the presigned url to expire
+		time.Sleep(2 * time.Second)
+
+		req, err := http.NewRequest(v4req.Method, v4req.URL, nil)
+		if err != nil {
+			return err
+		}
+
+		httpClient := http.Client{
+			Timeout: s.opTimeout(),
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+
+		return checkAuthErr(resp, s3err.GetAPIError(s3err.ErrExpiredPresignRequest))
+	})
+}
+
+func PresignedAuth_GetObject_tampered_key(s *S3Conf) error {
+	testName := "PresignedAuth_GetObject_tampered_key"
+	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) (err error) {
+		bucket, obj, otherObj := getBucketName(), "my-obj", "other-obj"
+		err = setup(s, bucket)
+		if err != nil {
+			return err
+		}
+		defer teardownAfter(s, bucket, &err)
+
+		_, err = putObjects(s, s.GetClient(), []string{obj, otherObj}, bucket)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		v4req, err := client.PresignGetObject(ctx, &s3.GetObjectInput{Bucket: &bucket, Key: &obj})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		urlParsed, err := url.Parse(v4req.URL)
+		if err != nil {
+			return err
+		}
+
+		// reuse the signature for another object
+		urlParsed.Path = strings.TrimSuffix(urlParsed.Path, obj) + otherObj
+
+		req, err := http.NewRequest(v4req.Method, urlParsed.String(), nil)
+		if err != nil {
+			return err
+		}
+
+		httpClient := http.Client{
+			Timeout: s.opTimeout(),
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+
+		return checkAuthErr(resp, s3err.GetAPIError(s3err.ErrSignatureDoesNotMatch))
 	})
 }
 
 func PresignedAuth_UploadPart(s *S3Conf) error {
 	testName := "PresignedAuth_UploadPart"
-	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) error {
+	return presignedAuthHandler(s, testName, func(client *s3.PresignClient) (err error) {
 		bucket, key, partNumber := getBucketName(), "my-mp", int32(1)
 
-		err := setup(s, bucket)
+		err = setup(s, bucket)
 		if err != nil {
 			return err
 		}
+		defer teardownAfter(s, bucket, &err)
 
-		clt := s3.NewFromConfig(s.Config())
-		mp, err := createMp(clt, bucket, key)
+		clt := s.GetClient()
+		mp, err := createMp(s, clt, bucket, key)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		v4req, err := client.PresignUploadPart(ctx, &s3.UploadPartInput{Bucket: &bucket, Key: &key, UploadId: mp.UploadId, PartNumber: &partNumber})
 		cancel()
 		if err != nil {
@@ -1566,7 +1779,7 @@ func PresignedAuth_UploadPart(s *S3Conf) error {
 		}
 
 		httpClient := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		req, err := http.NewRequest(v4req.Method, v4req.URL, nil)
@@ -1585,7 +1798,7 @@ func PresignedAuth_UploadPart(s *S3Conf) error {
 
 		etag := resp.Header.Get("Etag")
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := clt.ListParts(ctx, &s3.ListPartsInput{Bucket: &bucket, Key: &key, UploadId: mp.UploadId})
 		cancel()
 		if err != nil {
@@ -1602,11 +1815,6 @@ func PresignedAuth_UploadPart(s *S3Conf) error {
 			return fmt.Errorf("expected uploaded part part-number to be %v, instead got %v", partNumber, *out.Parts[0].PartNumber)
 		}
 
-		err = teardown(s, bucket)
-		if err != nil {
-			return err
-		}
-
 		return nil
 	})
 }
@@ -1641,6 +1849,27 @@ func CreateBucket_invalid_bucket_name(s *S3Conf) error {
 	return nil
 }
 
+func CreateBucket_invalid_bucket_name_rules(s *S3Conf) error {
+	testName := "CreateBucket_invalid_bucket_name_rules"
+	runF(testName)
+	for _, bucket := range []string{
+		"ab",
+		strings.Repeat("a", 64),
+		"My-Bucket",
+		"my_bucket",
+		"192.168.1.1",
+		"my..bucket",
+	} {
+		err := setup(s, bucket)
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrInvalidBucketName)); err != nil {
+			failF("%v: %v: %v", testName, bucket, err)
+			return fmt.Errorf("%v: %v: %w", testName, bucket, err)
+		}
+	}
+	passF(testName)
+	return nil
+}
+
 func CreateBucket_as_user(s *S3Conf) error {
 	testName := "CreateBucket_as_user"
 	runF(testName)
@@ -1710,7 +1939,7 @@ func CreateBucket_existing_bucket(s *S3Conf) error {
 func CreateBucket_owned_by_you(s *S3Conf) error {
 	testName := "CreateBucket_owned_by_you"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.CreateBucket(ctx, &s3.CreateBucketInput{
 			Bucket: &bucket,
 		})
@@ -1747,9 +1976,9 @@ func CreateBucket_ownership_with_acl(s *S3Conf) error {
 	testName := "CreateBucket_ownership_with_acl"
 
 	runF(testName)
-	client := s3.NewFromConfig(s.Config())
+	client := s.GetClient()
 
-	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 	_, err := client.CreateBucket(ctx, &s3.CreateBucketInput{
 		Bucket:          getPtr(getBucketName()),
 		ObjectOwnership: types.ObjectOwnershipBucketOwnerEnforced,
@@ -1769,7 +1998,7 @@ func CreateBucket_default_acl(s *S3Conf) error {
 	testName := "CreateBucket_default_acl"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.GetBucketAcl(ctx, &s3.GetBucketAclInput{Bucket: &bucket})
 		cancel()
 		if err != nil {
@@ -1840,9 +2069,9 @@ func CreateBucket_non_default_acl(s *S3Conf) error {
 	}
 
 	bucket := getBucketName()
-	client := s3.NewFromConfig(s.Config())
+	client := s.GetClient()
 
-	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 	_, err = client.CreateBucket(ctx, &s3.CreateBucketInput{
 		Bucket:           &bucket,
 		GrantFullControl: getPtr("grt1"),
@@ -1856,7 +2085,7 @@ func CreateBucket_non_default_acl(s *S3Conf) error {
 		return fmt.Errorf("%v: %w", testName, err)
 	}
 
-	ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+	ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 	out, err := client.GetBucketAcl(ctx, &s3.GetBucketAclInput{Bucket: &bucket})
 	cancel()
 	if err != nil {
@@ -1886,9 +2115,9 @@ func CreateBucket_default_object_lock(s *S3Conf) error {
 	bucket := getBucketName()
 	lockEnabled := true
 
-	client := s3.NewFromConfig(s.Config())
+	client := s.GetClient()
 
-	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 	_, err := client.CreateBucket(ctx, &s3.CreateBucketInput{
 		Bucket:                     &bucket,
 		ObjectLockEnabledForBucket: &lockEnabled,
@@ -1899,7 +2128,7 @@ func CreateBucket_default_object_lock(s *S3Conf) error {
 		return fmt.Errorf("%v: %w", testName, err)
 	}
 
-	ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+	ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 	resp, err := client.GetObjectLockConfiguration(ctx, &s3.GetObjectLockConfigurationInput{
 		Bucket: &bucket,
 	})
@@ -1929,7 +2158,7 @@ func HeadBucket_non_existing_bucket(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		bcktName := getBucketName()
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.HeadBucket(ctx, &s3.HeadBucketInput{
 			Bucket: &bcktName,
 		})
@@ -1944,7 +2173,7 @@ func HeadBucket_non_existing_bucket(s *S3Conf) error {
 func HeadBucket_success(s *S3Conf) error {
 	testName := "HeadBucket_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		resp, err := s3client.HeadBucket(ctx, &s3.HeadBucketInput{
 			Bucket: &bucket,
 		})
@@ -1964,17 +2193,86 @@ func HeadBucket_success(s *S3Conf) error {
 	})
 }
 
+func HeadBucket_user_access_denied(s *S3Conf) error {
+	testName := "HeadBucket_user_access_denied"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		usr := user{
+			access: "grt1",
+			secret: "grt1secret",
+			role:   "user",
+		}
+		err := createUsers(s, []user{usr})
+		if err != nil {
+			return err
+		}
+
+		userClient := getUserS3Client(usr, s)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = userClient.HeadBucket(ctx, &s3.HeadBucketInput{
+			Bucket: &bucket,
+		})
+		cancel()
+		if err := checkSdkApiErr(err, "Forbidden"); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func GetBucketLocation_non_existing_bucket(s *S3Conf) error {
+	testName := "GetBucketLocation_non_existing_bucket"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err := s3client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{
+			Bucket: getPtr(getBucketName()),
+		})
+		cancel()
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrNoSuchBucket)); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func GetBucketLocation_success(s *S3Conf) error {
+	testName := "GetBucketLocation_success"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		resp, err := s3client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{
+			Bucket: &bucket,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		// us-east-1 is reported as an empty location constraint
+		expected := s.awsRegion
+		if expected == "us-east-1" {
+			expected = ""
+		}
+		if string(resp.LocationConstraint) != expected {
+			return fmt.Errorf("expected the bucket location to be %q, instead got %q", expected, resp.LocationConstraint)
+		}
+
+		return nil
+	})
+}
+
 func ListBuckets_as_user(s *S3Conf) error {
 	testName := "ListBuckets_as_user"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) (err error) {
 		buckets := []s3response.ListAllMyBucketsEntry{{Name: bucket}}
 		for i := 0; i < 6; i++ {
 			bckt := getBucketName()
 
-			err := setup(s, bckt)
+			err = setup(s, bckt)
 			if err != nil {
 				return err
 			}
+			defer teardownAfter(s, bckt, &err)
 
 			buckets = append(buckets, s3response.ListAllMyBucketsEntry{
 				Name: bckt,
@@ -1986,7 +2284,7 @@ func ListBuckets_as_user(s *S3Conf) error {
 			role:   "user",
 		}
 
-		err := createUsers(s, []user{usr})
+		err = createUsers(s, []user{usr})
 		if err != nil {
 			return err
 		}
@@ -2005,9 +2303,9 @@ func ListBuckets_as_user(s *S3Conf) error {
 			return err
 		}
 
-		userClient := s3.NewFromConfig(cfg.Config())
+		userClient := cfg.GetClient()
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := userClient.ListBuckets(ctx, &s3.ListBucketsInput{})
 		cancel()
 		if err != nil {
@@ -2021,28 +2319,22 @@ func ListBuckets_as_user(s *S3Conf) error {
 			return fmt.Errorf("expected list buckets result to be %v, instead got %v", buckets[:3], out.Buckets)
 		}
 
-		for _, elem := range buckets[1:] {
-			err = teardown(s, elem.Name)
-			if err != nil {
-				return err
-			}
-		}
-
 		return nil
 	})
 }
 
 func ListBuckets_as_admin(s *S3Conf) error {
 	testName := "ListBuckets_as_admin"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) (err error) {
 		buckets := []s3response.ListAllMyBucketsEntry{{Name: bucket}}
 		for i := 0; i < 6; i++ {
 			bckt := getBucketName()
 
-			err := setup(s, bckt)
+			err = setup(s, bckt)
 			if err != nil {
 				return err
 			}
+			defer teardownAfter(s, bckt, &err)
 
 			buckets = append(buckets, s3response.ListAllMyBucketsEntry{
 				Name: bckt,
@@ -2059,7 +2351,7 @@ func ListBuckets_as_admin(s *S3Conf) error {
 			role:   "admin",
 		}
 
-		err := createUsers(s, []user{usr, admin})
+		err = createUsers(s, []user{usr, admin})
 		if err != nil {
 			return err
 		}
@@ -2078,9 +2370,9 @@ func ListBuckets_as_admin(s *S3Conf) error {
 			return err
 		}
 
-		adminClient := s3.NewFromConfig(cfg.Config())
+		adminClient := cfg.GetClient()
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := adminClient.ListBuckets(ctx, &s3.ListBucketsInput{})
 		cancel()
 		if err != nil {
@@ -2094,35 +2386,29 @@ func ListBuckets_as_admin(s *S3Conf) error {
 			return fmt.Errorf("expected list buckets result to be %v, instead got %v", buckets, out.Buckets)
 		}
 
-		for _, elem := range buckets[1:] {
-			err = teardown(s, elem.Name)
-			if err != nil {
-				return err
-			}
-		}
-
 		return nil
 	})
 }
 
 func ListBuckets_success(s *S3Conf) error {
 	testName := "ListBuckets_success"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) (err error) {
 		buckets := []s3response.ListAllMyBucketsEntry{{Name: bucket}}
 		for i := 0; i < 5; i++ {
 			bckt := getBucketName()
 
-			err := setup(s, bckt)
+			err = setup(s, bckt)
 			if err != nil {
 				return err
 			}
+			defer teardownAfter(s, bckt, &err)
 
 			buckets = append(buckets, s3response.ListAllMyBucketsEntry{
 				Name: bckt,
 			})
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.ListBuckets(ctx, &s3.ListBucketsInput{})
 		cancel()
 		if err != nil {
@@ -2136,13 +2422,6 @@ func ListBuckets_success(s *S3Conf) error {
 			return fmt.Errorf("expected list buckets result to be %v, instead got %v", buckets, out.Buckets)
 		}
 
-		for _, elem := range buckets[1:] {
-			err = teardown(s, elem.Name)
-			if err != nil {
-				return err
-			}
-		}
-
 		return nil
 	})
 }
@@ -2173,9 +2452,9 @@ func DeleteBucket_non_existing_bucket(s *S3Conf) error {
 	testName := "DeleteBucket_non_existing_bucket"
 	runF(testName)
 	bucket := getBucketName()
-	s3client := s3.NewFromConfig(s.Config())
+	s3client := s.GetClient()
 
-	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 	_, err := s3client.DeleteBucket(ctx, &s3.DeleteBucketInput{
 		Bucket: &bucket,
 	})
@@ -2190,13 +2469,23 @@ func DeleteBucket_non_existing_bucket(s *S3Conf) error {
 
 func DeleteBucket_non_empty_bucket(s *S3Conf) error {
 	testName := "DeleteBucket_non_empty_bucket"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		_, err := putObjects(s3client, []string{"foo"}, bucket)
-		if err != nil {
-			return err
-		}
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		_, err = s3client.DeleteBucket(ctx, &s3.DeleteBucketInput{
+	runF(testName)
+	bucket, obj := getBucketName(), "foo"
+
+	err := setup(s, bucket)
+	if err != nil {
+		failF("%v: %v", testName, err)
+		return fmt.Errorf("%v: %w", testName, err)
+	}
+
+	err = func() error {
+		s3client := s.GetClient()
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.DeleteBucket(ctx, &s3.DeleteBucketInput{
 			Bucket: &bucket,
 		})
 		cancel()
@@ -2204,8 +2493,63 @@ func DeleteBucket_non_empty_bucket(s *S3Conf) error {
 			return err
 		}
 
-		return nil
-	})
+		// the failed delete leaves the bucket and the object intact
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.HeadBucket(ctx, &s3.HeadBucketInput{
+			Bucket: &bucket,
+		})
+		cancel()
+		if err != nil {
+			return fmt.Errorf("expected the bucket to exist: %w", err)
+		}
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		cancel()
+		if err != nil {
+			return fmt.Errorf("expected the object to exist: %w", err)
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		// the emptied bucket can be removed
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.DeleteBucket(ctx, &s3.DeleteBucketInput{
+			Bucket: &bucket,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.HeadBucket(ctx, &s3.HeadBucketInput{
+			Bucket: &bucket,
+		})
+		cancel()
+		return checkSdkApiErr(err, "NotFound")
+	}()
+	if err != nil {
+		// the bucket is left over after a failure
+		if tErr := teardown(s, bucket); tErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to delete the bucket: %w", tErr))
+		}
+		failF("%v: %v", testName, err)
+		return fmt.Errorf("%v: %w", testName, err)
+	}
+
+	passF(testName)
+	return nil
 }
 
 func DeleteBucket_success_status_code(s *S3Conf) error {
@@ -2226,7 +2570,7 @@ func DeleteBucket_success_status_code(s *S3Conf) error {
 	}
 
 	client := http.Client{
-		Timeout: shortTimeout,
+		Timeout: s.opTimeout(),
 	}
 
 	resp, err := client.Do(req)
@@ -2247,7 +2591,7 @@ func DeleteBucket_success_status_code(s *S3Conf) error {
 func PutBucketOwnershipControls_non_existing_bucket(s *S3Conf) error {
 	testName := "PutBucketOwnershipControls_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketOwnershipControls(ctx, &s3.PutBucketOwnershipControlsInput{
 			Bucket: getPtr(getBucketName()),
 			OwnershipControls: &types.OwnershipControls{
@@ -2270,7 +2614,7 @@ func PutBucketOwnershipControls_non_existing_bucket(s *S3Conf) error {
 func PutBucketOwnershipControls_multiple_rules(s *S3Conf) error {
 	testName := "PutBucketOwnershipControls_multiple_rules"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketOwnershipControls(ctx, &s3.PutBucketOwnershipControlsInput{
 			Bucket: &bucket,
 			OwnershipControls: &types.OwnershipControls{
@@ -2296,7 +2640,7 @@ func PutBucketOwnershipControls_multiple_rules(s *S3Conf) error {
 func PutBucketOwnershipControls_invalid_ownership(s *S3Conf) error {
 	testName := "PutBucketOwnershipControls_invalid_ownership"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketOwnershipControls(ctx, &s3.PutBucketOwnershipControlsInput{
 			Bucket: &bucket,
 			OwnershipControls: &types.OwnershipControls{
@@ -2319,7 +2663,7 @@ func PutBucketOwnershipControls_invalid_ownership(s *S3Conf) error {
 func PutBucketOwnershipControls_success(s *S3Conf) error {
 	testName := "PutBucketOwnershipControls_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketOwnershipControls(ctx, &s3.PutBucketOwnershipControlsInput{
 			Bucket: &bucket,
 			OwnershipControls: &types.OwnershipControls{
@@ -2342,7 +2686,7 @@ func PutBucketOwnershipControls_success(s *S3Conf) error {
 func GetBucketOwnershipControls_non_existing_bucket(s *S3Conf) error {
 	testName := "GetBucketOwnershipControls_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.GetBucketOwnershipControls(ctx, &s3.GetBucketOwnershipControlsInput{
 			Bucket: getPtr(getBucketName()),
 		})
@@ -2358,7 +2702,7 @@ func GetBucketOwnershipControls_non_existing_bucket(s *S3Conf) error {
 func GetBucketOwnershipControls_default_ownership(s *S3Conf) error {
 	testName := "GetBucketOwnershipControls_default_ownership"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		resp, err := s3client.GetBucketOwnershipControls(ctx, &s3.GetBucketOwnershipControlsInput{
 			Bucket: &bucket,
 		})
@@ -2381,7 +2725,7 @@ func GetBucketOwnershipControls_default_ownership(s *S3Conf) error {
 func GetBucketOwnershipControls_success(s *S3Conf) error {
 	testName := "GetBucketOwnershipControls_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketOwnershipControls(ctx, &s3.PutBucketOwnershipControlsInput{
 			Bucket: &bucket,
 			OwnershipControls: &types.OwnershipControls{
@@ -2397,7 +2741,7 @@ func GetBucketOwnershipControls_success(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		resp, err := s3client.GetBucketOwnershipControls(ctx, &s3.GetBucketOwnershipControlsInput{
 			Bucket: &bucket,
 		})
@@ -2420,7 +2764,7 @@ func GetBucketOwnershipControls_success(s *S3Conf) error {
 func DeleteBucketOwnershipControls_non_existing_bucket(s *S3Conf) error {
 	testName := "DeleteBucketOwnershipControls_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.DeleteBucketOwnershipControls(ctx, &s3.DeleteBucketOwnershipControlsInput{
 			Bucket: getPtr(getBucketName()),
 		})
@@ -2436,7 +2780,7 @@ func DeleteBucketOwnershipControls_non_existing_bucket(s *S3Conf) error {
 func DeleteBucketOwnershipControls_success(s *S3Conf) error {
 	testName := "DeleteBucketOwnershipControls_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.DeleteBucketOwnershipControls(ctx, &s3.DeleteBucketOwnershipControlsInput{
 			Bucket: &bucket,
 		})
@@ -2445,7 +2789,7 @@ func DeleteBucketOwnershipControls_success(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.GetBucketOwnershipControls(ctx, &s3.GetBucketOwnershipControlsInput{
 			Bucket: &bucket,
 		})
@@ -2458,10 +2802,136 @@ func DeleteBucketOwnershipControls_success(s *S3Conf) error {
 	})
 }
 
+func PutBucketAccelerateConfiguration_non_existing_bucket(s *S3Conf) error {
+	testName := "PutBucketAccelerateConfiguration_non_existing_bucket"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err := s3client.PutBucketAccelerateConfiguration(ctx, &s3.PutBucketAccelerateConfigurationInput{
+			Bucket: getPtr(getBucketName()),
+			AccelerateConfiguration: &types.AccelerateConfiguration{
+				Status: types.BucketAccelerateStatusEnabled,
+			},
+		})
+		cancel()
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrNoSuchBucket)); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func PutBucketAccelerateConfiguration_invalid_status(s *S3Conf) error {
+	testName := "PutBucketAccelerateConfiguration_invalid_status"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err := s3client.PutBucketAccelerateConfiguration(ctx, &s3.PutBucketAccelerateConfigurationInput{
+			Bucket: &bucket,
+			AccelerateConfiguration: &types.AccelerateConfiguration{
+				Status: types.BucketAccelerateStatus("invalid_status"),
+			},
+		})
+		cancel()
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrMalformedXML)); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func PutBucketAccelerateConfiguration_success(s *S3Conf) error {
+	testName := "PutBucketAccelerateConfiguration_success"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err := s3client.PutBucketAccelerateConfiguration(ctx, &s3.PutBucketAccelerateConfigurationInput{
+			Bucket: &bucket,
+			AccelerateConfiguration: &types.AccelerateConfiguration{
+				Status: types.BucketAccelerateStatusEnabled,
+			},
+		})
+		cancel()
+		return err
+	})
+}
+
+func GetBucketAccelerateConfiguration_non_existing_bucket(s *S3Conf) error {
+	testName := "GetBucketAccelerateConfiguration_non_existing_bucket"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err := s3client.GetBucketAccelerateConfiguration(ctx, &s3.GetBucketAccelerateConfigurationInput{
+			Bucket: getPtr(getBucketName()),
+		})
+		cancel()
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrNoSuchBucket)); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func GetBucketAccelerateConfiguration_empty_status(s *S3Conf) error {
+	testName := "GetBucketAccelerateConfiguration_empty_status"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		res, err := s3client.GetBucketAccelerateConfiguration(ctx, &s3.GetBucketAccelerateConfigurationInput{
+			Bucket: &bucket,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if res.Status != "" {
+			return fmt.Errorf("expected empty accelerate status, instead got %v", res.Status)
+		}
+
+		return nil
+	})
+}
+
+func GetBucketAccelerateConfiguration_success(s *S3Conf) error {
+	testName := "GetBucketAccelerateConfiguration_success"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		for _, status := range []types.BucketAccelerateStatus{
+			types.BucketAccelerateStatusEnabled,
+			types.BucketAccelerateStatusSuspended,
+		} {
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			_, err := s3client.PutBucketAccelerateConfiguration(ctx, &s3.PutBucketAccelerateConfigurationInput{
+				Bucket: &bucket,
+				AccelerateConfiguration: &types.AccelerateConfiguration{
+					Status: status,
+				},
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+			res, err := s3client.GetBucketAccelerateConfiguration(ctx, &s3.GetBucketAccelerateConfigurationInput{
+				Bucket: &bucket,
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
+
+			if res.Status != status {
+				return fmt.Errorf("expected the accelerate status to be %v, instead got %v", status, res.Status)
+			}
+		}
+
+		return nil
+	})
+}
+
 func PutBucketTagging_non_existing_bucket(s *S3Conf) error {
 	testName := "PutBucketTagging_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketTagging(ctx, &s3.PutBucketTaggingInput{
 			Bucket:  getPtr(getBucketName()),
 			Tagging: &types.Tagging{TagSet: []types.Tag{}},
@@ -2480,7 +2950,7 @@ func PutBucketTagging_long_tags(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		tagging := types.Tagging{TagSet: []types.Tag{{Key: getPtr(genRandString(200)), Value: getPtr("val")}}}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketTagging(ctx, &s3.PutBucketTaggingInput{
 			Bucket:  &bucket,
 			Tagging: &tagging})
@@ -2491,7 +2961,7 @@ func PutBucketTagging_long_tags(s *S3Conf) error {
 
 		tagging = types.Tagging{TagSet: []types.Tag{{Key: getPtr("key"), Value: getPtr(genRandString(300))}}}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutBucketTagging(ctx, &s3.PutBucketTaggingInput{
 			Bucket:  &bucket,
 			Tagging: &tagging})
@@ -2509,7 +2979,7 @@ func PutBucketTagging_success(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		tagging := types.Tagging{TagSet: []types.Tag{{Key: getPtr("key1"), Value: getPtr("val2")}, {Key: getPtr("key2"), Value: getPtr("val2")}}}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketTagging(ctx, &s3.PutBucketTaggingInput{
 			Bucket:  &bucket,
 			Tagging: &tagging})
@@ -2525,7 +2995,7 @@ func PutBucketTagging_success(s *S3Conf) error {
 func GetBucketTagging_non_existing_bucket(s *S3Conf) error {
 	testName := "GetBucketTagging_non_existing_object"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{
 			Bucket: getPtr(getBucketName()),
 		})
@@ -2540,7 +3010,7 @@ func GetBucketTagging_non_existing_bucket(s *S3Conf) error {
 func GetBucketTagging_unset_tags(s *S3Conf) error {
 	testName := "GetBucketTagging_unset_tags"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{
 			Bucket: &bucket,
 		})
@@ -2557,7 +3027,7 @@ func GetBucketTagging_success(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		tagging := types.Tagging{TagSet: []types.Tag{{Key: getPtr("key1"), Value: getPtr("val2")}, {Key: getPtr("key2"), Value: getPtr("val2")}}}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketTagging(ctx, &s3.PutBucketTaggingInput{
 			Bucket:  &bucket,
 			Tagging: &tagging})
@@ -2566,7 +3036,7 @@ func GetBucketTagging_success(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{
 			Bucket: &bucket,
 		})
@@ -2586,7 +3056,7 @@ func GetBucketTagging_success(s *S3Conf) error {
 func DeleteBucketTagging_non_existing_object(s *S3Conf) error {
 	testName := "DeleteBucketTagging_non_existing_object"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.DeleteBucketTagging(ctx, &s3.DeleteBucketTaggingInput{
 			Bucket: getPtr(getBucketName()),
 		})
@@ -2610,7 +3080,7 @@ func DeleteBucketTagging_success_status(s *S3Conf) error {
 			},
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketTagging(ctx, &s3.PutBucketTaggingInput{
 			Bucket:  &bucket,
 			Tagging: &tagging,
@@ -2626,7 +3096,7 @@ func DeleteBucketTagging_success_status(s *S3Conf) error {
 		}
 
 		client := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		resp, err := client.Do(req)
@@ -2647,7 +3117,7 @@ func DeleteBucketTagging_success(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		tagging := types.Tagging{TagSet: []types.Tag{{Key: getPtr("key1"), Value: getPtr("val2")}, {Key: getPtr("key2"), Value: getPtr("val2")}}}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketTagging(ctx, &s3.PutBucketTaggingInput{
 			Bucket:  &bucket,
 			Tagging: &tagging})
@@ -2656,7 +3126,7 @@ func DeleteBucketTagging_success(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.DeleteBucketTagging(ctx, &s3.DeleteBucketTaggingInput{
 			Bucket: &bucket,
 		})
@@ -2665,7 +3135,7 @@ func DeleteBucketTagging_success(s *S3Conf) error {
 			return nil
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{
 			Bucket: &bucket,
 		})
@@ -2685,7 +3155,7 @@ func DeleteBucketTagging_success(s *S3Conf) error {
 func PutObject_non_existing_bucket(s *S3Conf) error {
 	testName := "PutObject_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		_, err := putObjects(s3client, []string{"my-obj"}, "non-existing-bucket")
+		_, err := putObjects(s, s3client, []string{"my-obj"}, "non-existing-bucket")
 		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrNoSuchBucket)); err != nil {
 			return err
 		}
@@ -2708,12 +3178,12 @@ func PutObject_special_chars(s *S3Conf) error {
 	}
 
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		objs, err := putObjects(s3client, objnames, bucket)
+		objs, err := putObjects(s, s3client, objnames, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
 			Bucket: &bucket,
 		})
@@ -2730,6 +3200,109 @@ func PutObject_special_chars(s *S3Conf) error {
 		return nil
 	})
 }
+func PutObject_unicode_keys(s *S3Conf) error {
+	testName := "PutObject_unicode_keys"
+
+	// the posix backends limit each path component to 255 bytes, so the
+	// key close to the 1024 bytes limit is split into components
+	longSeg := strings.Repeat("x", 255)
+	longKey := strings.Join([]string{longSeg, longSeg, longSeg, longSeg}, "/")
+
+	objnames := []string{
+		"my key with spaces", "my+key", "my%20key", "100%", "emoji-😀-🚀",
+		"日本語のキー", "中文/键", "한국어", "mixed é ñ ü", "nested/dir/key",
+		longKey,
+	}
+
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		data := map[string][]byte{}
+		for i, obj := range objnames {
+			r, err := putObjectWithData(s, int64(i+1), &s3.PutObjectInput{
+				Bucket: &bucket,
+				Key:    &obj,
+			}, s3client)
+			if err != nil {
+				return fmt.Errorf("put %q: %w", obj, err)
+			}
+			data[obj] = r.data
+		}
+
+		for _, obj := range objnames {
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			head, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
+				Bucket: &bucket,
+				Key:    &obj,
+			})
+			cancel()
+			if err != nil {
+				return fmt.Errorf("head %q: %w", obj, err)
+			}
+			if head.ContentLength == nil || *head.ContentLength != int64(len(data[obj])) {
+				return fmt.Errorf("head %q: expected content length %v, instead got %v", obj, len(data[obj]), head.ContentLength)
+			}
+
+			ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+			out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+				Bucket: &bucket,
+				Key:    &obj,
+			})
+			if err != nil {
+				cancel()
+				return fmt.Errorf("get %q: %w", obj, err)
+			}
+			bdy, err := io.ReadAll(out.Body)
+			out.Body.Close()
+			cancel()
+			if err != nil {
+				return err
+			}
+			if !isEqual(bdy, data[obj]) {
+				return fmt.Errorf("get %q: unexpected object data", obj)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		res, err := s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+			Bucket: &bucket,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		expected := slices.Clone(objnames)
+		slices.Sort(expected)
+		if !slices.Equal(objStrings(res.Contents), expected) {
+			return fmt.Errorf("expected the listed keys to be %q, instead got %q", expected, objStrings(res.Contents))
+		}
+
+		for _, obj := range objnames {
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			_, err := s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
+				Bucket: &bucket,
+				Key:    &obj,
+			})
+			cancel()
+			if err != nil {
+				return fmt.Errorf("delete %q: %w", obj, err)
+			}
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		res, err = s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+			Bucket: &bucket,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+		if len(res.Contents) != 0 {
+			return fmt.Errorf("expected empty bucket after the deletes, instead got %q", objStrings(res.Contents))
+		}
+
+		return nil
+	})
+}
 
 func PutObject_invalid_long_tags(s *S3Conf) error {
 	testName := "PutObject_invalid_long_tags"
@@ -2737,7 +3310,7 @@ func PutObject_invalid_long_tags(s *S3Conf) error {
 		key := "my-obj"
 		tagging := fmt.Sprintf("%v=val", genRandString(200))
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObject(ctx, &s3.PutObjectInput{
 			Bucket:  &bucket,
 			Key:     &key,
@@ -2751,14 +3324,79 @@ func PutObject_invalid_long_tags(s *S3Conf) error {
 
 		tagging = fmt.Sprintf("key=%v", genRandString(300))
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:  &bucket,
+			Key:     &key,
+			Tagging: &tagging,
+		})
+		cancel()
+
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrInvalidTag)); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func PutObject_tagging_header_limits(s *S3Conf) error {
+	testName := "PutObject_tagging_header_limits"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		key := "my-obj"
+		tags := []string{}
+		for i := 0; i < 10; i++ {
+			tags = append(tags, fmt.Sprintf("key-%v=val-%v", i, i))
+		}
+
+		// exactly 10 tags are allowed
+		tagging := strings.Join(tags, "&")
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err := s3client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:  &bucket,
+			Key:     &key,
+			Tagging: &tagging,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		// 11 tags exceed the limit
+		tagging = strings.Join(append(tags, "key-10=val-10"), "&")
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObject(ctx, &s3.PutObjectInput{
 			Bucket:  &bucket,
 			Key:     &key,
 			Tagging: &tagging,
 		})
 		cancel()
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrObjectTaggingLimited)); err != nil {
+			return err
+		}
+
+		// disallowed characters
+		for _, tagging := range []string{"key%24=val", "key=val%23"} {
+			ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+			_, err = s3client.PutObject(ctx, &s3.PutObjectInput{
+				Bucket:  &bucket,
+				Key:     &key,
+				Tagging: &tagging,
+			})
+			cancel()
+			if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrInvalidTag)); err != nil {
+				return err
+			}
+		}
 
+		// the same validation applies to multipart uploads
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+			Bucket:  &bucket,
+			Key:     &key,
+			Tagging: getPtr("key%24=val"),
+		})
+		cancel()
 		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrInvalidTag)); err != nil {
 			return err
 		}
@@ -2772,7 +3410,7 @@ func PutObject_missing_object_lock_retention_config(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		key := "my-obj"
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObject(ctx, &s3.PutObjectInput{
 			Bucket:         &bucket,
 			Key:            &key,
@@ -2785,7 +3423,7 @@ func PutObject_missing_object_lock_retention_config(s *S3Conf) error {
 
 		retainDate := time.Now().Add(time.Hour * 48)
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObject(ctx, &s3.PutObjectInput{
 			Bucket:                    &bucket,
 			Key:                       &key,
@@ -2805,8 +3443,8 @@ func PutObject_with_object_lock(s *S3Conf) error {
 	runF(testName)
 	bucket, obj, lockStatus := getBucketName(), "my-obj", true
 
-	client := s3.NewFromConfig(s.Config())
-	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	client := s.GetClient()
+	ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 	_, err := client.CreateBucket(ctx, &s3.CreateBucketInput{
 		Bucket:                     &bucket,
 		ObjectLockEnabledForBucket: &lockStatus,
@@ -2819,7 +3457,7 @@ func PutObject_with_object_lock(s *S3Conf) error {
 
 	retainDate := time.Now().Add(time.Hour * 48)
 
-	ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+	ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 	_, err = client.PutObject(ctx, &s3.PutObjectInput{
 		Bucket:                    &bucket,
 		Key:                       &obj,
@@ -2834,7 +3472,7 @@ func PutObject_with_object_lock(s *S3Conf) error {
 		return fmt.Errorf("%v: %w", testName, err)
 	}
 
-	ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+	ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 	out, err := client.HeadObject(ctx, &s3.HeadObjectInput{
 		Bucket: &bucket,
 		Key:    &obj,
@@ -2854,7 +3492,7 @@ func PutObject_with_object_lock(s *S3Conf) error {
 		return fmt.Errorf("%v: expected object lock mode to be %v, instead got %v", testName, types.ObjectLockLegalHoldStatusOn, out.ObjectLockLegalHoldStatus)
 	}
 
-	if err := changeBucketObjectLockStatus(client, bucket, false); err != nil {
+	if err := changeBucketObjectLockStatus(s, client, bucket, false); err != nil {
 		failF("%v: %v", err)
 		return fmt.Errorf("%v: %w", testName, err)
 	}
@@ -2874,8 +3512,8 @@ func PutObject_racey_success(s *S3Conf) error {
 	runF(testName)
 	bucket, obj, lockStatus := getBucketName(), "my-obj", true
 
-	client := s3.NewFromConfig(s.Config())
-	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	client := s.GetClient()
+	ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 	_, err := client.CreateBucket(ctx, &s3.CreateBucketInput{
 		Bucket:                     &bucket,
 		ObjectLockEnabledForBucket: &lockStatus,
@@ -2889,7 +3527,7 @@ func PutObject_racey_success(s *S3Conf) error {
 	eg := errgroup.Group{}
 	for i := 0; i < 10; i++ {
 		eg.Go(func() error {
-			ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 			_, err := client.PutObject(ctx, &s3.PutObjectInput{
 				Bucket: &bucket,
 				Key:    &obj,
@@ -2918,160 +3556,1473 @@ func PutObject_racey_success(s *S3Conf) error {
 func PutObject_success(s *S3Conf) error {
 	testName := "PutObject_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		_, err := putObjects(s3client, []string{"my-obj"}, bucket)
+		_, err := putObjects(s, s3client, []string{"my-obj"}, bucket)
 		if err != nil {
 			return err
 		}
 		return nil
 	})
 }
-
-func PutObject_invalid_credentials(s *S3Conf) error {
-	testName := "PutObject_invalid_credentials"
+func PutObject_overwrite(s *S3Conf) error {
+	testName := "PutObject_overwrite"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		newconf := *s
-		newconf.awsSecret = newconf.awsSecret + "badpassword"
-		client := s3.NewFromConfig(newconf.Config())
-		_, err := putObjects(client, []string{"my-obj"}, bucket)
-		return checkApiErr(err, s3err.GetAPIError(s3err.ErrSignatureDoesNotMatch))
-	})
-}
+		obj := "my-obj"
+		first, err := putObjectWithData(s, 100, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+		second, err := putObjectWithData(s, 200, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		head, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if getString(head.ETag) == getString(first.res.ETag) {
+			return fmt.Errorf("expected the etag to change after the overwrite, instead got %v", getString(head.ETag))
+		}
+		if getString(head.ETag) != getString(second.res.ETag) {
+			return fmt.Errorf("expected the etag to be %v, instead got %v", getString(second.res.ETag), getString(head.ETag))
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		defer cancel()
+		if err != nil {
+			return err
+		}
+		defer out.Body.Close()
+
+		if out.ContentLength == nil || *out.ContentLength != 200 {
+			return fmt.Errorf("expected the content length to be 200, instead got %v", out.ContentLength)
+		}
+		bdy, err := io.ReadAll(out.Body)
+		if err != nil {
+			return err
+		}
+		if sha256.Sum256(bdy) != second.csum {
+			return fmt.Errorf("expected the object data to be the overwritten content")
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		res, err := s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+			Bucket: &bucket,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if len(res.Contents) != 1 || getString(res.Contents[0].Key) != obj {
+			return fmt.Errorf("expected a single %v object, instead got %v", obj, objStrings(res.Contents))
+		}
+		if *res.Contents[0].Size != 200 {
+			return fmt.Errorf("expected the listed size to be 200, instead got %v", *res.Contents[0].Size)
+		}
+
+		return nil
+	})
+}
+func PutObject_content_type(s *S3Conf) error {
+	testName := "PutObject_content_type"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		checkContentType := func(obj string, expected ...string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			head, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
+				Bucket: &bucket,
+				Key:    &obj,
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
+			if !slices.Contains(expected, getString(head.ContentType)) {
+				return fmt.Errorf("expected %v HeadObject content type to be %v, instead got %v",
+					obj, expected[0], getString(head.ContentType))
+			}
+
+			ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+			defer cancel()
+			out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+				Bucket: &bucket,
+				Key:    &obj,
+			})
+			if err != nil {
+				return err
+			}
+			defer out.Body.Close()
+			if !slices.Contains(expected, getString(out.ContentType)) {
+				return fmt.Errorf("expected %v GetObject content type to be %v, instead got %v",
+					obj, expected[0], getString(out.ContentType))
+			}
+			return nil
+		}
+
+		for obj, cType := range map[string]string{
+			"image.png": "image/png",
+			"doc.json":  "application/json",
+		} {
+			_, err := putObjectWithData(s, 100, &s3.PutObjectInput{
+				Bucket:      &bucket,
+				Key:         getPtr(obj),
+				ContentType: getPtr(cType),
+			}, s3client)
+			if err != nil {
+				return err
+			}
+			if err := checkContentType(obj, cType); err != nil {
+				return err
+			}
+		}
+
+		// no content type given, the default one is returned
+		obj := "no-content-type"
+		_, err := putObjectWithData(s, 100, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+		if err := checkContentType(obj, "binary/octet-stream", "application/octet-stream"); err != nil {
+			return err
+		}
+
+		// the multipart upload content type is the one given at create
+		obj = "multipart.json"
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		mp, err := s3client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+			Bucket:      &bucket,
+			Key:         &obj,
+			ContentType: getPtr("application/json"),
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		parts, _, err := uploadParts(s, s3client, 1024, 1, bucket, obj, *mp.UploadId)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:   &bucket,
+			Key:      &obj,
+			UploadId: mp.UploadId,
+			MultipartUpload: &types.CompletedMultipartUpload{
+				Parts: []types.CompletedPart{
+					{
+						ETag:       parts[0].ETag,
+						PartNumber: parts[0].PartNumber,
+					},
+				},
+			},
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		return checkContentType(obj, "application/json")
+	})
+}
+func PutObject_user_metadata(s *S3Conf) error {
+	testName := "PutObject_user_metadata"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		checkMetadata := func(obj string, expected map[string]string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			head, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
+				Bucket: &bucket,
+				Key:    &obj,
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
+			if !areMapsSame(head.Metadata, expected) {
+				return fmt.Errorf("expected %v HeadObject metadata to be %v, instead got %v",
+					obj, expected, head.Metadata)
+			}
+
+			ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+			defer cancel()
+			out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+				Bucket: &bucket,
+				Key:    &obj,
+			})
+			if err != nil {
+				return err
+			}
+			defer out.Body.Close()
+			if !areMapsSame(out.Metadata, expected) {
+				return fmt.Errorf("expected %v GetObject metadata to be %v, instead got %v",
+					obj, expected, out.Metadata)
+			}
+			return nil
+		}
+
+		obj := "my-obj"
+		// the metadata keys are case insensitive and returned in lower case
+		meta := map[string]string{
+			"key1":           "val1",
+			"key2":           "some other value",
+			"Mixed-Case-Key": "Mixed Case Value",
+			"utf8-value":     "héllo wörld ✓",
+		}
+		_, err := putObjectWithData(s, 100, &s3.PutObjectInput{
+			Bucket:   &bucket,
+			Key:      &obj,
+			Metadata: meta,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+		if err := checkMetadata(obj, meta); err != nil {
+			return err
+		}
+
+		copyObj := "copied-obj"
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.CopyObject(ctx, &s3.CopyObjectInput{
+			Bucket:            &bucket,
+			Key:               &copyObj,
+			CopySource:        getPtr(bucket + "/" + obj),
+			MetadataDirective: types.MetadataDirectiveCopy,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+		if err := checkMetadata(copyObj, meta); err != nil {
+			return err
+		}
+
+		replacedObj := "replaced-obj"
+		newMeta := map[string]string{
+			"new-key": "new value",
+		}
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.CopyObject(ctx, &s3.CopyObjectInput{
+			Bucket:            &bucket,
+			Key:               &replacedObj,
+			CopySource:        getPtr(bucket + "/" + obj),
+			MetadataDirective: types.MetadataDirectiveReplace,
+			Metadata:          newMeta,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		return checkMetadata(replacedObj, newMeta)
+	})
+}
+func PutObject_content_headers(s *S3Conf) error {
+	testName := "PutObject_content_headers"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		cacheControl := "max-age=3600, public"
+		contentDisposition := `attachment; filename="report.pdf"`
+		contentEncoding := "gzip"
+
+		checkHeaders := func(obj string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			head, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
+				Bucket: &bucket,
+				Key:    &obj,
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
+			if getString(head.CacheControl) != cacheControl {
+				return fmt.Errorf("expected %v HeadObject cache control to be %v, instead got %v",
+					obj, cacheControl, getString(head.CacheControl))
+			}
+			if getString(head.ContentDisposition) != contentDisposition {
+				return fmt.Errorf("expected %v HeadObject content disposition to be %v, instead got %v",
+					obj, contentDisposition, getString(head.ContentDisposition))
+			}
+			if getString(head.ContentEncoding) != contentEncoding {
+				return fmt.Errorf("expected %v HeadObject content encoding to be %v, instead got %v",
+					obj, contentEncoding, getString(head.ContentEncoding))
+			}
+
+			ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+			defer cancel()
+			out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+				Bucket: &bucket,
+				Key:    &obj,
+			})
+			if err != nil {
+				return err
+			}
+			// the body isn't gzip data, only the headers are checked
+			out.Body.Close()
+			if getString(out.CacheControl) != cacheControl {
+				return fmt.Errorf("expected %v GetObject cache control to be %v, instead got %v",
+					obj, cacheControl, getString(out.CacheControl))
+			}
+			if getString(out.ContentDisposition) != contentDisposition {
+				return fmt.Errorf("expected %v GetObject content disposition to be %v, instead got %v",
+					obj, contentDisposition, getString(out.ContentDisposition))
+			}
+			if getString(out.ContentEncoding) != contentEncoding {
+				return fmt.Errorf("expected %v GetObject content encoding to be %v, instead got %v",
+					obj, contentEncoding, getString(out.ContentEncoding))
+			}
+			return nil
+		}
+
+		obj := "my-obj"
+		_, err := putObjectWithData(s, 100, &s3.PutObjectInput{
+			Bucket:             &bucket,
+			Key:                &obj,
+			CacheControl:       &cacheControl,
+			ContentDisposition: &contentDisposition,
+			ContentEncoding:    &contentEncoding,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+		if err := checkHeaders(obj); err != nil {
+			return err
+		}
+
+		copyObj := "copied-obj"
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.CopyObject(ctx, &s3.CopyObjectInput{
+			Bucket:            &bucket,
+			Key:               &copyObj,
+			CopySource:        getPtr(bucket + "/" + obj),
+			MetadataDirective: types.MetadataDirectiveCopy,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		return checkHeaders(copyObj)
+	})
+}
+
+func PutObject_sse_c(s *S3Conf) error {
+	testName := "PutObject_sse_c"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		sseCKey := func(key []byte) (*string, *string) {
+			sum := md5.Sum(key)
+			return getPtr(base64.StdEncoding.EncodeToString(key)),
+				getPtr(base64.StdEncoding.EncodeToString(sum[:]))
+		}
+		algorithm := "AES256"
+		key, keyMD5 := sseCKey(bytes.Repeat([]byte{'a'}, 32))
+		wrongKey, wrongKeyMD5 := sseCKey(bytes.Repeat([]byte{'b'}, 32))
+
+		obj := "my-obj"
+		out, err := putObjectWithData(s, 1000, &s3.PutObjectInput{
+			Bucket:               &bucket,
+			Key:                  &obj,
+			SSECustomerAlgorithm: &algorithm,
+			SSECustomerKey:       key,
+			SSECustomerKeyMD5:    keyMD5,
+		}, s3client)
+		// the customer key is only accepted over TLS
+		if !strings.HasPrefix(s.endpoint, "https://") {
+			return checkApiErr(err, s3err.GetAPIError(s3err.ErrSSECustomerKeyInsecure))
+		}
+		if err != nil {
+			return err
+		}
+		if getString(out.res.SSECustomerAlgorithm) != algorithm {
+			return fmt.Errorf("expected PutObject sse-c algorithm to be %v, instead got %v",
+				algorithm, getString(out.res.SSECustomerAlgorithm))
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		res, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket:               &bucket,
+			Key:                  &obj,
+			SSECustomerAlgorithm: &algorithm,
+			SSECustomerKey:       key,
+			SSECustomerKeyMD5:    keyMD5,
+		})
+		if err != nil {
+			cancel()
+			return err
+		}
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		cancel()
+		if err != nil {
+			return err
+		}
+		if sha256.Sum256(body) != out.csum {
+			return fmt.Errorf("expected the decrypted object data to match the put data")
+		}
+		if getString(res.SSECustomerKeyMD5) != *keyMD5 {
+			return fmt.Errorf("expected GetObject sse-c key md5 to be %v, instead got %v",
+				*keyMD5, getString(res.SSECustomerKeyMD5))
+		}
+
+		// the ranges are decrypted from the middle of the object
+		rng := "bytes=100-599"
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		res, err = s3client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket:               &bucket,
+			Key:                  &obj,
+			Range:                &rng,
+			SSECustomerAlgorithm: &algorithm,
+			SSECustomerKey:       key,
+			SSECustomerKeyMD5:    keyMD5,
+		})
+		if err != nil {
+			cancel()
+			return err
+		}
+		body, err = io.ReadAll(res.Body)
+		res.Body.Close()
+		cancel()
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(body, out.data[100:600]) {
+			return fmt.Errorf("expected the decrypted object range to match the put data")
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		cancel()
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrSSECustomerKeyRequired)); err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket:               &bucket,
+			Key:                  &obj,
+			SSECustomerAlgorithm: &algorithm,
+			SSECustomerKey:       wrongKey,
+			SSECustomerKeyMD5:    wrongKeyMD5,
+		})
+		cancel()
+		if err := checkSdkApiErr(err, "Forbidden"); err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		head, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket:               &bucket,
+			Key:                  &obj,
+			SSECustomerAlgorithm: &algorithm,
+			SSECustomerKey:       key,
+			SSECustomerKeyMD5:    keyMD5,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+		if getString(head.SSECustomerAlgorithm) != algorithm {
+			return fmt.Errorf("expected HeadObject sse-c algorithm to be %v, instead got %v",
+				algorithm, getString(head.SSECustomerAlgorithm))
+		}
+		if head.ContentLength == nil {
+			return fmt.Errorf("expected non nil HeadObject content length")
+		}
+		if *head.ContentLength != 1000 {
+			return fmt.Errorf("expected HeadObject content length to be 1000, instead got %v",
+				*head.ContentLength)
+		}
+
+		return nil
+	})
+}
+
+func PutObject_checksum_algorithms(s *S3Conf) error {
+	testName := "PutObject_checksum_algorithms"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		data := make([]byte, 1000)
+		rand.Read(data)
+		sum := func(h hash.Hash) string {
+			h.Write(data)
+			return base64.StdEncoding.EncodeToString(h.Sum(nil))
+		}
+
+		for _, test := range []struct {
+			algo     types.ChecksumAlgorithm
+			checksum string
+		}{
+			{types.ChecksumAlgorithmCrc32, sum(crc32.NewIEEE())},
+			{types.ChecksumAlgorithmCrc32c, sum(crc32.New(crc32.MakeTable(crc32.Castagnoli)))},
+			{types.ChecksumAlgorithmSha1, sum(sha1.New())},
+			{types.ChecksumAlgorithmSha256, sum(sha256.New())},
+		} {
+			// the checksum of the algorithm from the output checksum fields
+			getChecksum := func(crc32, crc32c, sha1, sha256 *string) string {
+				return getString(map[types.ChecksumAlgorithm]*string{
+					types.ChecksumAlgorithmCrc32:  crc32,
+					types.ChecksumAlgorithmCrc32c: crc32c,
+					types.ChecksumAlgorithmSha1:   sha1,
+					types.ChecksumAlgorithmSha256: sha256,
+				}[test.algo])
+			}
+
+			obj := "my-obj-" + strings.ToLower(string(test.algo))
+			input := &s3.PutObjectInput{
+				Bucket:            &bucket,
+				Key:               &obj,
+				Body:              bytes.NewReader(data),
+				ChecksumAlgorithm: test.algo,
+			}
+			// the checksum is sent in the header, not computed in a trailer
+			switch test.algo {
+			case types.ChecksumAlgorithmCrc32:
+				input.ChecksumCRC32 = &test.checksum
+			case types.ChecksumAlgorithmCrc32c:
+				input.ChecksumCRC32C = &test.checksum
+			case types.ChecksumAlgorithmSha1:
+				input.ChecksumSHA1 = &test.checksum
+			case types.ChecksumAlgorithmSha256:
+				input.ChecksumSHA256 = &test.checksum
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			res, err := s3client.PutObject(ctx, input)
+			cancel()
+			if err != nil {
+				return err
+			}
+			got := getChecksum(res.ChecksumCRC32, res.ChecksumCRC32C, res.ChecksumSHA1, res.ChecksumSHA256)
+			if got != test.checksum {
+				return fmt.Errorf("expected PutObject %v checksum to be %v, instead got %v",
+					test.algo, test.checksum, got)
+			}
+
+			ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+			head, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
+				Bucket:       &bucket,
+				Key:          &obj,
+				ChecksumMode: types.ChecksumModeEnabled,
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
+			got = getChecksum(head.ChecksumCRC32, head.ChecksumCRC32C, head.ChecksumSHA1, head.ChecksumSHA256)
+			if got != test.checksum {
+				return fmt.Errorf("expected HeadObject %v checksum to be %v, instead got %v",
+					test.algo, test.checksum, got)
+			}
+
+			ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+			out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+				Bucket:       &bucket,
+				Key:          &obj,
+				ChecksumMode: types.ChecksumModeEnabled,
+			})
+			if err != nil {
+				cancel()
+				return err
+			}
+			// the sdk validates the body against the response checksum
+			_, err = io.ReadAll(out.Body)
+			out.Body.Close()
+			cancel()
+			if err != nil {
+				return err
+			}
+			got = getChecksum(out.ChecksumCRC32, out.ChecksumCRC32C, out.ChecksumSHA1, out.ChecksumSHA256)
+			if got != test.checksum {
+				return fmt.Errorf("expected GetObject %v checksum to be %v, instead got %v",
+					test.algo, test.checksum, got)
+			}
+
+			ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+			attrs, err := s3client.GetObjectAttributes(ctx, &s3.GetObjectAttributesInput{
+				Bucket: &bucket,
+				Key:    &obj,
+				ObjectAttributes: []types.ObjectAttributes{
+					types.ObjectAttributesChecksum,
+				},
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
+			if attrs.Checksum == nil {
+				return fmt.Errorf("expected non nil GetObjectAttributes %v checksum", test.algo)
+			}
+			got = getChecksum(attrs.Checksum.ChecksumCRC32, attrs.Checksum.ChecksumCRC32C,
+				attrs.Checksum.ChecksumSHA1, attrs.Checksum.ChecksumSHA256)
+			if got != test.checksum {
+				return fmt.Errorf("expected GetObjectAttributes %v checksum to be %v, instead got %v",
+					test.algo, test.checksum, got)
+			}
+		}
+
+		return nil
+	})
+}
+
+func PutObject_incorrect_checksum(s *S3Conf) error {
+	testName := "PutObject_incorrect_checksum"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-obj"
+		data := []byte("dummy data")
+		// a well formed crc32c checksum of other data
+		checksum := base64.StdEncoding.EncodeToString(
+			crc32.New(crc32.MakeTable(crc32.Castagnoli)).Sum(nil))
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err := s3client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:            &bucket,
+			Key:               &obj,
+			Body:              bytes.NewReader(data),
+			ChecksumAlgorithm: types.ChecksumAlgorithmCrc32c,
+			ChecksumCRC32C:    &checksum,
+		})
+		cancel()
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrBadDigest)); err != nil {
+			return err
+		}
+
+		invalid := "invalid checksum"
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:            &bucket,
+			Key:               &obj,
+			Body:              bytes.NewReader(data),
+			ChecksumAlgorithm: types.ChecksumAlgorithmSha256,
+			ChecksumSHA256:    &invalid,
+		})
+		cancel()
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrInvalidChecksumHeader)); err != nil {
+			return err
+		}
+
+		// the object isn't created by the failed uploads
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		cancel()
+		if err := checkSdkApiErr(err, "NotFound"); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func PutObject_invalid_credentials(s *S3Conf) error {
+	testName := "PutObject_invalid_credentials"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		newconf := *s
+		newconf.awsSecret = newconf.awsSecret + "badpassword"
+		client := newconf.GetClient()
+		_, err := putObjects(s, client, []string{"my-obj"}, bucket)
+		return checkApiErr(err, s3err.GetAPIError(s3err.ErrSignatureDoesNotMatch))
+	})
+}
+
+func PutObject_expected_bucket_owner(s *S3Conf) error {
+	testName := "PutObject_expected_bucket_owner"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-obj"
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err := s3client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:              &bucket,
+			Key:                 &obj,
+			ExpectedBucketOwner: getPtr("incorrect-owner"),
+		})
+		cancel()
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrAccessDenied)); err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.HeadBucket(ctx, &s3.HeadBucketInput{
+			Bucket:              &bucket,
+			ExpectedBucketOwner: getPtr("incorrect-owner"),
+		})
+		cancel()
+		if err := checkSdkApiErr(err, "Forbidden"); err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:              &bucket,
+			Key:                 &obj,
+			ExpectedBucketOwner: &s.awsID,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket:              &bucket,
+			Key:                 &obj,
+			ExpectedBucketOwner: &s.awsID,
+		})
+		cancel()
+		return err
+	})
+}
+
+func PutObject_idempotency_token(s *S3Conf) error {
+	testName := "PutObject_idempotency_token"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-obj"
+		token := "my-idempotency-token"
+
+		client := http.Client{
+			Timeout: s.opTimeout(),
+		}
+
+		putObject := func(body []byte) (string, error) {
+			req, err := createSignedReq(
+				http.MethodPut,
+				s.endpoint,
+				fmt.Sprintf("%v/%v", bucket, obj),
+				s.awsID,
+				s.awsSecret,
+				"s3",
+				s.awsRegion,
+				body,
+				time.Now(),
+				map[string]string{
+					"X-Versity-Idempotency-Token": token,
+				},
+			)
+			if err != nil {
+				return "", err
+			}
+
+			resp, err := client.Do(req)
+			if err != nil {
+				return "", err
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				return "", fmt.Errorf("expected the response status to be %v, instead got %v", http.StatusOK, resp.StatusCode)
+			}
+
+			return resp.Header.Get("ETag"), nil
+		}
+
+		data := []byte("first request data")
+		etag, err := putObject(data)
+		if err != nil {
+			return err
+		}
+
+		// the retried request with the same token must not re-write the object
+		retryEtag, err := putObject([]byte("retried request data"))
+		if err != nil {
+			return err
+		}
+
+		if retryEtag != etag {
+			return fmt.Errorf("expected the retried request etag to be %v, instead got %v", etag, retryEtag)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		defer cancel()
+		if err != nil {
+			return err
+		}
+		defer out.Body.Close()
+
+		if getString(out.ETag) != etag {
+			return fmt.Errorf("expected the object etag to be %v, instead got %v", etag, getString(out.ETag))
+		}
+
+		bdy, err := io.ReadAll(out.Body)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(bdy, data) {
+			return fmt.Errorf("expected the object data to be %q, instead got %q", data, bdy)
+		}
+
+		return nil
+	})
+}
 
 func HeadObject_non_existing_object(s *S3Conf) error {
 	testName := "HeadObject_non_existing_object"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: &bucket,
+			Key:    getPtr("my-obj"),
+		})
+		cancel()
+		if err := checkSdkApiErr(err, "NotFound"); err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+func HeadObject_invalid_part_number(s *S3Conf) error {
+	testName := "HeadObject_invalid_part_number"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		partNumber := int32(-3)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket:     &bucket,
+			Key:        getPtr("my-obj"),
+			PartNumber: &partNumber,
+		})
+		cancel()
+		if err := checkSdkApiErr(err, "BadRequest"); err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+func HeadObject_non_existing_mp(s *S3Conf) error {
+	testName := "HeadObject_non_existing_mp"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		partNumber := int32(4)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket:     &bucket,
+			Key:        getPtr("my-obj"),
+			PartNumber: &partNumber,
+		})
+		cancel()
+		if err := checkSdkApiErr(err, "NotFound"); err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+func HeadObject_mp_success(s *S3Conf) error {
+	testName := "HeadObject_mp_success"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-obj"
+		partCount, partSize := int64(5), int64(1024)
+		partNumber := int32(3)
+
+		mp, err := createMp(s, s3client, bucket, obj)
+		if err != nil {
+			return err
+		}
+
+		parts, _, err := uploadParts(s, s3client, partCount*partSize, partCount, bucket, obj, *mp.UploadId)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket:     &bucket,
+			Key:        &obj,
+			PartNumber: &partNumber,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if *out.ContentLength != int64(partSize) {
+			return fmt.Errorf("expected content length to be %v, instead got %v", partSize, *out.ContentLength)
+		}
+		if *out.ETag != *parts[partNumber-1].ETag {
+			return fmt.Errorf("expected ETag to be %v, instead got %v", *parts[partNumber-1].ETag, *out.ETag)
+		}
+		if *out.PartsCount != int32(partCount) {
+			return fmt.Errorf("expected part count to be %v, instead got %v", partCount, *out.PartsCount)
+		}
+		if out.StorageClass != types.StorageClassStandard {
+			return fmt.Errorf("expected the storage class to be %v, instead got %v", types.StorageClassStandard, out.StorageClass)
+		}
+
+		return nil
+	})
+}
+
+func HeadObject_mp_completed_success(s *S3Conf) error {
+	testName := "HeadObject_mp_completed_success"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj, cType := "my-obj", "application/json"
+		partCount, objSize := int64(4), int64(4*5*1024*1024)
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		mp, err := s3client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+			Bucket:      &bucket,
+			Key:         &obj,
+			ContentType: &cType,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		parts, _, err := uploadParts(s, s3client, objSize, partCount, bucket, obj, *mp.UploadId)
+		if err != nil {
+			return err
+		}
+
+		compParts := []types.CompletedPart{}
+		for _, el := range parts {
+			compParts = append(compParts, types.CompletedPart{
+				ETag:       el.ETag,
+				PartNumber: el.PartNumber,
+			})
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		res, err := s3client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:   &bucket,
+			Key:      &obj,
+			UploadId: mp.UploadId,
+			MultipartUpload: &types.CompletedMultipartUpload{
+				Parts: compParts,
+			},
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if getString(out.ETag) != getString(res.ETag) {
+			return fmt.Errorf("expected ETag to be %v, instead got %v", getString(res.ETag), getString(out.ETag))
+		}
+		etagSuffix := fmt.Sprintf("-%v", partCount)
+		if !strings.HasSuffix(strings.Trim(getString(out.ETag), `"`), etagSuffix) {
+			return fmt.Errorf("expected multipart ETag %v to end with %v", getString(out.ETag), etagSuffix)
+		}
+		if out.ContentLength == nil || *out.ContentLength != objSize {
+			return fmt.Errorf("expected content length to be %v, instead got %v", objSize, out.ContentLength)
+		}
+		if getString(out.ContentType) != cType {
+			return fmt.Errorf("expected content type to be %v, instead got %v", cType, getString(out.ContentType))
+		}
+		if out.PartsCount != nil && *out.PartsCount != int32(partCount) {
+			return fmt.Errorf("expected part count to be %v, instead got %v", partCount, *out.PartsCount)
+		}
+		if out.StorageClass != types.StorageClassStandard {
+			return fmt.Errorf("expected the storage class to be %v, instead got %v", types.StorageClassStandard, out.StorageClass)
+		}
+
+		return nil
+	})
+}
+
+func HeadObject_non_existing_dir_object(s *S3Conf) error {
+	testName := "HeadObject_non_existing_dir_object"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj, dataLen := "my-obj", int64(1234567)
+		meta := map[string]string{
+			"key1": "val1",
+			"key2": "val2",
+		}
+
+		_, err := putObjectWithData(s, dataLen, &s3.PutObjectInput{
+			Bucket:   &bucket,
+			Key:      &obj,
+			Metadata: meta,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+
+		obj = "my-obj/"
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		defer cancel()
+		if err := checkSdkApiErr(err, "NotFound"); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func HeadObject_directory_object_noslash(s *S3Conf) error {
+	testName := "HeadObject_directory_object_noslash"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-obj/"
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err := s3client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		obj = "my-obj"
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.HeadObject(ctx, &s3.HeadObjectInput{
 			Bucket: &bucket,
-			Key:    getPtr("my-obj"),
+			Key:    &obj,
 		})
 		cancel()
 		if err := checkSdkApiErr(err, "NotFound"); err != nil {
 			return err
 		}
+
 		return nil
 	})
 }
 
-func HeadObject_invalid_part_number(s *S3Conf) error {
-	testName := "HeadObject_invalid_part_number"
+const defaultContentType = "binary/octet-stream"
+
+func HeadObject_with_contenttype(s *S3Conf) error {
+	testName := "HeadObject_with_contenttype"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		partNumber := int32(-3)
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		_, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
-			Bucket:     &bucket,
-			Key:        getPtr("my-obj"),
-			PartNumber: &partNumber,
+		obj, dataLen := "my-obj", int64(1234567)
+		contentType := "text/plain"
+		contentEncoding := "gzip"
+
+		_, err := putObjectWithData(s, dataLen, &s3.PutObjectInput{
+			Bucket:          &bucket,
+			Key:             &obj,
+			ContentType:     &contentType,
+			ContentEncoding: &contentEncoding,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		defer cancel()
+		if err != nil {
+			return err
+		}
+
+		contentLength := int64(0)
+		if out.ContentLength != nil {
+			contentLength = *out.ContentLength
+		}
+		if contentLength != dataLen {
+			return fmt.Errorf("expected data length %v, instead got %v", dataLen, contentLength)
+		}
+		if out.ContentType == nil {
+			return fmt.Errorf("expected content type %v, instead got nil", contentType)
+		}
+		if *out.ContentType != contentType {
+			return fmt.Errorf("expected content type %v, instead got %v", contentType, *out.ContentType)
+		}
+		if out.ContentEncoding == nil {
+			return fmt.Errorf("expected content encoding %v, instead got nil", contentEncoding)
+		}
+		if *out.ContentEncoding != contentEncoding {
+			return fmt.Errorf("expected content encoding %v, instead got %v", contentEncoding, *out.ContentEncoding)
+		}
+		if out.StorageClass != types.StorageClassStandard {
+			return fmt.Errorf("expected the storage class to be %v, instead got %v", types.StorageClassStandard, out.StorageClass)
+		}
+
+		return nil
+	})
+}
+
+func HeadObject_success(s *S3Conf) error {
+	testName := "HeadObject_success"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj, dataLen := "my-obj", int64(1234567)
+		meta := map[string]string{
+			"key1": "val1",
+			"key2": "val2",
+		}
+		ctype := defaultContentType
+
+		_, err := putObjectWithData(s, dataLen, &s3.PutObjectInput{
+			Bucket:      &bucket,
+			Key:         &obj,
+			Metadata:    meta,
+			ContentType: &ctype,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		defer cancel()
+		if err != nil {
+			return err
+		}
+
+		var c checker
+		if !areMapsSame(out.Metadata, meta) {
+			c.errorf("incorrect object metadata")
+		}
+		contentLength := int64(0)
+		if out.ContentLength != nil {
+			contentLength = *out.ContentLength
+		}
+		if contentLength != dataLen {
+			c.errorf("expected data length %v, instead got %v", dataLen, contentLength)
+		}
+		if getString(out.ContentType) != defaultContentType {
+			c.errorf("expected content type %v, instead got %v", defaultContentType, getString(out.ContentType))
+		}
+		if out.StorageClass != types.StorageClassStandard {
+			c.errorf("expected the storage class to be %v, instead got %v", types.StorageClassStandard, out.StorageClass)
+		}
+
+		return c.err()
+	})
+}
+
+func GetObjectAttributes_non_existing_bucket(s *S3Conf) error {
+	testName := "GetObjectAttributes_non_existing_bucket"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err := s3client.GetObjectAttributes(ctx, &s3.GetObjectAttributesInput{
+			Bucket:           getPtr(getBucketName()),
+			Key:              getPtr("my-obj"),
+			ObjectAttributes: []types.ObjectAttributes{},
 		})
 		cancel()
-		if err := checkSdkApiErr(err, "BadRequest"); err != nil {
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrNoSuchBucket)); err != nil {
 			return err
 		}
+
 		return nil
 	})
 }
 
-func HeadObject_non_existing_mp(s *S3Conf) error {
-	testName := "HeadObject_non_existing_mp"
+func GetObjectAttributes_non_existing_object(s *S3Conf) error {
+	testName := "GetObjectAttributes_non_existing_object"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		partNumber := int32(4)
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		_, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
-			Bucket:     &bucket,
-			Key:        getPtr("my-obj"),
-			PartNumber: &partNumber,
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err := s3client.GetObjectAttributes(ctx, &s3.GetObjectAttributesInput{
+			Bucket:           &bucket,
+			Key:              getPtr("my-obj"),
+			ObjectAttributes: []types.ObjectAttributes{},
 		})
 		cancel()
-		if err := checkSdkApiErr(err, "NotFound"); err != nil {
+		if err := checkSdkApiErr(err, "NoSuchKey"); err != nil {
 			return err
 		}
+
 		return nil
 	})
 }
 
-func HeadObject_mp_success(s *S3Conf) error {
-	testName := "HeadObject_mp_success"
+func GetObjectAttributes_existing_object(s *S3Conf) error {
+	testName := "GetObjectAttributes_existing_object"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		obj := "my-obj"
-		partCount, partSize := int64(5), int64(1024)
-		partNumber := int32(3)
+		obj, data_len := "my-obj", int64(45679)
+		data := make([]byte, data_len)
+
+		_, err := rand.Read(data)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		resp, err := s3client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+			Body:   bytes.NewReader(data),
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.GetObjectAttributes(ctx, &s3.GetObjectAttributesInput{
+			Bucket: &bucket,
+			Key:    &obj,
+			ObjectAttributes: []types.ObjectAttributes{
+				types.ObjectAttributesEtag,
+				types.ObjectAttributesObjectSize,
+				types.ObjectAttributesStorageClass,
+			},
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		var c checker
+		if resp.ETag == nil || out.ETag == nil {
+			c.errorf("nil ETag output")
+		} else if *resp.ETag != *out.ETag {
+			c.errorf("expected ETag to be %v, instead got %v", *resp.ETag, *out.ETag)
+		}
+		if out.ObjectSize == nil {
+			c.errorf("nil object size output")
+		} else if *out.ObjectSize != data_len {
+			c.errorf("expected object size to be %v, instead got %v", data_len, *out.ObjectSize)
+		}
+		if out.Checksum != nil {
+			c.errorf("expected checksum do be nil, instead got %v", *out.Checksum)
+		}
+		if out.StorageClass != types.StorageClassStandard {
+			c.errorf("expected the storage class to be %v, instead got %v", types.StorageClassStandard, out.StorageClass)
+		}
+
+		return c.err()
+	})
+}
+func GetObjectAttributes_object_parts(s *S3Conf) error {
+	testName := "GetObjectAttributes_object_parts"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		attrs := []types.ObjectAttributes{
+			types.ObjectAttributesEtag,
+			types.ObjectAttributesObjectSize,
+			types.ObjectAttributesObjectParts,
+		}
+
+		single := "single-part-obj"
+		r, err := putObjectWithData(s, 1024, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &single,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.GetObjectAttributes(ctx, &s3.GetObjectAttributesInput{
+			Bucket:           &bucket,
+			Key:              &single,
+			ObjectAttributes: attrs,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		// the attributes etag isn't quoted
+		if strings.Trim(getString(out.ETag), "\"") != strings.Trim(getString(r.res.ETag), "\"") {
+			return fmt.Errorf("expected ETag to be %v, instead got %v", getString(r.res.ETag), getString(out.ETag))
+		}
+		if out.ObjectSize == nil {
+			return fmt.Errorf("nil object size output")
+		}
+		if *out.ObjectSize != 1024 {
+			return fmt.Errorf("expected object size to be 1024, instead got %v", *out.ObjectSize)
+		}
+		if out.ObjectParts != nil {
+			return fmt.Errorf("expected no object parts for a single part object, instead got %+v", *out.ObjectParts)
+		}
+
+		obj := "multipart-obj"
+		mp, err := createMp(s, s3client, bucket, obj)
+		if err != nil {
+			return err
+		}
+
+		// only the last part may be smaller than 5MiB
+		sizes := []int64{5 * 1024 * 1024, 5 * 1024 * 1024, 1024 * 1024}
+		var objSize int64
+		compParts := []types.CompletedPart{}
+		for i, size := range sizes {
+			partNumber := int32(i + 1)
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			pres, err := s3client.UploadPart(ctx, &s3.UploadPartInput{
+				Bucket:     &bucket,
+				Key:        &obj,
+				UploadId:   mp.UploadId,
+				PartNumber: &partNumber,
+				Body:       bytes.NewReader(make([]byte, size)),
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
+			compParts = append(compParts, types.CompletedPart{
+				ETag:       pres.ETag,
+				PartNumber: &partNumber,
+			})
+			objSize += size
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		res, err := s3client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:   &bucket,
+			Key:      &obj,
+			UploadId: mp.UploadId,
+			MultipartUpload: &types.CompletedMultipartUpload{
+				Parts: compParts,
+			},
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		out, err = s3client.GetObjectAttributes(ctx, &s3.GetObjectAttributesInput{
+			Bucket:           &bucket,
+			Key:              &obj,
+			ObjectAttributes: attrs,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if strings.Trim(getString(out.ETag), "\"") != strings.Trim(getString(res.ETag), "\"") {
+			return fmt.Errorf("expected ETag to be %v, instead got %v", getString(res.ETag), getString(out.ETag))
+		}
+		if out.ObjectSize == nil {
+			return fmt.Errorf("nil object size output")
+		}
+		if *out.ObjectSize != objSize {
+			return fmt.Errorf("expected object size to be %v, instead got %v", objSize, *out.ObjectSize)
+		}
+		if out.ObjectParts == nil {
+			return fmt.Errorf("expected the object parts of a multipart object")
+		}
+		if out.ObjectParts.TotalPartsCount == nil {
+			return fmt.Errorf("nil parts count output")
+		}
+		if *out.ObjectParts.TotalPartsCount != int32(len(sizes)) {
+			return fmt.Errorf("expected the parts count to be %v, instead got %v", len(sizes), *out.ObjectParts.TotalPartsCount)
+		}
+		if len(out.ObjectParts.Parts) != len(sizes) {
+			return fmt.Errorf("expected %v parts, instead got %v", len(sizes), len(out.ObjectParts.Parts))
+		}
+		for i, part := range out.ObjectParts.Parts {
+			if part.PartNumber == nil || part.Size == nil {
+				return fmt.Errorf("nil part number or size output for part %v", i+1)
+			}
+			if *part.PartNumber != int32(i+1) {
+				return fmt.Errorf("expected part number to be %v, instead got %v", i+1, *part.PartNumber)
+			}
+			if *part.Size != sizes[i] {
+				return fmt.Errorf("expected part %v size to be %v, instead got %v", i+1, sizes[i], *part.Size)
+			}
+		}
 
-		mp, err := createMp(s3client, bucket, obj)
+		// page through the parts
+		maxParts := int32(2)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		out, err = s3client.GetObjectAttributes(ctx, &s3.GetObjectAttributesInput{
+			Bucket:           &bucket,
+			Key:              &obj,
+			ObjectAttributes: attrs,
+			MaxParts:         &maxParts,
+		})
+		cancel()
 		if err != nil {
 			return err
 		}
 
-		parts, _, err := uploadParts(s3client, partCount*partSize, partCount, bucket, obj, *mp.UploadId)
-		if err != nil {
-			return err
+		if out.ObjectParts == nil || len(out.ObjectParts.Parts) != 2 {
+			return fmt.Errorf("expected 2 parts in the first page, instead got %+v", out.ObjectParts)
+		}
+		if out.ObjectParts.IsTruncated == nil || !*out.ObjectParts.IsTruncated {
+			return fmt.Errorf("expected the first page of parts to be truncated")
+		}
+		if getString(out.ObjectParts.NextPartNumberMarker) != "2" {
+			return fmt.Errorf("expected the next part number marker to be 2, instead got %v", getString(out.ObjectParts.NextPartNumberMarker))
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		out, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
-			Bucket:     &bucket,
-			Key:        &obj,
-			PartNumber: &partNumber,
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		out, err = s3client.GetObjectAttributes(ctx, &s3.GetObjectAttributesInput{
+			Bucket:           &bucket,
+			Key:              &obj,
+			ObjectAttributes: attrs,
+			MaxParts:         &maxParts,
+			PartNumberMarker: getPtr("2"),
 		})
 		cancel()
 		if err != nil {
 			return err
 		}
 
-		if *out.ContentLength != int64(partSize) {
-			return fmt.Errorf("expected content length to be %v, instead got %v", partSize, *out.ContentLength)
-		}
-		if *out.ETag != *parts[partNumber-1].ETag {
-			return fmt.Errorf("expected ETag to be %v, instead got %v", *parts[partNumber-1].ETag, *out.ETag)
+		if out.ObjectParts == nil || len(out.ObjectParts.Parts) != 1 {
+			return fmt.Errorf("expected 1 part in the last page, instead got %+v", out.ObjectParts)
 		}
-		if *out.PartsCount != int32(partCount) {
-			return fmt.Errorf("expected part count to be %v, instead got %v", partCount, *out.PartsCount)
+		if out.ObjectParts.IsTruncated != nil && *out.ObjectParts.IsTruncated {
+			return fmt.Errorf("expected the last page of parts to not be truncated")
 		}
-		if out.StorageClass != types.StorageClassStandard {
-			return fmt.Errorf("expected the storage class to be %v, instead got %v", types.StorageClassStandard, out.StorageClass)
+		if *out.ObjectParts.Parts[0].PartNumber != 3 || *out.ObjectParts.Parts[0].Size != sizes[2] {
+			return fmt.Errorf("expected the last part to be 3 with size %v, instead got %v with size %v",
+				sizes[2], *out.ObjectParts.Parts[0].PartNumber, *out.ObjectParts.Parts[0].Size)
 		}
 
 		return nil
 	})
 }
 
-func HeadObject_non_existing_dir_object(s *S3Conf) error {
-	testName := "HeadObject_non_existing_dir_object"
+func GetObject_non_existing_key(s *S3Conf) error {
+	testName := "GetObject_non_existing_key"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		obj, dataLen := "my-obj", int64(1234567)
-		meta := map[string]string{
-			"key1": "val1",
-			"key2": "val2",
-		}
-
-		_, err := putObjectWithData(dataLen, &s3.PutObjectInput{
-			Bucket:   &bucket,
-			Key:      &obj,
-			Metadata: meta,
-		}, s3client)
-		if err != nil {
-			return err
-		}
-
-		obj = "my-obj/"
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		_, err = s3client.HeadObject(ctx, &s3.HeadObjectInput{
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err := s3client.GetObject(ctx, &s3.GetObjectInput{
 			Bucket: &bucket,
-			Key:    &obj,
+			Key:    getPtr("non-existing-key"),
 		})
-		defer cancel()
-		if err := checkSdkApiErr(err, "NotFound"); err != nil {
+		cancel()
+		var bae *types.NoSuchKey
+		if !errors.As(err, &bae) {
 			return err
 		}
-
 		return nil
 	})
 }
 
-func HeadObject_directory_object_noslash(s *S3Conf) error {
-	testName := "HeadObject_directory_object_noslash"
+func GetObject_directory_object_noslash(s *S3Conf) error {
+	testName := "GetObject_directory_object_noslash"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj/"
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObject(ctx, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -3082,98 +5033,122 @@ func HeadObject_directory_object_noslash(s *S3Conf) error {
 		}
 
 		obj = "my-obj"
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
-		_, err = s3client.HeadObject(ctx, &s3.HeadObjectInput{
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.GetObject(ctx, &s3.GetObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		})
 		cancel()
-		if err := checkSdkApiErr(err, "NotFound"); err != nil {
+		var bae *types.NoSuchKey
+		if !errors.As(err, &bae) {
 			return err
 		}
-
 		return nil
 	})
 }
 
-const defaultContentType = "binary/octet-stream"
-
-func HeadObject_with_contenttype(s *S3Conf) error {
-	testName := "HeadObject_with_contenttype"
+func GetObject_invalid_ranges(s *S3Conf) error {
+	testName := "GetObject_invalid_ranges"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		obj, dataLen := "my-obj", int64(1234567)
-		contentType := "text/plain"
-		contentEncoding := "gzip"
+		dataLength, obj := int64(1234567), "my-obj"
 
-		_, err := putObjectWithData(dataLen, &s3.PutObjectInput{
-			Bucket:          &bucket,
-			Key:             &obj,
-			ContentType:     &contentType,
-			ContentEncoding: &contentEncoding,
+		_, err := putObjectWithData(s, dataLength, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
 		}, s3client)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		out, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
+		// the malformed ranges are ignored, and the whole object returned
+		for _, rng := range []string{"bytes=abc", "bytes=invalid-range", "bytes=33-10"} {
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			resp, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+				Bucket: &bucket,
+				Key:    &obj,
+				Range:  &rng,
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
+			resp.Body.Close()
+
+			if *resp.ContentLength != dataLength {
+				return fmt.Errorf("%v: expected content-length to be %v, instead got %v", rng, dataLength, *resp.ContentLength)
+			}
+			if getString(resp.ContentRange) != "" {
+				return fmt.Errorf("%v: expected empty content range, instead got %v", rng, getString(resp.ContentRange))
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		resp, err := s3client.GetObject(ctx, &s3.GetObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
+			Range:  getPtr("bytes=1500-999999999999"),
 		})
-		defer cancel()
+		cancel()
 		if err != nil {
 			return err
 		}
 
-		contentLength := int64(0)
-		if out.ContentLength != nil {
-			contentLength = *out.ContentLength
-		}
-		if contentLength != dataLen {
-			return fmt.Errorf("expected data length %v, instead got %v", dataLen, contentLength)
-		}
-		if out.ContentType == nil {
-			return fmt.Errorf("expected content type %v, instead got nil", contentType)
+		if *resp.ContentLength != dataLength-1500 {
+			return fmt.Errorf("expected content-length to be %v, instead got %v", dataLength-1500, *resp.ContentLength)
 		}
-		if *out.ContentType != contentType {
-			return fmt.Errorf("expected content type %v, instead got %v", contentType, *out.ContentType)
+		return nil
+	})
+}
+
+func GetObject_with_meta(s *S3Conf) error {
+	testName := "GetObject_with_meta"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-obj"
+		meta := map[string]string{
+			"key1": "val1",
+			"key2": "val2",
 		}
-		if out.ContentEncoding == nil {
-			return fmt.Errorf("expected content encoding %v, instead got nil", contentEncoding)
+
+		_, err := putObjectWithData(s, 0, &s3.PutObjectInput{Bucket: &bucket, Key: &obj, Metadata: meta}, s3client)
+		if err != nil {
+			return err
 		}
-		if *out.ContentEncoding != contentEncoding {
-			return fmt.Errorf("expected content encoding %v, instead got %v", contentEncoding, *out.ContentEncoding)
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		defer cancel()
+		if err != nil {
+			return err
 		}
-		if out.StorageClass != types.StorageClassStandard {
-			return fmt.Errorf("expected the storage class to be %v, instead got %v", types.StorageClassStandard, out.StorageClass)
+
+		if !areMapsSame(out.Metadata, meta) {
+			return fmt.Errorf("incorrect object metadata")
 		}
 
 		return nil
 	})
 }
 
-func HeadObject_success(s *S3Conf) error {
-	testName := "HeadObject_success"
+func GetObject_success(s *S3Conf) error {
+	testName := "GetObject_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		obj, dataLen := "my-obj", int64(1234567)
-		meta := map[string]string{
-			"key1": "val1",
-			"key2": "val2",
-		}
+		dataLength, obj := int64(1234567), "my-obj"
 		ctype := defaultContentType
 
-		_, err := putObjectWithData(dataLen, &s3.PutObjectInput{
+		r, err := putObjectWithData(s, dataLength, &s3.PutObjectInput{
 			Bucket:      &bucket,
 			Key:         &obj,
-			Metadata:    meta,
 			ContentType: &ctype,
 		}, s3client)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		out, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		})
@@ -3181,176 +5156,201 @@ func HeadObject_success(s *S3Conf) error {
 		if err != nil {
 			return err
 		}
+		defer out.Body.Close()
 
-		if !areMapsSame(out.Metadata, meta) {
-			return fmt.Errorf("incorrect object metadata")
+		var c checker
+		if out.ContentLength == nil || *out.ContentLength != dataLength {
+			c.errorf("expected content-length %v, instead got %v", dataLength, out.ContentLength)
 		}
-		contentLength := int64(0)
-		if out.ContentLength != nil {
-			contentLength = *out.ContentLength
-		}
-		if contentLength != dataLen {
-			return fmt.Errorf("expected data length %v, instead got %v", dataLen, contentLength)
-		}
-		if *out.ContentType != defaultContentType {
-			return fmt.Errorf("expected content type %v, instead got %v", defaultContentType, *out.ContentType)
+		if getString(out.ContentType) != defaultContentType {
+			c.errorf("expected content type %v, instead got %v", defaultContentType, getString(out.ContentType))
 		}
 		if out.StorageClass != types.StorageClassStandard {
-			return fmt.Errorf("expected the storage class to be %v, instead got %v", types.StorageClassStandard, out.StorageClass)
+			c.errorf("expected the storage class to be %v, instead got %v", types.StorageClassStandard, out.StorageClass)
 		}
 
-		return nil
+		bdy, err := io.ReadAll(out.Body)
+		if err != nil {
+			c.errorf("read object data: %w", err)
+		} else if sha256.Sum256(bdy) != r.csum {
+			c.errorf("invalid object data")
+		}
+
+		return c.err()
 	})
 }
 
-func GetObjectAttributes_non_existing_bucket(s *S3Conf) error {
-	testName := "GetObjectAttributes_non_existing_bucket"
+const directoryContentType = "application/x-directory"
+
+func GetObject_directory_success(s *S3Conf) error {
+	testName := "GetObject_directory_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		_, err := s3client.GetObjectAttributes(ctx, &s3.GetObjectAttributesInput{
-			Bucket:           getPtr(getBucketName()),
-			Key:              getPtr("my-obj"),
-			ObjectAttributes: []types.ObjectAttributes{},
-		})
-		cancel()
-		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrNoSuchBucket)); err != nil {
+		dataLength, obj := int64(0), "my-dir/"
+
+		_, err := putObjectWithData(s, dataLength, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		}, s3client)
+		if err != nil {
 			return err
 		}
 
-		return nil
-	})
-}
-
-func GetObjectAttributes_non_existing_object(s *S3Conf) error {
-	testName := "GetObjectAttributes_non_existing_object"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		_, err := s3client.GetObjectAttributes(ctx, &s3.GetObjectAttributesInput{
-			Bucket:           &bucket,
-			Key:              getPtr("my-obj"),
-			ObjectAttributes: []types.ObjectAttributes{},
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
 		})
-		cancel()
-		if err := checkSdkApiErr(err, "NoSuchKey"); err != nil {
+		defer cancel()
+		if err != nil {
 			return err
 		}
+		if *out.ContentLength != dataLength {
+			return fmt.Errorf("expected content-length %v, instead got %v", dataLength, out.ContentLength)
+		}
+		if *out.ContentType != directoryContentType {
+			return fmt.Errorf("expected content type %v, instead got %v", directoryContentType, *out.ContentType)
+		}
+		if out.StorageClass != types.StorageClassStandard {
+			return fmt.Errorf("expected the storage class to be %v, instead got %v", types.StorageClassStandard, out.StorageClass)
+		}
 
+		out.Body.Close()
 		return nil
 	})
 }
 
-func GetObjectAttributes_existing_object(s *S3Conf) error {
-	testName := "GetObjectAttributes_existing_object"
+func GetObject_directory_marker_zero_bytes(s *S3Conf) error {
+	testName := "GetObject_directory_marker_zero_bytes"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		obj, data_len := "my-obj", int64(45679)
-		data := make([]byte, data_len)
+		obj := "my-dir/"
+		emptySum := md5.Sum(nil)
+		emptyETag := hex.EncodeToString(emptySum[:])
 
-		_, err := rand.Read(data)
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		resp, err := s3client.PutObject(ctx, &s3.PutObjectInput{
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
-			Body:   bytes.NewReader(data),
 		})
-		cancel()
+		defer cancel()
 		if err != nil {
 			return err
 		}
+		defer out.Body.Close()
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
-		out, err := s3client.GetObjectAttributes(ctx, &s3.GetObjectAttributesInput{
+		bdy, err := io.ReadAll(out.Body)
+		if err != nil {
+			return err
+		}
+		if len(bdy) != 0 {
+			return fmt.Errorf("expected empty directory marker body, instead got %v bytes", len(bdy))
+		}
+		if strings.Trim(getString(out.ETag), `"`) != emptyETag {
+			return fmt.Errorf("expected the directory marker etag to be %v, instead got %v",
+				emptyETag, getString(out.ETag))
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		head, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
-			ObjectAttributes: []types.ObjectAttributes{
-				types.ObjectAttributesEtag,
-				types.ObjectAttributesObjectSize,
-				types.ObjectAttributesStorageClass,
-			},
 		})
 		cancel()
 		if err != nil {
 			return err
 		}
-
-		if resp.ETag == nil || out.ETag == nil {
-			return fmt.Errorf("nil ETag output")
-		}
-		if *resp.ETag != *out.ETag {
-			return fmt.Errorf("expected ETag to be %v, instead got %v", *resp.ETag, *out.ETag)
-		}
-		if out.ObjectSize == nil {
-			return fmt.Errorf("nil object size output")
-		}
-		if *out.ObjectSize != data_len {
-			return fmt.Errorf("expected object size to be %v, instead got %v", data_len, *out.ObjectSize)
-		}
-		if out.Checksum != nil {
-			return fmt.Errorf("expected checksum do be nil, instead got %v", *out.Checksum)
+		if head.ContentLength == nil || *head.ContentLength != 0 {
+			return fmt.Errorf("expected the directory marker content-length to be 0, instead got %v",
+				head.ContentLength)
 		}
-		if out.StorageClass != types.StorageClassStandard {
-			return fmt.Errorf("expected the storage class to be %v, instead got %v", types.StorageClassStandard, out.StorageClass)
+		if getString(head.ETag) != getString(out.ETag) {
+			return fmt.Errorf("expected the head etag to be %v, instead got %v",
+				getString(out.ETag), getString(head.ETag))
 		}
 
 		return nil
 	})
 }
+func GetObject_zero_bytes(s *S3Conf) error {
+	testName := "GetObject_zero_bytes"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-obj"
+		emptySum := md5.Sum(nil)
+		emptyETag := hex.EncodeToString(emptySum[:])
+
+		_, err := putObjectWithData(s, 0, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		}, s3client)
+		if err != nil {
+			return err
+		}
 
-func GetObject_non_existing_key(s *S3Conf) error {
-	testName := "GetObject_non_existing_key"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		_, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		head, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
 			Bucket: &bucket,
-			Key:    getPtr("non-existing-key"),
+			Key:    &obj,
 		})
 		cancel()
-		var bae *types.NoSuchKey
-		if !errors.As(err, &bae) {
+		if err != nil {
 			return err
 		}
-		return nil
-	})
-}
+		if head.ContentLength == nil || *head.ContentLength != 0 {
+			return fmt.Errorf("expected zero content length, instead got %v", head.ContentLength)
+		}
+		// azure only has md5 etags in the md5 etags mode
+		if (!s.azureTests || s.md5ETags) && strings.Trim(getString(head.ETag), `"`) != emptyETag {
+			return fmt.Errorf("expected the etag to be %v, instead got %v", emptyETag, getString(head.ETag))
+		}
 
-func GetObject_directory_object_noslash(s *S3Conf) error {
-	testName := "GetObject_directory_object_noslash"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		obj := "my-obj/"
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		_, err := s3client.PutObject(ctx, &s3.PutObjectInput{
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		})
-		cancel()
+		defer cancel()
 		if err != nil {
 			return err
 		}
+		defer out.Body.Close()
 
-		obj = "my-obj"
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		if out.ContentLength == nil || *out.ContentLength != 0 {
+			return fmt.Errorf("expected zero content length, instead got %v", out.ContentLength)
+		}
+		bdy, err := io.ReadAll(out.Body)
+		if err != nil {
+			return err
+		}
+		if len(bdy) != 0 {
+			return fmt.Errorf("expected empty body, instead got %v bytes", len(bdy))
+		}
+
+		// no range can be satisfied on an empty object
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.GetObject(ctx, &s3.GetObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
+			Range:  getPtr("bytes=0-"),
 		})
 		cancel()
-		var bae *types.NoSuchKey
-		if !errors.As(err, &bae) {
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrInvalidRange)); err != nil {
 			return err
 		}
+
 		return nil
 	})
 }
 
-func GetObject_invalid_ranges(s *S3Conf) error {
-	testName := "GetObject_invalid_ranges"
+func GetObject_by_range_success(s *S3Conf) error {
+	testName := "GetObject_by_range_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		dataLength, obj := int64(1234567), "my-obj"
 
-		_, err := putObjectWithData(dataLength, &s3.PutObjectInput{
+		r, err := putObjectWithData(s, dataLength, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		}, s3client)
@@ -3358,274 +5358,536 @@ func GetObject_invalid_ranges(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		_, err = s3client.GetObject(ctx, &s3.GetObjectInput{
+		rangeString := "bytes=100-200"
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
-			Range:  getPtr("bytes=invalid-range"),
+			Range:  &rangeString,
 		})
-		cancel()
-		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrInvalidRange)); err != nil {
+		defer cancel()
+		if err != nil {
 			return err
 		}
+		defer out.Body.Close()
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
-		_, err = s3client.GetObject(ctx, &s3.GetObjectInput{
-			Bucket: &bucket,
-			Key:    &obj,
-			Range:  getPtr("bytes=33-10"),
-		})
-		cancel()
-		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrInvalidRange)); err != nil {
+		if getString(out.ContentRange) != fmt.Sprintf("bytes 100-200/%v", dataLength) {
+			return fmt.Errorf("expected content range: %v, instead got: %v", fmt.Sprintf("bytes 100-200/%v", dataLength), getString(out.ContentRange))
+		}
+		if getString(out.AcceptRanges) != rangeString {
+			return fmt.Errorf("expected accept range: %v, instead got: %v", rangeString, getString(out.AcceptRanges))
+		}
+		b, err := io.ReadAll(out.Body)
+		if err != nil && !errors.Is(err, io.EOF) {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
-		resp, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+		// bytes range is inclusive, go range for second value is not
+		if !isEqual(b, r.data[100:201]) {
+			return fmt.Errorf("data mismatch of range")
+		}
+
+		rangeString = "bytes=100-"
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		out, err = s3client.GetObject(ctx, &s3.GetObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
-			Range:  getPtr("bytes=1500-999999999999"),
+			Range:  &rangeString,
 		})
-		cancel()
+		defer cancel()
 		if err != nil {
 			return err
 		}
+		defer out.Body.Close()
 
-		if *resp.ContentLength != dataLength-1500 {
-			return fmt.Errorf("expected content-length to be %v, instead got %v", dataLength-1500, *resp.ContentLength)
+		b, err = io.ReadAll(out.Body)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		// bytes range is inclusive, go range for second value is not
+		if !isEqual(b, r.data[100:]) {
+			return fmt.Errorf("data mismatch of range")
 		}
 		return nil
 	})
 }
 
-func GetObject_with_meta(s *S3Conf) error {
-	testName := "GetObject_with_meta"
+func GetObject_by_range_resp_status(s *S3Conf) error {
+	testName := "GetObject_by_range_resp_status"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		obj := "my-obj"
-		meta := map[string]string{
-			"key1": "val1",
-			"key2": "val2",
+		obj, dLen := "my-obj", int64(4000)
+		_, err := putObjectWithData(s, dLen, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+
+		req, err := createSignedReq(
+			http.MethodGet,
+			s.endpoint,
+			fmt.Sprintf("%v/%v", bucket, obj),
+			s.awsID,
+			s.awsSecret,
+			"s3",
+			s.awsRegion,
+			nil,
+			time.Now(),
+			map[string]string{
+				"Range": "bytes=100-200",
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		client := http.Client{
+			Timeout: s.opTimeout(),
 		}
 
-		_, err := putObjectWithData(0, &s3.PutObjectInput{Bucket: &bucket, Key: &obj, Metadata: meta}, s3client)
+		resp, err := client.Do(req)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusPartialContent {
+			return fmt.Errorf("expected response status to be %v, instead got %v", http.StatusPartialContent, resp.StatusCode)
+		}
+
+		expectedRange := fmt.Sprintf("bytes 100-200/%v", dLen)
+		if contentRange := resp.Header.Get("Content-Range"); contentRange != expectedRange {
+			return fmt.Errorf("expected the content range to be %v, instead got %v", expectedRange, contentRange)
+		}
+		if resp.ContentLength != 101 {
+			return fmt.Errorf("expected the content length to be %v, instead got %v", 101, resp.ContentLength)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		if len(body) != 101 {
+			return fmt.Errorf("expected the response body length to be %v, instead got %v", 101, len(body))
+		}
+
+		return nil
+	})
+}
+func GetObject_suffix_and_open_ended_ranges(s *S3Conf) error {
+	testName := "GetObject_suffix_and_open_ended_ranges"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj, dLen := "my-obj", int64(10000)
+		r, err := putObjectWithData(s, dLen, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
-		})
-		defer cancel()
+		}, s3client)
 		if err != nil {
 			return err
 		}
 
-		if !areMapsSame(out.Metadata, meta) {
-			return fmt.Errorf("incorrect object metadata")
+		client := http.Client{
+			Timeout: s.opTimeout(),
+		}
+
+		for _, test := range []struct {
+			rng          string
+			status       int
+			contentRange string
+			start, end   int64
+		}{
+			{"bytes=-500", http.StatusPartialContent, "bytes 9500-9999/10000", 9500, 10000},
+			{"bytes=-20000", http.StatusPartialContent, "bytes 0-9999/10000", 0, 10000},
+			{"bytes=9000-", http.StatusPartialContent, "bytes 9000-9999/10000", 9000, 10000},
+			{"bytes=9999-", http.StatusPartialContent, "bytes 9999-9999/10000", 9999, 10000},
+			{"bytes=999999-", http.StatusRequestedRangeNotSatisfiable, "bytes */10000", 0, 0},
+			{"bytes=10000-10010", http.StatusRequestedRangeNotSatisfiable, "bytes */10000", 0, 0},
+			{"bytes=abc", http.StatusOK, "", 0, 10000},
+		} {
+			req, err := createSignedReq(http.MethodGet, s.endpoint, fmt.Sprintf("%v/%v", bucket, obj), s.awsID, s.awsSecret, "s3", s.awsRegion, nil, time.Now(), map[string]string{
+				"Range": test.rng,
+			})
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Do(req)
+			if err != nil {
+				return err
+			}
+			body, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return err
+			}
+
+			if resp.StatusCode != test.status {
+				return fmt.Errorf("%v: expected response status to be %v, instead got %v", test.rng, test.status, resp.StatusCode)
+			}
+			if contentRange := resp.Header.Get("Content-Range"); contentRange != test.contentRange {
+				return fmt.Errorf("%v: expected the content range to be %v, instead got %v", test.rng, test.contentRange, contentRange)
+			}
+
+			if test.status == http.StatusRequestedRangeNotSatisfiable {
+				var errResp s3err.APIErrorResponse
+				err = xml.Unmarshal(body, &errResp)
+				if err != nil {
+					return err
+				}
+				if errResp.Code != "InvalidRange" {
+					return fmt.Errorf("%v: expected error code to be InvalidRange, instead got %v", test.rng, errResp.Code)
+				}
+				continue
+			}
+
+			if !isEqual(body, r.data[test.start:test.end]) {
+				return fmt.Errorf("%v: expected the response body to be bytes %v-%v of the object", test.rng, test.start, test.end-1)
+			}
 		}
 
 		return nil
 	})
 }
-
-func GetObject_success(s *S3Conf) error {
-	testName := "GetObject_success"
+func GetObject_multiple_ranges(s *S3Conf) error {
+	testName := "GetObject_multiple_ranges"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		dataLength, obj := int64(1234567), "my-obj"
-		ctype := defaultContentType
-
-		r, err := putObjectWithData(dataLength, &s3.PutObjectInput{
-			Bucket:      &bucket,
-			Key:         &obj,
-			ContentType: &ctype,
+		obj, dLen := "my-obj", int64(1000)
+		r, err := putObjectWithData(s, dLen, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
 		}, s3client)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+		client := http.Client{
+			Timeout: s.opTimeout(),
+		}
+
+		// S3 doesn't support multiple ranges in one request, instead of
+		// a multipart/byteranges body the whole object is returned. A
+		// single range is a plain partial content response.
+		for _, test := range []struct {
+			rng          string
+			status       int
+			contentRange string
+			start, end   int64
+		}{
+			{"bytes=0-99,200-299", http.StatusOK, "", 0, dLen},
+			{"bytes=0-99,-100", http.StatusOK, "", 0, dLen},
+			{"bytes=0-99", http.StatusPartialContent, "bytes 0-99/1000", 0, 100},
+		} {
+			req, err := createSignedReq(http.MethodGet, s.endpoint, fmt.Sprintf("%v/%v", bucket, obj), s.awsID, s.awsSecret, "s3", s.awsRegion, nil, time.Now(), map[string]string{
+				"Range": test.rng,
+			})
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Do(req)
+			if err != nil {
+				return err
+			}
+			body, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return err
+			}
+
+			if resp.StatusCode != test.status {
+				return fmt.Errorf("%v: expected response status to be %v, instead got %v", test.rng, test.status, resp.StatusCode)
+			}
+			if contentRange := resp.Header.Get("Content-Range"); contentRange != test.contentRange {
+				return fmt.Errorf("%v: expected the content range to be %q, instead got %q", test.rng, test.contentRange, contentRange)
+			}
+			if contentType := resp.Header.Get("Content-Type"); strings.HasPrefix(contentType, "multipart/") {
+				return fmt.Errorf("%v: expected a non multipart response, instead got %v", test.rng, contentType)
+			}
+			if !isEqual(body, r.data[test.start:test.end]) {
+				return fmt.Errorf("%v: expected the response body to be bytes %v-%v of the object", test.rng, test.start, test.end-1)
+			}
+		}
+
+		return nil
+	})
+}
+
+func GetObject_if_match_precondition_failed(s *S3Conf) error {
+	testName := "GetObject_if_match_precondition_failed"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		dataLength, obj := int64(1024*1024), "my-obj"
+		r, err := putObjectWithData(s, dataLength, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
-		})
-		defer cancel()
+		}, s3client)
 		if err != nil {
 			return err
 		}
-		if *out.ContentLength != dataLength {
-			return fmt.Errorf("expected content-length %v, instead got %v", dataLength, out.ContentLength)
+
+		req, err := createSignedReq(
+			http.MethodGet,
+			s.endpoint,
+			fmt.Sprintf("%v/%v", bucket, obj),
+			s.awsID,
+			s.awsSecret,
+			"s3",
+			s.awsRegion,
+			nil,
+			time.Now(),
+			map[string]string{
+				"If-Match": `"invalid-etag"`,
+			},
+		)
+		if err != nil {
+			return err
 		}
-		if *out.ContentType != defaultContentType {
-			return fmt.Errorf("expected content type %v, instead got %v", defaultContentType, *out.ContentType)
+
+		client := http.Client{
+			Timeout: s.opTimeout(),
 		}
-		if out.StorageClass != types.StorageClassStandard {
-			return fmt.Errorf("expected the storage class to be %v, instead got %v", types.StorageClassStandard, out.StorageClass)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+
+		// only the error response is expected, no object data
+		if int64(len(body)) >= dataLength {
+			return fmt.Errorf("expected no object data in the response body, instead got %v bytes", len(body))
+		}
+
+		var errResp s3err.APIErrorResponse
+		err = xml.Unmarshal(body, &errResp)
+		if err != nil {
+			return err
+		}
+
+		apiErr := s3err.GetAPIError(s3err.ErrPreconditionFailed)
+		if resp.StatusCode != apiErr.HTTPStatusCode {
+			return fmt.Errorf("expected response status code to be %v, instead got %v", apiErr.HTTPStatusCode, resp.StatusCode)
+		}
+		if errResp.Code != apiErr.Code {
+			return fmt.Errorf("expected error code to be %v, instead got %v", apiErr.Code, errResp.Code)
+		}
+
+		// the matching etag returns the object
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket:  &bucket,
+			Key:     &obj,
+			IfMatch: r.res.ETag,
+		})
+		defer cancel()
+		if err != nil {
+			return err
 		}
+		defer out.Body.Close()
 
 		bdy, err := io.ReadAll(out.Body)
 		if err != nil {
 			return err
 		}
-		defer out.Body.Close()
-		outCsum := sha256.Sum256(bdy)
-		if outCsum != r.csum {
+		if sha256.Sum256(bdy) != r.csum {
 			return fmt.Errorf("invalid object data")
 		}
+
 		return nil
 	})
 }
-
-const directoryContentType = "application/x-directory"
-
-func GetObject_directory_success(s *S3Conf) error {
-	testName := "GetObject_directory_success"
+func GetObject_conditional_headers(s *S3Conf) error {
+	testName := "GetObject_conditional_headers"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		dataLength, obj := int64(0), "my-dir/"
-
-		_, err := putObjectWithData(dataLength, &s3.PutObjectInput{
-			Bucket: &bucket,
-			Key:    &obj,
-		}, s3client)
+		obj := "my-obj"
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		})
-		defer cancel()
+		cancel()
 		if err != nil {
 			return err
 		}
-		if *out.ContentLength != dataLength {
-			return fmt.Errorf("expected content-length %v, instead got %v", dataLength, out.ContentLength)
-		}
-		if *out.ContentType != directoryContentType {
-			return fmt.Errorf("expected content type %v, instead got %v", directoryContentType, *out.ContentType)
-		}
-		if out.StorageClass != types.StorageClassStandard {
-			return fmt.Errorf("expected the storage class to be %v, instead got %v", types.StorageClassStandard, out.StorageClass)
+
+		etag := getString(out.ETag)
+		before := out.LastModified.Add(-time.Hour).UTC().Format(http.TimeFormat)
+		after := out.LastModified.Add(time.Hour).UTC().Format(http.TimeFormat)
+
+		client := http.Client{
+			Timeout: s.opTimeout(),
+		}
+
+		for _, test := range []struct {
+			headers map[string]string
+			status  int
+		}{
+			{map[string]string{"If-Match": etag}, http.StatusOK},
+			{map[string]string{"If-Match": `"invalid-etag"`}, http.StatusPreconditionFailed},
+			{map[string]string{"If-None-Match": etag}, http.StatusNotModified},
+			{map[string]string{"If-None-Match": `"invalid-etag"`}, http.StatusOK},
+			{map[string]string{"If-Modified-Since": before}, http.StatusOK},
+			{map[string]string{"If-Modified-Since": after}, http.StatusNotModified},
+			{map[string]string{"If-Unmodified-Since": after}, http.StatusOK},
+			{map[string]string{"If-Unmodified-Since": before}, http.StatusPreconditionFailed},
+			// If-Match takes precedence over If-Unmodified-Since, and
+			// If-None-Match over If-Modified-Since
+			{map[string]string{"If-Match": etag, "If-Unmodified-Since": before}, http.StatusOK},
+			{map[string]string{"If-None-Match": `"invalid-etag"`, "If-Modified-Since": after}, http.StatusOK},
+		} {
+			req, err := createSignedReq(http.MethodGet, s.endpoint, fmt.Sprintf("%v/%v", bucket, obj), s.awsID, s.awsSecret, "s3", s.awsRegion, nil, time.Now(), test.headers)
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Do(req)
+			if err != nil {
+				return err
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != test.status {
+				return fmt.Errorf("%v: expected response status to be %v, instead got %v", test.headers, test.status, resp.StatusCode)
+			}
 		}
 
-		out.Body.Close()
 		return nil
 	})
 }
 
-func GetObject_by_range_success(s *S3Conf) error {
-	testName := "GetObject_by_range_success"
+func GetObject_torrent_not_implemented(s *S3Conf) error {
+	testName := "GetObject_torrent_not_implemented"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		dataLength, obj := int64(1234567), "my-obj"
-
-		r, err := putObjectWithData(dataLength, &s3.PutObjectInput{
-			Bucket: &bucket,
-			Key:    &obj,
-		}, s3client)
+		obj := "my-obj"
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
 		if err != nil {
 			return err
 		}
 
-		rangeString := "bytes=100-200"
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.GetObjectTorrent(ctx, &s3.GetObjectTorrentInput{
 			Bucket: &bucket,
 			Key:    &obj,
-			Range:  &rangeString,
 		})
-		defer cancel()
-		if err != nil {
-			return err
-		}
-		defer out.Body.Close()
-
-		if getString(out.ContentRange) != fmt.Sprintf("bytes 100-200/%v", dataLength) {
-			return fmt.Errorf("expected content range: %v, instead got: %v", fmt.Sprintf("bytes 100-200/%v", dataLength), getString(out.ContentRange))
-		}
-		if getString(out.AcceptRanges) != rangeString {
-			return fmt.Errorf("expected accept range: %v, instead got: %v", rangeString, getString(out.AcceptRanges))
-		}
-		b, err := io.ReadAll(out.Body)
-		if err != nil && !errors.Is(err, io.EOF) {
+		cancel()
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrNotImplemented)); err != nil {
 			return err
 		}
 
-		// bytes range is inclusive, go range for second value is not
-		if !isEqual(b, r.data[100:201]) {
-			return fmt.Errorf("data mismatch of range")
-		}
-
-		rangeString = "bytes=100-"
+		return nil
+	})
+}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
-		out, err = s3client.GetObject(ctx, &s3.GetObjectInput{
+func GetObject_sequential_ranges(s *S3Conf) error {
+	testName := "GetObject_sequential_ranges"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		// resumable downloaders fetch large objects with
+		// many sequential small ranges
+		const (
+			rangeCount = 1000
+			rangeSize  = 1024
+		)
+		obj := "my-obj"
+		r, err := putObjectWithData(s, rangeCount*rangeSize, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
-			Range:  &rangeString,
-		})
-		defer cancel()
+		}, s3client)
 		if err != nil {
 			return err
 		}
-		defer out.Body.Close()
 
-		b, err = io.ReadAll(out.Body)
-		if err != nil && !errors.Is(err, io.EOF) {
-			return err
-		}
+		for i := 0; i < rangeCount; i++ {
+			offset := i * rangeSize
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+				Bucket: &bucket,
+				Key:    &obj,
+				Range:  getPtr(fmt.Sprintf("bytes=%v-%v", offset, offset+rangeSize-1)),
+			})
+			if err != nil {
+				cancel()
+				return err
+			}
 
-		// bytes range is inclusive, go range for second value is not
-		if !isEqual(b, r.data[100:]) {
-			return fmt.Errorf("data mismatch of range")
+			bdy, err := io.ReadAll(out.Body)
+			out.Body.Close()
+			cancel()
+			if err != nil {
+				return err
+			}
+
+			if !bytes.Equal(bdy, r.data[offset:offset+rangeSize]) {
+				return fmt.Errorf("invalid object data in range %v", i)
+			}
 		}
+
 		return nil
 	})
 }
 
-func GetObject_by_range_resp_status(s *S3Conf) error {
-	testName := "GetObject_by_range_resp_status"
+func GetObject_response_overrides(s *S3Conf) error {
+	testName := "GetObject_response_overrides"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		obj, dLen := "my-obj", int64(4000)
-		_, err := putObjectWithData(dLen, &s3.PutObjectInput{
-			Bucket: &bucket,
-			Key:    &obj,
+		obj := "my-obj"
+		_, err := putObjectWithData(s, 10, &s3.PutObjectInput{
+			Bucket:       &bucket,
+			Key:          &obj,
+			ContentType:  getPtr("text/plain"),
+			CacheControl: getPtr("no-cache"),
 		}, s3client)
 		if err != nil {
 			return err
 		}
 
-		req, err := createSignedReq(
-			http.MethodGet,
-			s.endpoint,
-			fmt.Sprintf("%v/%v", bucket, obj),
-			s.awsID,
-			s.awsSecret,
-			"s3",
-			s.awsRegion,
-			nil,
-			time.Now(),
-			map[string]string{
-				"Range": "bytes=100-200",
-			},
-		)
+		expires := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket:                     &bucket,
+			Key:                        &obj,
+			ResponseCacheControl:       getPtr("max-age=60"),
+			ResponseContentDisposition: getPtr(`attachment; filename="my-file.txt"`),
+			ResponseContentEncoding:    getPtr("identity"),
+			ResponseContentLanguage:    getPtr("en-US"),
+			ResponseContentType:        getPtr("application/json"),
+			ResponseExpires:            &expires,
+		})
+		cancel()
 		if err != nil {
 			return err
 		}
+		defer out.Body.Close()
 
-		client := http.Client{
-			Timeout: shortTimeout,
+		if getString(out.CacheControl) != "max-age=60" {
+			return fmt.Errorf("expected the cache control to be max-age=60, instead got %v", getString(out.CacheControl))
 		}
-
-		resp, err := client.Do(req)
-		if err != nil {
-			return err
+		if getString(out.ContentDisposition) != `attachment; filename="my-file.txt"` {
+			return fmt.Errorf("expected the content disposition to be %q, instead got %q",
+				`attachment; filename="my-file.txt"`, getString(out.ContentDisposition))
 		}
-
-		if resp.StatusCode != http.StatusPartialContent {
-			return fmt.Errorf("expected response status to be %v, instead got %v", http.StatusPartialContent, resp.StatusCode)
+		if getString(out.ContentEncoding) != "identity" {
+			return fmt.Errorf("expected the content encoding to be identity, instead got %v", getString(out.ContentEncoding))
+		}
+		if getString(out.ContentLanguage) != "en-US" {
+			return fmt.Errorf("expected the content language to be en-US, instead got %v", getString(out.ContentLanguage))
+		}
+		if getString(out.ContentType) != "application/json" {
+			return fmt.Errorf("expected the content type to be application/json, instead got %v", getString(out.ContentType))
+		}
+		if out.Expires == nil || !out.Expires.Equal(expires) {
+			return fmt.Errorf("expected the expires to be %v, instead got %v", expires, out.Expires)
 		}
 
 		return nil
@@ -3637,7 +5899,7 @@ func GetObject_non_existing_dir_object(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		dataLength, obj := int64(1234567), "my-obj"
 
-		_, err := putObjectWithData(dataLength, &s3.PutObjectInput{
+		_, err := putObjectWithData(s, dataLength, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		}, s3client)
@@ -3646,7 +5908,7 @@ func GetObject_non_existing_dir_object(s *S3Conf) error {
 		}
 
 		obj = "my-obj/"
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.GetObject(ctx, &s3.GetObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -3659,11 +5921,63 @@ func GetObject_non_existing_dir_object(s *S3Conf) error {
 	})
 }
 
+func GetObject_implicit_prefix(s *S3Conf) error {
+	testName := "GetObject_implicit_prefix"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		// "foo/" is only a prefix of "foo/bar", not a directory object
+		obj, prefix := "foo/bar", "foo/"
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: &bucket,
+			Key:    &prefix,
+		})
+		cancel()
+		if err := checkSdkApiErr(err, "NotFound"); err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: &bucket,
+			Key:    &prefix,
+		})
+		cancel()
+		if err := checkSdkApiErr(err, "NoSuchKey"); err != nil {
+			return err
+		}
+
+		// the prefix is still listed as a common prefix
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+			Bucket:    &bucket,
+			Delimiter: getPtr("/"),
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if len(out.Contents) != 0 {
+			return fmt.Errorf("expected empty contents, instead got %v", objStrings(out.Contents))
+		}
+		if !comparePrefixes([]string{prefix}, out.CommonPrefixes) {
+			return fmt.Errorf("expected the common prefixes to be %v, instead got %v", []string{prefix}, pfxStrings(out.CommonPrefixes))
+		}
+
+		return nil
+	})
+}
+
 func ListObjects_non_existing_bucket(s *S3Conf) error {
 	testName := "ListObjects_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		bckt := getBucketName()
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.ListObjects(ctx, &s3.ListObjectsInput{
 			Bucket: &bckt,
 		})
@@ -3680,56 +5994,60 @@ func ListObjects_with_prefix(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		prefix := "obj"
 		objWithPrefix := []string{prefix + "/bar", prefix + "/baz/bla", prefix + "/foo"}
-		contents, err := putObjects(s3client, append(objWithPrefix, []string{"azy/csf", "hell"}...), bucket)
+		contents, err := putObjects(s, s3client, append(objWithPrefix, []string{"azy/csf", "hell"}...), bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		out, err := s3client.ListObjects(ctx, &s3.ListObjectsInput{
-			Bucket: &bucket,
-			Prefix: &prefix,
-		})
-		cancel()
-		if err != nil {
-			return err
-		}
+		return retry(func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			out, err := s3client.ListObjects(ctx, &s3.ListObjectsInput{
+				Bucket: &bucket,
+				Prefix: &prefix,
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
 
-		if *out.Prefix != prefix {
-			return fmt.Errorf("expected prefix %v, instead got %v", prefix, *out.Prefix)
-		}
-		if !compareObjects(contents[2:], out.Contents) {
-			return fmt.Errorf("expected the output to be %v, instead got %v", contents[2:], out.Contents)
-		}
+			if *out.Prefix != prefix {
+				return fmt.Errorf("expected prefix %v, instead got %v", prefix, *out.Prefix)
+			}
+			if !compareObjects(contents[2:], out.Contents) {
+				return fmt.Errorf("expected the output to be %v, instead got %v", contents[2:], out.Contents)
+			}
 
-		return nil
+			return nil
+		}, s.attempts(), retryDelay)
 	})
 }
 
 func ListObjects_paginated(s *S3Conf) error {
 	testName := "ListObjects_paginated"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		_, err := putObjects(s3client, []string{"dir1/subdir/file.txt", "dir1/subdir.ext", "dir1/subdir1.ext", "dir1/subdir2.ext"}, bucket)
+		_, err := putObjects(s, s3client, []string{"dir1/subdir/file.txt", "dir1/subdir.ext", "dir1/subdir1.ext", "dir1/subdir2.ext"}, bucket)
 		if err != nil {
 			return err
 		}
 
-		objs, prefixes, err := listObjects(s3client, bucket, "dir1/", "/", 2)
-		if err != nil {
-			return err
-		}
+		return retry(func() error {
+			objs, prefixes, err := listObjects(s, s3client, bucket, "dir1/", "/", 2)
+			if err != nil {
+				return err
+			}
 
-		expected := []string{"dir1/subdir.ext", "dir1/subdir1.ext", "dir1/subdir2.ext"}
-		if !hasObjNames(objs, expected) {
-			return fmt.Errorf("expected objects %v, instead got %v", expected, objStrings(objs))
-		}
+			expected := []string{"dir1/subdir.ext", "dir1/subdir1.ext", "dir1/subdir2.ext"}
+			if !hasObjNames(objs, expected) {
+				return fmt.Errorf("expected objects %v, instead got %v", expected, objStrings(objs))
+			}
 
-		expectedPrefix := []string{"dir1/subdir/"}
-		if !hasPrefixName(prefixes, expectedPrefix) {
-			return fmt.Errorf("expected prefixes %v, instead got %v", expectedPrefix, pfxStrings(prefixes))
-		}
+			expectedPrefix := []string{"dir1/subdir/"}
+			if !hasPrefixName(prefixes, expectedPrefix) {
+				return fmt.Errorf("expected prefixes %v, instead got %v", expectedPrefix, pfxStrings(prefixes))
+			}
 
-		return nil
+			return nil
+		}, s.attempts(), retryDelay)
 	})
 }
 
@@ -3737,12 +6055,12 @@ func ListObjects_truncated(s *S3Conf) error {
 	testName := "ListObjects_truncated"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		maxKeys := int32(2)
-		contents, err := putObjects(s3client, []string{"foo", "bar", "baz"}, bucket)
+		contents, err := putObjects(s, s3client, []string{"foo", "bar", "baz"}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out1, err := s3client.ListObjects(ctx, &s3.ListObjectsInput{
 			Bucket:  &bucket,
 			MaxKeys: &maxKeys,
@@ -3768,7 +6086,7 @@ func ListObjects_truncated(s *S3Conf) error {
 			return fmt.Errorf("expected the output to be %v, instead got %v", contents[:2], out1.Contents)
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		out2, err := s3client.ListObjects(ctx, &s3.ListObjectsInput{
 			Bucket: &bucket,
 			Marker: out1.NextMarker,
@@ -3797,7 +6115,7 @@ func ListObjects_invalid_max_keys(s *S3Conf) error {
 	testName := "ListObjects_invalid_max_keys"
 	maxKeys := int32(-5)
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.ListObjects(ctx, &s3.ListObjectsInput{
 			Bucket:  &bucket,
 			MaxKeys: &maxKeys,
@@ -3815,11 +6133,11 @@ func ListObjects_max_keys_0(s *S3Conf) error {
 	testName := "ListObjects_max_keys_0"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		objects := []string{"foo", "bar", "baz"}
-		_, err := putObjects(s3client, objects, bucket)
+		_, err := putObjects(s, s3client, objects, bucket)
 		if err != nil {
 			return err
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		maxKeys := int32(0)
 		out, err := s3client.ListObjects(ctx, &s3.ListObjectsInput{
 			Bucket:  &bucket,
@@ -3841,48 +6159,50 @@ func ListObjects_max_keys_0(s *S3Conf) error {
 func ListObjects_delimiter(s *S3Conf) error {
 	testName := "ListObjects_delimiter"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		_, err := putObjects(s3client, []string{"foo/bar/baz", "foo/bar/xyzzy", "quux/thud", "asdf"}, bucket)
+		_, err := putObjects(s, s3client, []string{"foo/bar/baz", "foo/bar/xyzzy", "quux/thud", "asdf"}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		out, err := s3client.ListObjects(ctx, &s3.ListObjectsInput{
-			Bucket:    &bucket,
-			Delimiter: getPtr("/"),
-		})
-		cancel()
-		if err != nil {
-			return err
-		}
+		return retry(func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			out, err := s3client.ListObjects(ctx, &s3.ListObjectsInput{
+				Bucket:    &bucket,
+				Delimiter: getPtr("/"),
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
 
-		if out.Delimiter == nil || *out.Delimiter != "/" {
-			if out.Delimiter == nil {
-				return fmt.Errorf("expected delimiter to be /, instead got nil delim")
+			if out.Delimiter == nil || *out.Delimiter != "/" {
+				if out.Delimiter == nil {
+					return fmt.Errorf("expected delimiter to be /, instead got nil delim")
+				}
+				return fmt.Errorf("expected delimiter to be /, instead got %v", *out.Delimiter)
+			}
+			if len(out.Contents) != 1 || *out.Contents[0].Key != "asdf" {
+				return fmt.Errorf("expected result [\"asdf\"], instead got %v", out.Contents)
 			}
-			return fmt.Errorf("expected delimiter to be /, instead got %v", *out.Delimiter)
-		}
-		if len(out.Contents) != 1 || *out.Contents[0].Key != "asdf" {
-			return fmt.Errorf("expected result [\"asdf\"], instead got %v", out.Contents)
-		}
 
-		if !comparePrefixes([]string{"foo/", "quux/"}, out.CommonPrefixes) {
-			return fmt.Errorf("expected common prefixes to be %v, instead got %v", []string{"foo/", "quux/"}, out.CommonPrefixes)
-		}
+			if !comparePrefixes([]string{"foo/", "quux/"}, out.CommonPrefixes) {
+				return fmt.Errorf("expected common prefixes to be %v, instead got %v", []string{"foo/", "quux/"}, out.CommonPrefixes)
+			}
 
-		return nil
+			return nil
+		}, s.attempts(), retryDelay)
 	})
 }
 
 func ListObjects_max_keys_none(s *S3Conf) error {
 	testName := "ListObjects_max_keys_none"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		_, err := putObjects(s3client, []string{"foo", "bar", "baz"}, bucket)
+		_, err := putObjects(s, s3client, []string{"foo", "bar", "baz"}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.ListObjects(ctx, &s3.ListObjectsInput{
 			Bucket: &bucket,
 		})
@@ -3902,12 +6222,12 @@ func ListObjects_max_keys_none(s *S3Conf) error {
 func ListObjects_marker_not_from_obj_list(s *S3Conf) error {
 	testName := "ListObjects_marker_not_from_obj_list"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		contents, err := putObjects(s3client, []string{"foo", "bar", "baz", "qux", "hello", "xyz"}, bucket)
+		contents, err := putObjects(s, s3client, []string{"foo", "bar", "baz", "qux", "hello", "xyz"}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.ListObjects(ctx, &s3.ListObjectsInput{
 			Bucket: &bucket,
 			Marker: getPtr("ceil"),
@@ -3928,12 +6248,12 @@ func ListObjects_marker_not_from_obj_list(s *S3Conf) error {
 func ListObjects_list_all_objs(s *S3Conf) error {
 	testName := "ListObjects_list_all_objs"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		contents, err := putObjects(s3client, []string{"foo", "bar", "baz", "quxx/ceil", "ceil", "hello/world"}, bucket)
+		contents, err := putObjects(s, s3client, []string{"foo", "bar", "baz", "quxx/ceil", "ceil", "hello/world"}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.ListObjects(ctx, &s3.ListObjectsInput{
 			Bucket: &bucket,
 		})
@@ -3955,8 +6275,116 @@ func ListObjects_list_all_objs(s *S3Conf) error {
 			return fmt.Errorf("expected the Prefix to be nil, instead got %v", *out.Prefix)
 		}
 
-		if !compareObjects(out.Contents, contents) {
-			return fmt.Errorf("expected the contents to be %v, instead got %v", contents, out.Contents)
+		if !compareObjects(out.Contents, contents) {
+			return fmt.Errorf("expected the contents to be %v, instead got %v", contents, out.Contents)
+		}
+
+		return nil
+	})
+}
+func ListObjects_marker_pagination_parity(s *S3Conf) error {
+	testName := "ListObjects_marker_pagination_parity"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		objs := []string{"a.txt", "a/1", "a/2", "a0", "b/c/d", "b/e", "b.1", "c"}
+		for i := 0; i < 25; i++ {
+			objs = append(objs, fmt.Sprintf("k-%v", i))
+		}
+		_, err := putObjects(s, s3client, objs, bucket)
+		if err != nil {
+			return err
+		}
+
+		maxKeys := int32(7)
+		for _, delim := range []string{"", "/"} {
+			var v1Keys, v1Prefixes []string
+			var marker *string
+			for {
+				ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+				out, err := s3client.ListObjects(ctx, &s3.ListObjectsInput{
+					Bucket:    &bucket,
+					Delimiter: &delim,
+					Marker:    marker,
+					MaxKeys:   &maxKeys,
+				})
+				cancel()
+				if err != nil {
+					return err
+				}
+
+				for _, obj := range out.Contents {
+					v1Keys = append(v1Keys, *obj.Key)
+				}
+				for _, cp := range out.CommonPrefixes {
+					v1Prefixes = append(v1Prefixes, *cp.Prefix)
+				}
+				if out.IsTruncated == nil || !*out.IsTruncated {
+					break
+				}
+
+				// NextMarker is only returned with a delimiter, the
+				// clients continue from the last key otherwise
+				if delim != "" && getString(out.NextMarker) == "" {
+					return fmt.Errorf("delimiter %q: expected the next marker on a truncated page", delim)
+				}
+				if getString(out.NextMarker) != "" {
+					marker = out.NextMarker
+				} else if len(out.Contents) != 0 {
+					marker = out.Contents[len(out.Contents)-1].Key
+				} else {
+					return fmt.Errorf("delimiter %q: truncated page without a marker to continue from", delim)
+				}
+			}
+
+			var v2Keys, v2Prefixes []string
+			var token *string
+			for {
+				ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+				out, err := s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+					Bucket:            &bucket,
+					Delimiter:         &delim,
+					ContinuationToken: token,
+					MaxKeys:           &maxKeys,
+				})
+				cancel()
+				if err != nil {
+					return err
+				}
+
+				for _, obj := range out.Contents {
+					v2Keys = append(v2Keys, *obj.Key)
+				}
+				for _, cp := range out.CommonPrefixes {
+					v2Prefixes = append(v2Prefixes, *cp.Prefix)
+				}
+				if out.IsTruncated == nil || !*out.IsTruncated {
+					break
+				}
+				token = out.NextContinuationToken
+			}
+
+			if !slices.Equal(v1Keys, v2Keys) {
+				return fmt.Errorf("delimiter %q: expected the ListObjects keys %v to match the ListObjectsV2 keys %v", delim, v1Keys, v2Keys)
+			}
+			if !slices.Equal(v1Prefixes, v2Prefixes) {
+				return fmt.Errorf("delimiter %q: expected the ListObjects common prefixes %v to match the ListObjectsV2 common prefixes %v", delim, v1Prefixes, v2Prefixes)
+			}
+			expectedKeys, expectedPrefixes := objs, []string(nil)
+			if delim == "/" {
+				expectedKeys, expectedPrefixes = nil, []string{"a/", "b/"}
+				for _, obj := range objs {
+					if !strings.Contains(obj, "/") {
+						expectedKeys = append(expectedKeys, obj)
+					}
+				}
+			}
+			expectedKeys = slices.Clone(expectedKeys)
+			slices.Sort(expectedKeys)
+			if !slices.Equal(v1Keys, expectedKeys) {
+				return fmt.Errorf("delimiter %q: expected the keys to be %v, instead got %v", delim, expectedKeys, v1Keys)
+			}
+			if !slices.Equal(v1Prefixes, expectedPrefixes) {
+				return fmt.Errorf("delimiter %q: expected the common prefixes to be %v, instead got %v", delim, expectedPrefixes, v1Prefixes)
+			}
 		}
 
 		return nil
@@ -3966,13 +6394,13 @@ func ListObjects_list_all_objs(s *S3Conf) error {
 func ListObjectsV2_start_after(s *S3Conf) error {
 	testName := "ListObjectsV2_start_after"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		contents, err := putObjects(s3client, []string{"foo", "bar", "baz"}, bucket)
+		contents, err := putObjects(s, s3client, []string{"foo", "bar", "baz"}, bucket)
 		if err != nil {
 			return err
 		}
 
 		startAfter := "bar"
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
 			Bucket:     &bucket,
 			StartAfter: &startAfter,
@@ -3996,13 +6424,13 @@ func ListObjectsV2_start_after(s *S3Conf) error {
 func ListObjectsV2_both_start_after_and_continuation_token(s *S3Conf) error {
 	testName := "ListObjectsV2_both_start_after_and_continuation_token"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		contents, err := putObjects(s3client, []string{"foo", "bar", "baz", "quxx"}, bucket)
+		contents, err := putObjects(s, s3client, []string{"foo", "bar", "baz", "quxx"}, bucket)
 		if err != nil {
 			return err
 		}
 		var maxKeys int32 = 1
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
 			Bucket:  &bucket,
 			MaxKeys: &maxKeys,
@@ -4028,7 +6456,7 @@ func ListObjectsV2_both_start_after_and_continuation_token(s *S3Conf) error {
 			return fmt.Errorf("expected the output to be %v, instead got %v", contents[:1], out.Contents)
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		resp, err := s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
 			Bucket:            &bucket,
 			ContinuationToken: out.NextContinuationToken,
@@ -4046,16 +6474,78 @@ func ListObjectsV2_both_start_after_and_continuation_token(s *S3Conf) error {
 		return nil
 	})
 }
+func ListObjectsV2_start_after_with_prefix_and_pagination(s *S3Conf) error {
+	testName := "ListObjectsV2_start_after_with_prefix_and_pagination"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		objs := []string{}
+		for i := 0; i < 10; i++ {
+			objs = append(objs, fmt.Sprintf("k%v", i), fmt.Sprintf("p/k%v", i))
+		}
+		contents, err := putObjects(s, s3client, objs, bucket)
+		if err != nil {
+			return err
+		}
+		// the sorted contents are k0..k9 followed by p/k0..p/k9
+		kObjs, pObjs := contents[:10], contents[10:]
+
+		for _, test := range []struct {
+			prefix     string
+			startAfter string
+			maxKeys    int32
+			expected   []types.Object
+		}{
+			{"k", "k4", 1000, kObjs[5:]},
+			{"p/", "p/k4", 1000, pObjs[5:]},
+			// a start after before the prefix lists the whole prefix
+			{"p/", "k4", 1000, pObjs},
+			{"", "k9", 1000, pObjs},
+			{"k", "k4", 2, kObjs[5:]},
+			{"p/", "p/k2", 3, pObjs[3:]},
+		} {
+			var result []types.Object
+			var token *string
+			for {
+				ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+				out, err := s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+					Bucket:            &bucket,
+					Prefix:            &test.prefix,
+					StartAfter:        &test.startAfter,
+					MaxKeys:           &test.maxKeys,
+					ContinuationToken: token,
+				})
+				cancel()
+				if err != nil {
+					return err
+				}
+
+				if getString(out.StartAfter) != test.startAfter {
+					return fmt.Errorf("prefix %q start after %q: expected StartAfter to be %v, instead got %v", test.prefix, test.startAfter, test.startAfter, getString(out.StartAfter))
+				}
+				result = append(result, out.Contents...)
+				if out.IsTruncated == nil || !*out.IsTruncated {
+					break
+				}
+				token = out.NextContinuationToken
+			}
+
+			if !compareObjects(test.expected, result) {
+				return fmt.Errorf("prefix %q start after %q max keys %v: expected the output to be %v, instead got %v", test.prefix, test.startAfter, test.maxKeys, objStrings(test.expected), objStrings(result))
+			}
+		}
+
+		return nil
+	})
+}
 
 func ListObjectsV2_start_after_not_in_list(s *S3Conf) error {
 	testName := "ListObjectsV2_start_after_not_in_list"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		contents, err := putObjects(s3client, []string{"foo", "bar", "baz", "quxx"}, bucket)
+		contents, err := putObjects(s, s3client, []string{"foo", "bar", "baz", "quxx"}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
 			Bucket:     &bucket,
 			StartAfter: getPtr("blah"),
@@ -4076,12 +6566,12 @@ func ListObjectsV2_start_after_not_in_list(s *S3Conf) error {
 func ListObjectsV2_start_after_empty_result(s *S3Conf) error {
 	testName := "ListObjectsV2_start_after_empty_result"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		_, err := putObjects(s3client, []string{"foo", "bar", "baz", "quxx"}, bucket)
+		_, err := putObjects(s, s3client, []string{"foo", "bar", "baz", "quxx"}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
 			Bucket:     &bucket,
 			StartAfter: getPtr("zzz"),
@@ -4102,7 +6592,7 @@ func ListObjectsV2_start_after_empty_result(s *S3Conf) error {
 func ListObjectsV2_both_delimiter_and_prefix(s *S3Conf) error {
 	testName := "ListObjectsV2_both_delimiter_and_prefix"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		_, err := putObjects(s3client, []string{
+		_, err := putObjects(s, s3client, []string{
 			"sample.jpg",
 			"photos/2006/January/sample.jpg",
 			"photos/2006/February/sample2.jpg",
@@ -4114,7 +6604,7 @@ func ListObjectsV2_both_delimiter_and_prefix(s *S3Conf) error {
 		}
 		delim, prefix := "/", "photos/2006/"
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
 			Bucket:    &bucket,
 			Delimiter: &delim,
@@ -4141,18 +6631,68 @@ func ListObjectsV2_both_delimiter_and_prefix(s *S3Conf) error {
 		return nil
 	})
 }
+func ListObjectsV2_common_prefixes(s *S3Conf) error {
+	testName := "ListObjectsV2_common_prefixes"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		contents, err := putObjects(s, s3client, []string{"a/1", "a/2", "b/1"}, bucket)
+		if err != nil {
+			return err
+		}
+
+		for _, test := range []struct {
+			prefix   *string
+			delim    *string
+			contents []types.Object
+			prefixes []string
+		}{
+			{nil, getPtr("/"), nil, []string{"a/", "b/"}},
+			{getPtr("a/"), getPtr("/"), contents[:2], nil},
+			{getPtr("a/"), nil, contents[:2], nil},
+			{getPtr("b"), getPtr("/"), nil, []string{"b/"}},
+		} {
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			out, err := s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+				Bucket:    &bucket,
+				Prefix:    test.prefix,
+				Delimiter: test.delim,
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
+
+			if !compareObjects(test.contents, out.Contents) {
+				return fmt.Errorf("prefix %q delimiter %q: expected the objects to be %v, instead got %v", getString(test.prefix), getString(test.delim), test.contents, out.Contents)
+			}
+			if !comparePrefixes(test.prefixes, out.CommonPrefixes) {
+				return fmt.Errorf("prefix %q delimiter %q: expected the common prefixes to be %v, instead got %v", getString(test.prefix), getString(test.delim), test.prefixes, out.CommonPrefixes)
+			}
+
+			// an object is either listed or rolled up into a common prefix
+			for _, obj := range out.Contents {
+				for _, cp := range out.CommonPrefixes {
+					if strings.HasPrefix(*obj.Key, getString(cp.Prefix)) {
+						return fmt.Errorf("prefix %q delimiter %q: object %v overlaps the common prefix %v", getString(test.prefix), getString(test.delim), *obj.Key, getString(cp.Prefix))
+					}
+				}
+			}
+		}
+
+		return nil
+	})
+}
 
 func ListObjectsV2_single_dir_object_with_delim_and_prefix(s *S3Conf) error {
 	testName := "ListObjectsV2_single_dir_object_with_delim_and_prefix"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		contents, err := putObjects(s3client, []string{"a/"}, bucket)
+		contents, err := putObjects(s, s3client, []string{"a/"}, bucket)
 		if err != nil {
 			return err
 		}
 
 		delim, prefix := "/", "a"
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
 			Bucket:    &bucket,
 			Delimiter: &delim,
@@ -4172,7 +6712,7 @@ func ListObjectsV2_single_dir_object_with_delim_and_prefix(s *S3Conf) error {
 
 		prefix = "a/"
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		res, err = s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
 			Bucket:    &bucket,
 			Delimiter: &delim,
@@ -4197,14 +6737,14 @@ func ListObjectsV2_single_dir_object_with_delim_and_prefix(s *S3Conf) error {
 func ListObjectsV2_truncated_common_prefixes(s *S3Conf) error {
 	testName := "ListObjectsV2_truncated_common_prefixes"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		_, err := putObjects(s3client, []string{"d1/f1", "d2/f2", "d3/f3", "d4/f4"}, bucket)
+		_, err := putObjects(s, s3client, []string{"d1/f1", "d2/f2", "d3/f3", "d4/f4"}, bucket)
 		if err != nil {
 			return err
 		}
 
 		delim, maxKeys := "/", int32(3)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
 			Bucket:    &bucket,
 			Delimiter: &delim,
@@ -4228,7 +6768,7 @@ func ListObjectsV2_truncated_common_prefixes(s *S3Conf) error {
 			return fmt.Errorf("expected the delimiter to be %v, instead got %v", delim, *out.Delimiter)
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		out, err = s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
 			Bucket:            &bucket,
 			Delimiter:         &delim,
@@ -4253,14 +6793,14 @@ func ListObjectsV2_truncated_common_prefixes(s *S3Conf) error {
 func ListObjectsV2_all_objs_max_keys(s *S3Conf) error {
 	testName := "ListObjectsV2_all_objs_max_keys"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		contents, err := putObjects(s3client, []string{"bar", "baz", "foo"}, bucket)
+		contents, err := putObjects(s, s3client, []string{"bar", "baz", "foo"}, bucket)
 		if err != nil {
 			return err
 		}
 
 		maxKeys := int32(3)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
 			Bucket:  &bucket,
 			MaxKeys: &maxKeys,
@@ -4291,12 +6831,12 @@ func ListObjectsV2_all_objs_max_keys(s *S3Conf) error {
 func ListObjectsV2_list_all_objs(s *S3Conf) error {
 	testName := "ListObjectsV2_list_all_objs"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		contents, err := putObjects(s3client, []string{"bar", "baz", "foo", "obj1", "hell/", "xyzz/quxx"}, bucket)
+		contents, err := putObjects(s, s3client, []string{"bar", "baz", "foo", "obj1", "hell/", "xyzz/quxx"}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
 			Bucket: &bucket,
 		})
@@ -4328,6 +6868,73 @@ func ListObjectsV2_list_all_objs(s *S3Conf) error {
 		return nil
 	})
 }
+func ListObjectsV2_paginate_max_keys(s *S3Conf) error {
+	testName := "ListObjectsV2_paginate_max_keys"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		objs := []string{}
+		for i := 0; i < 2500; i++ {
+			objs = append(objs, fmt.Sprintf("obj-%v", i))
+		}
+		contents, err := putObjects(s, s3client, objs, bucket)
+		if err != nil {
+			return err
+		}
+
+		var result []types.Object
+		var token *string
+		maxKeys := int32(1000)
+		for pages := 1; ; pages++ {
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			out, err := s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+				Bucket:            &bucket,
+				MaxKeys:           &maxKeys,
+				ContinuationToken: token,
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
+
+			if getString(out.ContinuationToken) != getString(token) {
+				return fmt.Errorf("page %v: expected the continuation token to be %v, instead got %v", pages, getString(token), getString(out.ContinuationToken))
+			}
+			if len(out.Contents) > int(maxKeys) {
+				return fmt.Errorf("page %v: expected at most %v objects, instead got %v", pages, maxKeys, len(out.Contents))
+			}
+			if out.KeyCount == nil || int(*out.KeyCount) != len(out.Contents) {
+				return fmt.Errorf("page %v: expected the key count to be %v, instead got %v", pages, len(out.Contents), out.KeyCount)
+			}
+			result = append(result, out.Contents...)
+
+			if out.IsTruncated == nil || !*out.IsTruncated {
+				if out.NextContinuationToken != nil {
+					return fmt.Errorf("page %v: expected nil next continuation token on the last page, instead got %v", pages, *out.NextContinuationToken)
+				}
+				if pages != 3 {
+					return fmt.Errorf("expected 3 pages, instead got %v", pages)
+				}
+				break
+			}
+			if getString(out.NextContinuationToken) == "" {
+				return fmt.Errorf("page %v: expected a next continuation token on a truncated page", pages)
+			}
+			if pages == 3 {
+				return fmt.Errorf("expected the third page to be the last")
+			}
+			token = out.NextContinuationToken
+		}
+
+		// compareObjects also catches out of order and duplicate keys
+		if len(result) != len(contents) {
+			return fmt.Errorf("expected %v objects, instead got %v", len(contents), len(result))
+		}
+		if !compareObjects(contents, result) {
+			return fmt.Errorf("expected the paginated objects to match the uploaded objects in lexicographic order")
+		}
+
+		return nil
+	})
+}
 
 func ListObjectVersions_VD_success(s *S3Conf) error {
 	testName := "ListObjectVersions_VD_success"
@@ -4336,7 +6943,7 @@ func ListObjectVersions_VD_success(s *S3Conf) error {
 		for i := 0; i < 5; i++ {
 			dLgth := int64(i * 100)
 			key := fmt.Sprintf("my-obj-%v", i)
-			out, err := putObjectWithData(dLgth, &s3.PutObjectInput{
+			out, err := putObjectWithData(s, dLgth, &s3.PutObjectInput{
 				Bucket: &bucket,
 				Key:    &key,
 			}, s3client)
@@ -4354,7 +6961,7 @@ func ListObjectVersions_VD_success(s *S3Conf) error {
 			})
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{
 			Bucket: &bucket,
 		})
@@ -4373,7 +6980,7 @@ func ListObjectVersions_VD_success(s *S3Conf) error {
 func DeleteObject_non_existing_object(s *S3Conf) error {
 	testName := "DeleteObject_non_existing_object"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket: &bucket,
 			Key:    getPtr("my-obj"),
@@ -4387,7 +6994,7 @@ func DeleteObject_directory_object_noslash(s *S3Conf) error {
 	testName := "DeleteObject_directory_object_noslash"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj/"
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObject(ctx, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -4398,7 +7005,7 @@ func DeleteObject_directory_object_noslash(s *S3Conf) error {
 		}
 
 		obj = "my-obj"
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -4412,7 +7019,7 @@ func DeleteObject_directory_object_noslash(s *S3Conf) error {
 		// since it should not correctly match the directory name
 		// so the below head object should also succeed
 		obj = "my-obj/"
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.HeadObject(ctx, &s3.HeadObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -4426,13 +7033,13 @@ func DeleteObject_non_existing_dir_object(s *S3Conf) error {
 	testName := "DeleteObject_non_existing_dir_object"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		_, err := putObjects(s3client, []string{obj}, bucket)
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
 		if err != nil {
 			return err
 		}
 
 		obj = "my-obj/"
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -4446,12 +7053,12 @@ func DeleteObject_success(s *S3Conf) error {
 	testName := "DeleteObject_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		_, err := putObjects(s3client, []string{obj}, bucket)
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -4461,7 +7068,7 @@ func DeleteObject_success(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.GetObject(ctx, &s3.GetObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -4478,7 +7085,7 @@ func DeleteObject_success_status_code(s *S3Conf) error {
 	testName := "DeleteObject_success_status_code"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		_, err := putObjects(s3client, []string{obj}, bucket)
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
 		if err != nil {
 			return err
 		}
@@ -4489,35 +7096,219 @@ func DeleteObject_success_status_code(s *S3Conf) error {
 		}
 
 		client := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode != http.StatusNoContent {
+			return fmt.Errorf("expected response status to be %v, instead got %v", http.StatusNoContent, resp.StatusCode)
+		}
+
+		return nil
+	})
+}
+
+func DeleteObjects_empty_input(s *S3Conf) error {
+	testName := "DeleteObjects_empty_input"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		contents, err := putObjects(s, s3client, []string{"foo", "bar", "baz"}, bucket)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: &bucket,
+			Delete: &types.Delete{
+				Objects: []types.ObjectIdentifier{},
+			},
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if len(out.Deleted) != 0 {
+			return fmt.Errorf("expected deleted object count 0, instead got %v", len(out.Deleted))
+		}
+		if len(out.Errors) != 0 {
+			return fmt.Errorf("expected 0 errors, instead got %v", len(out.Errors))
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		res, err := s3client.ListObjects(ctx, &s3.ListObjectsInput{
+			Bucket: &bucket,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if !compareObjects(contents, res.Contents) {
+			return fmt.Errorf("expected the output to be %v, instead got %v", contents, res.Contents)
+		}
+
+		return nil
+	})
+}
+
+func DeleteObjects_non_existing_objects(s *S3Conf) error {
+	testName := "DeleteObjects_non_existing_objects"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		delObjects := []types.ObjectIdentifier{{Key: getPtr("obj1")}, {Key: getPtr("obj2")}}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: &bucket,
+			Delete: &types.Delete{
+				Objects: delObjects,
+			},
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if len(out.Deleted) != 2 {
+			return fmt.Errorf("expected deleted object count 2, instead got %v", len(out.Deleted))
+		}
+		if len(out.Errors) != 0 {
+			return fmt.Errorf("expected 0 errors, instead got %v, %v", len(out.Errors), out.Errors)
+		}
+
+		return nil
+	})
+}
+
+func DeleteObjects_success(s *S3Conf) error {
+	testName := "DeleteObjects_success"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		objects, objToDel := []string{"obj1", "obj2", "obj3"}, []string{"foo", "bar", "baz"}
+		contents, err := putObjects(s, s3client, append(objToDel, objects...), bucket)
+		if err != nil {
+			return err
+		}
+
+		delObjects := []types.ObjectIdentifier{}
+		delResult := []types.DeletedObject{}
+		for _, key := range objToDel {
+			k := key
+			delObjects = append(delObjects, types.ObjectIdentifier{Key: &k})
+			delResult = append(delResult, types.DeletedObject{Key: &k})
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: &bucket,
+			Delete: &types.Delete{
+				Objects: delObjects,
+			},
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if len(out.Deleted) != 3 {
+			return fmt.Errorf("expected deleted object count 3, instead got %v", len(out.Deleted))
+		}
+		if len(out.Errors) != 0 {
+			return fmt.Errorf("expected 2 errors, instead got %v", len(out.Errors))
+		}
+
+		if !compareDelObjects(delResult, out.Deleted) {
+			return fmt.Errorf("unexpected deleted output")
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		res, err := s3client.ListObjects(ctx, &s3.ListObjectsInput{
+			Bucket: &bucket,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if !compareObjects(contents[3:], res.Contents) {
+			return fmt.Errorf("expected the output to be %v, instead got %v", contents[3:], res.Contents)
+		}
+
+		return nil
+	})
+}
+func DeleteObjects_mixed_existing_and_non_existing(s *S3Conf) error {
+	testName := "DeleteObjects_mixed_existing_and_non_existing"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		objects := []string{"obj1", "obj2", "obj3"}
+		_, err := putObjects(s, s3client, objects, bucket)
+		if err != nil {
+			return err
+		}
+
+		// deleting a missing key is reported as deleted, not as an error
+		delObjects := []types.ObjectIdentifier{}
+		delResult := []types.DeletedObject{}
+		for _, key := range append(objects, "non-existing-obj") {
+			k := key
+			delObjects = append(delObjects, types.ObjectIdentifier{Key: &k})
+			delResult = append(delResult, types.DeletedObject{Key: &k})
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: &bucket,
+			Delete: &types.Delete{
+				Objects: delObjects,
+			},
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if len(out.Errors) != 0 {
+			return fmt.Errorf("expected 0 errors, instead got %v, %v", len(out.Errors), out.Errors)
+		}
+		if !compareDelObjects(delResult, out.Deleted) {
+			return fmt.Errorf("expected the deleted objects to be %v, instead got %v", delResult, out.Deleted)
 		}
 
-		resp, err := client.Do(req)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		res, err := s3client.ListObjects(ctx, &s3.ListObjectsInput{
+			Bucket: &bucket,
+		})
+		cancel()
 		if err != nil {
 			return err
 		}
 
-		if resp.StatusCode != http.StatusNoContent {
-			return fmt.Errorf("expected response status to be %v, instead got %v", http.StatusNoContent, resp.StatusCode)
+		if len(res.Contents) != 0 {
+			return fmt.Errorf("expected empty bucket, instead got %v objects", len(res.Contents))
 		}
 
 		return nil
 	})
 }
 
-func DeleteObjects_empty_input(s *S3Conf) error {
-	testName := "DeleteObjects_empty_input"
+func DeleteObjects_quiet_mode(s *S3Conf) error {
+	testName := "DeleteObjects_quiet_mode"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		contents, err := putObjects(s3client, []string{"foo", "bar", "baz"}, bucket)
+		objects := []string{"obj1", "obj2", "obj3"}
+		contents, err := putObjects(s, s3client, objects, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		delObjects := []types.ObjectIdentifier{{Key: getPtr("obj1")}, {Key: getPtr("obj2")}}
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
 			Bucket: &bucket,
 			Delete: &types.Delete{
-				Objects: []types.ObjectIdentifier{},
+				Objects: delObjects,
+				Quiet:   getBoolPtr(true),
 			},
 		})
 		cancel()
@@ -4525,14 +7316,15 @@ func DeleteObjects_empty_input(s *S3Conf) error {
 			return err
 		}
 
+		// the quiet mode response only lists the errors
 		if len(out.Deleted) != 0 {
-			return fmt.Errorf("expected deleted object count 0, instead got %v", len(out.Deleted))
+			return fmt.Errorf("expected no deleted objects in quiet mode, instead got %v", len(out.Deleted))
 		}
 		if len(out.Errors) != 0 {
-			return fmt.Errorf("expected 0 errors, instead got %v", len(out.Errors))
+			return fmt.Errorf("expected 0 errors, instead got %v, %v", len(out.Errors), out.Errors)
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.ListObjects(ctx, &s3.ListObjectsInput{
 			Bucket: &bucket,
 		})
@@ -4541,63 +7333,63 @@ func DeleteObjects_empty_input(s *S3Conf) error {
 			return err
 		}
 
-		if !compareObjects(contents, res.Contents) {
-			return fmt.Errorf("expected the output to be %v, instead got %v", contents, res.Contents)
+		if !compareObjects(contents[2:], res.Contents) {
+			return fmt.Errorf("expected the output to be %v, instead got %v", contents[2:], res.Contents)
 		}
 
 		return nil
 	})
 }
 
-func DeleteObjects_non_existing_objects(s *S3Conf) error {
-	testName := "DeleteObjects_empty_input"
+func DeleteObjects_exceeding_keys_limit(s *S3Conf) error {
+	testName := "DeleteObjects_exceeding_keys_limit"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		delObjects := []types.ObjectIdentifier{{Key: getPtr("obj1")}, {Key: getPtr("obj2")}}
+		objs := []string{"foo", "bar", "baz"}
+		contents, err := putObjects(s, s3client, objs, bucket)
+		if err != nil {
+			return err
+		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		out, err := s3client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+		delObjects := []types.ObjectIdentifier{}
+		for _, obj := range objs {
+			delObjects = append(delObjects, types.ObjectIdentifier{Key: getPtr(obj)})
+		}
+		for i := len(objs); i < 1001; i++ {
+			delObjects = append(delObjects, types.ObjectIdentifier{Key: getPtr(fmt.Sprintf("obj-%v", i))})
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
 			Bucket: &bucket,
 			Delete: &types.Delete{
 				Objects: delObjects,
 			},
 		})
 		cancel()
-		if err != nil {
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrMalformedXML)); err != nil {
 			return err
 		}
 
-		if len(out.Deleted) != 2 {
-			return fmt.Errorf("expected deleted object count 2, instead got %v", len(out.Deleted))
-		}
-		if len(out.Errors) != 0 {
-			return fmt.Errorf("expected 0 errors, instead got %v, %v", len(out.Errors), out.Errors)
-		}
-
-		return nil
-	})
-}
-
-func DeleteObjects_success(s *S3Conf) error {
-	testName := "DeleteObjects_success"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		objects, objToDel := []string{"obj1", "obj2", "obj3"}, []string{"foo", "bar", "baz"}
-		contents, err := putObjects(s3client, append(objToDel, objects...), bucket)
+		// nothing should be deleted on the rejected request
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		res, err := s3client.ListObjects(ctx, &s3.ListObjectsInput{
+			Bucket: &bucket,
+		})
+		cancel()
 		if err != nil {
 			return err
 		}
 
-		delObjects := []types.ObjectIdentifier{}
-		delResult := []types.DeletedObject{}
-		for _, key := range objToDel {
-			k := key
-			delObjects = append(delObjects, types.ObjectIdentifier{Key: &k})
-			delResult = append(delResult, types.DeletedObject{Key: &k})
+		if !compareObjects(contents, res.Contents) {
+			return fmt.Errorf("expected the output to be %v, instead got %v", contents, res.Contents)
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+
+		// exactly 1000 keys are allowed
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
 			Bucket: &bucket,
 			Delete: &types.Delete{
-				Objects: delObjects,
+				Objects: delObjects[:1000],
 			},
 		})
 		cancel()
@@ -4605,19 +7397,15 @@ func DeleteObjects_success(s *S3Conf) error {
 			return err
 		}
 
-		if len(out.Deleted) != 3 {
-			return fmt.Errorf("expected deleted object count 3, instead got %v", len(out.Deleted))
+		if len(out.Deleted) != 1000 {
+			return fmt.Errorf("expected deleted object count 1000, instead got %v", len(out.Deleted))
 		}
 		if len(out.Errors) != 0 {
-			return fmt.Errorf("expected 2 errors, instead got %v", len(out.Errors))
-		}
-
-		if !compareDelObjects(delResult, out.Deleted) {
-			return fmt.Errorf("unexpected deleted output")
+			return fmt.Errorf("expected 0 errors, instead got %v", len(out.Errors))
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
-		res, err := s3client.ListObjects(ctx, &s3.ListObjectsInput{
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		res, err = s3client.ListObjects(ctx, &s3.ListObjectsInput{
 			Bucket: &bucket,
 		})
 		cancel()
@@ -4625,8 +7413,8 @@ func DeleteObjects_success(s *S3Conf) error {
 			return err
 		}
 
-		if !compareObjects(contents[3:], res.Contents) {
-			return fmt.Errorf("expected the output to be %v, instead got %v", contents[3:], res.Contents)
+		if len(res.Contents) != 0 {
+			return fmt.Errorf("expected the bucket to be empty, instead got %v", objStrings(res.Contents))
 		}
 
 		return nil
@@ -4637,11 +7425,11 @@ func CopyObject_non_existing_dst_bucket(s *S3Conf) error {
 	testName := "CopyObject_non_existing_dst_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		_, err := putObjects(s3client, []string{obj}, bucket)
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
 		if err != nil {
 			return err
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.CopyObject(ctx, &s3.CopyObjectInput{
 			Bucket:     &bucket,
 			Key:        &obj,
@@ -4657,9 +7445,9 @@ func CopyObject_non_existing_dst_bucket(s *S3Conf) error {
 
 func CopyObject_not_owned_source_bucket(s *S3Conf) error {
 	testName := "CopyObject_not_owned_source_bucket"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) (err error) {
 		srcObj := "my-obj"
-		_, err := putObjects(s3client, []string{srcObj}, bucket)
+		_, err = putObjects(s, s3client, []string{srcObj}, bucket)
 		if err != nil {
 			return err
 		}
@@ -4674,7 +7462,7 @@ func CopyObject_not_owned_source_bucket(s *S3Conf) error {
 		cfg.awsID = usr.access
 		cfg.awsSecret = usr.secret
 
-		userS3Client := s3.NewFromConfig(cfg.Config())
+		userS3Client := cfg.GetClient()
 
 		err = createUsers(s, []user{usr})
 		if err != nil {
@@ -4686,13 +7474,14 @@ func CopyObject_not_owned_source_bucket(s *S3Conf) error {
 		if err != nil {
 			return err
 		}
+		defer teardownAfter(s, dstBucket, &err)
 
 		err = changeBucketsOwner(s, []string{bucket}, usr.access)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = userS3Client.CopyObject(ctx, &s3.CopyObjectInput{
 			Bucket:     &dstBucket,
 			Key:        getPtr("obj-1"),
@@ -4703,10 +7492,6 @@ func CopyObject_not_owned_source_bucket(s *S3Conf) error {
 			return err
 		}
 
-		err = teardown(s, dstBucket)
-		if err != nil {
-			return err
-		}
 		return nil
 	})
 }
@@ -4715,11 +7500,11 @@ func CopyObject_copy_to_itself(s *S3Conf) error {
 	testName := "CopyObject_copy_to_itself"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		_, err := putObjects(s3client, []string{obj}, bucket)
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
 		if err != nil {
 			return err
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.CopyObject(ctx, &s3.CopyObjectInput{
 			Bucket:     &bucket,
 			Key:        &obj,
@@ -4737,11 +7522,11 @@ func CopyObject_copy_to_itself_invalid_directive(s *S3Conf) error {
 	testName := "CopyObject_copy_to_itself_invalid_directive"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		_, err := putObjects(s3client, []string{obj}, bucket)
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
 		if err != nil {
 			return err
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.CopyObject(ctx, &s3.CopyObjectInput{
 			Bucket:            &bucket,
 			Key:               &obj,
@@ -4765,11 +7550,11 @@ func CopyObject_to_itself_with_new_metadata(s *S3Conf) error {
 
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		_, err := putObjects(s3client, []string{obj}, bucket)
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
 		if err != nil {
 			return err
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.CopyObject(ctx, &s3.CopyObjectInput{
 			Bucket:            &bucket,
 			Key:               &obj,
@@ -4782,7 +7567,7 @@ func CopyObject_to_itself_with_new_metadata(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		resp, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -4800,7 +7585,7 @@ func CopyObject_to_itself_with_new_metadata(s *S3Conf) error {
 		meta = map[string]string{
 			"New": "Metadata",
 		}
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.CopyObject(ctx, &s3.CopyObjectInput{
 			Bucket:            &bucket,
 			Key:               &obj,
@@ -4813,34 +7598,228 @@ func CopyObject_to_itself_with_new_metadata(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
-		resp, err = s3client.HeadObject(ctx, &s3.HeadObjectInput{
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		resp, err = s3client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if !areMapsSame(resp.Metadata, meta) {
+			return fmt.Errorf("expected uploaded object metadata to be %v, instead got %v", meta, resp.Metadata)
+		}
+
+		return nil
+	})
+}
+
+func CopyObject_CopySource_starting_with_slash(s *S3Conf) error {
+	testName := "CopyObject_CopySource_starting_with_slash"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) (err error) {
+		dataLength, obj := int64(1234567), "src-obj"
+		dstBucket := getBucketName()
+		err = setup(s, dstBucket)
+		if err != nil {
+			return err
+		}
+		defer teardownAfter(s, dstBucket, &err)
+
+		r, err := putObjectWithData(s, dataLength, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.CopyObject(ctx, &s3.CopyObjectInput{
+			Bucket:     &dstBucket,
+			Key:        &obj,
+			CopySource: getPtr(fmt.Sprintf("/%v/%v", bucket, obj)),
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: &dstBucket,
+			Key:    &obj,
+		})
+		defer cancel()
+		if err != nil {
+			return err
+		}
+		if *out.ContentLength != dataLength {
+			return fmt.Errorf("expected content-length %v, instead got %v", dataLength, *out.ContentLength)
+		}
+
+		defer out.Body.Close()
+
+		bdy, err := io.ReadAll(out.Body)
+		if err != nil {
+			return err
+		}
+		outCsum := sha256.Sum256(bdy)
+		if outCsum != r.csum {
+			return fmt.Errorf("invalid object data")
+		}
+
+		return nil
+	})
+}
+
+func CopyObject_non_existing_dir_object(s *S3Conf) error {
+	testName := "CopyObject_non_existing_dir_object"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) (err error) {
+		dataLength, obj := int64(1234567), "my-obj"
+		dstBucket := getBucketName()
+		err = setup(s, dstBucket)
+		if err != nil {
+			return err
+		}
+		defer teardownAfter(s, dstBucket, &err)
+
+		_, err = putObjectWithData(s, dataLength, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+
+		obj = "my-obj/"
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.CopyObject(ctx, &s3.CopyObjectInput{
+			Bucket:     &dstBucket,
+			Key:        &obj,
+			CopySource: getPtr(fmt.Sprintf("%v/%v", bucket, obj)),
+		})
+		cancel()
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrNoSuchKey)); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func CopyObject_success(s *S3Conf) error {
+	testName := "CopyObject_success"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) (err error) {
+		dataLength, obj := int64(1234567), "my obj with spaces"
+		dstBucket := getBucketName()
+		err = setup(s, dstBucket)
+		if err != nil {
+			return err
+		}
+		defer teardownAfter(s, dstBucket, &err)
+
+		r, err := putObjectWithData(s, dataLength, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.CopyObject(ctx, &s3.CopyObjectInput{
+			Bucket:     &dstBucket,
+			Key:        &obj,
+			CopySource: getPtr(fmt.Sprintf("%v/%v", bucket, obj)),
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: &dstBucket,
+			Key:    &obj,
+		})
+		defer cancel()
+		if err != nil {
+			return err
+		}
+		if *out.ContentLength != dataLength {
+			return fmt.Errorf("expected content-length %v, instead got %v", dataLength, *out.ContentLength)
+		}
+
+		bdy, err := io.ReadAll(out.Body)
+		if err != nil {
+			return err
+		}
+		defer out.Body.Close()
+		outCsum := sha256.Sum256(bdy)
+		if outCsum != r.csum {
+			return fmt.Errorf("invalid object data")
+		}
+
+		return nil
+	})
+}
+
+func CopyObject_etag_matches_source(s *S3Conf) error {
+	testName := "CopyObject_etag_matches_source"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj, dstObj := "my-obj", "my-obj-copy"
+		r, err := putObjectWithData(s, 2345, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.CopyObject(ctx, &s3.CopyObjectInput{
+			Bucket:     &bucket,
+			Key:        &dstObj,
+			CopySource: getPtr(fmt.Sprintf("%v/%v", bucket, obj)),
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if out.CopyObjectResult == nil {
+			return fmt.Errorf("expected non nil copy object result")
+		}
+		if getString(out.CopyObjectResult.ETag) != getString(r.res.ETag) {
+			return fmt.Errorf("expected the copy object result etag to be %v, instead got %v", getString(r.res.ETag), getString(out.CopyObjectResult.ETag))
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		resp, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
 			Bucket: &bucket,
-			Key:    &obj,
+			Key:    &dstObj,
 		})
 		cancel()
 		if err != nil {
 			return err
 		}
 
-		if !areMapsSame(resp.Metadata, meta) {
-			return fmt.Errorf("expected uploaded object metadata to be %v, instead got %v", meta, resp.Metadata)
+		if getString(resp.ETag) != getString(r.res.ETag) {
+			return fmt.Errorf("expected the destination object etag to be %v, instead got %v", getString(r.res.ETag), getString(resp.ETag))
 		}
 
 		return nil
 	})
 }
 
-func CopyObject_CopySource_starting_with_slash(s *S3Conf) error {
-	testName := "CopyObject_CopySource_starting_with_slash"
+func CopyObject_ignores_copy_source_range(s *S3Conf) error {
+	testName := "CopyObject_ignores_copy_source_range"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		dataLength, obj := int64(1234567), "src-obj"
-		dstBucket := getBucketName()
-		if err := setup(s, dstBucket); err != nil {
-			return err
-		}
-
-		r, err := putObjectWithData(dataLength, &s3.PutObjectInput{
+		dataLength, obj, dstObj := int64(1234567), "my-obj", "my-obj-copy"
+		r, err := putObjectWithData(s, dataLength, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		}, s3client)
@@ -4848,32 +7827,56 @@ func CopyObject_CopySource_starting_with_slash(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		_, err = s3client.CopyObject(ctx, &s3.CopyObjectInput{
-			Bucket:     &dstBucket,
-			Key:        &obj,
-			CopySource: getPtr(fmt.Sprintf("/%v/%v", bucket, obj)),
-		})
-		cancel()
+		// The sdk doesn't allow a copy source range on CopyObject,
+		// so the request is sent directly
+		req, err := createSignedReq(
+			http.MethodPut,
+			s.endpoint,
+			fmt.Sprintf("%v/%v", bucket, dstObj),
+			s.awsID,
+			s.awsSecret,
+			"s3",
+			s.awsRegion,
+			nil,
+			time.Now(),
+			map[string]string{
+				"X-Amz-Copy-Source":       fmt.Sprintf("%v/%v", bucket, obj),
+				"X-Amz-Copy-Source-Range": "bytes=0-99",
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		client := http.Client{
+			Timeout: s.opTimeout(),
+		}
+
+		resp, err := client.Do(req)
 		if err != nil {
 			return err
 		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("expected the response status to be %v, instead got %v", http.StatusOK, resp.StatusCode)
+		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
-			Bucket: &dstBucket,
-			Key:    &obj,
+			Bucket: &bucket,
+			Key:    &dstObj,
 		})
 		defer cancel()
 		if err != nil {
 			return err
 		}
+		defer out.Body.Close()
+
 		if *out.ContentLength != dataLength {
-			return fmt.Errorf("expected content-length %v, instead got %v", dataLength, *out.ContentLength)
+			return fmt.Errorf("expected the whole object to be copied with content-length %v, instead got %v", dataLength, *out.ContentLength)
 		}
 
-		defer out.Body.Close()
-
 		bdy, err := io.ReadAll(out.Body)
 		if err != nil {
 			return err
@@ -4883,109 +7886,173 @@ func CopyObject_CopySource_starting_with_slash(s *S3Conf) error {
 			return fmt.Errorf("invalid object data")
 		}
 
-		if err := teardown(s, dstBucket); err != nil {
-			return err
-		}
-
 		return nil
 	})
 }
 
-func CopyObject_non_existing_dir_object(s *S3Conf) error {
-	testName := "CopyObject_non_existing_dir_object"
+func CopyObject_move_source(s *S3Conf) error {
+	testName := "CopyObject_move_source"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		dataLength, obj := int64(1234567), "my-obj"
-		dstBucket := getBucketName()
-		err := setup(s, dstBucket)
+		dataLength, obj, dstObj := int64(1234567), "foo/bar/my-obj", "baz/my-obj-moved"
+		meta := map[string]string{
+			"key1": "val1",
+			"key2": "val2",
+		}
+		r, err := putObjectWithData(s, dataLength, &s3.PutObjectInput{
+			Bucket:   &bucket,
+			Key:      &obj,
+			Metadata: meta,
+		}, s3client)
 		if err != nil {
 			return err
 		}
 
-		_, err = putObjectWithData(dataLength, &s3.PutObjectInput{
-			Bucket: &bucket,
-			Key:    &obj,
-		}, s3client)
+		// The move intent is signaled with a custom header,
+		// so the request is sent directly
+		req, err := createSignedReq(
+			http.MethodPut,
+			s.endpoint,
+			fmt.Sprintf("%v/%v", bucket, dstObj),
+			s.awsID,
+			s.awsSecret,
+			"s3",
+			s.awsRegion,
+			nil,
+			time.Now(),
+			map[string]string{
+				"X-Amz-Copy-Source":     fmt.Sprintf("%v/%v", bucket, obj),
+				"X-Versity-Move-Source": "true",
+			},
+		)
 		if err != nil {
 			return err
 		}
 
-		obj = "my-obj/"
+		client := http.Client{
+			Timeout: s.opTimeout(),
+		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		_, err = s3client.CopyObject(ctx, &s3.CopyObjectInput{
-			Bucket:     &dstBucket,
-			Key:        &obj,
-			CopySource: getPtr(fmt.Sprintf("%v/%v", bucket, obj)),
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("expected the response status to be %v, instead got %v", http.StatusOK, resp.StatusCode)
+		}
+
+		// the source object should be removed
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
 		})
 		cancel()
-		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrNoSuchKey)); err != nil {
+		if err := checkSdkApiErr(err, "NotFound"); err != nil {
 			return err
 		}
 
-		err = teardown(s, dstBucket)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: &bucket,
+			Key:    &dstObj,
+		})
+		defer cancel()
 		if err != nil {
-			return nil
+			return err
+		}
+		defer out.Body.Close()
+
+		if getString(out.ETag) != getString(r.res.ETag) {
+			return fmt.Errorf("expected the moved object etag to be %v, instead got %v",
+				getString(r.res.ETag), getString(out.ETag))
+		}
+		if !areMapsSame(out.Metadata, meta) {
+			return fmt.Errorf("expected the moved object metadata to be %v, instead got %v",
+				meta, out.Metadata)
+		}
+		if *out.ContentLength != dataLength {
+			return fmt.Errorf("expected the moved object content-length to be %v, instead got %v",
+				dataLength, *out.ContentLength)
+		}
+
+		bdy, err := io.ReadAll(out.Body)
+		if err != nil {
+			return err
+		}
+		if sha256.Sum256(bdy) != r.csum {
+			return fmt.Errorf("invalid object data")
 		}
 
 		return nil
 	})
 }
 
-func CopyObject_success(s *S3Conf) error {
-	testName := "CopyObject_success"
+func CopyObject_move_source_to_itself(s *S3Conf) error {
+	testName := "CopyObject_move_source_to_itself"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		dataLength, obj := int64(1234567), "my obj with spaces"
-		dstBucket := getBucketName()
-		err := setup(s, dstBucket)
+		obj := "my-obj"
+		r, err := putObjectWithData(s, 1234, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		}, s3client)
 		if err != nil {
 			return err
 		}
 
-		r, err := putObjectWithData(dataLength, &s3.PutObjectInput{
-			Bucket: &bucket,
-			Key:    &obj,
-		}, s3client)
+		req, err := createSignedReq(
+			http.MethodPut,
+			s.endpoint,
+			fmt.Sprintf("%v/%v", bucket, obj),
+			s.awsID,
+			s.awsSecret,
+			"s3",
+			s.awsRegion,
+			nil,
+			time.Now(),
+			map[string]string{
+				"X-Amz-Copy-Source":        fmt.Sprintf("%v/%v", bucket, obj),
+				"X-Amz-Metadata-Directive": "REPLACE",
+				"X-Versity-Move-Source":    "true",
+			},
+		)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		_, err = s3client.CopyObject(ctx, &s3.CopyObjectInput{
-			Bucket:     &dstBucket,
-			Key:        &obj,
-			CopySource: getPtr(fmt.Sprintf("%v/%v", bucket, obj)),
-		})
-		cancel()
+		client := http.Client{
+			Timeout: s.opTimeout(),
+		}
+
+		resp, err := client.Do(req)
 		if err != nil {
 			return err
 		}
+		defer resp.Body.Close()
+
+		if err := checkAuthErr(resp, s3err.GetAPIError(s3err.ErrInvalidCopyDest)); err != nil {
+			return err
+		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		// the object should be left in place
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
-			Bucket: &dstBucket,
+			Bucket: &bucket,
 			Key:    &obj,
 		})
 		defer cancel()
 		if err != nil {
 			return err
 		}
-		if *out.ContentLength != dataLength {
-			return fmt.Errorf("expected content-length %v, instead got %v", dataLength, *out.ContentLength)
-		}
+		defer out.Body.Close()
 
 		bdy, err := io.ReadAll(out.Body)
 		if err != nil {
 			return err
 		}
-		defer out.Body.Close()
-		outCsum := sha256.Sum256(bdy)
-		if outCsum != r.csum {
-			return fmt.Errorf("invalid object data")
-		}
-
-		err = teardown(s, dstBucket)
-		if err != nil {
-			return nil
+		if !isEqual(bdy, r.data) {
+			return fmt.Errorf("expected the object data to be left unchanged")
 		}
 
 		return nil
@@ -4995,7 +8062,7 @@ func CopyObject_success(s *S3Conf) error {
 func PutObjectTagging_non_existing_object(s *S3Conf) error {
 	testName := "PutObjectTagging_non_existing_object"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObjectTagging(ctx, &s3.PutObjectTaggingInput{
 			Bucket:  &bucket,
 			Key:     getPtr("my-obj"),
@@ -5013,12 +8080,24 @@ func PutObjectTagging_long_tags(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
 		tagging := types.Tagging{TagSet: []types.Tag{{Key: getPtr(genRandString(129)), Value: getPtr("val")}}}
-		_, err := putObjects(s3client, []string{obj}, bucket)
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.PutObjectTagging(ctx, &s3.PutObjectTaggingInput{
+			Bucket:  &bucket,
+			Key:     &obj,
+			Tagging: &tagging})
+		cancel()
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrInvalidTag)); err != nil {
+			return err
+		}
+
+		tagging = types.Tagging{TagSet: []types.Tag{{Key: getPtr("key"), Value: getPtr(genRandString(257))}}}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectTagging(ctx, &s3.PutObjectTaggingInput{
 			Bucket:  &bucket,
 			Key:     &obj,
@@ -5028,42 +8107,234 @@ func PutObjectTagging_long_tags(s *S3Conf) error {
 			return err
 		}
 
-		tagging = types.Tagging{TagSet: []types.Tag{{Key: getPtr("key"), Value: getPtr(genRandString(257))}}}
-
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		return nil
+	})
+}
+
+func PutObjectTagging_tag_count_limit(s *S3Conf) error {
+	testName := "PutObjectTagging_tag_count_limit"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-obj"
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
+		if err != nil {
+			return err
+		}
+
+		tagSet := []types.Tag{}
+		for i := 0; i < 10; i++ {
+			tagSet = append(tagSet, types.Tag{
+				Key:   getPtr(fmt.Sprintf("key-%v", i)),
+				Value: getPtr(fmt.Sprintf("val-%v", i)),
+			})
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.PutObjectTagging(ctx, &s3.PutObjectTaggingInput{
+			Bucket:  &bucket,
+			Key:     &obj,
+			Tagging: &types.Tagging{TagSet: tagSet},
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		tagSet = append(tagSet, types.Tag{
+			Key:   getPtr("key-10"),
+			Value: getPtr("val-10"),
+		})
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.PutObjectTagging(ctx, &s3.PutObjectTaggingInput{
+			Bucket:  &bucket,
+			Key:     &obj,
+			Tagging: &types.Tagging{TagSet: tagSet},
+		})
+		cancel()
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrObjectTaggingLimited)); err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.GetObjectTagging(ctx, &s3.GetObjectTaggingInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if !areTagsSame(out.TagSet, tagSet[:10]) {
+			return fmt.Errorf("expected the object tags to be %v, instead got %v",
+				tagSet[:10], out.TagSet)
+		}
+
+		return nil
+	})
+}
+
+func PutObjectTagging_invalid_tag_chars(s *S3Conf) error {
+	testName := "PutObjectTagging_invalid_tag_chars"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-obj"
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
+		if err != nil {
+			return err
+		}
+
+		for _, tag := range []types.Tag{
+			{Key: getPtr("key$"), Value: getPtr("val")},
+			{Key: getPtr("key"), Value: getPtr("val#")},
+			{Key: getPtr("key?"), Value: getPtr("val!")},
+		} {
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			_, err = s3client.PutObjectTagging(ctx, &s3.PutObjectTaggingInput{
+				Bucket:  &bucket,
+				Key:     &obj,
+				Tagging: &types.Tagging{TagSet: []types.Tag{tag}},
+			})
+			cancel()
+			if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrInvalidTag)); err != nil {
+				return err
+			}
+		}
+
+		// letters, numbers, spaces and + - = . _ : / @ are allowed
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.PutObjectTagging(ctx, &s3.PutObjectTaggingInput{
+			Bucket: &bucket,
+			Key:    &obj,
+			Tagging: &types.Tagging{TagSet: []types.Tag{
+				{Key: getPtr("my key+-=._:/@"), Value: getPtr("my value+-=._:/@")},
+			}},
+		})
+		cancel()
+		return err
+	})
+}
+
+func PutObjectTagging_success(s *S3Conf) error {
+	testName := "PutObjectTagging_success"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-obj"
+		tagging := types.Tagging{TagSet: []types.Tag{{Key: getPtr("key1"), Value: getPtr("val2")}, {Key: getPtr("key2"), Value: getPtr("val2")}}}
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectTagging(ctx, &s3.PutObjectTaggingInput{
 			Bucket:  &bucket,
 			Key:     &obj,
 			Tagging: &tagging})
 		cancel()
-		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrInvalidTag)); err != nil {
+		if err != nil {
 			return err
 		}
 
 		return nil
 	})
 }
+func PutObjectTagging_overwrite_tags(s *S3Conf) error {
+	testName := "PutObjectTagging_overwrite_tags"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-obj"
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
+		if err != nil {
+			return err
+		}
 
-func PutObjectTagging_success(s *S3Conf) error {
-	testName := "PutObjectTagging_success"
+		for _, tagging := range []types.Tagging{
+			{TagSet: []types.Tag{{Key: getPtr("key1"), Value: getPtr("val1")}, {Key: getPtr("key2"), Value: getPtr("val2")}}},
+			{TagSet: []types.Tag{{Key: getPtr("key3"), Value: getPtr("val3")}}},
+		} {
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			_, err = s3client.PutObjectTagging(ctx, &s3.PutObjectTaggingInput{
+				Bucket:  &bucket,
+				Key:     &obj,
+				Tagging: &tagging,
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+			out, err := s3client.GetObjectTagging(ctx, &s3.GetObjectTaggingInput{
+				Bucket: &bucket,
+				Key:    &obj,
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
+
+			if !areTagsSame(out.TagSet, tagging.TagSet) {
+				return fmt.Errorf("expected %v instead got %v", tagging.TagSet, out.TagSet)
+			}
+		}
+
+		return nil
+	})
+}
+
+func PutObjectTagging_object_overwrite(s *S3Conf) error {
+	testName := "PutObjectTagging_object_overwrite"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		tagging := types.Tagging{TagSet: []types.Tag{{Key: getPtr("key1"), Value: getPtr("val2")}, {Key: getPtr("key2"), Value: getPtr("val2")}}}
-		_, err := putObjects(s3client, []string{obj}, bucket)
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectTagging(ctx, &s3.PutObjectTaggingInput{
 			Bucket:  &bucket,
 			Key:     &obj,
-			Tagging: &tagging})
+			Tagging: &types.Tagging{TagSet: []types.Tag{{Key: getPtr("key1"), Value: getPtr("val1")}}},
+		})
 		cancel()
 		if err != nil {
 			return err
 		}
 
+		// Overwriting the object replaces its tags with the ones sent in
+		// the new PutObject request, if any.
+		for _, test := range []struct {
+			tagging *string
+			tags    []types.Tag
+		}{
+			{nil, nil},
+			{getPtr("key2=val2"), []types.Tag{{Key: getPtr("key2"), Value: getPtr("val2")}}},
+		} {
+			ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+			_, err = s3client.PutObject(ctx, &s3.PutObjectInput{
+				Bucket:  &bucket,
+				Key:     &obj,
+				Tagging: test.tagging,
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+			out, err := s3client.GetObjectTagging(ctx, &s3.GetObjectTaggingInput{
+				Bucket: &bucket,
+				Key:    &obj,
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
+
+			if !areTagsSame(out.TagSet, test.tags) {
+				return fmt.Errorf("expected %v instead got %v", test.tags, out.TagSet)
+			}
+		}
+
 		return nil
 	})
 }
@@ -5071,7 +8342,7 @@ func PutObjectTagging_success(s *S3Conf) error {
 func GetObjectTagging_non_existing_object(s *S3Conf) error {
 	testName := "GetObjectTagging_non_existing_object"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.GetObjectTagging(ctx, &s3.GetObjectTaggingInput{
 			Bucket: &bucket,
 			Key:    getPtr("my-obj"),
@@ -5088,34 +8359,38 @@ func GetObjectTagging_unset_tags(s *S3Conf) error {
 	testName := "GetObjectTagging_unset_tags"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		_, err := putObjects(s3client, []string{obj}, bucket)
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
 		if err != nil {
 			return err
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		_, err = s3client.GetObjectTagging(ctx, &s3.GetObjectTaggingInput{
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.GetObjectTagging(ctx, &s3.GetObjectTaggingInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		})
 		cancel()
-		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrBucketTaggingNotFound)); err != nil {
+		if err != nil {
 			return err
 		}
+
+		if len(out.TagSet) > 0 {
+			return fmt.Errorf("expected empty tag set, instead got %v", out.TagSet)
+		}
 		return nil
 	})
 }
 
 func GetObjectTagging_success(s *S3Conf) error {
-	testName := "PutObjectTagging_success"
+	testName := "GetObjectTagging_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
 		tagging := types.Tagging{TagSet: []types.Tag{{Key: getPtr("key1"), Value: getPtr("val2")}, {Key: getPtr("key2"), Value: getPtr("val2")}}}
-		_, err := putObjects(s3client, []string{obj}, bucket)
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectTagging(ctx, &s3.PutObjectTaggingInput{
 			Bucket:  &bucket,
 			Key:     &obj,
@@ -5125,14 +8400,14 @@ func GetObjectTagging_success(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.GetObjectTagging(ctx, &s3.GetObjectTaggingInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		})
 		cancel()
 		if err != nil {
-			return nil
+			return err
 		}
 
 		if !areTagsSame(out.TagSet, tagging.TagSet) {
@@ -5146,7 +8421,7 @@ func GetObjectTagging_success(s *S3Conf) error {
 func DeleteObjectTagging_non_existing_object(s *S3Conf) error {
 	testName := "DeleteObjectTagging_non_existing_object"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.DeleteObjectTagging(ctx, &s3.DeleteObjectTaggingInput{
 			Bucket: &bucket,
 			Key:    getPtr("my-obj"),
@@ -5163,7 +8438,7 @@ func DeleteObjectTagging_success_status(s *S3Conf) error {
 	testName := "DeleteObjectTagging_success_status"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		_, err := putObjects(s3client, []string{obj}, bucket)
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
 		if err != nil {
 			return err
 		}
@@ -5177,7 +8452,7 @@ func DeleteObjectTagging_success_status(s *S3Conf) error {
 			},
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectTagging(ctx, &s3.PutObjectTaggingInput{
 			Bucket:  &bucket,
 			Key:     &obj,
@@ -5194,7 +8469,7 @@ func DeleteObjectTagging_success_status(s *S3Conf) error {
 		}
 
 		client := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		resp, err := client.Do(req)
@@ -5215,12 +8490,12 @@ func DeleteObjectTagging_success(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
 		tagging := types.Tagging{TagSet: []types.Tag{{Key: getPtr("key1"), Value: getPtr("val2")}, {Key: getPtr("key2"), Value: getPtr("val2")}}}
-		_, err := putObjects(s3client, []string{obj}, bucket)
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectTagging(ctx, &s3.PutObjectTaggingInput{
 			Bucket:  &bucket,
 			Key:     &obj,
@@ -5230,24 +8505,24 @@ func DeleteObjectTagging_success(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.DeleteObjectTagging(ctx, &s3.DeleteObjectTaggingInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		})
 		cancel()
 		if err != nil {
-			return nil
+			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.GetObjectTagging(ctx, &s3.GetObjectTaggingInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		})
 		cancel()
 		if err != nil {
-			return nil
+			return err
 		}
 
 		if len(out.TagSet) > 0 {
@@ -5262,7 +8537,7 @@ func CreateMultipartUpload_non_existing_bucket(s *S3Conf) error {
 	testName := "CreateMultipartUpload_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		bucketName := getBucketName()
-		_, err := createMp(s3client, bucketName, "my-obj")
+		_, err := createMp(s, s3client, bucketName, "my-obj")
 		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrNoSuchBucket)); err != nil {
 			return err
 		}
@@ -5282,7 +8557,7 @@ func CreateMultipartUpload_with_metadata(s *S3Conf) error {
 		contentType := "application/text"
 		contentEncoding := "testenc"
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
 			Bucket:          &bucket,
 			Key:             &obj,
@@ -5295,7 +8570,7 @@ func CreateMultipartUpload_with_metadata(s *S3Conf) error {
 			return err
 		}
 
-		parts, _, err := uploadParts(s3client, 100, 1, bucket, obj, *out.UploadId)
+		parts, _, err := uploadParts(s, s3client, 100, 1, bucket, obj, *out.UploadId)
 		if err != nil {
 			return err
 		}
@@ -5308,7 +8583,7 @@ func CreateMultipartUpload_with_metadata(s *S3Conf) error {
 			})
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
 			Bucket:   &bucket,
 			Key:      &obj,
@@ -5322,7 +8597,7 @@ func CreateMultipartUpload_with_metadata(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		resp, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -5358,7 +8633,7 @@ func CreateMultipartUpload_with_content_type(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
 		cType := "application/octet-stream"
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
 			Bucket:      &bucket,
 			Key:         &obj,
@@ -5369,7 +8644,7 @@ func CreateMultipartUpload_with_content_type(s *S3Conf) error {
 			return err
 		}
 
-		parts, _, err := uploadParts(s3client, 100, 1, bucket, obj, *out.UploadId)
+		parts, _, err := uploadParts(s, s3client, 100, 1, bucket, obj, *out.UploadId)
 		if err != nil {
 			return err
 		}
@@ -5382,7 +8657,7 @@ func CreateMultipartUpload_with_content_type(s *S3Conf) error {
 			})
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
 			Bucket:   &bucket,
 			Key:      &obj,
@@ -5396,7 +8671,7 @@ func CreateMultipartUpload_with_content_type(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		resp, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -5419,7 +8694,7 @@ func CreateMultipartUpload_with_object_lock(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
 		retainUntilDate := time.Now().Add(24 * time.Hour)
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
 			Bucket:                    &bucket,
 			Key:                       &obj,
@@ -5432,7 +8707,7 @@ func CreateMultipartUpload_with_object_lock(s *S3Conf) error {
 			return err
 		}
 
-		parts, _, err := uploadParts(s3client, 100, 1, bucket, obj, *out.UploadId)
+		parts, _, err := uploadParts(s, s3client, 100, 1, bucket, obj, *out.UploadId)
 		if err != nil {
 			return err
 		}
@@ -5445,7 +8720,7 @@ func CreateMultipartUpload_with_object_lock(s *S3Conf) error {
 			})
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
 			Bucket:   &bucket,
 			Key:      &obj,
@@ -5459,7 +8734,7 @@ func CreateMultipartUpload_with_object_lock(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		resp, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -5476,7 +8751,7 @@ func CreateMultipartUpload_with_object_lock(s *S3Conf) error {
 			return fmt.Errorf("expected uploaded object lock mode to be %v, instead got %v", types.ObjectLockModeGovernance, resp.ObjectLockMode)
 		}
 
-		if err := changeBucketObjectLockStatus(s3client, bucket, false); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, false); err != nil {
 			return err
 		}
 
@@ -5488,7 +8763,7 @@ func CreateMultipartUpload_with_object_lock_not_enabled(s *S3Conf) error {
 	testName := "CreateMultipartUpload_with_object_lock_not_enabled"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
 			Bucket:                    &bucket,
 			Key:                       &obj,
@@ -5508,7 +8783,7 @@ func CreateMultipartUpload_with_object_lock_invalid_retention(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
 		retentionDate := time.Now().Add(24 * time.Hour)
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
 			Bucket:         &bucket,
 			Key:            &obj,
@@ -5519,7 +8794,7 @@ func CreateMultipartUpload_with_object_lock_invalid_retention(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
 			Bucket:                    &bucket,
 			Key:                       &obj,
@@ -5539,7 +8814,7 @@ func CreateMultipartUpload_past_retain_until_date(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
 		rDate := time.Now().Add(-5 * time.Hour)
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
 			Bucket:                    &bucket,
 			Key:                       &obj,
@@ -5559,7 +8834,7 @@ func CreateMultipartUpload_with_invalid_tagging(s *S3Conf) error {
 	testName := "CreateMultipartUpload_with_invalid_tagging"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
 			Bucket:  &bucket,
 			Key:     &obj,
@@ -5579,7 +8854,7 @@ func CreateMultipartUpload_with_tagging(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
 		tagging := "key1=val1&key2=val2"
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
 			Bucket:  &bucket,
 			Key:     &obj,
@@ -5590,7 +8865,7 @@ func CreateMultipartUpload_with_tagging(s *S3Conf) error {
 			return err
 		}
 
-		parts, _, err := uploadParts(s3client, 100, 1, bucket, obj, *out.UploadId)
+		parts, _, err := uploadParts(s, s3client, 100, 1, bucket, obj, *out.UploadId)
 		if err != nil {
 			return err
 		}
@@ -5603,7 +8878,7 @@ func CreateMultipartUpload_with_tagging(s *S3Conf) error {
 			})
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
 			Bucket:   &bucket,
 			Key:      &obj,
@@ -5617,7 +8892,7 @@ func CreateMultipartUpload_with_tagging(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		resp, err := s3client.GetObjectTagging(ctx, &s3.GetObjectTaggingInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -5650,7 +8925,7 @@ func CreateMultipartUpload_success(s *S3Conf) error {
 	testName := "CreateMultipartUpload_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		out, err := createMp(s3client, bucket, obj)
+		out, err := createMp(s, s3client, bucket, obj)
 		if err != nil {
 			return err
 		}
@@ -5666,12 +8941,162 @@ func CreateMultipartUpload_success(s *S3Conf) error {
 	})
 }
 
+func CreateMultipartUpload_upload_id_uniqueness(s *S3Conf) error {
+	testName := "CreateMultipartUpload_upload_id_uniqueness"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-obj"
+
+		// concurrent uploads on the same key get distinct upload ids
+		var wg sync.WaitGroup
+		mps := make([]*s3.CreateMultipartUploadOutput, 2)
+		errs := make([]error, 2)
+		for i := range mps {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				mps[i], errs[i] = createMp(s, s3client, bucket, obj)
+			}(i)
+		}
+		wg.Wait()
+		for _, err := range errs {
+			if err != nil {
+				return err
+			}
+		}
+
+		first, second := *mps[0].UploadId, *mps[1].UploadId
+		if first == second {
+			return fmt.Errorf("expected distinct upload ids, instead got %v twice", first)
+		}
+
+		objSize := int64(5 * 1024 * 1024)
+		firstParts, csum, err := uploadParts(s, s3client, objSize, 1, bucket, obj, first)
+		if err != nil {
+			return err
+		}
+		secondParts, _, err := uploadParts(s, s3client, objSize, 1, bucket, obj, second)
+		if err != nil {
+			return err
+		}
+
+		// the parts of the uploads don't cross-contaminate
+		for _, upload := range []struct {
+			uploadId string
+			parts    []types.Part
+		}{
+			{first, firstParts},
+			{second, secondParts},
+		} {
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			out, err := s3client.ListParts(ctx, &s3.ListPartsInput{
+				Bucket:   &bucket,
+				Key:      &obj,
+				UploadId: &upload.uploadId,
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
+
+			if len(out.Parts) != 1 {
+				return fmt.Errorf("expected 1 part for the upload %v, instead got %v", upload.uploadId, len(out.Parts))
+			}
+			if getString(out.Parts[0].ETag) != getString(upload.parts[0].ETag) {
+				return fmt.Errorf("expected the upload %v part etag to be %v, instead got %v",
+					upload.uploadId, getString(upload.parts[0].ETag), getString(out.Parts[0].ETag))
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:   &bucket,
+			Key:      &obj,
+			UploadId: &first,
+			MultipartUpload: &types.CompletedMultipartUpload{
+				Parts: []types.CompletedPart{
+					{
+						ETag:       firstParts[0].ETag,
+						PartNumber: firstParts[0].PartNumber,
+					},
+				},
+			},
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+			Bucket:   &bucket,
+			Key:      &obj,
+			UploadId: &second,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		// the object has the completed upload data only
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		defer cancel()
+		if err != nil {
+			return err
+		}
+		defer out.Body.Close()
+
+		bdy, err := io.ReadAll(out.Body)
+		if err != nil {
+			return err
+		}
+
+		sum := sha256.Sum256(bdy)
+		if getsum := hex.EncodeToString(sum[:]); getsum != csum {
+			return fmt.Errorf("expected the object checksum to be %v, instead got %v", csum, getsum)
+		}
+
+		// the completed and aborted upload ids can't be reused
+		partNumber := int32(1)
+		for _, uploadId := range []string{first, second} {
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			_, err := s3client.UploadPart(ctx, &s3.UploadPartInput{
+				Bucket:     &bucket,
+				Key:        &obj,
+				UploadId:   &uploadId,
+				PartNumber: &partNumber,
+				Body:       strings.NewReader("data"),
+			})
+			cancel()
+			if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrNoSuchUpload)); err != nil {
+				return err
+			}
+
+			ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+			_, err = s3client.ListParts(ctx, &s3.ListPartsInput{
+				Bucket:   &bucket,
+				Key:      &obj,
+				UploadId: &uploadId,
+			})
+			cancel()
+			if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrNoSuchUpload)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
 func UploadPart_non_existing_bucket(s *S3Conf) error {
 	testName := "UploadPart_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		bucketName := getBucketName()
 		partNumber := int32(1)
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.UploadPart(ctx, &s3.UploadPartInput{
 			Bucket:     &bucketName,
 			Key:        getPtr("my-obj"),
@@ -5691,7 +9116,7 @@ func UploadPart_invalid_part_number(s *S3Conf) error {
 	testName := "UploadPart_invalid_part_number"
 	partNumber := int32(-10)
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.UploadPart(ctx, &s3.UploadPartInput{
 			Bucket:     &bucket,
 			Key:        getPtr("my-obj"),
@@ -5710,7 +9135,7 @@ func UploadPart_non_existing_mp_upload(s *S3Conf) error {
 	testName := "UploadPart_non_existing_mp_upload"
 	partNumber := int32(1)
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.UploadPart(ctx, &s3.UploadPartInput{
 			Bucket:     &bucket,
 			Key:        getPtr("my-obj"),
@@ -5730,12 +9155,12 @@ func UploadPart_non_existing_key(s *S3Conf) error {
 	partNumber := int32(1)
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		out, err := createMp(s3client, bucket, obj)
+		out, err := createMp(s, s3client, bucket, obj)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.UploadPart(ctx, &s3.UploadPartInput{
 			Bucket:     &bucket,
 			Key:        getPtr("non-existing-object-key"),
@@ -5755,11 +9180,11 @@ func UploadPart_success(s *S3Conf) error {
 	partNumber := int32(1)
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		out, err := createMp(s3client, bucket, obj)
+		out, err := createMp(s, s3client, bucket, obj)
 		if err != nil {
 			return err
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.UploadPart(ctx, &s3.UploadPartInput{
 			Bucket:     &bucket,
 			Key:        &obj,
@@ -5783,7 +9208,7 @@ func UploadPartCopy_non_existing_bucket(s *S3Conf) error {
 		bucketName := getBucketName()
 		partNumber := int32(1)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.UploadPartCopy(ctx, &s3.UploadPartCopyInput{
 			Bucket:     &bucketName,
 			CopySource: getPtr("Copy-Source"),
@@ -5801,23 +9226,24 @@ func UploadPartCopy_non_existing_bucket(s *S3Conf) error {
 
 func UploadPartCopy_incorrect_uploadId(s *S3Conf) error {
 	testName := "UploadPartCopy_incorrect_uploadId"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) (err error) {
 		obj, srcBucket, srcObj := "my-obj", getBucketName(), "src-obj"
-		err := setup(s, srcBucket)
+		err = setup(s, srcBucket)
 		if err != nil {
 			return err
 		}
-		_, err = putObjects(s3client, []string{srcObj}, srcBucket)
+		defer teardownAfter(s, srcBucket, &err)
+		_, err = putObjects(s, s3client, []string{srcObj}, srcBucket)
 		if err != nil {
 			return err
 		}
 
-		_, err = createMp(s3client, bucket, obj)
+		_, err = createMp(s, s3client, bucket, obj)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		partNumber := int32(1)
 		_, err = s3client.UploadPartCopy(ctx, &s3.UploadPartCopyInput{
 			Bucket:     &bucket,
@@ -5831,34 +9257,30 @@ func UploadPartCopy_incorrect_uploadId(s *S3Conf) error {
 			return err
 		}
 
-		err = teardown(s, srcBucket)
-		if err != nil {
-			return err
-		}
-
 		return nil
 	})
 }
 
 func UploadPartCopy_incorrect_object_key(s *S3Conf) error {
 	testName := "UploadPartCopy_incorrect_object_key"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) (err error) {
 		obj, srcBucket, srcObj := "my-obj", getBucketName(), "src-obj"
-		err := setup(s, srcBucket)
+		err = setup(s, srcBucket)
 		if err != nil {
 			return err
 		}
-		_, err = putObjects(s3client, []string{srcObj}, srcBucket)
+		defer teardownAfter(s, srcBucket, &err)
+		_, err = putObjects(s, s3client, []string{srcObj}, srcBucket)
 		if err != nil {
 			return err
 		}
 
-		out, err := createMp(s3client, bucket, obj)
+		out, err := createMp(s, s3client, bucket, obj)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		partNumber := int32(1)
 		_, err = s3client.UploadPartCopy(ctx, &s3.UploadPartCopyInput{
 			Bucket:     &bucket,
@@ -5872,11 +9294,6 @@ func UploadPartCopy_incorrect_object_key(s *S3Conf) error {
 			return err
 		}
 
-		err = teardown(s, srcBucket)
-		if err != nil {
-			return err
-		}
-
 		return nil
 	})
 }
@@ -5884,7 +9301,7 @@ func UploadPartCopy_incorrect_object_key(s *S3Conf) error {
 func UploadPartCopy_invalid_part_number(s *S3Conf) error {
 	testName := "UploadPartCopy_invalid_part_number"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		partNumber := int32(-10)
 		_, err := s3client.UploadPartCopy(ctx, &s3.UploadPartCopyInput{
 			Bucket:     &bucket,
@@ -5907,12 +9324,12 @@ func UploadPartCopy_invalid_copy_source(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
 
-		out, err := createMp(s3client, bucket, obj)
+		out, err := createMp(s, s3client, bucket, obj)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		partNumber := int32(1)
 		_, err = s3client.UploadPartCopy(ctx, &s3.UploadPartCopyInput{
 			Bucket:     &bucket,
@@ -5935,12 +9352,12 @@ func UploadPartCopy_non_existing_source_bucket(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
 
-		out, err := createMp(s3client, bucket, obj)
+		out, err := createMp(s, s3client, bucket, obj)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		partNumber := int32(1)
 		_, err = s3client.UploadPartCopy(ctx, &s3.UploadPartCopyInput{
 			Bucket:     &bucket,
@@ -5960,20 +9377,21 @@ func UploadPartCopy_non_existing_source_bucket(s *S3Conf) error {
 
 func UploadPartCopy_non_existing_source_object_key(s *S3Conf) error {
 	testName := "UploadPartCopy_non_existing_source_object_key"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) (err error) {
 		obj, srcBucket := "my-obj", getBucketName()
 
-		err := setup(s, srcBucket)
+		err = setup(s, srcBucket)
 		if err != nil {
-			return nil
+			return err
 		}
+		defer teardownAfter(s, srcBucket, &err)
 
-		out, err := createMp(s3client, bucket, obj)
+		out, err := createMp(s, s3client, bucket, obj)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		partNumber := int32(1)
 		_, err = s3client.UploadPartCopy(ctx, &s3.UploadPartCopyInput{
 			Bucket:     &bucket,
@@ -5987,25 +9405,21 @@ func UploadPartCopy_non_existing_source_object_key(s *S3Conf) error {
 			return err
 		}
 
-		err = teardown(s, srcBucket)
-		if err != nil {
-			return err
-		}
-
 		return nil
 	})
 }
 
 func UploadPartCopy_success(s *S3Conf) error {
 	testName := "UploadPartCopy_success"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) (err error) {
 		obj, srcBucket, srcObj := "my-obj", getBucketName(), "src-obj"
-		err := setup(s, srcBucket)
+		err = setup(s, srcBucket)
 		if err != nil {
 			return err
 		}
+		defer teardownAfter(s, srcBucket, &err)
 		objSize := 5 * 1024 * 1024
-		_, err = putObjectWithData(int64(objSize), &s3.PutObjectInput{
+		_, err = putObjectWithData(s, int64(objSize), &s3.PutObjectInput{
 			Bucket: &srcBucket,
 			Key:    &srcObj,
 		}, s3client)
@@ -6013,12 +9427,12 @@ func UploadPartCopy_success(s *S3Conf) error {
 			return err
 		}
 
-		out, err := createMp(s3client, bucket, obj)
+		out, err := createMp(s, s3client, bucket, obj)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		partNumber := int32(1)
 		copyOut, err := s3client.UploadPartCopy(ctx, &s3.UploadPartCopyInput{
 			Bucket:     &bucket,
@@ -6032,7 +9446,7 @@ func UploadPartCopy_success(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.ListParts(ctx, &s3.ListPartsInput{
 			Bucket:   &bucket,
 			Key:      &obj,
@@ -6056,25 +9470,21 @@ func UploadPartCopy_success(s *S3Conf) error {
 			return fmt.Errorf("expected part etag to be %v, instead got %v", *copyOut.CopyPartResult.ETag, *res.Parts[0].ETag)
 		}
 
-		err = teardown(s, srcBucket)
-		if err != nil {
-			return err
-		}
-
 		return nil
 	})
 }
 
 func UploadPartCopy_by_range_invalid_range(s *S3Conf) error {
 	testName := "UploadPartCopy_by_range_invalid_range"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) (err error) {
 		obj, srcBucket, srcObj := "my-obj", getBucketName(), "src-obj"
-		err := setup(s, srcBucket)
+		err = setup(s, srcBucket)
 		if err != nil {
 			return err
 		}
+		defer teardownAfter(s, srcBucket, &err)
 		objSize := 5 * 1024 * 1024
-		_, err = putObjectWithData(int64(objSize), &s3.PutObjectInput{
+		_, err = putObjectWithData(s, int64(objSize), &s3.PutObjectInput{
 			Bucket: &srcBucket,
 			Key:    &srcObj,
 		}, s3client)
@@ -6082,12 +9492,12 @@ func UploadPartCopy_by_range_invalid_range(s *S3Conf) error {
 			return err
 		}
 
-		out, err := createMp(s3client, bucket, obj)
+		out, err := createMp(s, s3client, bucket, obj)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		partNumber := int32(1)
 		_, err = s3client.UploadPartCopy(ctx, &s3.UploadPartCopyInput{
 			Bucket:          &bucket,
@@ -6102,25 +9512,21 @@ func UploadPartCopy_by_range_invalid_range(s *S3Conf) error {
 			return err
 		}
 
-		err = teardown(s, srcBucket)
-		if err != nil {
-			return err
-		}
-
 		return nil
 	})
 }
 
 func UploadPartCopy_greater_range_than_obj_size(s *S3Conf) error {
 	testName := "UploadPartCopy_greater_range_than_obj_size"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) (err error) {
 		obj, srcBucket, srcObj := "my-obj", getBucketName(), "src-obj"
-		err := setup(s, srcBucket)
+		err = setup(s, srcBucket)
 		if err != nil {
 			return err
 		}
+		defer teardownAfter(s, srcBucket, &err)
 		srcObjSize := 5 * 1024 * 1024
-		_, err = putObjectWithData(int64(srcObjSize), &s3.PutObjectInput{
+		_, err = putObjectWithData(s, int64(srcObjSize), &s3.PutObjectInput{
 			Bucket: &srcBucket,
 			Key:    &srcObj,
 		}, s3client)
@@ -6128,12 +9534,12 @@ func UploadPartCopy_greater_range_than_obj_size(s *S3Conf) error {
 			return err
 		}
 
-		out, err := createMp(s3client, bucket, obj)
+		out, err := createMp(s, s3client, bucket, obj)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		partNumber := int32(1)
 		_, err = s3client.UploadPartCopy(ctx, &s3.UploadPartCopyInput{
 			Bucket:          &bucket,
@@ -6148,25 +9554,21 @@ func UploadPartCopy_greater_range_than_obj_size(s *S3Conf) error {
 			return err
 		}
 
-		err = teardown(s, srcBucket)
-		if err != nil {
-			return err
-		}
-
 		return nil
 	})
 }
 
 func UploadPartCopy_by_range_success(s *S3Conf) error {
 	testName := "UploadPartCopy_by_range_success"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) (err error) {
 		obj, srcBucket, srcObj := "my-obj", getBucketName(), "src-obj"
-		err := setup(s, srcBucket)
+		err = setup(s, srcBucket)
 		if err != nil {
 			return err
 		}
+		defer teardownAfter(s, srcBucket, &err)
 		objSize := 5 * 1024 * 1024
-		_, err = putObjectWithData(int64(objSize), &s3.PutObjectInput{
+		_, err = putObjectWithData(s, int64(objSize), &s3.PutObjectInput{
 			Bucket: &srcBucket,
 			Key:    &srcObj,
 		}, s3client)
@@ -6174,12 +9576,12 @@ func UploadPartCopy_by_range_success(s *S3Conf) error {
 			return err
 		}
 
-		out, err := createMp(s3client, bucket, obj)
+		out, err := createMp(s, s3client, bucket, obj)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		partNumber := int32(1)
 		copyOut, err := s3client.UploadPartCopy(ctx, &s3.UploadPartCopyInput{
 			Bucket:          &bucket,
@@ -6194,7 +9596,7 @@ func UploadPartCopy_by_range_success(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.ListParts(ctx, &s3.ListPartsInput{
 			Bucket:   &bucket,
 			Key:      &obj,
@@ -6218,10 +9620,112 @@ func UploadPartCopy_by_range_success(s *S3Conf) error {
 			return fmt.Errorf("expected part etag to be %v, instead got %v", *copyOut.CopyPartResult.ETag, *res.Parts[0].ETag)
 		}
 
-		err = teardown(s, srcBucket)
+		return nil
+	})
+}
+func UploadPartCopy_by_range_complete(s *S3Conf) error {
+	testName := "UploadPartCopy_by_range_complete"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj, srcObj := "my-obj", "src-obj"
+		partSize, objSize := int64(5*1024*1024), int64(12*1024*1024+123)
+		r, err := putObjectWithData(s, objSize, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &srcObj,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+
+		out, err := createMp(s, s3client, bucket, obj)
+		if err != nil {
+			return err
+		}
+
+		// the last range ends on the final byte of the source object and
+		// is shorter than the others
+		compParts := []types.CompletedPart{}
+		for start, partNumber := int64(0), int32(1); start < objSize; start, partNumber = start+partSize, partNumber+1 {
+			pn := partNumber
+			end := start + partSize - 1
+			if end >= objSize {
+				end = objSize - 1
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			copyOut, err := s3client.UploadPartCopy(ctx, &s3.UploadPartCopyInput{
+				Bucket:          &bucket,
+				CopySource:      getPtr(bucket + "/" + srcObj),
+				CopySourceRange: getPtr(fmt.Sprintf("bytes=%v-%v", start, end)),
+				UploadId:        out.UploadId,
+				Key:             &obj,
+				PartNumber:      &pn,
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
+
+			compParts = append(compParts, types.CompletedPart{
+				ETag:       copyOut.CopyPartResult.ETag,
+				PartNumber: &pn,
+			})
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		res, err := s3client.ListParts(ctx, &s3.ListPartsInput{
+			Bucket:   &bucket,
+			Key:      &obj,
+			UploadId: out.UploadId,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if len(res.Parts) != 3 {
+			return fmt.Errorf("expected parts to be 3, instead got %v", len(res.Parts))
+		}
+		lastSize := objSize - 2*partSize
+		if *res.Parts[2].Size != lastSize {
+			return fmt.Errorf("expected the last part size to be %v, instead got %v", lastSize, *res.Parts[2].Size)
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:   &bucket,
+			Key:      &obj,
+			UploadId: out.UploadId,
+			MultipartUpload: &types.CompletedMultipartUpload{
+				Parts: compParts,
+			},
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		getOut, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		defer cancel()
+		if err != nil {
+			return err
+		}
+		defer getOut.Body.Close()
+
+		if *getOut.ContentLength != objSize {
+			return fmt.Errorf("expected content-length %v, instead got %v", objSize, *getOut.ContentLength)
+		}
+
+		bdy, err := io.ReadAll(getOut.Body)
 		if err != nil {
 			return err
 		}
+		if sha256.Sum256(bdy) != r.csum {
+			return fmt.Errorf("expected the assembled object data to match the source object")
+		}
 
 		return nil
 	})
@@ -6230,7 +9734,7 @@ func UploadPartCopy_by_range_success(s *S3Conf) error {
 func ListParts_incorrect_uploadId(s *S3Conf) error {
 	testName := "ListParts_incorrect_uploadId"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.ListParts(ctx, &s3.ListPartsInput{
 			Bucket:   &bucket,
 			Key:      getPtr("my-obj"),
@@ -6249,12 +9753,12 @@ func ListParts_incorrect_object_key(s *S3Conf) error {
 	testName := "ListParts_incorrect_object_key"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		out, err := createMp(s3client, bucket, obj)
+		out, err := createMp(s, s3client, bucket, obj)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.ListParts(ctx, &s3.ListPartsInput{
 			Bucket:   &bucket,
 			Key:      getPtr("incorrect-object-key"),
@@ -6273,19 +9777,19 @@ func ListParts_truncated(s *S3Conf) error {
 	testName := "ListParts_truncated"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		out, err := createMp(s3client, bucket, obj)
+		out, err := createMp(s, s3client, bucket, obj)
 		if err != nil {
 			return err
 		}
 
-		parts, _, err := uploadParts(s3client, 5*1024*1024, 5, bucket, obj, *out.UploadId)
+		parts, _, err := uploadParts(s, s3client, 5*1024*1024, 5, bucket, obj, *out.UploadId)
 		if err != nil {
 			return err
 		}
 
 		maxParts := int32(3)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.ListParts(ctx, &s3.ListPartsInput{
 			Bucket:   &bucket,
 			Key:      &obj,
@@ -6310,7 +9814,7 @@ func ListParts_truncated(s *S3Conf) error {
 			return fmt.Errorf("expected the parts data to be %v, instead got %v", parts[:3], res.Parts)
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		res2, err := s3client.ListParts(ctx, &s3.ListPartsInput{
 			Bucket:           &bucket,
 			Key:              &obj,
@@ -6332,22 +9836,93 @@ func ListParts_truncated(s *S3Conf) error {
 		return nil
 	})
 }
+func ListParts_paginated(s *S3Conf) error {
+	testName := "ListParts_paginated"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-obj"
+		out, err := createMp(s, s3client, bucket, obj)
+		if err != nil {
+			return err
+		}
+
+		partCount, partSize := int64(150), int64(1024)
+		parts, _, err := uploadParts(s, s3client, partCount*partSize, partCount, bucket, obj, *out.UploadId)
+		if err != nil {
+			return err
+		}
+
+		maxParts := int32(50)
+		var marker *string
+		var listed []types.Part
+		pages := 0
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			res, err := s3client.ListParts(ctx, &s3.ListPartsInput{
+				Bucket:           &bucket,
+				Key:              &obj,
+				UploadId:         out.UploadId,
+				MaxParts:         &maxParts,
+				PartNumberMarker: marker,
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
+			pages++
+
+			if len(res.Parts) > int(maxParts) {
+				return fmt.Errorf("expected at most %v parts in a page, instead got %v", maxParts, len(res.Parts))
+			}
+			for _, part := range res.Parts {
+				if part.Size == nil || *part.Size != partSize {
+					return fmt.Errorf("expected part %v size to be %v, instead got %v", *part.PartNumber, partSize, part.Size)
+				}
+			}
+			listed = append(listed, res.Parts...)
+
+			truncated := res.IsTruncated != nil && *res.IsTruncated
+			if len(listed) < int(partCount) {
+				if !truncated {
+					return fmt.Errorf("expected page %v to be truncated", pages)
+				}
+			} else if truncated {
+				return fmt.Errorf("expected the last page to not be truncated")
+			}
+			if !truncated {
+				break
+			}
+			if res.NextPartNumberMarker == nil {
+				return fmt.Errorf("expected a next part number marker on page %v", pages)
+			}
+			marker = res.NextPartNumberMarker
+		}
+
+		if pages != 3 {
+			return fmt.Errorf("expected 3 pages of parts, instead got %v", pages)
+		}
+		if !compareParts(parts, listed) {
+			return fmt.Errorf("expected the parts data to be %v, instead got %v", parts, listed)
+		}
+
+		return nil
+	})
+}
 
 func ListParts_success(s *S3Conf) error {
 	testName := "ListParts_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		out, err := createMp(s3client, bucket, obj)
+		out, err := createMp(s, s3client, bucket, obj)
 		if err != nil {
 			return err
 		}
 
-		parts, _, err := uploadParts(s3client, 5*1024*1024, 5, bucket, obj, *out.UploadId)
+		parts, _, err := uploadParts(s, s3client, 5*1024*1024, 5, bucket, obj, *out.UploadId)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.ListParts(ctx, &s3.ListPartsInput{
 			Bucket:   &bucket,
 			Key:      &obj,
@@ -6373,7 +9948,7 @@ func ListMultipartUploads_non_existing_bucket(s *S3Conf) error {
 	testName := "ListMultipartUploads_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		bucketName := getBucketName()
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.ListMultipartUploads(ctx, &s3.ListMultipartUploadsInput{
 			Bucket: &bucketName,
 		})
@@ -6389,7 +9964,7 @@ func ListMultipartUploads_non_existing_bucket(s *S3Conf) error {
 func ListMultipartUploads_empty_result(s *S3Conf) error {
 	testName := "ListMultipartUploads_empty_result"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.ListMultipartUploads(ctx, &s3.ListMultipartUploadsInput{
 			Bucket: &bucket,
 		})
@@ -6409,7 +9984,7 @@ func ListMultipartUploads_invalid_max_uploads(s *S3Conf) error {
 	testName := "ListMultipartUploads_invalid_max_uploads"
 	maxUploads := int32(-3)
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.ListMultipartUploads(ctx, &s3.ListMultipartUploadsInput{
 			Bucket:     &bucket,
 			MaxUploads: &maxUploads,
@@ -6428,7 +10003,7 @@ func ListMultipartUploads_max_uploads(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		uploads := []types.MultipartUpload{}
 		for i := 1; i < 6; i++ {
-			out, err := createMp(s3client, bucket, fmt.Sprintf("obj%v", i))
+			out, err := createMp(s, s3client, bucket, fmt.Sprintf("obj%v", i))
 			if err != nil {
 				return err
 			}
@@ -6438,7 +10013,7 @@ func ListMultipartUploads_max_uploads(s *S3Conf) error {
 				StorageClass: types.StorageClassStandard,
 			})
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		maxUploads := int32(2)
 		out, err := s3client.ListMultipartUploads(ctx, &s3.ListMultipartUploadsInput{
 			Bucket:     &bucket,
@@ -6464,7 +10039,7 @@ func ListMultipartUploads_max_uploads(s *S3Conf) error {
 			return fmt.Errorf("expected next-upload-id-marker to be %v, instead got %v", *uploads[1].UploadId, *out.NextUploadIdMarker)
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		out, err = s3client.ListMultipartUploads(ctx, &s3.ListMultipartUploadsInput{
 			Bucket:    &bucket,
 			KeyMarker: out.NextKeyMarker,
@@ -6485,12 +10060,12 @@ func ListMultipartUploads_incorrect_next_key_marker(s *S3Conf) error {
 	testName := "ListMultipartUploads_incorrect_next_key_marker"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		for i := 1; i < 6; i++ {
-			_, err := createMp(s3client, bucket, fmt.Sprintf("obj%v", i))
+			_, err := createMp(s, s3client, bucket, fmt.Sprintf("obj%v", i))
 			if err != nil {
 				return err
 			}
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.ListMultipartUploads(ctx, &s3.ListMultipartUploadsInput{
 			Bucket:    &bucket,
 			KeyMarker: getPtr("wrong_object_key"),
@@ -6513,7 +10088,7 @@ func ListMultipartUploads_ignore_upload_id_marker(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		uploads := []types.MultipartUpload{}
 		for i := 1; i < 6; i++ {
-			out, err := createMp(s3client, bucket, fmt.Sprintf("obj%v", i))
+			out, err := createMp(s, s3client, bucket, fmt.Sprintf("obj%v", i))
 			if err != nil {
 				return err
 			}
@@ -6523,7 +10098,7 @@ func ListMultipartUploads_ignore_upload_id_marker(s *S3Conf) error {
 				StorageClass: types.StorageClassStandard,
 			})
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.ListMultipartUploads(ctx, &s3.ListMultipartUploadsInput{
 			Bucket:         &bucket,
 			UploadIdMarker: uploads[2].UploadId,
@@ -6544,17 +10119,17 @@ func ListMultipartUploads_success(s *S3Conf) error {
 	testName := "ListMultipartUploads_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj1, obj2 := "my-obj-1", "my-obj-2"
-		out1, err := createMp(s3client, bucket, obj1)
+		out1, err := createMp(s, s3client, bucket, obj1)
 		if err != nil {
 			return err
 		}
 
-		out2, err := createMp(s3client, bucket, obj2)
+		out2, err := createMp(s, s3client, bucket, obj2)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.ListMultipartUploads(ctx, &s3.ListMultipartUploadsInput{
 			Bucket: &bucket,
 		})
@@ -6587,10 +10162,66 @@ func ListMultipartUploads_success(s *S3Conf) error {
 	})
 }
 
+func ListMultipartUploads_initiator_and_initiated(s *S3Conf) error {
+	testName := "ListMultipartUploads_initiator_and_initiated"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-obj"
+		before := time.Now().Add(-time.Minute)
+		out, err := createMp(s, s3client, bucket, obj)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		res, err := s3client.ListMultipartUploads(ctx, &s3.ListMultipartUploadsInput{
+			Bucket: &bucket,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if len(res.Uploads) != 1 {
+			return fmt.Errorf("expected 1 upload, instead got %v", len(res.Uploads))
+		}
+
+		upload := res.Uploads[0]
+		if getString(upload.UploadId) != getString(out.UploadId) {
+			return fmt.Errorf("expected the upload id to be %v, instead got %v",
+				getString(out.UploadId), getString(upload.UploadId))
+		}
+
+		// the initiated time should be a plausible recent timestamp
+		if upload.Initiated == nil {
+			return fmt.Errorf("expected non nil upload initiated time")
+		}
+		if upload.Initiated.Before(before) || upload.Initiated.After(time.Now().Add(time.Minute)) {
+			return fmt.Errorf("expected the upload initiated time to be recent, instead got %v",
+				*upload.Initiated)
+		}
+
+		if upload.StorageClass != types.StorageClassStandard {
+			return fmt.Errorf("expected the storage class to be %v, instead got %v",
+				types.StorageClassStandard, upload.StorageClass)
+		}
+
+		if upload.Owner == nil || getString(upload.Owner.ID) != s.awsID {
+			return fmt.Errorf("expected the upload owner to be %v, instead got %+v",
+				s.awsID, upload.Owner)
+		}
+		if upload.Initiator == nil || getString(upload.Initiator.ID) != s.awsID {
+			return fmt.Errorf("expected the upload initiator to be %v, instead got %+v",
+				s.awsID, upload.Initiator)
+		}
+
+		return nil
+	})
+}
+
 func AbortMultipartUpload_non_existing_bucket(s *S3Conf) error {
 	testName := "AbortMultipartUpload_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
 			Bucket:   getPtr("incorrectBucket"),
 			Key:      getPtr("my-obj"),
@@ -6608,7 +10239,7 @@ func AbortMultipartUpload_non_existing_bucket(s *S3Conf) error {
 func AbortMultipartUpload_incorrect_uploadId(s *S3Conf) error {
 	testName := "AbortMultipartUpload_incorrect_uploadId"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
 			Bucket:   &bucket,
 			Key:      getPtr("my-obj"),
@@ -6627,12 +10258,12 @@ func AbortMultipartUpload_incorrect_object_key(s *S3Conf) error {
 	testName := "AbortMultipartUpload_incorrect_object_key"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		out, err := createMp(s3client, bucket, obj)
+		out, err := createMp(s, s3client, bucket, obj)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
 			Bucket:   &bucket,
 			Key:      getPtr("incorrect-object-key"),
@@ -6646,17 +10277,106 @@ func AbortMultipartUpload_incorrect_object_key(s *S3Conf) error {
 		return nil
 	})
 }
+func AbortMultipartUpload_non_existing_uploadId(s *S3Conf) error {
+	testName := "AbortMultipartUpload_non_existing_uploadId"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-obj"
+		_, err := createMp(s, s3client, bucket, obj)
+		if err != nil {
+			return err
+		}
+
+		// a well formed upload id that doesn't belong to the object upload
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+			Bucket:   &bucket,
+			Key:      &obj,
+			UploadId: getPtr("4d1a7b6e-5c3f-4e2a-9b8d-1f0e2d3c4b5a"),
+		})
+		cancel()
+		if err := checkSdkApiErr(err, "NoSuchUpload"); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func AbortMultipartUpload_upload_is_gone(s *S3Conf) error {
+	testName := "AbortMultipartUpload_upload_is_gone"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-obj"
+		out, err := createMp(s, s3client, bucket, obj)
+		if err != nil {
+			return err
+		}
+
+		_, _, err = uploadParts(s, s3client, 1024, 1, bucket, obj, *out.UploadId)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+			Bucket:   &bucket,
+			Key:      &obj,
+			UploadId: out.UploadId,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		partNumber := int32(2)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:     &bucket,
+			Key:        &obj,
+			UploadId:   out.UploadId,
+			PartNumber: &partNumber,
+			Body:       bytes.NewReader([]byte("dummy")),
+		})
+		cancel()
+		if err := checkSdkApiErr(err, "NoSuchUpload"); err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.ListParts(ctx, &s3.ListPartsInput{
+			Bucket:   &bucket,
+			Key:      &obj,
+			UploadId: out.UploadId,
+		})
+		cancel()
+		if err := checkSdkApiErr(err, "NoSuchUpload"); err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+			Bucket:   &bucket,
+			Key:      &obj,
+			UploadId: out.UploadId,
+		})
+		cancel()
+		if err := checkSdkApiErr(err, "NoSuchUpload"); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
 
 func AbortMultipartUpload_success(s *S3Conf) error {
 	testName := "AbortMultipartUpload_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		out, err := createMp(s3client, bucket, obj)
+		out, err := createMp(s, s3client, bucket, obj)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
 			Bucket:   &bucket,
 			Key:      &obj,
@@ -6667,7 +10387,7 @@ func AbortMultipartUpload_success(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.ListMultipartUploads(ctx, &s3.ListMultipartUploadsInput{
 			Bucket: &bucket,
 		})
@@ -6688,7 +10408,7 @@ func AbortMultipartUpload_success_status_code(s *S3Conf) error {
 	testName := "AbortMultipartUpload_success_status_code"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		out, err := createMp(s3client, bucket, obj)
+		out, err := createMp(s, s3client, bucket, obj)
 		if err != nil {
 			return err
 		}
@@ -6699,7 +10419,7 @@ func AbortMultipartUpload_success_status_code(s *S3Conf) error {
 		}
 
 		client := http.Client{
-			Timeout: shortTimeout,
+			Timeout: s.opTimeout(),
 		}
 
 		resp, err := client.Do(req)
@@ -6718,7 +10438,7 @@ func AbortMultipartUpload_success_status_code(s *S3Conf) error {
 func CompletedMultipartUpload_non_existing_bucket(s *S3Conf) error {
 	testName := "CompletedMultipartUpload_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
 			Bucket:   getPtr("non-existing-bucket"),
 			Key:      getPtr("some/key"),
@@ -6737,11 +10457,11 @@ func CompleteMultipartUpload_invalid_part_number(s *S3Conf) error {
 	testName := "CompleteMultipartUpload_invalid_part_number"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		out, err := createMp(s3client, bucket, obj)
+		out, err := createMp(s, s3client, bucket, obj)
 		if err != nil {
 			return err
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		partNumber := int32(1)
 		res, err := s3client.UploadPart(ctx, &s3.UploadPartInput{
 			Bucket:     &bucket,
@@ -6754,7 +10474,7 @@ func CompleteMultipartUpload_invalid_part_number(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		partNumber = int32(5)
 		_, err = s3client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
 			Bucket:   &bucket,
@@ -6782,11 +10502,11 @@ func CompleteMultipartUpload_invalid_ETag(s *S3Conf) error {
 	testName := "CompleteMultipartUpload_invalid_ETag"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		out, err := createMp(s3client, bucket, obj)
+		out, err := createMp(s, s3client, bucket, obj)
 		if err != nil {
 			return err
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		partNumber := int32(1)
 		_, err = s3client.UploadPart(ctx, &s3.UploadPartInput{
 			Bucket:     &bucket,
@@ -6799,23 +10519,164 @@ func CompleteMultipartUpload_invalid_ETag(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
-		_, err = s3client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
-			Bucket:   &bucket,
-			Key:      &obj,
-			UploadId: out.UploadId,
-			MultipartUpload: &types.CompletedMultipartUpload{
-				Parts: []types.CompletedPart{
-					{
-						ETag:       getPtr("invalidETag"),
-						PartNumber: &partNumber,
-					},
-				},
-			},
-		})
-		cancel()
-		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrInvalidPart)); err != nil {
-			return err
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:   &bucket,
+			Key:      &obj,
+			UploadId: out.UploadId,
+			MultipartUpload: &types.CompletedMultipartUpload{
+				Parts: []types.CompletedPart{
+					{
+						ETag:       getPtr("invalidETag"),
+						PartNumber: &partNumber,
+					},
+				},
+			},
+		})
+		cancel()
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrInvalidPart)); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+func CompleteMultipartUpload_small_middle_part(s *S3Conf) error {
+	testName := "CompleteMultipartUpload_small_middle_part"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-obj"
+		out, err := createMp(s, s3client, bucket, obj)
+		if err != nil {
+			return err
+		}
+
+		// only the last part may be smaller than 5MiB
+		partSizes := []int{5 * 1024 * 1024, 1024 * 1024, 1024 * 1024}
+		compParts := []types.CompletedPart{}
+		for i, size := range partSizes {
+			partNumber := int32(i + 1)
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			res, err := s3client.UploadPart(ctx, &s3.UploadPartInput{
+				Bucket:     &bucket,
+				Key:        &obj,
+				UploadId:   out.UploadId,
+				PartNumber: &partNumber,
+				Body:       bytes.NewReader(make([]byte, size)),
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
+			compParts = append(compParts, types.CompletedPart{
+				ETag:       res.ETag,
+				PartNumber: &partNumber,
+			})
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:   &bucket,
+			Key:      &obj,
+			UploadId: out.UploadId,
+			MultipartUpload: &types.CompletedMultipartUpload{
+				Parts: compParts,
+			},
+		})
+		cancel()
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrEntityTooSmall)); err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		cancel()
+		if err := checkSdkApiErr(err, "NotFound"); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+func CompleteMultipartUpload_invalid_part_order(s *S3Conf) error {
+	testName := "CompleteMultipartUpload_invalid_part_order"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-obj"
+		out, err := createMp(s, s3client, bucket, obj)
+		if err != nil {
+			return err
+		}
+
+		objSize := int64(15 * 1024 * 1024)
+		parts, csum, err := uploadParts(s, s3client, objSize, 3, bucket, obj, *out.UploadId)
+		if err != nil {
+			return err
+		}
+
+		compParts := []types.CompletedPart{}
+		for _, el := range parts {
+			compParts = append(compParts, types.CompletedPart{
+				ETag:       el.ETag,
+				PartNumber: el.PartNumber,
+			})
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:   &bucket,
+			Key:      &obj,
+			UploadId: out.UploadId,
+			MultipartUpload: &types.CompletedMultipartUpload{
+				Parts: []types.CompletedPart{compParts[1], compParts[0], compParts[2]},
+			},
+		})
+		cancel()
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrInvalidPartOrder)); err != nil {
+			return err
+		}
+
+		// the failed completion must leave the upload intact
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:   &bucket,
+			Key:      &obj,
+			UploadId: out.UploadId,
+			MultipartUpload: &types.CompletedMultipartUpload{
+				Parts: compParts,
+			},
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		defer cancel()
+		rget, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		if err != nil {
+			return err
+		}
+		defer rget.Body.Close()
+
+		if *rget.ContentLength != objSize {
+			return fmt.Errorf("expected the object content-length to be %v, instead got %v", objSize, *rget.ContentLength)
+		}
+
+		bdy, err := io.ReadAll(rget.Body)
+		if err != nil {
+			return err
+		}
+
+		sum := sha256.Sum256(bdy)
+		getsum := hex.EncodeToString(sum[:])
+
+		if csum != getsum {
+			return fmt.Errorf("expected the object checksum to be %v, instead got %v", csum, getsum)
 		}
 
 		return nil
@@ -6826,13 +10687,13 @@ func CompleteMultipartUpload_success(s *S3Conf) error {
 	testName := "CompleteMultipartUpload_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		out, err := createMp(s3client, bucket, obj)
+		out, err := createMp(s, s3client, bucket, obj)
 		if err != nil {
 			return err
 		}
 
-		objSize := int64(5 * 1024 * 1024)
-		parts, csum, err := uploadParts(s3client, objSize, 5, bucket, obj, *out.UploadId)
+		objSize := int64(25 * 1024 * 1024)
+		parts, csum, err := uploadParts(s, s3client, objSize, 5, bucket, obj, *out.UploadId)
 		if err != nil {
 			return err
 		}
@@ -6845,7 +10706,7 @@ func CompleteMultipartUpload_success(s *S3Conf) error {
 			})
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
 			Bucket:   &bucket,
 			Key:      &obj,
@@ -6863,7 +10724,7 @@ func CompleteMultipartUpload_success(s *S3Conf) error {
 			return fmt.Errorf("expected object key to be %v, instead got %v", obj, *res.Key)
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		resp, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -6880,7 +10741,7 @@ func CompleteMultipartUpload_success(s *S3Conf) error {
 			return fmt.Errorf("expected the uploaded object size to be %v, instead got %v", objSize, resp.ContentLength)
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		defer cancel()
 		rget, err := s3client.GetObject(ctx, &s3.GetObjectInput{
 			Bucket: &bucket,
@@ -6911,6 +10772,99 @@ func CompleteMultipartUpload_success(s *S3Conf) error {
 	})
 }
 
+func CompleteMultipartUpload_with_metadata_and_tagging(s *S3Conf) error {
+	testName := "CompleteMultipartUpload_with_metadata_and_tagging"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj, contentType := "my-obj", "application/json"
+		meta := map[string]string{
+			"key1": "val1",
+			"key2": "val2",
+		}
+		tagging := "tag1=val1&tag2=val2"
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+			Bucket:      &bucket,
+			Key:         &obj,
+			Metadata:    meta,
+			ContentType: &contentType,
+			Tagging:     &tagging,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		objSize := int64(10 * 1024 * 1024)
+		parts, _, err := uploadParts(s, s3client, objSize, 2, bucket, obj, *out.UploadId)
+		if err != nil {
+			return err
+		}
+
+		compParts := []types.CompletedPart{}
+		for _, el := range parts {
+			compParts = append(compParts, types.CompletedPart{
+				ETag:       el.ETag,
+				PartNumber: el.PartNumber,
+			})
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:   &bucket,
+			Key:      &obj,
+			UploadId: out.UploadId,
+			MultipartUpload: &types.CompletedMultipartUpload{
+				Parts: compParts,
+			},
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		resp, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if !areMapsSame(resp.Metadata, meta) {
+			return fmt.Errorf("expected the object metadata to be %v, instead got %v", meta, resp.Metadata)
+		}
+		if getString(resp.ContentType) != contentType {
+			return fmt.Errorf("expected the object content type to be %v, instead got %v", contentType, getString(resp.ContentType))
+		}
+		if resp.ContentLength == nil || *resp.ContentLength != objSize {
+			return fmt.Errorf("expected the object size to be %v, instead got %v", objSize, resp.ContentLength)
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		tagOut, err := s3client.GetObjectTagging(ctx, &s3.GetObjectTaggingInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		expectedTags := []types.Tag{
+			{Key: getPtr("tag1"), Value: getPtr("val1")},
+			{Key: getPtr("tag2"), Value: getPtr("val2")},
+		}
+		if !areTagsSame(tagOut.TagSet, expectedTags) {
+			return fmt.Errorf("expected the object tags to be %v, instead got %v", expectedTags, tagOut.TagSet)
+		}
+
+		return nil
+	})
+}
+
 type mpinfo struct {
 	uploadId *string
 	parts    []types.CompletedPart
@@ -6924,18 +10878,18 @@ func CompleteMultipartUpload_racey_success(s *S3Conf) error {
 		var mu sync.RWMutex
 		uploads := make([]mpinfo, 10)
 		sums := make([]string, 10)
-		objSize := int64(5 * 1024 * 1024)
+		objSize := int64(10 * 1024 * 1024)
 
 		eg := errgroup.Group{}
 		for i := 0; i < 10; i++ {
 			func(i int) {
 				eg.Go(func() error {
-					out, err := createMp(s3client, bucket, obj)
+					out, err := createMp(s, s3client, bucket, obj)
 					if err != nil {
 						return err
 					}
 
-					parts, csum, err := uploadParts(s3client, objSize, 5, bucket, obj, *out.UploadId)
+					parts, csum, err := uploadParts(s, s3client, objSize, 2, bucket, obj, *out.UploadId)
 					mu.Lock()
 					sums[i] = csum
 					mu.Unlock()
@@ -6971,7 +10925,7 @@ func CompleteMultipartUpload_racey_success(s *S3Conf) error {
 		for i := 0; i < 10; i++ {
 			func(i int) {
 				eg.Go(func() error {
-					ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+					ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 					mu.RLock()
 					res, err := s3client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
 						Bucket:   &bucket,
@@ -6984,7 +10938,7 @@ func CompleteMultipartUpload_racey_success(s *S3Conf) error {
 					mu.RUnlock()
 					cancel()
 					if err != nil {
-						fmt.Println("GOT ERROR: ", err)
+						fmt.Fprintln(LogOutput, "GOT ERROR: ", err)
 						return err
 					}
 
@@ -7002,7 +10956,7 @@ func CompleteMultipartUpload_racey_success(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		defer cancel()
 		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
 			Bucket: &bucket,
@@ -7016,30 +10970,286 @@ func CompleteMultipartUpload_racey_success(s *S3Conf) error {
 			return fmt.Errorf("expected the object content-length to be %v, instead got %v", objSize, *out.ContentLength)
 		}
 
-		bdy, err := io.ReadAll(out.Body)
+		bdy, err := io.ReadAll(out.Body)
+		if err != nil {
+			return err
+		}
+		defer out.Body.Close()
+
+		sum := sha256.Sum256(bdy)
+		csum := hex.EncodeToString(sum[:])
+
+		mu.RLock()
+		defer mu.RUnlock()
+		for _, s := range sums {
+			if csum == s {
+				return nil
+			}
+		}
+		return fmt.Errorf("expected the object checksum to be one of %v, instead got %v", sums, csum)
+	})
+}
+
+func CompleteMultipartUpload_if_none_match(s *S3Conf) error {
+	testName := "CompleteMultipartUpload_if_none_match"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj, newObj := "my-obj", "new-obj"
+		existing, err := putObjectWithData(s, 100, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+
+		ifNoneMatch := func(value string) func(*s3.Options) {
+			return func(o *s3.Options) {
+				o.APIOptions = append(o.APIOptions,
+					smithyhttp.AddHeaderValue("If-None-Match", value))
+			}
+		}
+
+		completeMp := func(key, value string) (*s3.CompleteMultipartUploadOutput, *string, error) {
+			out, err := createMp(s, s3client, bucket, key)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			parts, _, err := uploadParts(s, s3client, 5*1024*1024, 1, bucket, key, *out.UploadId)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			compParts := []types.CompletedPart{}
+			for _, el := range parts {
+				compParts = append(compParts, types.CompletedPart{
+					ETag:       el.ETag,
+					PartNumber: el.PartNumber,
+				})
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			res, err := s3client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+				Bucket:   &bucket,
+				Key:      &key,
+				UploadId: out.UploadId,
+				MultipartUpload: &types.CompletedMultipartUpload{
+					Parts: compParts,
+				},
+			}, ifNoneMatch(value))
+			cancel()
+			return res, out.UploadId, err
+		}
+
+		// only "*" is supported as the If-None-Match value
+		_, uploadId, err := completeMp(obj, *existing.res.ETag)
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrNotImplemented)); err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+			Bucket:   &bucket,
+			Key:      &obj,
+			UploadId: uploadId,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		// completing onto an existing key must fail
+		_, uploadId, err = completeMp(obj, "*")
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrPreconditionFailed)); err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+			Bucket:   &bucket,
+			Key:      &obj,
+			UploadId: uploadId,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		defer cancel()
+		if err != nil {
+			return err
+		}
+		defer out.Body.Close()
+
+		if getString(out.ETag) != getString(existing.res.ETag) {
+			return fmt.Errorf("expected the existing object etag to be %v, instead got %v",
+				getString(existing.res.ETag), getString(out.ETag))
+		}
+
+		bdy, err := io.ReadAll(out.Body)
+		if err != nil {
+			return err
+		}
+		if !isEqual(bdy, existing.data) {
+			return fmt.Errorf("expected the existing object data to be unchanged")
+		}
+
+		// completing onto a new key succeeds
+		res, _, err := completeMp(newObj, "*")
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		head, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: &bucket,
+			Key:    &newObj,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if getString(head.ETag) != getString(res.ETag) {
+			return fmt.Errorf("expected the new object etag to be %v, instead got %v",
+				getString(res.ETag), getString(head.ETag))
+		}
+
+		return nil
+	})
+}
+
+func PutObject_md5_etag(s *S3Conf) error {
+	testName := "PutObject_md5_etag"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		for i, size := range []int64{0, 1, 1024 * 1024} {
+			obj := fmt.Sprintf("my-obj-%v", i)
+			out, err := putObjectWithData(s, size, &s3.PutObjectInput{
+				Bucket: &bucket,
+				Key:    &obj,
+			}, s3client)
+			if err != nil {
+				return err
+			}
+
+			sum := md5.Sum(out.data)
+			expected := hex.EncodeToString(sum[:])
+			if strings.Trim(getString(out.res.ETag), `"`) != expected {
+				return fmt.Errorf("expected the %v bytes object etag to be %v, instead got %v",
+					size, expected, getString(out.res.ETag))
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			head, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
+				Bucket: &bucket,
+				Key:    &obj,
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
+			if strings.Trim(getString(head.ETag), `"`) != expected {
+				return fmt.Errorf("expected the %v bytes object head etag to be %v, instead got %v",
+					size, expected, getString(head.ETag))
+			}
+		}
+
+		return nil
+	})
+}
+
+func CompleteMultipartUpload_md5_etag(s *S3Conf) error {
+	testName := "CompleteMultipartUpload_md5_etag"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-obj"
+		mp, err := createMp(s, s3client, bucket, obj)
+		if err != nil {
+			return err
+		}
+
+		const partCount = 3
+		var partSums []byte
+		compParts := []types.CompletedPart{}
+		for i := int32(1); i <= partCount; i++ {
+			data := make([]byte, 5*1024*1024)
+			rand.Read(data)
+			sum := md5.Sum(data)
+			partSums = append(partSums, sum[:]...)
+
+			partNumber := i
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			out, err := s3client.UploadPart(ctx, &s3.UploadPartInput{
+				Bucket:     &bucket,
+				Key:        &obj,
+				UploadId:   mp.UploadId,
+				PartNumber: &partNumber,
+				Body:       bytes.NewReader(data),
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
+
+			expected := hex.EncodeToString(sum[:])
+			if strings.Trim(getString(out.ETag), `"`) != expected {
+				return fmt.Errorf("expected the part %v etag to be %v, instead got %v",
+					partNumber, expected, getString(out.ETag))
+			}
+
+			compParts = append(compParts, types.CompletedPart{
+				ETag:       out.ETag,
+				PartNumber: &partNumber,
+			})
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		res, err := s3client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:   &bucket,
+			Key:      &obj,
+			UploadId: mp.UploadId,
+			MultipartUpload: &types.CompletedMultipartUpload{
+				Parts: compParts,
+			},
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		sum := md5.Sum(partSums)
+		expected := fmt.Sprintf("%v-%v", hex.EncodeToString(sum[:]), partCount)
+		if strings.Trim(getString(res.ETag), `"`) != expected {
+			return fmt.Errorf("expected the multipart object etag to be %v, instead got %v",
+				expected, getString(res.ETag))
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		head, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		cancel()
 		if err != nil {
 			return err
 		}
-		defer out.Body.Close()
-
-		sum := sha256.Sum256(bdy)
-		csum := hex.EncodeToString(sum[:])
-
-		mu.RLock()
-		defer mu.RUnlock()
-		for _, s := range sums {
-			if csum == s {
-				return nil
-			}
+		if strings.Trim(getString(head.ETag), `"`) != expected {
+			return fmt.Errorf("expected the multipart object head etag to be %v, instead got %v",
+				expected, getString(head.ETag))
 		}
-		return fmt.Errorf("expected the object checksum to be one of %v, instead got %v", sums, csum)
+
+		return nil
 	})
 }
 
 func PutBucketAcl_non_existing_bucket(s *S3Conf) error {
 	testName := "PutBucketAcl_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketAcl(ctx, &s3.PutBucketAclInput{
 			Bucket: getPtr(getBucketName()),
 		})
@@ -7055,7 +11265,7 @@ func PutBucketAcl_non_existing_bucket(s *S3Conf) error {
 func PutBucketAcl_disabled(s *S3Conf) error {
 	testName := "PutBucketAcl_disabled"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketAcl(ctx, &s3.PutBucketAclInput{
 			Bucket:    &bucket,
 			ACL:       types.BucketCannedACLPublicRead,
@@ -7072,7 +11282,7 @@ func PutBucketAcl_disabled(s *S3Conf) error {
 func PutBucketAcl_none_of_the_options_specified(s *S3Conf) error {
 	testName := "PutBucketAcl_none_of_the_options_specified"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketAcl(ctx, &s3.PutBucketAclInput{
 			Bucket: &bucket,
 		})
@@ -7087,7 +11297,7 @@ func PutBucketAcl_none_of_the_options_specified(s *S3Conf) error {
 func PutBucketAcl_invalid_acl_canned_and_acp(s *S3Conf) error {
 	testName := "PutBucketAcl_invalid_acl_canned_and_acp"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketAcl(ctx, &s3.PutBucketAclInput{
 			Bucket:    &bucket,
 			ACL:       types.BucketCannedACLPrivate,
@@ -7105,7 +11315,7 @@ func PutBucketAcl_invalid_acl_canned_and_acp(s *S3Conf) error {
 func PutBucketAcl_invalid_acl_canned_and_grants(s *S3Conf) error {
 	testName := "PutBucketAcl_invalid_acl_canned_and_grants"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketAcl(ctx, &s3.PutBucketAclInput{
 			Bucket: &bucket,
 			ACL:    types.BucketCannedACLPrivate,
@@ -7135,7 +11345,7 @@ func PutBucketAcl_invalid_acl_canned_and_grants(s *S3Conf) error {
 func PutBucketAcl_invalid_acl_acp_and_grants(s *S3Conf) error {
 	testName := "PutBucketAcl_invalid_acl_acp_and_grants"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketAcl(ctx, &s3.PutBucketAclInput{
 			Bucket:           &bucket,
 			GrantFullControl: getPtr("userAccess"),
@@ -7181,7 +11391,7 @@ func PutBucketAcl_invalid_owner(s *S3Conf) error {
 
 		userClient := getUserS3Client(usr, s)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := userClient.PutBucketAcl(ctx, &s3.PutBucketAclInput{
 			Bucket: &bucket,
 			AccessControlPolicy: &types.AccessControlPolicy{
@@ -7214,7 +11424,7 @@ func PutBucketAcl_invalid_owner(s *S3Conf) error {
 func PutBucketAcl_invalid_owner_not_in_body(s *S3Conf) error {
 	testName := "PutBucketAcl_invalid_owner_not_in_body"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketAcl(ctx, &s3.PutBucketAclInput{
 			Bucket: &bucket,
 			AccessControlPolicy: &types.AccessControlPolicy{
@@ -7246,7 +11456,7 @@ func PutBucketAcl_success_access_denied(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutBucketAcl(ctx, &s3.PutBucketAclInput{
 			Bucket: &bucket,
 			AccessControlPolicy: &types.AccessControlPolicy{
@@ -7272,9 +11482,9 @@ func PutBucketAcl_success_access_denied(s *S3Conf) error {
 		newConf := *s
 		newConf.awsID = "grt1"
 		newConf.awsSecret = "grt1secret"
-		userClient := s3.NewFromConfig(newConf.Config())
+		userClient := newConf.GetClient()
 
-		_, err = putObjects(userClient, []string{"my-obj"}, bucket)
+		_, err = putObjects(s, userClient, []string{"my-obj"}, bucket)
 		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrAccessDenied)); err != nil {
 			return err
 		}
@@ -7291,7 +11501,7 @@ func PutBucketAcl_success_canned_acl(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutBucketAcl(ctx, &s3.PutBucketAclInput{
 			Bucket: &bucket,
 			ACL:    types.BucketCannedACLPublicReadWrite,
@@ -7304,9 +11514,9 @@ func PutBucketAcl_success_canned_acl(s *S3Conf) error {
 		newConf := *s
 		newConf.awsID = "grt1"
 		newConf.awsSecret = "grt1secret"
-		userClient := s3.NewFromConfig(newConf.Config())
+		userClient := newConf.GetClient()
 
-		_, err = putObjects(userClient, []string{"my-obj"}, bucket)
+		_, err = putObjects(s, userClient, []string{"my-obj"}, bucket)
 		if err != nil {
 			return err
 		}
@@ -7323,7 +11533,7 @@ func PutBucketAcl_success_acp(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutBucketAcl(ctx, &s3.PutBucketAclInput{
 			Bucket:    &bucket,
 			GrantRead: getPtr("grt1"),
@@ -7336,14 +11546,14 @@ func PutBucketAcl_success_acp(s *S3Conf) error {
 		newConf := *s
 		newConf.awsID = "grt1"
 		newConf.awsSecret = "grt1secret"
-		userClient := s3.NewFromConfig(newConf.Config())
+		userClient := newConf.GetClient()
 
-		_, err = putObjects(userClient, []string{"my-obj"}, bucket)
+		_, err = putObjects(s, userClient, []string{"my-obj"}, bucket)
 		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrAccessDenied)); err != nil {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = userClient.HeadBucket(ctx, &s3.HeadBucketInput{
 			Bucket: &bucket,
 		})
@@ -7364,7 +11574,7 @@ func PutBucketAcl_success_grants(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutBucketAcl(ctx, &s3.PutBucketAclInput{
 			Bucket: &bucket,
 			AccessControlPolicy: &types.AccessControlPolicy{
@@ -7390,9 +11600,9 @@ func PutBucketAcl_success_grants(s *S3Conf) error {
 		newConf := *s
 		newConf.awsID = "grt1"
 		newConf.awsSecret = "grt1secret"
-		userClient := s3.NewFromConfig(newConf.Config())
+		userClient := newConf.GetClient()
 
-		_, err = putObjects(userClient, []string{"my-obj"}, bucket)
+		_, err = putObjects(s, userClient, []string{"my-obj"}, bucket)
 		if err != nil {
 			return err
 		}
@@ -7404,7 +11614,7 @@ func PutBucketAcl_success_grants(s *S3Conf) error {
 func GetBucketAcl_non_existing_bucket(s *S3Conf) error {
 	testName := "GetBucketAcl_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.GetBucketAcl(ctx, &s3.GetBucketAclInput{
 			Bucket: getPtr(getBucketName()),
 		})
@@ -7437,7 +11647,7 @@ func GetBucketAcl_translation_canned_public_read(s *S3Conf) error {
 			},
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketAcl(ctx, &s3.PutBucketAclInput{
 			Bucket: &bucket,
 			ACL:    types.BucketCannedACLPublicRead,
@@ -7447,7 +11657,7 @@ func GetBucketAcl_translation_canned_public_read(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.GetBucketAcl(ctx, &s3.GetBucketAclInput{
 			Bucket: &bucket,
 		})
@@ -7494,7 +11704,7 @@ func GetBucketAcl_translation_canned_public_read_write(s *S3Conf) error {
 			},
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketAcl(ctx, &s3.PutBucketAclInput{
 			Bucket: &bucket,
 			ACL:    types.BucketCannedACLPublicReadWrite,
@@ -7504,7 +11714,7 @@ func GetBucketAcl_translation_canned_public_read_write(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.GetBucketAcl(ctx, &s3.GetBucketAclInput{
 			Bucket: &bucket,
 		})
@@ -7537,7 +11747,7 @@ func GetBucketAcl_translation_canned_private(s *S3Conf) error {
 			},
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketAcl(ctx, &s3.PutBucketAclInput{
 			Bucket: &bucket,
 			ACL:    types.BucketCannedACLPrivate,
@@ -7547,7 +11757,7 @@ func GetBucketAcl_translation_canned_private(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.GetBucketAcl(ctx, &s3.GetBucketAclInput{
 			Bucket: &bucket,
 		})
@@ -7578,9 +11788,9 @@ func GetBucketAcl_access_denied(s *S3Conf) error {
 		newConf := *s
 		newConf.awsID = "grt1"
 		newConf.awsSecret = "grt1secret"
-		userClient := s3.NewFromConfig(newConf.Config())
+		userClient := newConf.GetClient()
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = userClient.GetBucketAcl(ctx, &s3.GetBucketAclInput{
 			Bucket: &bucket,
 		})
@@ -7629,7 +11839,7 @@ func GetBucketAcl_success(s *S3Conf) error {
 			},
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutBucketAcl(ctx, &s3.PutBucketAclInput{
 			Bucket: &bucket,
 			AccessControlPolicy: &types.AccessControlPolicy{
@@ -7644,7 +11854,7 @@ func GetBucketAcl_success(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.GetBucketAcl(ctx, &s3.GetBucketAclInput{
 			Bucket: &bucket,
 		})
@@ -7677,7 +11887,7 @@ func GetBucketAcl_success(s *S3Conf) error {
 func PutBucketPolicy_non_existing_bucket(s *S3Conf) error {
 	testName := "PutBucketPolicy_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		doc := genPolicyDoc("Allow", `"*"`, `"s3:*"`, fmt.Sprintf(`"arn:aws:s3:::%v"`, bucket))
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: getPtr("non_existing_bucket"),
@@ -7701,7 +11911,7 @@ func PutBucketPolicy_empty_statement(s *S3Conf) error {
 		}
 		`
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -7719,7 +11929,7 @@ func PutBucketPolicy_invalid_effect(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		doc := genPolicyDoc("invalid_effect", `"*"`, `"s3:*"`, `"arn:aws:s3:::*"`)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -7738,7 +11948,7 @@ func PutBucketPolicy_empty_actions_string(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		doc := genPolicyDoc("Allow", `"*"`, `""`, `"arn:aws:s3:::*"`)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -7757,7 +11967,7 @@ func PutBucketPolicy_empty_actions_array(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		doc := genPolicyDoc("Allow", `"*"`, `[]`, `"arn:aws:s3:::*"`)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -7776,7 +11986,7 @@ func PutBucketPolicy_invalid_action(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		doc := genPolicyDoc("Allow", `"*"`, `"ListObjects"`, `"arn:aws:s3:::*"`)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -7795,7 +12005,7 @@ func PutBucketPolicy_unsupported_action(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		doc := genPolicyDoc("Allow", `"*"`, `"s3:PutLifecycleConfiguration"`, `"arn:aws:s3:::*"`)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -7814,7 +12024,7 @@ func PutBucketPolicy_incorrect_action_wildcard_usage(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		doc := genPolicyDoc("Allow", `"*"`, `"s3:hello*"`, `"arn:aws:s3:::*"`)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -7833,7 +12043,7 @@ func PutBucketPolicy_empty_principals_string(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		doc := genPolicyDoc("Allow", `""`, `"s3:*"`, `"arn:aws:s3:::*"`)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -7852,7 +12062,7 @@ func PutBucketPolicy_empty_principals_array(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		doc := genPolicyDoc("Allow", `[]`, `"s3:*"`, `"arn:aws:s3:::*"`)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -7871,7 +12081,7 @@ func PutBucketPolicy_principals_aws_struct_empty_string(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		doc := genPolicyDoc("Allow", `{"AWS": ""}`, `"s3:*"`, `"arn:aws:s3:::*"`)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -7890,7 +12100,7 @@ func PutBucketPolicy_principals_aws_struct_empty_string_slice(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		doc := genPolicyDoc("Allow", `{"AWS": []}`, `"s3:*"`, `"arn:aws:s3:::*"`)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -7909,7 +12119,7 @@ func PutBucketPolicy_principals_incorrect_wildcard_usage(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		doc := genPolicyDoc("Allow", `["*", "grt1"]`, `"s3:*"`, `"arn:aws:s3:::*"`)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -7928,7 +12138,7 @@ func PutBucketPolicy_non_existing_principals(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		doc := genPolicyDoc("Allow", `["a_rarely_existing_user_account_1", "a_rarely_existing_user_account_2"]`, `"s3:*"`, `"arn:aws:s3:::*"`)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -7948,7 +12158,7 @@ func PutBucketPolicy_empty_resources_string(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		doc := genPolicyDoc("Allow", `["*"]`, `"s3:*"`, `""`)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -7967,7 +12177,7 @@ func PutBucketPolicy_empty_resources_array(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		doc := genPolicyDoc("Allow", `["*"]`, `"s3:*"`, `[]`)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -7987,7 +12197,7 @@ func PutBucketPolicy_invalid_resource_prefix(s *S3Conf) error {
 		resource := fmt.Sprintf(`"arn:aws:iam:::%v"`, bucket)
 		doc := genPolicyDoc("Allow", `["*"]`, `"s3:*"`, resource)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -8007,7 +12217,7 @@ func PutBucketPolicy_invalid_resource_with_starting_slash(s *S3Conf) error {
 		resource := fmt.Sprintf(`"arn:aws:s3:::/%v"`, bucket)
 		doc := genPolicyDoc("Allow", `["*"]`, `"s3:*"`, resource)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -8027,7 +12237,7 @@ func PutBucketPolicy_duplicate_resource(s *S3Conf) error {
 		resource := fmt.Sprintf(`"arn:aws:s3:::%v"`, bucket)
 		doc := genPolicyDoc("Allow", `["*"]`, `"s3:*"`, fmt.Sprintf("[%v, %v]", resource, resource))
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -8047,7 +12257,7 @@ func PutBucketPolicy_incorrect_bucket_name(s *S3Conf) error {
 		resource := fmt.Sprintf(`"arn:aws:s3:::prefix-%v"`, bucket)
 		doc := genPolicyDoc("Allow", `["*"]`, `"s3:*"`, resource)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -8067,7 +12277,7 @@ func PutBucketPolicy_object_action_on_bucket_resource(s *S3Conf) error {
 		resource := fmt.Sprintf(`"arn:aws:s3:::%v"`, bucket)
 		doc := genPolicyDoc("Allow", `["*"]`, `"s3:PutObjectTagging"`, resource)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -8087,7 +12297,7 @@ func PutBucketPolicy_bucket_action_on_object_resource(s *S3Conf) error {
 		resource := fmt.Sprintf(`"arn:aws:s3:::%v/*"`, bucket)
 		doc := genPolicyDoc("Allow", `["*"]`, `"s3:DeleteBucket"`, resource)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -8125,7 +12335,7 @@ func PutBucketPolicy_success(s *S3Conf) error {
 			genPolicyDoc("Allow", `"*"`, `"s3:Get*"`, objectResource),
 			genPolicyDoc("Deny", `"*"`, `"s3:Create*"`, fmt.Sprintf(`[%v, %v]`, bucketResource, objectResource)),
 		} {
-			ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 			_, err = s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 				Bucket: &bucket,
 				Policy: &doc,
@@ -8143,7 +12353,7 @@ func PutBucketPolicy_success(s *S3Conf) error {
 func GetBucketPolicy_non_existing_bucket(s *S3Conf) error {
 	testName := "GetBucketPolicy_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{
 			Bucket: getPtr("non_existing_bucket"),
 		})
@@ -8159,7 +12369,7 @@ func GetBucketPolicy_non_existing_bucket(s *S3Conf) error {
 func GetBucketPolicy_not_set(s *S3Conf) error {
 	testName := "GetBucketPolicy_not_set"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{
 			Bucket: &bucket,
 		})
@@ -8176,7 +12386,7 @@ func GetBucketPolicy_success(s *S3Conf) error {
 	testName := "GetBucketPolicy_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		doc := genPolicyDoc("Allow", `"*"`, `["s3:DeleteBucket", "s3:GetBucketTagging"]`, fmt.Sprintf(`"arn:aws:s3:::%v"`, bucket))
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -8185,7 +12395,7 @@ func GetBucketPolicy_success(s *S3Conf) error {
 		if err != nil {
 			return err
 		}
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{
 			Bucket: &bucket,
 		})
@@ -8206,10 +12416,86 @@ func GetBucketPolicy_success(s *S3Conf) error {
 	})
 }
 
+func GetBucketPolicy_round_trip(s *S3Conf) error {
+	testName := "GetBucketPolicy_round_trip"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		doc := fmt.Sprintf(`{"Statement":[{"Effect":"Allow","Principal":"*","Action":["s3:GetObject","s3:ListBucket"],"Resource":["arn:aws:s3:::%v","arn:aws:s3:::%v/*"]}]}`, bucket, bucket)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
+			Bucket: &bucket,
+			Policy: &doc,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		// the malformed policies should be rejected
+		// and the existing one should stay in place
+		resource := fmt.Sprintf(`"arn:aws:s3:::%v"`, bucket)
+		for _, malformed := range []string{
+			// invalid json
+			`{"Statement": [{"Effect": "Allow",`,
+			// unknown action
+			genPolicyDoc("Allow", `"*"`, `"s3:UnknownAction"`, resource),
+			// invalid resource arns
+			genPolicyDoc("Allow", `"*"`, `"s3:ListBucket"`, fmt.Sprintf(`"arn:aws:s4:::%v"`, bucket)),
+			genPolicyDoc("Allow", `"*"`, `"s3:ListBucket"`, `"arn:aws:s3:::"`),
+			// missing required fields
+			fmt.Sprintf(`{"Statement":[{"Principal":"*","Action":"s3:ListBucket","Resource":%v}]}`, resource),
+			fmt.Sprintf(`{"Statement":[{"Effect":"Allow","Action":"s3:ListBucket","Resource":%v}]}`, resource),
+			fmt.Sprintf(`{"Statement":[{"Effect":"Allow","Principal":"*","Resource":%v}]}`, resource),
+			`{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:ListBucket"}]}`,
+			`{}`,
+		} {
+			ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+			_, err = s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
+				Bucket: &bucket,
+				Policy: &malformed,
+			})
+			cancel()
+			if err == nil {
+				return fmt.Errorf("expected MalformedPolicy for the policy %v, instead got nil", malformed)
+			}
+			if err := checkSdkApiErr(err, "MalformedPolicy"); err != nil {
+				return fmt.Errorf("policy %v: %w", malformed, err)
+			}
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{
+			Bucket: &bucket,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if out.Policy == nil {
+			return fmt.Errorf("expected non nil policy result")
+		}
+
+		// compare the policy documents semantically, not byte by byte
+		var expected, got any
+		if err := json.Unmarshal([]byte(doc), &expected); err != nil {
+			return err
+		}
+		if err := json.Unmarshal([]byte(*out.Policy), &got); err != nil {
+			return fmt.Errorf("invalid bucket policy json %q: %w", *out.Policy, err)
+		}
+
+		if !reflect.DeepEqual(expected, got) {
+			return fmt.Errorf("expected the bucket policy to be %v, instead got %v", doc, *out.Policy)
+		}
+
+		return nil
+	})
+}
+
 func DeleteBucketPolicy_non_existing_bucket(s *S3Conf) error {
 	testName := "DeleteBucketPolicy_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.DeleteBucketPolicy(ctx, &s3.DeleteBucketPolicyInput{
 			Bucket: getPtr("non_existing_bucket"),
 		})
@@ -8225,7 +12511,7 @@ func DeleteBucketPolicy_non_existing_bucket(s *S3Conf) error {
 func DeleteBucketPolicy_remove_before_setting(s *S3Conf) error {
 	testName := "DeleteBucketPolicy_remove_before_setting"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.DeleteBucketPolicy(ctx, &s3.DeleteBucketPolicyInput{
 			Bucket: &bucket,
 		})
@@ -8239,7 +12525,7 @@ func DeleteBucketPolicy_success(s *S3Conf) error {
 	testName := "DeleteBucketPolicy_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		doc := genPolicyDoc("Allow", `"*"`, `["s3:DeleteBucket", "s3:GetBucketTagging"]`, fmt.Sprintf(`"arn:aws:s3:::%v"`, bucket))
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -8249,7 +12535,7 @@ func DeleteBucketPolicy_success(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.DeleteBucketPolicy(ctx, &s3.DeleteBucketPolicyInput{
 			Bucket: &bucket,
 		})
@@ -8258,7 +12544,7 @@ func DeleteBucketPolicy_success(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{
 			Bucket: &bucket,
 		})
@@ -8275,7 +12561,7 @@ func DeleteBucketPolicy_success(s *S3Conf) error {
 func PutObjectLockConfiguration_non_existing_bucket(s *S3Conf) error {
 	testName := "PutObjectLockConfiguration_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObjectLockConfiguration(ctx, &s3.PutObjectLockConfigurationInput{
 			Bucket: getPtr(getBucketName()),
 		})
@@ -8291,7 +12577,7 @@ func PutObjectLockConfiguration_non_existing_bucket(s *S3Conf) error {
 func PutObjectLockConfiguration_empty_config(s *S3Conf) error {
 	testName := "PutObjectLockConfiguration_empty_config"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObjectLockConfiguration(ctx, &s3.PutObjectLockConfigurationInput{
 			Bucket: &bucket,
 		})
@@ -8307,7 +12593,7 @@ func PutObjectLockConfiguration_not_enabled_on_bucket_creation(s *S3Conf) error
 	testName := "PutObjectLockConfiguration_not_enabled_on_bucket_creation"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		var days int32 = 12
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObjectLockConfiguration(ctx, &s3.PutObjectLockConfigurationInput{
 			Bucket: &bucket,
 			ObjectLockConfiguration: &types.ObjectLockConfiguration{
@@ -8332,7 +12618,7 @@ func PutObjectLockConfiguration_invalid_status(s *S3Conf) error {
 	testName := "PutObjectLockConfiguration_invalid_status"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		var days int32 = 12
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObjectLockConfiguration(ctx, &s3.PutObjectLockConfigurationInput{
 			Bucket: &bucket,
 			ObjectLockConfiguration: &types.ObjectLockConfiguration{
@@ -8356,7 +12642,7 @@ func PutObjectLockConfiguration_invalid_mode(s *S3Conf) error {
 	testName := "PutObjectLockConfiguration_invalid_status"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		var days int32 = 12
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObjectLockConfiguration(ctx, &s3.PutObjectLockConfigurationInput{
 			Bucket: &bucket,
 			ObjectLockConfiguration: &types.ObjectLockConfiguration{
@@ -8381,7 +12667,7 @@ func PutObjectLockConfiguration_both_years_and_days(s *S3Conf) error {
 	testName := "PutObjectLockConfiguration_both_years_and_days"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		var days, years int32 = 12, 24
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObjectLockConfiguration(ctx, &s3.PutObjectLockConfigurationInput{
 			Bucket: &bucket,
 			ObjectLockConfiguration: &types.ObjectLockConfiguration{
@@ -8406,7 +12692,7 @@ func PutObjectLockConfiguration_invalid_years_days(s *S3Conf) error {
 	testName := "PutObjectLockConfiguration_invalid_years"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		var days, years int32 = -3, -5
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObjectLockConfiguration(ctx, &s3.PutObjectLockConfigurationInput{
 			Bucket: &bucket,
 			ObjectLockConfiguration: &types.ObjectLockConfiguration{
@@ -8423,7 +12709,7 @@ func PutObjectLockConfiguration_invalid_years_days(s *S3Conf) error {
 		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrObjectLockInvalidRetentionPeriod)); err != nil {
 			return err
 		}
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectLockConfiguration(ctx, &s3.PutObjectLockConfigurationInput{
 			Bucket: &bucket,
 			ObjectLockConfiguration: &types.ObjectLockConfiguration{
@@ -8448,17 +12734,84 @@ func PutObjectLockConfiguration_invalid_years_days(s *S3Conf) error {
 func PutObjectLockConfiguration_success(s *S3Conf) error {
 	testName := "PutObjectLockConfiguration_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err := s3client.PutObjectLockConfiguration(ctx, &s3.PutObjectLockConfigurationInput{
+			Bucket: &bucket,
+			ObjectLockConfiguration: &types.ObjectLockConfiguration{
+				ObjectLockEnabled: types.ObjectLockEnabledEnabled,
+			},
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+		return nil
+	}, withLock())
+}
+
+func PutObjectLockConfiguration_disable_not_allowed(s *S3Conf) error {
+	testName := "PutObjectLockConfiguration_disable_not_allowed"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		// object lock can't be disabled once it's enabled on the bucket
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObjectLockConfiguration(ctx, &s3.PutObjectLockConfigurationInput{
+			Bucket: &bucket,
+			ObjectLockConfiguration: &types.ObjectLockConfiguration{
+				ObjectLockEnabled: types.ObjectLockEnabled("Disabled"),
+			},
+		})
+		cancel()
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrMalformedXML)); err != nil {
+			return err
+		}
+
+		// setting a default retention on the enabled bucket is allowed
+		var days int32 = 1
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.PutObjectLockConfiguration(ctx, &s3.PutObjectLockConfigurationInput{
 			Bucket: &bucket,
 			ObjectLockConfiguration: &types.ObjectLockConfiguration{
 				ObjectLockEnabled: types.ObjectLockEnabledEnabled,
+				Rule: &types.ObjectLockRule{
+					DefaultRetention: &types.DefaultRetention{
+						Mode: types.ObjectLockRetentionModeGovernance,
+						Days: &days,
+					},
+				},
 			},
 		})
 		cancel()
 		if err != nil {
 			return err
 		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		resp, err := s3client.GetObjectLockConfiguration(ctx, &s3.GetObjectLockConfigurationInput{
+			Bucket: &bucket,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		respConfig := resp.ObjectLockConfiguration
+		if respConfig == nil {
+			return fmt.Errorf("got nil object lock configuration")
+		}
+		if respConfig.ObjectLockEnabled != types.ObjectLockEnabledEnabled {
+			return fmt.Errorf("expected lock status to be %v, instead got %v", types.ObjectLockEnabledEnabled, respConfig.ObjectLockEnabled)
+		}
+		if respConfig.Rule == nil || respConfig.Rule.DefaultRetention == nil {
+			return fmt.Errorf("expected the default retention to be set")
+		}
+		respDays := respConfig.Rule.DefaultRetention.Days
+		if respDays == nil || *respDays != days {
+			return fmt.Errorf("expected lock config days to be %v, instead got %v", days, respDays)
+		}
+		if respConfig.Rule.DefaultRetention.Mode != types.ObjectLockRetentionModeGovernance {
+			return fmt.Errorf("expected lock config mode to be %v, instead got %v", types.ObjectLockRetentionModeGovernance, respConfig.Rule.DefaultRetention.Mode)
+		}
+
 		return nil
 	}, withLock())
 }
@@ -8466,7 +12819,7 @@ func PutObjectLockConfiguration_success(s *S3Conf) error {
 func GetObjectLockConfiguration_non_existing_bucket(s *S3Conf) error {
 	testName := "GetObjectLockConfiguration_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.GetObjectLockConfiguration(ctx, &s3.GetObjectLockConfigurationInput{
 			Bucket: getPtr(getBucketName()),
 		})
@@ -8482,7 +12835,7 @@ func GetObjectLockConfiguration_non_existing_bucket(s *S3Conf) error {
 func GetObjectLockConfiguration_unset_config(s *S3Conf) error {
 	testName := "GetObjectLockConfiguration_unset_config"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.GetObjectLockConfiguration(ctx, &s3.GetObjectLockConfigurationInput{
 			Bucket: &bucket,
 		})
@@ -8508,7 +12861,7 @@ func GetObjectLockConfiguration_success(s *S3Conf) error {
 				},
 			},
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObjectLockConfiguration(ctx, &s3.PutObjectLockConfigurationInput{
 			Bucket:                  &bucket,
 			ObjectLockConfiguration: &config,
@@ -8518,7 +12871,7 @@ func GetObjectLockConfiguration_success(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		resp, err := s3client.GetObjectLockConfiguration(ctx, &s3.GetObjectLockConfigurationInput{
 			Bucket: &bucket,
 		})
@@ -8550,7 +12903,7 @@ func GetObjectLockConfiguration_success(s *S3Conf) error {
 func PutObjectRetention_non_existing_bucket(s *S3Conf) error {
 	testName := "PutObjectRetention_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
 			Bucket: getPtr(getBucketName()),
 			Key:    getPtr("my-obj"),
@@ -8567,12 +12920,12 @@ func PutObjectRetention_non_existing_bucket(s *S3Conf) error {
 func PutObjectRetention_non_existing_object(s *S3Conf) error {
 	testName := "PutObjectRetention_non_existing_object"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		if err := changeBucketObjectLockStatus(s3client, bucket, true); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, true); err != nil {
 			return err
 		}
 
 		date := time.Now().Add(time.Hour * 3)
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
 			Bucket: &bucket,
 			Key:    getPtr("my-obj"),
@@ -8596,12 +12949,12 @@ func PutObjectRetention_unset_bucket_object_lock_config(s *S3Conf) error {
 		date := time.Now().Add(time.Hour * 3)
 		key := "my-obj"
 
-		_, err := putObjects(s3client, []string{key}, bucket)
+		_, err := putObjects(s, s3client, []string{key}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
 			Bucket: &bucket,
 			Key:    &key,
@@ -8622,7 +12975,7 @@ func PutObjectRetention_unset_bucket_object_lock_config(s *S3Conf) error {
 func PutObjectRetention_disabled_bucket_object_lock_config(s *S3Conf) error {
 	testName := "PutObjectRetention_disabled_bucket_object_lock_config"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObjectLockConfiguration(ctx, &s3.PutObjectLockConfigurationInput{
 			Bucket:                  &bucket,
 			ObjectLockConfiguration: &types.ObjectLockConfiguration{},
@@ -8635,12 +12988,12 @@ func PutObjectRetention_disabled_bucket_object_lock_config(s *S3Conf) error {
 		date := time.Now().Add(time.Hour * 3)
 		key := "my-obj"
 
-		_, err = putObjects(s3client, []string{key}, bucket)
+		_, err = putObjects(s, s3client, []string{key}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
 			Bucket: &bucket,
 			Key:    &key,
@@ -8661,13 +13014,13 @@ func PutObjectRetention_disabled_bucket_object_lock_config(s *S3Conf) error {
 func PutObjectRetention_expired_retain_until_date(s *S3Conf) error {
 	testName := "PutObjectRetention_expired_retain_until_date"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		if err := changeBucketObjectLockStatus(s3client, bucket, true); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, true); err != nil {
 			return err
 		}
 
 		date := time.Now().Add(-time.Hour * 3)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
 			Bucket: &bucket,
 			Key:    getPtr("my-obj"),
@@ -8689,7 +13042,7 @@ func PutObjectRetention_invalid_mode(s *S3Conf) error {
 	testName := "PutObjectRetention_invalid_mode"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		date := time.Now().Add(time.Hour * 3)
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
 			Bucket: &bucket,
 			Key:    getPtr("my-obj"),
@@ -8712,12 +13065,12 @@ func PutObjectRetention_overwrite_compliance_mode(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		date := time.Now().Add(time.Hour * 3)
 		obj := "my-obj"
-		_, err := putObjects(s3client, []string{obj}, bucket)
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -8731,7 +13084,7 @@ func PutObjectRetention_overwrite_compliance_mode(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -8745,7 +13098,7 @@ func PutObjectRetention_overwrite_compliance_mode(s *S3Conf) error {
 			return err
 		}
 
-		if err := changeBucketObjectLockStatus(s3client, bucket, false); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, false); err != nil {
 			return err
 		}
 
@@ -8758,12 +13111,12 @@ func PutObjectRetention_overwrite_governance_without_bypass_specified(s *S3Conf)
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		date := time.Now().Add(time.Hour * 3)
 		obj := "my-obj"
-		_, err := putObjects(s3client, []string{obj}, bucket)
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -8777,7 +13130,7 @@ func PutObjectRetention_overwrite_governance_without_bypass_specified(s *S3Conf)
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -8791,7 +13144,7 @@ func PutObjectRetention_overwrite_governance_without_bypass_specified(s *S3Conf)
 			return err
 		}
 
-		if err := changeBucketObjectLockStatus(s3client, bucket, false); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, false); err != nil {
 			return err
 		}
 
@@ -8804,12 +13157,12 @@ func PutObjectRetention_overwrite_governance_with_permission(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		date := time.Now().Add(time.Hour * 3)
 		obj := "my-obj"
-		_, err := putObjects(s3client, []string{obj}, bucket)
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -8826,7 +13179,7 @@ func PutObjectRetention_overwrite_governance_with_permission(s *S3Conf) error {
 		policy := genPolicyDoc("Allow", `"*"`, `["s3:BypassGovernanceRetention"]`, fmt.Sprintf(`"arn:aws:s3:::%v/*"`, bucket))
 		bypass := true
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &policy,
@@ -8836,7 +13189,7 @@ func PutObjectRetention_overwrite_governance_with_permission(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -8851,7 +13204,7 @@ func PutObjectRetention_overwrite_governance_with_permission(s *S3Conf) error {
 			return err
 		}
 
-		if err := changeBucketObjectLockStatus(s3client, bucket, false); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, false); err != nil {
 			return err
 		}
 
@@ -8862,19 +13215,19 @@ func PutObjectRetention_overwrite_governance_with_permission(s *S3Conf) error {
 func PutObjectRetention_success(s *S3Conf) error {
 	testName := "PutObjectRetention_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		if err := changeBucketObjectLockStatus(s3client, bucket, true); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, true); err != nil {
 			return err
 		}
 
 		date := time.Now().Add(time.Hour * 3)
 		key := "my-obj"
 
-		_, err := putObjects(s3client, []string{key}, bucket)
+		_, err := putObjects(s, s3client, []string{key}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
 			Bucket: &bucket,
 			Key:    &key,
@@ -8888,7 +13241,7 @@ func PutObjectRetention_success(s *S3Conf) error {
 			return err
 		}
 
-		if err := changeBucketObjectLockStatus(s3client, bucket, false); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, false); err != nil {
 			return err
 		}
 
@@ -8899,7 +13252,7 @@ func PutObjectRetention_success(s *S3Conf) error {
 func GetObjectRetention_non_existing_bucket(s *S3Conf) error {
 	testName := "GetObjectRetention_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.GetObjectRetention(ctx, &s3.GetObjectRetentionInput{
 			Bucket: getPtr(getBucketName()),
 			Key:    getPtr("my-obj"),
@@ -8916,7 +13269,7 @@ func GetObjectRetention_non_existing_bucket(s *S3Conf) error {
 func GetObjectRetention_non_existing_object(s *S3Conf) error {
 	testName := "GetObjectRetention_non_existing_object"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.GetObjectRetention(ctx, &s3.GetObjectRetentionInput{
 			Bucket: &bucket,
 			Key:    getPtr("my-obj"),
@@ -8934,12 +13287,12 @@ func GetObjectRetention_disabled_lock(s *S3Conf) error {
 	testName := "GetObjectRetention_disabled_lock"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		key := "my-obj"
-		_, err := putObjects(s3client, []string{key}, bucket)
+		_, err := putObjects(s, s3client, []string{key}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.GetObjectRetention(ctx, &s3.GetObjectRetentionInput{
 			Bucket: &bucket,
 			Key:    &key,
@@ -8957,12 +13310,12 @@ func GetObjectRetention_unset_config(s *S3Conf) error {
 	testName := "GetObjectRetention_unset_config"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		key := "my-obj"
-		_, err := putObjects(s3client, []string{key}, bucket)
+		_, err := putObjects(s, s3client, []string{key}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.GetObjectRetention(ctx, &s3.GetObjectRetentionInput{
 			Bucket: &bucket,
 			Key:    &key,
@@ -8979,11 +13332,11 @@ func GetObjectRetention_unset_config(s *S3Conf) error {
 func GetObjectRetention_success(s *S3Conf) error {
 	testName := "GetObjectRetention_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		if err := changeBucketObjectLockStatus(s3client, bucket, true); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, true); err != nil {
 			return err
 		}
 		key := "my-obj"
-		_, err := putObjects(s3client, []string{key}, bucket)
+		_, err := putObjects(s, s3client, []string{key}, bucket)
 		if err != nil {
 			return err
 		}
@@ -8994,7 +13347,7 @@ func GetObjectRetention_success(s *S3Conf) error {
 			RetainUntilDate: &date,
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
 			Bucket:    &bucket,
 			Key:       &key,
@@ -9005,7 +13358,7 @@ func GetObjectRetention_success(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		resp, err := s3client.GetObjectRetention(ctx, &s3.GetObjectRetentionInput{
 			Bucket: &bucket,
 			Key:    &key,
@@ -9029,7 +13382,7 @@ func GetObjectRetention_success(s *S3Conf) error {
 		// 	return fmt.Errorf("expected retain until date to be %v, instead got %v", retention.RetainUntilDate.Format(iso8601Format), ret.RetainUntilDate.Format(iso8601Format))
 		// }
 
-		if err := changeBucketObjectLockStatus(s3client, bucket, false); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, false); err != nil {
 			return err
 		}
 
@@ -9040,7 +13393,7 @@ func GetObjectRetention_success(s *S3Conf) error {
 func PutObjectLegalHold_non_existing_bucket(s *S3Conf) error {
 	testName := "PutObjectLegalHold_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObjectLegalHold(ctx, &s3.PutObjectLegalHoldInput{
 			Bucket: getPtr(getBucketName()),
 			Key:    getPtr("my-obj"),
@@ -9057,11 +13410,11 @@ func PutObjectLegalHold_non_existing_bucket(s *S3Conf) error {
 func PutObjectLegalHold_non_existing_object(s *S3Conf) error {
 	testName := "PutObjectLegalHold_non_existing_object"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		if err := changeBucketObjectLockStatus(s3client, bucket, true); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, true); err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObjectLegalHold(ctx, &s3.PutObjectLegalHoldInput{
 			Bucket: &bucket,
 			Key:    getPtr("my-obj"),
@@ -9081,7 +13434,7 @@ func PutObjectLegalHold_non_existing_object(s *S3Conf) error {
 func PutObjectLegalHold_invalid_body(s *S3Conf) error {
 	testName := "PutObjectLegalHold_invalid_body"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObjectLegalHold(ctx, &s3.PutObjectLegalHoldInput{
 			Bucket: &bucket,
 			Key:    getPtr("my-obj"),
@@ -9098,7 +13451,7 @@ func PutObjectLegalHold_invalid_body(s *S3Conf) error {
 func PutObjectLegalHold_invalid_status(s *S3Conf) error {
 	testName := "PutObjectLegalHold_invalid_status"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObjectLegalHold(ctx, &s3.PutObjectLegalHoldInput{
 			Bucket: &bucket,
 			Key:    getPtr("my-obj"),
@@ -9120,12 +13473,12 @@ func PutObjectLegalHold_unset_bucket_object_lock_config(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		key := "my-obj"
 
-		_, err := putObjects(s3client, []string{key}, bucket)
+		_, err := putObjects(s, s3client, []string{key}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectLegalHold(ctx, &s3.PutObjectLegalHoldInput{
 			Bucket: &bucket,
 			Key:    &key,
@@ -9145,7 +13498,7 @@ func PutObjectLegalHold_unset_bucket_object_lock_config(s *S3Conf) error {
 func PutObjectLegalHold_disabled_bucket_object_lock_config(s *S3Conf) error {
 	testName := "PutObjectLegalHold_disabled_bucket_object_lock_config"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObjectLockConfiguration(ctx, &s3.PutObjectLockConfigurationInput{
 			Bucket:                  &bucket,
 			ObjectLockConfiguration: &types.ObjectLockConfiguration{},
@@ -9157,12 +13510,12 @@ func PutObjectLegalHold_disabled_bucket_object_lock_config(s *S3Conf) error {
 
 		key := "my-obj"
 
-		_, err = putObjects(s3client, []string{key}, bucket)
+		_, err = putObjects(s, s3client, []string{key}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectLegalHold(ctx, &s3.PutObjectLegalHoldInput{
 			Bucket: &bucket,
 			Key:    &key,
@@ -9182,18 +13535,18 @@ func PutObjectLegalHold_disabled_bucket_object_lock_config(s *S3Conf) error {
 func PutObjectLegalHold_success(s *S3Conf) error {
 	testName := "PutObjectLegalHold_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		if err := changeBucketObjectLockStatus(s3client, bucket, true); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, true); err != nil {
 			return err
 		}
 
 		key := "my-obj"
 
-		_, err := putObjects(s3client, []string{key}, bucket)
+		_, err := putObjects(s, s3client, []string{key}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectLegalHold(ctx, &s3.PutObjectLegalHoldInput{
 			Bucket: &bucket,
 			Key:    &key,
@@ -9206,7 +13559,7 @@ func PutObjectLegalHold_success(s *S3Conf) error {
 			return err
 		}
 
-		if err := changeBucketObjectLockStatus(s3client, bucket, false); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, false); err != nil {
 			return err
 		}
 
@@ -9217,7 +13570,7 @@ func PutObjectLegalHold_success(s *S3Conf) error {
 func GetObjectLegalHold_non_existing_bucket(s *S3Conf) error {
 	testName := "GetObjectLegalHold_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.GetObjectLegalHold(ctx, &s3.GetObjectLegalHoldInput{
 			Bucket: getPtr(getBucketName()),
 			Key:    getPtr("my-obj"),
@@ -9234,7 +13587,7 @@ func GetObjectLegalHold_non_existing_bucket(s *S3Conf) error {
 func GetObjectLegalHold_non_existing_object(s *S3Conf) error {
 	testName := "GetObjectLegalHold_non_existing_object"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.GetObjectLegalHold(ctx, &s3.GetObjectLegalHoldInput{
 			Bucket: &bucket,
 			Key:    getPtr("my-obj"),
@@ -9252,12 +13605,12 @@ func GetObjectLegalHold_disabled_lock(s *S3Conf) error {
 	testName := "GetObjectLegalHold_disabled_lock"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		key := "my-obj"
-		_, err := putObjects(s3client, []string{key}, bucket)
+		_, err := putObjects(s, s3client, []string{key}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.GetObjectLegalHold(ctx, &s3.GetObjectLegalHoldInput{
 			Bucket: &bucket,
 			Key:    &key,
@@ -9275,12 +13628,12 @@ func GetObjectLegalHold_unset_config(s *S3Conf) error {
 	testName := "GetObjectLegalHold_unset_config"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		key := "my-obj"
-		_, err := putObjects(s3client, []string{key}, bucket)
+		_, err := putObjects(s, s3client, []string{key}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.GetObjectLegalHold(ctx, &s3.GetObjectLegalHoldInput{
 			Bucket: &bucket,
 			Key:    &key,
@@ -9297,16 +13650,16 @@ func GetObjectLegalHold_unset_config(s *S3Conf) error {
 func GetObjectLegalHold_success(s *S3Conf) error {
 	testName := "GetObjectLegalHold_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		if err := changeBucketObjectLockStatus(s3client, bucket, true); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, true); err != nil {
 			return err
 		}
 		key := "my-obj"
-		_, err := putObjects(s3client, []string{key}, bucket)
+		_, err := putObjects(s, s3client, []string{key}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectLegalHold(ctx, &s3.PutObjectLegalHoldInput{
 			Bucket: &bucket,
 			Key:    &key,
@@ -9319,7 +13672,7 @@ func GetObjectLegalHold_success(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		resp, err := s3client.GetObjectLegalHold(ctx, &s3.GetObjectLegalHoldInput{
 			Bucket: &bucket,
 			Key:    &key,
@@ -9333,7 +13686,7 @@ func GetObjectLegalHold_success(s *S3Conf) error {
 			return fmt.Errorf("expected legal hold status to be On, instead got %v", resp.LegalHold.Status)
 		}
 
-		if err := changeBucketObjectLockStatus(s3client, bucket, false); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, false); err != nil {
 			return err
 		}
 
@@ -9346,7 +13699,7 @@ func WORMProtection_bucket_object_lock_configuration_compliance_mode(s *S3Conf)
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		var days int32 = 10
 		object := "my-obj"
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObjectLockConfiguration(ctx, &s3.PutObjectLockConfigurationInput{
 			Bucket: &bucket,
 			ObjectLockConfiguration: &types.ObjectLockConfiguration{
@@ -9364,15 +13717,15 @@ func WORMProtection_bucket_object_lock_configuration_compliance_mode(s *S3Conf)
 			return err
 		}
 
-		_, err = putObjects(s3client, []string{object}, bucket)
+		_, err = putObjects(s, s3client, []string{object}, bucket)
 		if err != nil {
 			return err
 		}
 
-		if err := checkWORMProtection(s3client, bucket, object); err != nil {
+		if err := checkWORMProtection(s, s3client, bucket, object); err != nil {
 			return err
 		}
-		if err := changeBucketObjectLockStatus(s3client, bucket, false); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, false); err != nil {
 			return err
 		}
 
@@ -9385,7 +13738,7 @@ func WORMProtection_bucket_object_lock_configuration_governance_mode(s *S3Conf)
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		var days int32 = 10
 		object := "my-obj"
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObjectLockConfiguration(ctx, &s3.PutObjectLockConfigurationInput{
 			Bucket: &bucket,
 			ObjectLockConfiguration: &types.ObjectLockConfiguration{
@@ -9403,15 +13756,15 @@ func WORMProtection_bucket_object_lock_configuration_governance_mode(s *S3Conf)
 			return err
 		}
 
-		_, err = putObjects(s3client, []string{object}, bucket)
+		_, err = putObjects(s, s3client, []string{object}, bucket)
 		if err != nil {
 			return err
 		}
 
-		if err := checkWORMProtection(s3client, bucket, object); err != nil {
+		if err := checkWORMProtection(s, s3client, bucket, object); err != nil {
 			return err
 		}
-		if err := changeBucketObjectLockStatus(s3client, bucket, false); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, false); err != nil {
 			return err
 		}
 
@@ -9424,7 +13777,7 @@ func WORMProtection_bucket_object_lock_governance_bypass_delete(s *S3Conf) error
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		var days int32 = 10
 		object := "my-obj"
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObjectLockConfiguration(ctx, &s3.PutObjectLockConfigurationInput{
 			Bucket: &bucket,
 			ObjectLockConfiguration: &types.ObjectLockConfiguration{
@@ -9442,7 +13795,7 @@ func WORMProtection_bucket_object_lock_governance_bypass_delete(s *S3Conf) error
 			return err
 		}
 
-		_, err = putObjects(s3client, []string{object}, bucket)
+		_, err = putObjects(s, s3client, []string{object}, bucket)
 		if err != nil {
 			return err
 		}
@@ -9450,7 +13803,7 @@ func WORMProtection_bucket_object_lock_governance_bypass_delete(s *S3Conf) error
 		policy := genPolicyDoc("Allow", `"*"`, `["s3:BypassGovernanceRetention"]`, fmt.Sprintf(`"arn:aws:s3:::%v/*"`, bucket))
 		bypass := true
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &policy,
@@ -9460,7 +13813,7 @@ func WORMProtection_bucket_object_lock_governance_bypass_delete(s *S3Conf) error
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket:                    &bucket,
 			Key:                       &object,
@@ -9471,7 +13824,7 @@ func WORMProtection_bucket_object_lock_governance_bypass_delete(s *S3Conf) error
 			return err
 		}
 
-		if err := changeBucketObjectLockStatus(s3client, bucket, false); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, false); err != nil {
 			return err
 		}
 
@@ -9484,7 +13837,7 @@ func WORMProtection_bucket_object_lock_governance_bypass_delete_multiple(s *S3Co
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		var days int32 = 10
 		obj1, obj2, obj3 := "my-obj-1", "my-obj-2", "my-obj-3"
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObjectLockConfiguration(ctx, &s3.PutObjectLockConfigurationInput{
 			Bucket: &bucket,
 			ObjectLockConfiguration: &types.ObjectLockConfiguration{
@@ -9502,7 +13855,7 @@ func WORMProtection_bucket_object_lock_governance_bypass_delete_multiple(s *S3Co
 			return err
 		}
 
-		_, err = putObjects(s3client, []string{obj1, obj2, obj3}, bucket)
+		_, err = putObjects(s, s3client, []string{obj1, obj2, obj3}, bucket)
 		if err != nil {
 			return err
 		}
@@ -9510,7 +13863,7 @@ func WORMProtection_bucket_object_lock_governance_bypass_delete_multiple(s *S3Co
 		policy := genPolicyDoc("Allow", `"*"`, `["s3:BypassGovernanceRetention"]`, fmt.Sprintf(`"arn:aws:s3:::%v/*"`, bucket))
 		bypass := true
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &policy,
@@ -9520,7 +13873,7 @@ func WORMProtection_bucket_object_lock_governance_bypass_delete_multiple(s *S3Co
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
 			Bucket:                    &bucket,
 			BypassGovernanceRetention: &bypass,
@@ -9543,7 +13896,7 @@ func WORMProtection_bucket_object_lock_governance_bypass_delete_multiple(s *S3Co
 			return err
 		}
 
-		if err := changeBucketObjectLockStatus(s3client, bucket, false); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, false); err != nil {
 			return err
 		}
 
@@ -9556,13 +13909,13 @@ func WORMProtection_object_lock_retention_compliance_locked(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		object := "my-obj"
 
-		_, err := putObjects(s3client, []string{object}, bucket)
+		_, err := putObjects(s, s3client, []string{object}, bucket)
 		if err != nil {
 			return err
 		}
 
 		date := time.Now().Add(time.Hour * 3)
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
 			Bucket: &bucket,
 			Key:    &object,
@@ -9576,10 +13929,10 @@ func WORMProtection_object_lock_retention_compliance_locked(s *S3Conf) error {
 			return err
 		}
 
-		if err := checkWORMProtection(s3client, bucket, object); err != nil {
+		if err := checkWORMProtection(s, s3client, bucket, object); err != nil {
 			return err
 		}
-		if err := changeBucketObjectLockStatus(s3client, bucket, false); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, false); err != nil {
 			return err
 		}
 
@@ -9592,13 +13945,13 @@ func WORMProtection_object_lock_retention_governance_locked(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		object := "my-obj"
 
-		_, err := putObjects(s3client, []string{object}, bucket)
+		_, err := putObjects(s, s3client, []string{object}, bucket)
 		if err != nil {
 			return err
 		}
 
 		date := time.Now().Add(time.Hour * 3)
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
 			Bucket: &bucket,
 			Key:    &object,
@@ -9612,10 +13965,10 @@ func WORMProtection_object_lock_retention_governance_locked(s *S3Conf) error {
 			return err
 		}
 
-		if err := checkWORMProtection(s3client, bucket, object); err != nil {
+		if err := checkWORMProtection(s, s3client, bucket, object); err != nil {
 			return err
 		}
-		if err := changeBucketObjectLockStatus(s3client, bucket, false); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, false); err != nil {
 			return err
 		}
 
@@ -9628,13 +13981,13 @@ func WORMProtection_object_lock_retention_governance_bypass_overwrite(s *S3Conf)
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		object := "my-obj"
 
-		_, err := putObjects(s3client, []string{object}, bucket)
+		_, err := putObjects(s, s3client, []string{object}, bucket)
 		if err != nil {
 			return err
 		}
 
 		date := time.Now().Add(time.Hour * 3)
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
 			Bucket: &bucket,
 			Key:    &object,
@@ -9650,7 +14003,7 @@ func WORMProtection_object_lock_retention_governance_bypass_overwrite(s *S3Conf)
 
 		policy := genPolicyDoc("Allow", `"*"`, `["s3:BypassGovernanceRetention"]`, fmt.Sprintf(`"arn:aws:s3:::%v/*"`, bucket))
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &policy,
@@ -9660,7 +14013,7 @@ func WORMProtection_object_lock_retention_governance_bypass_overwrite(s *S3Conf)
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObject(ctx, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &object,
@@ -9670,7 +14023,7 @@ func WORMProtection_object_lock_retention_governance_bypass_overwrite(s *S3Conf)
 			return err
 		}
 
-		if err := changeBucketObjectLockStatus(s3client, bucket, false); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, false); err != nil {
 			return err
 		}
 
@@ -9683,13 +14036,13 @@ func WORMProtection_object_lock_retention_governance_bypass_delete(s *S3Conf) er
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		object := "my-obj"
 
-		_, err := putObjects(s3client, []string{object}, bucket)
+		_, err := putObjects(s, s3client, []string{object}, bucket)
 		if err != nil {
 			return err
 		}
 
 		date := time.Now().Add(time.Hour * 3)
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
 			Bucket: &bucket,
 			Key:    &object,
@@ -9706,7 +14059,7 @@ func WORMProtection_object_lock_retention_governance_bypass_delete(s *S3Conf) er
 		policy := genPolicyDoc("Allow", `"*"`, `["s3:BypassGovernanceRetention"]`, fmt.Sprintf(`"arn:aws:s3:::%v/*"`, bucket))
 		bypass := true
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &policy,
@@ -9716,7 +14069,7 @@ func WORMProtection_object_lock_retention_governance_bypass_delete(s *S3Conf) er
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket:                    &bucket,
 			Key:                       &object,
@@ -9727,7 +14080,7 @@ func WORMProtection_object_lock_retention_governance_bypass_delete(s *S3Conf) er
 			return err
 		}
 
-		if err := changeBucketObjectLockStatus(s3client, bucket, false); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, false); err != nil {
 			return err
 		}
 
@@ -9740,7 +14093,7 @@ func WORMProtection_object_lock_retention_governance_bypass_delete_mul(s *S3Conf
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		objs := []string{"my-obj-1", "my-obj2", "my-obj-3"}
 
-		_, err := putObjects(s3client, objs, bucket)
+		_, err := putObjects(s, s3client, objs, bucket)
 		if err != nil {
 			return err
 		}
@@ -9748,7 +14101,7 @@ func WORMProtection_object_lock_retention_governance_bypass_delete_mul(s *S3Conf
 		for _, obj := range objs {
 			o := obj
 			date := time.Now().Add(time.Hour * 3)
-			ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 			_, err := s3client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
 				Bucket: &bucket,
 				Key:    &o,
@@ -9766,7 +14119,7 @@ func WORMProtection_object_lock_retention_governance_bypass_delete_mul(s *S3Conf
 		policy := genPolicyDoc("Allow", `"*"`, `["s3:BypassGovernanceRetention"]`, fmt.Sprintf(`"arn:aws:s3:::%v/*"`, bucket))
 		bypass := true
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &policy,
@@ -9776,7 +14129,7 @@ func WORMProtection_object_lock_retention_governance_bypass_delete_mul(s *S3Conf
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
 			Bucket:                    &bucket,
 			BypassGovernanceRetention: &bypass,
@@ -9799,7 +14152,7 @@ func WORMProtection_object_lock_retention_governance_bypass_delete_mul(s *S3Conf
 			return err
 		}
 
-		if err := changeBucketObjectLockStatus(s3client, bucket, false); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, false); err != nil {
 			return err
 		}
 
@@ -9810,18 +14163,18 @@ func WORMProtection_object_lock_retention_governance_bypass_delete_mul(s *S3Conf
 func WORMProtection_object_lock_legal_hold_locked(s *S3Conf) error {
 	testName := "WORMProtection_object_lock_legal_hold_locked"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		if err := changeBucketObjectLockStatus(s3client, bucket, true); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, true); err != nil {
 			return err
 		}
 
 		object := "my-obj"
 
-		_, err := putObjects(s3client, []string{object}, bucket)
+		_, err := putObjects(s, s3client, []string{object}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectLegalHold(ctx, &s3.PutObjectLegalHoldInput{
 			Bucket: &bucket,
 			Key:    &object,
@@ -9834,12 +14187,12 @@ func WORMProtection_object_lock_legal_hold_locked(s *S3Conf) error {
 			return err
 		}
 
-		_, err = putObjects(s3client, []string{object}, bucket)
+		_, err = putObjects(s, s3client, []string{object}, bucket)
 		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrObjectLocked)); err != nil {
 			return err
 		}
 
-		if err := changeBucketObjectLockStatus(s3client, bucket, false); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, false); err != nil {
 			return err
 		}
 
@@ -9851,13 +14204,13 @@ func WORMProtection_root_bypass_governance_retention_delete_object(s *S3Conf) er
 	testName := "WORMProtection_root_bypass_governance_retention_delete_object"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		_, err := putObjects(s3client, []string{obj}, bucket)
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
 		if err != nil {
 			return err
 		}
 
 		retDate := time.Now().Add(time.Hour * 48)
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -9871,13 +14224,172 @@ func WORMProtection_root_bypass_governance_retention_delete_object(s *S3Conf) er
 			return err
 		}
 
-		if err := checkWORMProtection(s3client, bucket, obj); err != nil {
+		if err := checkWORMProtection(s, s3client, bucket, obj); err != nil {
+			return err
+		}
+
+		policy := genPolicyDoc("Allow", fmt.Sprintf(`"%v"`, s.awsID), `["s3:BypassGovernanceRetention"]`, fmt.Sprintf(`"arn:aws:s3:::%v/*"`, bucket))
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
+			Bucket: &bucket,
+			Policy: &policy,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		bypass := true
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
+			Bucket:                    &bucket,
+			Key:                       &obj,
+			BypassGovernanceRetention: &bypass,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, false); err != nil {
+			return err
+		}
+
+		return nil
+	}, withLock())
+}
+
+func WORMProtection_put_object_governance_retention_delete(s *S3Conf) error {
+	testName := "WORMProtection_put_object_governance_retention_delete"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-obj"
+		// the retention dates are stored with a second precision
+		retDate := time.Now().Add(time.Hour * 24).Truncate(time.Second)
+		_, err := putObjectWithData(s, 100, &s3.PutObjectInput{
+			Bucket:                    &bucket,
+			Key:                       &obj,
+			ObjectLockMode:            types.ObjectLockModeGovernance,
+			ObjectLockRetainUntilDate: &retDate,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		res, err := s3client.GetObjectRetention(ctx, &s3.GetObjectRetentionInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+		if res.Retention == nil {
+			return fmt.Errorf("expected non nil object retention")
+		}
+		if res.Retention.Mode != types.ObjectLockRetentionModeGovernance {
+			return fmt.Errorf("expected the retention mode to be %v, instead got %v",
+				types.ObjectLockRetentionModeGovernance, res.Retention.Mode)
+		}
+		if res.Retention.RetainUntilDate == nil {
+			return fmt.Errorf("expected non nil retain until date")
+		}
+		if !res.Retention.RetainUntilDate.Equal(retDate) {
+			return fmt.Errorf("expected the retain until date to be %v, instead got %v",
+				retDate, *res.Retention.RetainUntilDate)
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		cancel()
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrObjectLocked)); err != nil {
 			return err
 		}
 
 		policy := genPolicyDoc("Allow", fmt.Sprintf(`"%v"`, s.awsID), `["s3:BypassGovernanceRetention"]`, fmt.Sprintf(`"arn:aws:s3:::%v/*"`, bucket))
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
+			Bucket: &bucket,
+			Policy: &policy,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		// the bypass permission alone doesn't unlock the object
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		cancel()
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrObjectLocked)); err != nil {
+			return err
+		}
+
+		bypass := true
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
+			Bucket:                    &bucket,
+			Key:                       &obj,
+			BypassGovernanceRetention: &bypass,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		cancel()
+		if err := checkSdkApiErr(err, "NotFound"); err != nil {
+			return err
+		}
+
+		return changeBucketObjectLockStatus(s, s3client, bucket, false)
+	}, withLock())
+}
+
+func WORMProtection_put_object_legal_hold_delete(s *S3Conf) error {
+	testName := "WORMProtection_put_object_legal_hold_delete"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-obj"
+		_, err := putObjectWithData(s, 100, &s3.PutObjectInput{
+			Bucket:                    &bucket,
+			Key:                       &obj,
+			ObjectLockLegalHoldStatus: types.ObjectLockLegalHoldStatusOn,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		res, err := s3client.GetObjectLegalHold(ctx, &s3.GetObjectLegalHoldInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+		if res.LegalHold == nil {
+			return fmt.Errorf("expected non nil object legal hold")
+		}
+		if res.LegalHold.Status != types.ObjectLockLegalHoldStatusOn {
+			return fmt.Errorf("expected the legal hold status to be %v, instead got %v",
+				types.ObjectLockLegalHoldStatusOn, res.LegalHold.Status)
+		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		policy := genPolicyDoc("Allow", fmt.Sprintf(`"%v"`, s.awsID), `["s3:BypassGovernanceRetention"]`, fmt.Sprintf(`"arn:aws:s3:::%v/*"`, bucket))
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &policy,
@@ -9887,23 +14399,43 @@ func WORMProtection_root_bypass_governance_retention_delete_object(s *S3Conf) er
 			return err
 		}
 
-		bypass := true
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		// the legal hold can't be bypassed, the object has no retention
+		bypass := true
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
+			Bucket:                    &bucket,
+			Key:                       &obj,
+			BypassGovernanceRetention: &bypass,
+		})
+		cancel()
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrObjectLocked)); err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.PutObjectLegalHold(ctx, &s3.PutObjectLegalHoldInput{
+			Bucket: &bucket,
+			Key:    &obj,
+			LegalHold: &types.ObjectLockLegalHold{
+				Status: types.ObjectLockLegalHoldStatusOff,
+			},
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
-			Bucket:                    &bucket,
-			Key:                       &obj,
-			BypassGovernanceRetention: &bypass,
+			Bucket: &bucket,
+			Key:    &obj,
 		})
 		cancel()
 		if err != nil {
 			return err
 		}
 
-		if err := changeBucketObjectLockStatus(s3client, bucket, false); err != nil {
-			return err
-		}
-
-		return nil
+		return changeBucketObjectLockStatus(s, s3client, bucket, false)
 	}, withLock())
 }
 
@@ -9925,7 +14457,7 @@ func AccessControl_default_ACL_user_access_denied(s *S3Conf) error {
 		cfg.awsID = usr.access
 		cfg.awsSecret = usr.secret
 
-		_, err = putObjects(s3.NewFromConfig(cfg.Config()), []string{"my-obj"}, bucket)
+		_, err = putObjects(s, cfg.GetClient(), []string{"my-obj"}, bucket)
 		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrAccessDenied)); err != nil {
 			return err
 		}
@@ -9951,7 +14483,7 @@ func AccessControl_default_ACL_userplus_access_denied(s *S3Conf) error {
 		cfg.awsID = usr.access
 		cfg.awsSecret = usr.secret
 
-		_, err = putObjects(s3.NewFromConfig(cfg.Config()), []string{"my-obj"}, bucket)
+		_, err = putObjects(s, cfg.GetClient(), []string{"my-obj"}, bucket)
 		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrAccessDenied)); err != nil {
 			return err
 		}
@@ -9977,7 +14509,7 @@ func AccessControl_default_ACL_admin_successful_access(s *S3Conf) error {
 		cfg.awsID = admin.access
 		cfg.awsSecret = admin.secret
 
-		_, err = putObjects(s3.NewFromConfig(cfg.Config()), []string{"my-obj"}, bucket)
+		_, err = putObjects(s, cfg.GetClient(), []string{"my-obj"}, bucket)
 		if err != nil {
 			return err
 		}
@@ -10005,7 +14537,7 @@ func AccessControl_bucket_resource_single_action(s *S3Conf) error {
 		}
 
 		doc := genPolicyDoc("Allow", `["grt1"]`, `"s3:PutBucketTagging"`, fmt.Sprintf(`"arn:aws:s3:::%v"`, bucket))
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -10017,7 +14549,7 @@ func AccessControl_bucket_resource_single_action(s *S3Conf) error {
 
 		user1Client := getUserS3Client(usr1, s)
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = user1Client.DeleteBucketTagging(ctx, &s3.DeleteBucketTaggingInput{
 			Bucket: &bucket,
 		})
@@ -10026,7 +14558,7 @@ func AccessControl_bucket_resource_single_action(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = user1Client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{
 			Bucket: &bucket,
 		})
@@ -10037,7 +14569,7 @@ func AccessControl_bucket_resource_single_action(s *S3Conf) error {
 
 		user2Client := getUserS3Client(usr2, s)
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = user2Client.DeleteBucketTagging(ctx, &s3.DeleteBucketTaggingInput{
 			Bucket: &bucket,
 		})
@@ -10072,7 +14604,7 @@ func AccessControl_bucket_resource_all_action(s *S3Conf) error {
 		objectResource := fmt.Sprintf(`"arn:aws:s3:::%v/*"`, bucket)
 		doc := genPolicyDoc("Allow", `["grt1"]`, `"s3:*"`, fmt.Sprintf(`[%v, %v]`, bucketResource, objectResource))
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -10083,14 +14615,14 @@ func AccessControl_bucket_resource_all_action(s *S3Conf) error {
 		}
 
 		user1Client := getUserS3Client(usr1, s)
-		_, err = putObjects(user1Client, []string{"my-obj"}, bucket)
+		_, err = putObjects(s, user1Client, []string{"my-obj"}, bucket)
 		if err != nil {
 			return err
 		}
 
 		user2Client := getUserS3Client(usr2, s)
 
-		_, err = putObjects(user2Client, []string{"my-obj"}, bucket)
+		_, err = putObjects(s, user2Client, []string{"my-obj"}, bucket)
 		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrAccessDenied)); err != nil {
 			return err
 		}
@@ -10103,7 +14635,7 @@ func AccessControl_single_object_resource_actions(s *S3Conf) error {
 	testName := "AccessControl_single_object_resource_actions"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj/nested-obj"
-		_, err := putObjects(s3client, []string{obj}, bucket)
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
 		if err != nil {
 			return err
 		}
@@ -10120,7 +14652,7 @@ func AccessControl_single_object_resource_actions(s *S3Conf) error {
 
 		doc := genPolicyDoc("Allow", `["grt1"]`, `"s3:*"`, fmt.Sprintf(`"arn:aws:s3:::%v/%v"`, bucket, obj))
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &doc,
@@ -10132,7 +14664,7 @@ func AccessControl_single_object_resource_actions(s *S3Conf) error {
 
 		user1Client := getUserS3Client(usr1, s)
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = user1Client.GetObject(ctx, &s3.GetObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -10142,7 +14674,7 @@ func AccessControl_single_object_resource_actions(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = user1Client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{
 			Bucket: &bucket,
 		})
@@ -10187,7 +14719,7 @@ func AccessControl_multi_statement_policy(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &policy,
@@ -10199,7 +14731,7 @@ func AccessControl_multi_statement_policy(s *S3Conf) error {
 
 		userClient := getUserS3Client(usr, s)
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = userClient.ListObjects(ctx, &s3.ListObjectsInput{
 			Bucket: &bucket,
 		})
@@ -10208,7 +14740,7 @@ func AccessControl_multi_statement_policy(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = userClient.DeleteBucket(ctx, &s3.DeleteBucketInput{
 			Bucket: &bucket,
 		})
@@ -10221,6 +14753,118 @@ func AccessControl_multi_statement_policy(s *S3Conf) error {
 	})
 }
 
+func AccessControl_policy_GetObject_enforcement(s *S3Conf) error {
+	testName := "AccessControl_policy_GetObject_enforcement"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		usr := user{
+			access: "grt1",
+			secret: "grt1secret",
+			role:   "user",
+		}
+		err := createUsers(s, []user{usr})
+		if err != nil {
+			return err
+		}
+
+		obj, secretObj := "my-obj", "secret-obj"
+		_, err = putObjects(s, s3client, []string{obj, secretObj}, bucket)
+		if err != nil {
+			return err
+		}
+
+		// the explicit deny overrides the allow statement before it
+		doc := fmt.Sprintf(`{"Statement":[{"Effect":"Allow","Principal":["%v"],"Action":"s3:GetObject","Resource":"arn:aws:s3:::%v/*"},{"Effect":"Deny","Principal":["%v"],"Action":"s3:GetObject","Resource":"arn:aws:s3:::%v/%v"}]}`,
+			usr.access, bucket, usr.access, bucket, secretObj)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
+			Bucket: &bucket,
+			Policy: &doc,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{
+			Bucket: &bucket,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+		if out.Policy == nil {
+			return fmt.Errorf("expected non nil policy result")
+		}
+		var expected, got any
+		if err := json.Unmarshal([]byte(doc), &expected); err != nil {
+			return err
+		}
+		if err := json.Unmarshal([]byte(*out.Policy), &got); err != nil {
+			return fmt.Errorf("invalid bucket policy json %q: %w", *out.Policy, err)
+		}
+		if !reflect.DeepEqual(expected, got) {
+			return fmt.Errorf("expected the bucket policy to be %v, instead got %v", doc, *out.Policy)
+		}
+
+		userClient := getUserS3Client(usr, s)
+		getObject := func(client *s3.Client, key string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			defer cancel()
+			res, err := client.GetObject(ctx, &s3.GetObjectInput{
+				Bucket: &bucket,
+				Key:    &key,
+			})
+			if err != nil {
+				return err
+			}
+			return res.Body.Close()
+		}
+
+		if err := getObject(userClient, obj); err != nil {
+			return err
+		}
+		err = getObject(userClient, secretObj)
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrAccessDenied)); err != nil {
+			return err
+		}
+		// the actions not allowed by the policy are denied
+		_, err = putObjects(s, userClient, []string{obj}, bucket)
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrAccessDenied)); err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.DeleteBucketPolicy(ctx, &s3.DeleteBucketPolicyInput{
+			Bucket: &bucket,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{
+			Bucket: &bucket,
+		})
+		cancel()
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrNoSuchBucketPolicy)); err != nil {
+			return err
+		}
+
+		// without the policy only the bucket owner has access
+		err = getObject(userClient, obj)
+		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrAccessDenied)); err != nil {
+			return err
+		}
+		if err := getObject(s3client, secretObj); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
 func AccessControl_bucket_ownership_to_user(s *S3Conf) error {
 	testName := "AccessControl_bucket_ownership_to_user"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
@@ -10240,7 +14884,7 @@ func AccessControl_bucket_ownership_to_user(s *S3Conf) error {
 
 		userClient := getUserS3Client(usr, s)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := userClient.HeadBucket(ctx, &s3.HeadBucketInput{
 			Bucket: &bucket,
 		})
@@ -10272,7 +14916,7 @@ func AccessControl_root_PutBucketAcl(s *S3Conf) error {
 
 		userClient := getUserS3Client(usr, s)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := userClient.PutBucketAcl(ctx, &s3.PutBucketAclInput{
 			Bucket: &bucket,
 			ACL:    types.BucketCannedACLPrivate,
@@ -10301,7 +14945,7 @@ func AccessControl_user_PutBucketAcl_with_policy_access(s *S3Conf) error {
 
 		policy := genPolicyDoc("Allow", fmt.Sprintf(`"%v"`, usr.access), `"s3:PutBucketAcl"`, fmt.Sprintf(`"arn:aws:s3:::%v"`, bucket))
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
 			Policy: &policy,
@@ -10311,88 +14955,340 @@ func AccessControl_user_PutBucketAcl_with_policy_access(s *S3Conf) error {
 			return err
 		}
 
-		userClient := getUserS3Client(usr, s)
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
-		_, err = userClient.PutBucketAcl(ctx, &s3.PutBucketAclInput{
+		userClient := getUserS3Client(usr, s)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = userClient.PutBucketAcl(ctx, &s3.PutBucketAclInput{
+			Bucket: &bucket,
+			ACL:    types.BucketCannedACLPublicRead,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		res, err := s3client.GetBucketAcl(ctx, &s3.GetBucketAclInput{
+			Bucket: &bucket,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		expectedGrants := []types.Grant{
+			{
+				Grantee: &types.Grantee{
+					ID:   &s.awsID,
+					Type: types.TypeCanonicalUser,
+				},
+				Permission: types.PermissionFullControl,
+			},
+			{
+				Grantee: &types.Grantee{
+					ID:   getPtr("all-users"),
+					Type: types.TypeGroup,
+				},
+				Permission: types.PermissionRead,
+			},
+		}
+
+		if !compareGrants(res.Grants, expectedGrants) {
+			return fmt.Errorf("expected the resulting grants to be %v, instead got %v", expectedGrants, res.Grants)
+		}
+
+		return nil
+	}, withOwnership(types.ObjectOwnershipBucketOwnerPreferred))
+}
+
+func AccessControl_copy_object_with_starting_slash_for_user(s *S3Conf) error {
+	testName := "AccessControl_copy_object_with_starting_slash_for_user"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-obj"
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
+		if err != nil {
+			return err
+		}
+
+		usr := user{
+			access: "grt1",
+			secret: "grt1secret",
+			role:   "user",
+		}
+
+		if err := changeBucketsOwner(s, []string{bucket}, usr.access); err != nil {
+			return err
+		}
+
+		copySource := fmt.Sprintf("/%v/%v", bucket, obj)
+		meta := map[string]string{
+			"key1": "val1",
+		}
+
+		userClient := getUserS3Client(usr, s)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = userClient.CopyObject(ctx, &s3.CopyObjectInput{
+			Bucket:            &bucket,
+			Key:               &obj,
+			CopySource:        &copySource,
+			Metadata:          meta,
+			MetadataDirective: types.MetadataDirectiveReplace,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func AccessControl_anonymous_ListObjects_with_policy(s *S3Conf) error {
+	testName := "AccessControl_anonymous_ListObjects_with_policy"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		_, err := putObjects(s, s3client, []string{"foo", "bar"}, bucket)
+		if err != nil {
+			return err
+		}
+
+		client := http.Client{
+			Timeout: s.opTimeout(),
+		}
+		listAnonymously := func() (int, error) {
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v/%v?list-type=2", s.endpoint, bucket), nil)
+			if err != nil {
+				return 0, err
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				return 0, err
+			}
+			defer resp.Body.Close()
+			return resp.StatusCode, nil
+		}
+
+		// the bucket has no policy: the anonymous request is denied
+		status, err := listAnonymously()
+		if err != nil {
+			return err
+		}
+		if status != http.StatusForbidden {
+			return fmt.Errorf("expected the response status to be %v, instead got %v", http.StatusForbidden, status)
+		}
+
+		doc := genPolicyDoc("Allow", `"*"`, `"s3:ListBucket"`, fmt.Sprintf(`"arn:aws:s3:::%v"`, bucket))
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
+			Bucket: &bucket,
+			Policy: &doc,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		status, err = listAnonymously()
+		if err != nil {
+			return err
+		}
+		if status != http.StatusOK {
+			return fmt.Errorf("expected the response status to be %v, instead got %v", http.StatusOK, status)
+		}
+
+		return nil
+	})
+}
+
+func AccessControl_anonymous_GetObject_response_overrides(s *S3Conf) error {
+	testName := "AccessControl_anonymous_GetObject_response_overrides"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-obj"
+		_, err := putObjects(s, s3client, []string{obj}, bucket)
+		if err != nil {
+			return err
+		}
+
+		doc := genPolicyDoc("Allow", `"*"`, `"s3:GetObject"`, fmt.Sprintf(`"arn:aws:s3:::%v/*"`, bucket))
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
+			Bucket: &bucket,
+			Policy: &doc,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		client := http.Client{
+			Timeout: s.opTimeout(),
+		}
+		getAnonymously := func(query string) (*http.Response, error) {
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v/%v/%v%v", s.endpoint, bucket, obj, query), nil)
+			if err != nil {
+				return nil, err
+			}
+			return client.Do(req)
+		}
+
+		// the public object can be read anonymously
+		resp, err := getAnonymously("")
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("expected the response status to be %v, instead got %v", http.StatusOK, resp.StatusCode)
+		}
+
+		// but the response headers can't be overridden anonymously
+		resp, err = getAnonymously("?response-content-type=application%2Fjson")
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if err := checkAuthErr(resp, s3err.GetAPIError(s3err.ErrAnonymousResponseHeaders)); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func AccessControl_anonymous_GetObject_public_read(s *S3Conf) error {
+	testName := "AccessControl_anonymous_GetObject_public_read"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		publicObj, privateObj := "public/my-obj", "private-obj"
+		objs, err := putObjects(s, s3client, []string{publicObj, privateObj}, bucket)
+		if err != nil {
+			return err
+		}
+
+		// only the objects under the "public/" prefix are public
+		doc := genPolicyDoc("Allow", `"*"`, `"s3:GetObject"`, fmt.Sprintf(`"arn:aws:s3:::%v/public/*"`, bucket))
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: &bucket,
-			ACL:    types.BucketCannedACLPublicRead,
+			Policy: &doc,
 		})
 		cancel()
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
-		res, err := s3client.GetBucketAcl(ctx, &s3.GetBucketAclInput{
-			Bucket: &bucket,
-		})
-		cancel()
+		client := http.Client{
+			Timeout: s.opTimeout(),
+		}
+		getAnonymously := func(obj string) (*http.Response, error) {
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v/%v/%v", s.endpoint, bucket, obj), nil)
+			if err != nil {
+				return nil, err
+			}
+			return client.Do(req)
+		}
+
+		resp, err := getAnonymously(publicObj)
 		if err != nil {
 			return err
 		}
-
-		expectedGrants := []types.Grant{
-			{
-				Grantee: &types.Grantee{
-					ID:   &s.awsID,
-					Type: types.TypeCanonicalUser,
-				},
-				Permission: types.PermissionFullControl,
-			},
-			{
-				Grantee: &types.Grantee{
-					ID:   getPtr("all-users"),
-					Type: types.TypeGroup,
-				},
-				Permission: types.PermissionRead,
-			},
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("expected the response status to be %v, instead got %v", http.StatusOK, resp.StatusCode)
+		}
+		if etag := resp.Header.Get("ETag"); etag != getString(objs[0].ETag) {
+			return fmt.Errorf("expected the object etag to be %v, instead got %v", getString(objs[0].ETag), etag)
 		}
 
-		if !compareGrants(res.Grants, expectedGrants) {
-			return fmt.Errorf("expected the resulting grants to be %v, instead got %v", expectedGrants, res.Grants)
+		resp, err = getAnonymously(privateObj)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if err := checkAuthErr(resp, s3err.GetAPIError(s3err.ErrAccessDenied)); err != nil {
+			return err
 		}
 
 		return nil
-	}, withOwnership(types.ObjectOwnershipBucketOwnerPreferred))
+	})
 }
 
-func AccessControl_copy_object_with_starting_slash_for_user(s *S3Conf) error {
-	testName := "AccessControl_copy_object_with_starting_slash_for_user"
+func AccessControl_user_PutObject_idempotency_token_access_denied(s *S3Conf) error {
+	testName := "AccessControl_user_PutObject_idempotency_token_access_denied"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		obj := "my-obj"
-		_, err := putObjects(s3client, []string{obj}, bucket)
-		if err != nil {
-			return err
-		}
-
+		obj, token := "my-obj", "my-idempotency-token"
 		usr := user{
 			access: "grt1",
 			secret: "grt1secret",
 			role:   "user",
 		}
+		err := createUsers(s, []user{usr})
+		if err != nil {
+			return err
+		}
 
-		if err := changeBucketsOwner(s, []string{bucket}, usr.access); err != nil {
+		client := http.Client{
+			Timeout: s.opTimeout(),
+		}
+
+		putObject := func(access, secret string, body []byte) (*http.Response, error) {
+			req, err := createSignedReq(
+				http.MethodPut,
+				s.endpoint,
+				fmt.Sprintf("%v/%v", bucket, obj),
+				access,
+				secret,
+				"s3",
+				s.awsRegion,
+				body,
+				time.Now(),
+				map[string]string{
+					"X-Versity-Idempotency-Token": token,
+				},
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			return client.Do(req)
+		}
+
+		data := []byte("root user data")
+		resp, err := putObject(s.awsID, s.awsSecret, data)
+		if err != nil {
 			return err
 		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("expected the response status to be %v, instead got %v", http.StatusOK, resp.StatusCode)
+		}
 
-		copySource := fmt.Sprintf("/%v/%v", bucket, obj)
-		meta := map[string]string{
-			"key1": "val1",
+		// the user without write access must not get the cached result
+		resp, err = putObject(usr.access, usr.secret, []byte("user data"))
+		if err != nil {
+			return err
 		}
+		defer resp.Body.Close()
 
-		userClient := getUserS3Client(usr, s)
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		_, err = userClient.CopyObject(ctx, &s3.CopyObjectInput{
-			Bucket:            &bucket,
-			Key:               &obj,
-			CopySource:        &copySource,
-			Metadata:          meta,
-			MetadataDirective: types.MetadataDirectiveReplace,
+		if err := checkAuthErr(resp, s3err.GetAPIError(s3err.ErrAccessDenied)); err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
 		})
-		cancel()
+		defer cancel()
+		if err != nil {
+			return err
+		}
+		defer out.Body.Close()
+
+		bdy, err := io.ReadAll(out.Body)
 		if err != nil {
 			return err
 		}
+		if !isEqual(bdy, data) {
+			return fmt.Errorf("expected the object data to be %q, instead got %q", data, bdy)
+		}
 
 		return nil
 	})
@@ -10476,127 +15372,431 @@ func IAM_userplus_CreateBucket(s *S3Conf) error {
 			return err
 		}
 
-		cfg := *s
-		cfg.awsID = usr.access
-		cfg.awsSecret = usr.secret
+		cfg := *s
+		cfg.awsID = usr.access
+		cfg.awsSecret = usr.secret
+
+		bckt := getBucketName()
+		err = setup(&cfg, bckt)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: &bckt})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		err = teardown(&cfg, bckt)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func IAM_admin_ChangeBucketOwner(s *S3Conf) error {
+	testName := "IAM_admin_ChangeBucketOwner"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		admin := user{
+			access: "admin1",
+			secret: "admin1secret",
+			role:   "admin",
+		}
+		usr := user{
+			access: "grt1",
+			secret: "grt1secret",
+			role:   "user",
+		}
+		err := createUsers(s, []user{admin, usr})
+		if err != nil {
+			return err
+		}
+
+		err = changeBucketsOwner(s, []string{bucket}, usr.access)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		resp, err := s3client.GetBucketAcl(ctx, &s3.GetBucketAclInput{Bucket: &bucket})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if *resp.Owner.ID != usr.access {
+			return fmt.Errorf("expected the bucket owner to be %v, instead got %v", usr.access, *resp.Owner.ID)
+		}
+
+		return nil
+	})
+}
+
+func IAM_ChangeBucketOwner_back_to_root(s *S3Conf) error {
+	testName := "IAM_ChangeBucketOwner_back_to_root"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		usr := user{
+			access: "grt1",
+			secret: "grt1secret",
+			role:   "user",
+		}
+
+		if err := createUsers(s, []user{usr}); err != nil {
+			return err
+		}
+
+		// Change the bucket ownership to a random user
+		if err := changeBucketsOwner(s, []string{bucket}, usr.access); err != nil {
+			return err
+		}
+
+		// Change the bucket ownership back to the root user
+		if err := changeBucketsOwner(s, []string{bucket}, s.awsID); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func IAM_admin_GetBucketStats(s *S3Conf) error {
+	testName := "IAM_admin_GetBucketStats"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		checkStats := func(expCount, expSize int64) error {
+			count, size, err := getBucketStats(s, bucket)
+			if err != nil {
+				return err
+			}
+			if count != expCount {
+				return fmt.Errorf("expected the object count to be %v, instead got %v", expCount, count)
+			}
+			if size != expSize {
+				return fmt.Errorf("expected the total size to be %v, instead got %v", expSize, size)
+			}
+			return nil
+		}
+
+		if err := checkStats(0, 0); err != nil {
+			return err
+		}
+
+		obj1, obj2 := "foo", "bar/baz"
+		_, err := putObjectWithData(s, 100, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &obj1,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+		_, err = putObjectWithData(s, 200, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &obj2,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+
+		if err := checkStats(2, 300); err != nil {
+			return err
+		}
+
+		// overwrite the object with smaller data
+		_, err = putObjectWithData(s, 50, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &obj1,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+
+		if err := checkStats(2, 250); err != nil {
+			return err
+		}
+
+		mpObj := "my-mp-obj"
+		out, err := createMp(s, s3client, bucket, mpObj)
+		if err != nil {
+			return err
+		}
+
+		mpSize := int64(10 * 1024 * 1024)
+		parts, _, err := uploadParts(s, s3client, mpSize, 2, bucket, mpObj, *out.UploadId)
+		if err != nil {
+			return err
+		}
+
+		// the incomplete multipart upload should not be counted
+		if err := checkStats(2, 250); err != nil {
+			return err
+		}
+
+		compParts := []types.CompletedPart{}
+		for _, el := range parts {
+			compParts = append(compParts, types.CompletedPart{
+				ETag:       el.ETag,
+				PartNumber: el.PartNumber,
+			})
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:   &bucket,
+			Key:      &mpObj,
+			UploadId: out.UploadId,
+			MultipartUpload: &types.CompletedMultipartUpload{
+				Parts: compParts,
+			},
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if err := checkStats(3, 250+mpSize); err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
+			Bucket: &bucket,
+			Key:    &obj2,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if err := checkStats(2, 50+mpSize); err != nil {
+			return err
+		}
 
-		bckt := getBucketName()
-		err = setup(&cfg, bckt)
+		// the empty directory object is counted as an object
+		dirObj := "my-dir/"
+		_, err = putObjects(s, s3client, []string{dirObj}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		_, err = s3client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: &bckt})
-		cancel()
+		return checkStats(3, 50+mpSize)
+	})
+}
+
+// Posix related tests
+func PutObject_overwrite_dir_obj(s *S3Conf) error {
+	testName := "PutObject_overwrite_dir_obj"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		// "foo" is a distinct key and doesn't replace the "foo/" directory object
+		contents, err := putObjects(s, s3client, []string{"foo/", "foo"}, bucket)
 		if err != nil {
 			return err
 		}
 
-		err = teardown(&cfg, bckt)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		res, err := s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+			Bucket: &bucket,
+		})
+		cancel()
 		if err != nil {
 			return err
 		}
 
+		if !compareObjects(contents, res.Contents) {
+			return fmt.Errorf("expected the objects to be %v, instead got %v", objStrings(contents), objStrings(res.Contents))
+		}
 		return nil
 	})
 }
 
-func IAM_admin_ChangeBucketOwner(s *S3Conf) error {
-	testName := "IAM_admin_ChangeBucketOwner"
+func PutObject_overwrite_file_obj(s *S3Conf) error {
+	testName := "PutObject_overwrite_file_obj"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		admin := user{
-			access: "admin1",
-			secret: "admin1secret",
-			role:   "admin",
-		}
-		usr := user{
-			access: "grt1",
-			secret: "grt1secret",
-			role:   "user",
+		// "foo/" is a distinct key and doesn't replace the "foo" file object
+		contents, err := putObjects(s, s3client, []string{"foo", "foo/"}, bucket)
+		if err != nil {
+			return err
 		}
-		err := createUsers(s, []user{admin, usr})
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		res, err := s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+			Bucket: &bucket,
+		})
+		cancel()
 		if err != nil {
 			return err
 		}
 
-		err = changeBucketsOwner(s, []string{bucket}, usr.access)
+		if !compareObjects(contents, res.Contents) {
+			return fmt.Errorf("expected the objects to be %v, instead got %v", objStrings(contents), objStrings(res.Contents))
+		}
+		return nil
+	})
+}
+
+func PutObject_overwrite_file_obj_with_nested_obj(s *S3Conf) error {
+	testName := "PutObject_overwrite_file_obj_with_nested_obj"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		// "foo" is only the prefix of "foo/bar", both objects are kept
+		contents, err := putObjects(s, s3client, []string{"foo", "foo/bar"}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		resp, err := s3client.GetBucketAcl(ctx, &s3.GetBucketAclInput{Bucket: &bucket})
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		res, err := s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+			Bucket: &bucket,
+		})
 		cancel()
 		if err != nil {
 			return err
 		}
 
-		if *resp.Owner.ID != usr.access {
-			return fmt.Errorf("expected the bucket owner to be %v, instead got %v", usr.access, *resp.Owner.ID)
+		if !compareObjects(contents, res.Contents) {
+			return fmt.Errorf("expected the objects to be %v, instead got %v", objStrings(contents), objStrings(res.Contents))
 		}
-
 		return nil
 	})
 }
 
-func IAM_ChangeBucketOwner_back_to_root(s *S3Conf) error {
-	testName := "IAM_ChangeBucketOwner_back_to_root"
+func PutObject_dir_obj_not_conflated_with_file_obj(s *S3Conf) error {
+	testName := "PutObject_dir_obj_not_conflated_with_file_obj"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		usr := user{
-			access: "grt1",
-			secret: "grt1secret",
-			role:   "user",
+		// "foo/" is a directory object and "foo" is an independent
+		// file object, both are retrievable and listed distinctly
+		dirObj, fileObj := "foo/", "foo"
+		_, err := putObjects(s, s3client, []string{dirObj}, bucket)
+		if err != nil {
+			return err
 		}
 
-		if err := createUsers(s, []user{usr}); err != nil {
+		dataLen := int64(100)
+		out, err := putObjectWithData(s, dataLen, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &fileObj,
+		}, s3client)
+		if err != nil {
 			return err
 		}
 
-		// Change the bucket ownership to a random user
-		if err := changeBucketsOwner(s, []string{bucket}, usr.access); err != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		res, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: &bucket,
+			Key:    &fileObj,
+		})
+		if err != nil {
+			cancel()
 			return err
 		}
-
-		// Change the bucket ownership back to the root user
-		if err := changeBucketsOwner(s, []string{bucket}, s.awsID); err != nil {
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		cancel()
+		if err != nil {
 			return err
 		}
+		if sha256.Sum256(body) != out.csum {
+			return fmt.Errorf("expected the %q object data to match the put data", fileObj)
+		}
 
-		return nil
-	})
-}
-
-// Posix related tests
-func PutObject_overwrite_dir_obj(s *S3Conf) error {
-	testName := "PutObject_overwrite_dir_obj"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		_, err := putObjects(s3client, []string{"foo/", "foo"}, bucket)
-		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrExistingObjectIsDirectory)); err != nil {
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		head, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: &bucket,
+			Key:    &dirObj,
+		})
+		cancel()
+		if err != nil {
 			return err
 		}
-		return nil
-	})
-}
+		if head.ContentLength == nil || *head.ContentLength != 0 {
+			return fmt.Errorf("expected the directory object content length to be 0, instead got %v", head.ContentLength)
+		}
 
-func PutObject_overwrite_file_obj(s *S3Conf) error {
-	testName := "PutObject_overwrite_file_obj"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		_, err := putObjects(s3client, []string{"foo", "foo/"}, bucket)
-		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrObjectParentIsFile)); err != nil {
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		list, err := s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+			Bucket: &bucket,
+		})
+		cancel()
+		if err != nil {
 			return err
 		}
-		return nil
-	})
-}
+		if keys := objStrings(list.Contents); !slices.Equal(keys, []string{fileObj, dirObj}) {
+			return fmt.Errorf("expected the objects to be %v, instead got %v", []string{fileObj, dirObj}, keys)
+		}
+		if *list.Contents[0].Size != dataLen || *list.Contents[1].Size != 0 {
+			return fmt.Errorf("expected the object sizes to be [%v 0], instead got [%v %v]",
+				dataLen, *list.Contents[0].Size, *list.Contents[1].Size)
+		}
 
-func PutObject_overwrite_file_obj_with_nested_obj(s *S3Conf) error {
-	testName := "PutObject_overwrite_file_obj_with_nested_obj"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		_, err := putObjects(s3client, []string{"foo", "foo/bar"}, bucket)
-		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrObjectParentIsFile)); err != nil {
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		list, err = s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+			Bucket:    &bucket,
+			Delimiter: getPtr("/"),
+		})
+		cancel()
+		if err != nil {
 			return err
 		}
+		if keys := objStrings(list.Contents); !slices.Equal(keys, []string{fileObj}) {
+			return fmt.Errorf("expected the objects to be %v, instead got %v", []string{fileObj}, keys)
+		}
+		if !comparePrefixes([]string{dirObj}, list.CommonPrefixes) {
+			return fmt.Errorf("expected the common prefixes to be %v, instead got %v", []string{dirObj}, list.CommonPrefixes)
+		}
+
+		// deleting either object keeps the other one
+		for _, keys := range [][2]string{{fileObj, dirObj}, {dirObj, fileObj}} {
+			del, kept := keys[0], keys[1]
+			ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+			_, err = s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
+				Bucket: &bucket,
+				Key:    &del,
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+			_, err = s3client.HeadObject(ctx, &s3.HeadObjectInput{
+				Bucket: &bucket,
+				Key:    &del,
+			})
+			cancel()
+			if err := checkSdkApiErr(err, "NotFound"); err != nil {
+				return err
+			}
+
+			ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+			_, err = s3client.HeadObject(ctx, &s3.HeadObjectInput{
+				Bucket: &bucket,
+				Key:    &kept,
+			})
+			cancel()
+			if err != nil {
+				return fmt.Errorf("expected %q to be kept after deleting %q: %w", kept, del, err)
+			}
+
+			ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+			list, err = s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+				Bucket: &bucket,
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
+			if keys := objStrings(list.Contents); !slices.Equal(keys, []string{kept}) {
+				return fmt.Errorf("expected the objects to be %v, instead got %v", []string{kept}, keys)
+			}
+
+			// put the deleted object back for the other way around
+			_, err = putObjects(s, s3client, []string{del}, bucket)
+			if err != nil {
+				return err
+			}
+		}
+
 		return nil
 	})
 }
@@ -10604,7 +15804,7 @@ func PutObject_overwrite_file_obj_with_nested_obj(s *S3Conf) error {
 func PutObject_dir_obj_with_data(s *S3Conf) error {
 	testName := "PutObject_dir_obj_with_data"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		_, err := putObjectWithData(int64(20), &s3.PutObjectInput{
+		_, err := putObjectWithData(s, int64(20), &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    getPtr("obj/"),
 		}, s3client)
@@ -10618,7 +15818,7 @@ func PutObject_dir_obj_with_data(s *S3Conf) error {
 func CreateMultipartUpload_dir_obj(s *S3Conf) error {
 	testName := "CreateMultipartUpload_dir_obj"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		_, err := createMp(s3client, bucket, "obj/")
+		_, err := createMp(s, s3client, bucket, "obj/")
 		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrDirectoryObjectContainsData)); err != nil {
 			return err
 		}
@@ -10631,7 +15831,7 @@ func PutObject_name_too_long(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		key := genRandString(300)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutObject(ctx, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &key,
@@ -10649,7 +15849,7 @@ func PutObject_name_too_long(s *S3Conf) error {
 func PutBucketVersioning_non_existing_bucket(s *S3Conf) error {
 	testName := "PutBucketVersioning_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		err := putBucketVersioningStatus(s3client, getBucketName(), types.BucketVersioningStatusSuspended)
+		err := putBucketVersioningStatus(s, s3client, getBucketName(), types.BucketVersioningStatusSuspended)
 		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrNoSuchBucket)); err != nil {
 			return err
 		}
@@ -10661,7 +15861,7 @@ func PutBucketVersioning_non_existing_bucket(s *S3Conf) error {
 func HeadObject_name_too_long(s *S3Conf) error {
 	testName := "HeadObject_name_too_long"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
 			Bucket: &bucket,
 			Key:    getPtr(genRandString(300)),
@@ -10678,7 +15878,7 @@ func HeadObject_name_too_long(s *S3Conf) error {
 func PutBucketVersioning_invalid_status(s *S3Conf) error {
 	testName := "PutBucketVersioning_invalid_status"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		err := putBucketVersioningStatus(s3client, bucket, types.BucketVersioningStatus("invalid_status"))
+		err := putBucketVersioningStatus(s, s3client, bucket, types.BucketVersioningStatus("invalid_status"))
 		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrMalformedXML)); err != nil {
 			return err
 		}
@@ -10690,7 +15890,7 @@ func PutBucketVersioning_invalid_status(s *S3Conf) error {
 func PutBucketVersioning_success_enabled(s *S3Conf) error {
 	testName := "PutBucketVersioning_success_enabled"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		err := putBucketVersioningStatus(s3client, bucket, types.BucketVersioningStatusEnabled)
+		err := putBucketVersioningStatus(s, s3client, bucket, types.BucketVersioningStatusEnabled)
 		if err != nil {
 			return err
 		}
@@ -10702,7 +15902,7 @@ func PutBucketVersioning_success_enabled(s *S3Conf) error {
 func PutBucketVersioning_success_suspended(s *S3Conf) error {
 	testName := "PutBucketVersioning_success_suspended"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		err := putBucketVersioningStatus(s3client, bucket, types.BucketVersioningStatusSuspended)
+		err := putBucketVersioningStatus(s, s3client, bucket, types.BucketVersioningStatusSuspended)
 		if err != nil {
 			return err
 		}
@@ -10714,7 +15914,7 @@ func PutBucketVersioning_success_suspended(s *S3Conf) error {
 func GetBucketVersioning_non_existing_bucket(s *S3Conf) error {
 	testName := "GetBucketVersioning_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{
 			Bucket: getPtr(getBucketName()),
 		})
@@ -10730,7 +15930,7 @@ func GetBucketVersioning_non_existing_bucket(s *S3Conf) error {
 func GetBucketVersioning_empty_response(s *S3Conf) error {
 	testName := "GetBucketVersioning_empty_response"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{
 			Bucket: &bucket,
 		})
@@ -10753,7 +15953,7 @@ func GetBucketVersioning_empty_response(s *S3Conf) error {
 func GetBucketVersioning_success(s *S3Conf) error {
 	testName := "GetBucketVersioning_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{
 			Bucket: &bucket,
 		})
@@ -10773,12 +15973,12 @@ func Versioning_DeleteBucket_not_empty(s *S3Conf) error {
 	testName := "Versioning_DeleteBucket_not_empty"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		_, err := createObjVersions(s3client, bucket, obj, 2)
+		_, err := createObjVersions(s, s3client, bucket, obj, 2)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.DeleteBucket(ctx, &s3.DeleteBucketInput{
 			Bucket: &bucket,
 		})
@@ -10795,7 +15995,7 @@ func Versioning_PutObject_suspended_null_versionId_obj(s *S3Conf) error {
 	testName := "Versioning_PutObject_suspended_null_versionId_obj"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		out, err := putObjectWithData(1222, &s3.PutObjectInput{
+		out, err := putObjectWithData(s, 1222, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		}, s3client)
@@ -10815,7 +16015,7 @@ func Versioning_PutObject_null_versionId_obj(s *S3Conf) error {
 	testName := "Versioning_PutObject_null_versionId_obj"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj, lgth := "my-obj", int64(1234)
-		out, err := putObjectWithData(lgth, &s3.PutObjectInput{
+		out, err := putObjectWithData(s, lgth, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		}, s3client)
@@ -10824,12 +16024,12 @@ func Versioning_PutObject_null_versionId_obj(s *S3Conf) error {
 		}
 
 		// Enable bucket versioning
-		err = putBucketVersioningStatus(s3client, bucket, types.BucketVersioningStatusEnabled)
+		err = putBucketVersioningStatus(s, s3client, bucket, types.BucketVersioningStatusEnabled)
 		if err != nil {
 			return err
 		}
 
-		versions, err := createObjVersions(s3client, bucket, obj, 4)
+		versions, err := createObjVersions(s, s3client, bucket, obj, 4)
 		if err != nil {
 			return err
 		}
@@ -10843,7 +16043,7 @@ func Versioning_PutObject_null_versionId_obj(s *S3Conf) error {
 			StorageClass: types.ObjectVersionStorageClassStandard,
 		})
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{
 			Bucket: &bucket,
 		})
@@ -10864,7 +16064,7 @@ func Versioning_PutObject_overwrite_null_versionId_obj(s *S3Conf) error {
 	testName := "Versioning_PutObject_overwrite_null_versionId_obj"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		_, err := putObjectWithData(int64(1233), &s3.PutObjectInput{
+		_, err := putObjectWithData(s, int64(1233), &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		}, s3client)
@@ -10873,24 +16073,24 @@ func Versioning_PutObject_overwrite_null_versionId_obj(s *S3Conf) error {
 		}
 
 		// Enable bucket versioning
-		err = putBucketVersioningStatus(s3client, bucket, types.BucketVersioningStatusEnabled)
+		err = putBucketVersioningStatus(s, s3client, bucket, types.BucketVersioningStatusEnabled)
 		if err != nil {
 			return err
 		}
 
-		versions, err := createObjVersions(s3client, bucket, obj, 4)
+		versions, err := createObjVersions(s, s3client, bucket, obj, 4)
 		if err != nil {
 			return err
 		}
 
 		// Set bucket versioning status to Suspended
-		err = putBucketVersioningStatus(s3client, bucket, types.BucketVersioningStatusSuspended)
+		err = putBucketVersioningStatus(s, s3client, bucket, types.BucketVersioningStatusSuspended)
 		if err != nil {
 			return err
 		}
 
 		lgth := int64(3200)
-		out, err := putObjectWithData(lgth, &s3.PutObjectInput{
+		out, err := putObjectWithData(s, lgth, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		}, s3client)
@@ -10915,7 +16115,7 @@ func Versioning_PutObject_overwrite_null_versionId_obj(s *S3Conf) error {
 			},
 		}, versions...)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{
 			Bucket: &bucket,
 		})
@@ -10935,7 +16135,7 @@ func Versioning_PutObject_overwrite_null_versionId_obj(s *S3Conf) error {
 func Versioning_PutObject_success(s *S3Conf) error {
 	testName := "Versioning_PutObject_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.PutObject(ctx, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    getPtr("my-obj"),
@@ -10955,21 +16155,23 @@ func Versioning_PutObject_success(s *S3Conf) error {
 
 func Versioning_CopyObject_success(s *S3Conf) error {
 	testName := "Versioning_CopyObject_success"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) (err error) {
 		dstObj := "dst-obj"
 		srcBucket, srcObj := getBucketName(), "src-obj"
 
-		if err := setup(s, srcBucket); err != nil {
+		err = setup(s, srcBucket)
+		if err != nil {
 			return err
 		}
+		defer teardownAfter(s, srcBucket, &err)
 
-		dstObjVersions, err := createObjVersions(s3client, bucket, dstObj, 1)
+		dstObjVersions, err := createObjVersions(s, s3client, bucket, dstObj, 1)
 		if err != nil {
 			return err
 		}
 
 		srcObjLen := int64(2345)
-		_, err = putObjectWithData(srcObjLen, &s3.PutObjectInput{
+		_, err = putObjectWithData(s, srcObjLen, &s3.PutObjectInput{
 			Bucket: &srcBucket,
 			Key:    &srcObj,
 		}, s3client)
@@ -10977,7 +16179,7 @@ func Versioning_CopyObject_success(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.CopyObject(ctx, &s3.CopyObjectInput{
 			Bucket:     &bucket,
 			Key:        &dstObj,
@@ -10988,10 +16190,6 @@ func Versioning_CopyObject_success(s *S3Conf) error {
 			return err
 		}
 
-		if err := teardown(s, srcBucket); err != nil {
-			return err
-		}
-
 		if out.VersionId == nil || *out.VersionId == "" {
 			return fmt.Errorf("expected non empty versionId in the result")
 		}
@@ -11008,7 +16206,7 @@ func Versioning_CopyObject_success(s *S3Conf) error {
 			},
 		}, dstObjVersions...)
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{
 			Bucket: &bucket,
 		})
@@ -11027,20 +16225,22 @@ func Versioning_CopyObject_success(s *S3Conf) error {
 
 func Versioning_CopyObject_non_existing_version_id(s *S3Conf) error {
 	testName := "Versioning_CopyObject_non_existing_version_id"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) (err error) {
 		dstBucket, dstObj := getBucketName(), "my-obj"
 		srcObj := "my-obj"
 
-		if err := setup(s, dstBucket); err != nil {
+		err = setup(s, dstBucket)
+		if err != nil {
 			return err
 		}
+		defer teardownAfter(s, dstBucket, &err)
 
-		_, err := createObjVersions(s3client, bucket, srcObj, 1)
+		_, err = createObjVersions(s, s3client, bucket, srcObj, 1)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.CopyObject(ctx, &s3.CopyObjectInput{
 			Bucket:     &dstBucket,
 			Key:        &dstObj,
@@ -11051,29 +16251,27 @@ func Versioning_CopyObject_non_existing_version_id(s *S3Conf) error {
 			return err
 		}
 
-		if err := teardown(s, dstBucket); err != nil {
-			return err
-		}
-
 		return nil
 	}, withVersioning(types.BucketVersioningStatusEnabled))
 }
 
 func Versioning_CopyObject_from_an_object_version(s *S3Conf) error {
 	testName := "Versioning_CopyObject_from_an_object_version"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) (err error) {
 		srcBucket, srcObj, dstObj := getBucketName(), "my-obj", "my-dst-obj"
-		if err := setup(s, srcBucket, withVersioning(types.BucketVersioningStatusEnabled)); err != nil {
+		err = setup(s, srcBucket, withVersioning(types.BucketVersioningStatusEnabled))
+		if err != nil {
 			return err
 		}
+		defer teardownAfter(s, srcBucket, &err)
 
-		srcObjVersions, err := createObjVersions(s3client, srcBucket, srcObj, 1)
+		srcObjVersions, err := createObjVersions(s, s3client, srcBucket, srcObj, 1)
 		if err != nil {
 			return err
 		}
 		srcObjVersion := srcObjVersions[0]
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.CopyObject(ctx, &s3.CopyObjectInput{
 			Bucket:     &bucket,
 			Key:        &dstObj,
@@ -11084,10 +16282,6 @@ func Versioning_CopyObject_from_an_object_version(s *S3Conf) error {
 			return err
 		}
 
-		if err := teardown(s, srcBucket); err != nil {
-			return err
-		}
-
 		if out.VersionId == nil || *out.VersionId == "" {
 			return fmt.Errorf("expected non empty versionId")
 		}
@@ -11095,7 +16289,7 @@ func Versioning_CopyObject_from_an_object_version(s *S3Conf) error {
 			return fmt.Errorf("expected the SourceVersionId to be %v, instead got %v", *srcObjVersion.VersionId, *out.CopySourceVersionId)
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
 			Bucket:    &bucket,
 			Key:       &dstObj,
@@ -11119,21 +16313,22 @@ func Versioning_CopyObject_from_an_object_version(s *S3Conf) error {
 
 func Versioning_CopyObject_special_chars(s *S3Conf) error {
 	testName := "Versioning_CopyObject_special_chars"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) (err error) {
 		srcObj, dstBucket, dstObj := "foo?bar", getBucketName(), "bar&foo"
-		err := setup(s, dstBucket)
+		err = setup(s, dstBucket)
 		if err != nil {
 			return err
 		}
+		defer teardownAfter(s, dstBucket, &err)
 
-		srcObjVersions, err := createObjVersions(s3client, bucket, srcObj, 1)
+		srcObjVersions, err := createObjVersions(s, s3client, bucket, srcObj, 1)
 		if err != nil {
 			return err
 		}
 
 		srcObjVersionId := *srcObjVersions[0].VersionId
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.CopyObject(ctx, &s3.CopyObjectInput{
 			Bucket:     &bucket,
 			Key:        &dstObj,
@@ -11151,7 +16346,7 @@ func Versioning_CopyObject_special_chars(s *S3Conf) error {
 			return fmt.Errorf("expected the SourceVersionId to be %v, instead got %v", srcObjVersionId, *res.CopySourceVersionId)
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
 			Bucket:    &bucket,
 			Key:       &dstObj,
@@ -11166,11 +16361,6 @@ func Versioning_CopyObject_special_chars(s *S3Conf) error {
 			return fmt.Errorf("expected the copied object versionId to be %v, instead got %v", *res.VersionId, *out.VersionId)
 		}
 
-		err = teardown(s, dstBucket)
-		if err != nil {
-			return err
-		}
-
 		return nil
 	}, withVersioning(types.BucketVersioningStatusEnabled))
 }
@@ -11180,7 +16370,7 @@ func Versioning_HeadObject_invalid_versionId(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		dLen := int64(2000)
 		obj := "my-obj"
-		_, err := putObjectWithData(dLen, &s3.PutObjectInput{
+		_, err := putObjectWithData(s, dLen, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		}, s3client)
@@ -11188,7 +16378,7 @@ func Versioning_HeadObject_invalid_versionId(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.HeadObject(ctx, &s3.HeadObjectInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -11205,7 +16395,7 @@ func Versioning_HeadObject_invalid_versionId(s *S3Conf) error {
 func DeleteObject_name_too_long(s *S3Conf) error {
 	testName := "DeleteObject_name_too_long"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket: &bucket,
 			Key:    getPtr(genRandString(300)),
@@ -11223,7 +16413,7 @@ func Versioning_HeadObject_success(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		dLen := int64(2000)
 		obj := "my-obj"
-		r, err := putObjectWithData(dLen, &s3.PutObjectInput{
+		r, err := putObjectWithData(s, dLen, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		}, s3client)
@@ -11231,7 +16421,7 @@ func Versioning_HeadObject_success(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -11258,7 +16448,7 @@ func Versioning_HeadObject_delete_marker(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		dLen := int64(2000)
 		obj := "my-obj"
-		_, err := putObjectWithData(dLen, &s3.PutObjectInput{
+		_, err := putObjectWithData(s, dLen, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		}, s3client)
@@ -11266,7 +16456,7 @@ func Versioning_HeadObject_delete_marker(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -11280,7 +16470,7 @@ func Versioning_HeadObject_delete_marker(s *S3Conf) error {
 			return fmt.Errorf("expected non empty versionId")
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.HeadObject(ctx, &s3.HeadObjectInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -11300,7 +16490,7 @@ func Versioning_GetObject_invalid_versionId(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		dLen := int64(2000)
 		obj := "my-obj"
-		_, err := putObjectWithData(dLen, &s3.PutObjectInput{
+		_, err := putObjectWithData(s, dLen, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		}, s3client)
@@ -11308,7 +16498,7 @@ func Versioning_GetObject_invalid_versionId(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.GetObject(ctx, &s3.GetObjectInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -11328,7 +16518,7 @@ func Versioning_GetObject_success(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		dLen := int64(2000)
 		obj := "my-obj"
-		r, err := putObjectWithData(dLen, &s3.PutObjectInput{
+		r, err := putObjectWithData(s, dLen, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		}, s3client)
@@ -11337,7 +16527,7 @@ func Versioning_GetObject_success(s *S3Conf) error {
 		}
 
 		// Get the object by versionId
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -11367,7 +16557,7 @@ func Versioning_GetObject_success(s *S3Conf) error {
 		}
 
 		// Get the object without versionId
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		out, err = s3client.GetObject(ctx, &s3.GetObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -11384,49 +16574,147 @@ func Versioning_GetObject_success(s *S3Conf) error {
 			return fmt.Errorf("expected the versionId to be %v, instead got %v", *r.res.VersionId, *out.VersionId)
 		}
 
-		bdy, err = io.ReadAll(out.Body)
+		bdy, err = io.ReadAll(out.Body)
+		if err != nil {
+			return err
+		}
+		defer out.Body.Close()
+
+		outCsum = sha256.Sum256(bdy)
+		if outCsum != r.csum {
+			return fmt.Errorf("incorrect output content")
+		}
+
+		return nil
+	}, withVersioning(types.BucketVersioningStatusEnabled))
+}
+
+func Versioning_GetObject_delete_marker_without_versionId(s *S3Conf) error {
+	testName := "Versioning_GetObject_delete_marker_without_versionId"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-obj"
+		_, err := putObjectWithData(s, 1234, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+
+		err = putBucketVersioningStatus(s, s3client, bucket, types.BucketVersioningStatusEnabled)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		cancel()
+		if err := checkSdkApiErr(err, "NoSuchKey"); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func Versioning_overwrite_and_delete_marker(s *S3Conf) error {
+	testName := "Versioning_overwrite_and_delete_marker"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		err := putBucketVersioningStatus(s, s3client, bucket, types.BucketVersioningStatusEnabled)
+		if err != nil {
+			return err
+		}
+
+		obj := "my-obj"
+		first, err := putObjectWithData(s, 100, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+		second, err := putObjectWithData(s, 200, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+
+		firstId, secondId := getString(first.res.VersionId), getString(second.res.VersionId)
+		if firstId == "" || secondId == "" || firstId == secondId {
+			return fmt.Errorf("expected distinct non-empty version ids, instead got %q and %q", firstId, secondId)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{
+			Bucket: &bucket,
+		})
+		cancel()
 		if err != nil {
 			return err
 		}
-		defer out.Body.Close()
 
-		outCsum = sha256.Sum256(bdy)
-		if outCsum != r.csum {
-			return fmt.Errorf("incorrect output content")
+		if len(out.Versions) != 2 {
+			return fmt.Errorf("expected 2 versions, instead got %v", len(out.Versions))
+		}
+		if getString(out.Versions[0].VersionId) != secondId || !*out.Versions[0].IsLatest {
+			return fmt.Errorf("expected the latest version to be %v, instead got %v", secondId, getString(out.Versions[0].VersionId))
+		}
+		if getString(out.Versions[1].VersionId) != firstId || *out.Versions[1].IsLatest {
+			return fmt.Errorf("expected the second version to be %v, instead got %v", firstId, getString(out.Versions[1].VersionId))
 		}
 
-		return nil
-	}, withVersioning(types.BucketVersioningStatusEnabled))
-}
-
-func Versioning_GetObject_delete_marker_without_versionId(s *S3Conf) error {
-	testName := "Versioning_GetObject_delete_marker_without_versionId"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		obj := "my-obj"
-		_, err := putObjectWithData(1234, &s3.PutObjectInput{
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		delOut, err := s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
-		}, s3client)
+		})
+		cancel()
 		if err != nil {
 			return err
 		}
 
-		err = putBucketVersioningStatus(s3client, bucket, types.BucketVersioningStatusEnabled)
-		if err != nil {
-			return err
+		if delOut.DeleteMarker == nil || !*delOut.DeleteMarker {
+			return fmt.Errorf("expected the delete marker to be created")
+		}
+		if getString(delOut.VersionId) == "" {
+			return fmt.Errorf("expected the delete marker to have a version id")
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-		_, err = s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		out, err = s3client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{
 			Bucket: &bucket,
-			Key:    &obj,
 		})
 		cancel()
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		if len(out.DeleteMarkers) != 1 {
+			return fmt.Errorf("expected 1 delete marker, instead got %v", len(out.DeleteMarkers))
+		}
+		if getString(out.DeleteMarkers[0].VersionId) != getString(delOut.VersionId) || !*out.DeleteMarkers[0].IsLatest {
+			return fmt.Errorf("expected the latest delete marker to be %v, instead got %v", getString(delOut.VersionId), getString(out.DeleteMarkers[0].VersionId))
+		}
+		if len(out.Versions) != 2 {
+			return fmt.Errorf("expected 2 versions after delete, instead got %v", len(out.Versions))
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.GetObject(ctx, &s3.GetObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -11436,6 +16724,29 @@ func Versioning_GetObject_delete_marker_without_versionId(s *S3Conf) error {
 			return err
 		}
 
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		res, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket:    &bucket,
+			Key:       &obj,
+			VersionId: &firstId,
+		})
+		defer cancel()
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+
+		if getString(res.VersionId) != firstId {
+			return fmt.Errorf("expected the version id to be %v, instead got %v", firstId, getString(res.VersionId))
+		}
+		b, err := io.ReadAll(res.Body)
+		if err != nil {
+			return err
+		}
+		if sha256.Sum256(b) != first.csum {
+			return fmt.Errorf("expected the versioned object data checksum to match")
+		}
+
 		return nil
 	})
 }
@@ -11445,7 +16756,7 @@ func Versioning_GetObject_delete_marker(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		dLen := int64(2000)
 		obj := "my-obj"
-		_, err := putObjectWithData(dLen, &s3.PutObjectInput{
+		_, err := putObjectWithData(s, dLen, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		}, s3client)
@@ -11453,7 +16764,7 @@ func Versioning_GetObject_delete_marker(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -11467,7 +16778,7 @@ func Versioning_GetObject_delete_marker(s *S3Conf) error {
 			return fmt.Errorf("expected non empty versionId")
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.GetObject(ctx, &s3.GetObjectInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -11486,7 +16797,7 @@ func Versioning_GetObject_null_versionId_obj(s *S3Conf) error {
 	testName := "Versioning_GetObject_null_versionId_obj"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj, lgth := "my-obj", int64(234)
-		out, err := putObjectWithData(lgth, &s3.PutObjectInput{
+		out, err := putObjectWithData(s, lgth, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		}, s3client)
@@ -11494,12 +16805,12 @@ func Versioning_GetObject_null_versionId_obj(s *S3Conf) error {
 			return err
 		}
 
-		err = putBucketVersioningStatus(s3client, bucket, types.BucketVersioningStatusEnabled)
+		err = putBucketVersioningStatus(s, s3client, bucket, types.BucketVersioningStatusEnabled)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.GetObject(ctx, &s3.GetObjectInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -11529,7 +16840,7 @@ func Versioning_DeleteObject_delete_object_version(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		oLen := int64(1000)
 		obj := "my-obj"
-		r, err := putObjectWithData(oLen, &s3.PutObjectInput{
+		r, err := putObjectWithData(s, oLen, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		}, s3client)
@@ -11542,12 +16853,12 @@ func Versioning_DeleteObject_delete_object_version(s *S3Conf) error {
 			return fmt.Errorf("expected non empty versionId")
 		}
 
-		_, err = putObjects(s3client, []string{obj}, bucket)
+		_, err = putObjects(s, s3client, []string{obj}, bucket)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -11571,7 +16882,7 @@ func Versioning_DeleteObject_non_existing_object(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
 
-		ctx, canel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, canel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -11581,7 +16892,7 @@ func Versioning_DeleteObject_non_existing_object(s *S3Conf) error {
 			return err
 		}
 
-		ctx, canel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, canel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -11601,7 +16912,7 @@ func Versioning_DeleteObject_delete_a_delete_marker(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		oLen := int64(1000)
 		obj := "my-obj"
-		_, err := putObjectWithData(oLen, &s3.PutObjectInput{
+		_, err := putObjectWithData(s, oLen, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		}, s3client)
@@ -11609,7 +16920,7 @@ func Versioning_DeleteObject_delete_a_delete_marker(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -11626,7 +16937,7 @@ func Versioning_DeleteObject_delete_a_delete_marker(s *S3Conf) error {
 			return fmt.Errorf("expected non empty versionId")
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -11652,7 +16963,7 @@ func Versioning_Delete_null_versionId_object(s *S3Conf) error {
 	testName := "Versioning_Delete_null_versionId_object"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj, nObjLgth := "my-obj", int64(3211)
-		_, err := putObjectWithData(nObjLgth, &s3.PutObjectInput{
+		_, err := putObjectWithData(s, nObjLgth, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		}, s3client)
@@ -11660,17 +16971,17 @@ func Versioning_Delete_null_versionId_object(s *S3Conf) error {
 			return err
 		}
 
-		err = putBucketVersioningStatus(s3client, bucket, types.BucketVersioningStatusEnabled)
+		err = putBucketVersioningStatus(s, s3client, bucket, types.BucketVersioningStatusEnabled)
 		if err != nil {
 			return err
 		}
 
-		_, err = createObjVersions(s3client, bucket, obj, 3)
+		_, err = createObjVersions(s, s3client, bucket, obj, 3)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -11693,20 +17004,20 @@ func Versioning_DeleteObjects_success(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj1, obj2, obj3 := "foo", "bar", "baz"
 
-		obj1Version, err := createObjVersions(s3client, bucket, obj1, 1)
+		obj1Version, err := createObjVersions(s, s3client, bucket, obj1, 1)
 		if err != nil {
 			return err
 		}
-		obj2Version, err := createObjVersions(s3client, bucket, obj2, 1)
+		obj2Version, err := createObjVersions(s, s3client, bucket, obj2, 1)
 		if err != nil {
 			return err
 		}
-		obj3Version, err := createObjVersions(s3client, bucket, obj3, 1)
+		obj3Version, err := createObjVersions(s, s3client, bucket, obj3, 1)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
 			Bucket: &bucket,
 			Delete: &types.Delete{
@@ -11752,7 +17063,7 @@ func Versioning_DeleteObjects_success(s *S3Conf) error {
 			return fmt.Errorf("expected the deleted objects to be %v, instead got %v", delResult, out.Deleted)
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{
 			Bucket: &bucket,
 		})
@@ -11793,16 +17104,16 @@ func Versioning_DeleteObjects_delete_deleteMarkers(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj1, obj2 := "foo", "bar"
 
-		obj1Version, err := createObjVersions(s3client, bucket, obj1, 1)
+		obj1Version, err := createObjVersions(s, s3client, bucket, obj1, 1)
 		if err != nil {
 			return err
 		}
-		obj2Version, err := createObjVersions(s3client, bucket, obj2, 1)
+		obj2Version, err := createObjVersions(s, s3client, bucket, obj2, 1)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
 			Bucket: &bucket,
 			Delete: &types.Delete{
@@ -11839,7 +17150,7 @@ func Versioning_DeleteObjects_delete_deleteMarkers(s *S3Conf) error {
 			return fmt.Errorf("expected the deleted objects to be %v, instead got %v", delResult, out.Deleted)
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
 			Bucket: &bucket,
 			Delete: &types.Delete{
@@ -11886,10 +17197,141 @@ func Versioning_DeleteObjects_delete_deleteMarkers(s *S3Conf) error {
 	}, withVersioning(types.BucketVersioningStatusEnabled))
 }
 
+func Versioning_DeleteObjects_specific_versions(s *S3Conf) error {
+	testName := "Versioning_DeleteObjects_specific_versions"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj1, obj2, obj3, obj4 := "bar", "baz", "foo", "qux"
+
+		obj1Versions, err := createObjVersions(s, s3client, bucket, obj1, 2)
+		if err != nil {
+			return err
+		}
+		obj2Versions, err := createObjVersions(s, s3client, bucket, obj2, 1)
+		if err != nil {
+			return err
+		}
+		obj3Versions, err := createObjVersions(s, s3client, bucket, obj3, 3)
+		if err != nil {
+			return err
+		}
+		obj4Versions, err := createObjVersions(s, s3client, bucket, obj4, 1)
+		if err != nil {
+			return err
+		}
+
+		// put delete markers on top of obj2 and obj4
+		delMarkerIds := []*string{}
+		for _, obj := range []string{obj2, obj4} {
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			out, err := s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
+				Bucket: &bucket,
+				Key:    &obj,
+			})
+			cancel()
+			if err != nil {
+				return err
+			}
+			if out.DeleteMarker == nil || !*out.DeleteMarker {
+				return fmt.Errorf("expected a delete marker to be created for %v", obj)
+			}
+			delMarkerIds = append(delMarkerIds, out.VersionId)
+		}
+
+		// delete the oldest obj1 version, the middle obj3 version
+		// and the obj2 delete marker
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: &bucket,
+			Delete: &types.Delete{
+				Objects: []types.ObjectIdentifier{
+					{
+						Key:       &obj1,
+						VersionId: obj1Versions[1].VersionId,
+					},
+					{
+						Key:       &obj2,
+						VersionId: delMarkerIds[0],
+					},
+					{
+						Key:       &obj3,
+						VersionId: obj3Versions[1].VersionId,
+					},
+				},
+			},
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if len(out.Errors) != 0 {
+			return fmt.Errorf("errors occurred during the deletion: %v", out.Errors)
+		}
+
+		delResult := []types.DeletedObject{
+			{
+				Key:          &obj1,
+				VersionId:    obj1Versions[1].VersionId,
+				DeleteMarker: getBoolPtr(false),
+			},
+			{
+				Key:                   &obj2,
+				VersionId:             delMarkerIds[0],
+				DeleteMarker:          getBoolPtr(true),
+				DeleteMarkerVersionId: delMarkerIds[0],
+			},
+			{
+				Key:          &obj3,
+				VersionId:    obj3Versions[1].VersionId,
+				DeleteMarker: getBoolPtr(false),
+			},
+		}
+		if !compareDelObjects(delResult, out.Deleted) {
+			return fmt.Errorf("expected the deleted objects to be %v, instead got %v", delResult, out.Deleted)
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		res, err := s3client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{
+			Bucket: &bucket,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		// removing the obj2 delete marker makes the previous version
+		// the latest one again, while the obj4 delete marker remains
+		obj4Versions[0].IsLatest = getBoolPtr(false)
+		versions := []types.ObjectVersion{
+			obj1Versions[0],
+			obj2Versions[0],
+			obj3Versions[0],
+			obj3Versions[2],
+			obj4Versions[0],
+		}
+		delMarkers := []types.DeleteMarkerEntry{
+			{
+				IsLatest:  getBoolPtr(true),
+				Key:       &obj4,
+				VersionId: delMarkerIds[1],
+			},
+		}
+
+		if !compareVersions(versions, res.Versions) {
+			return fmt.Errorf("expected the resulting versions to be %v, instead got %v", versions, res.Versions)
+		}
+		if !compareDelMarkers(delMarkers, res.DeleteMarkers) {
+			return fmt.Errorf("expected the resulting delete markers to be %v, instead got %v", delMarkers, res.DeleteMarkers)
+		}
+
+		return nil
+	}, withVersioning(types.BucketVersioningStatusEnabled))
+}
+
 func ListObjectVersions_non_existing_bucket(s *S3Conf) error {
 	testName := "ListObjectVersions_non_existing_bucket"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{
 			Bucket: getPtr(getBucketName()),
 		})
@@ -11906,12 +17348,12 @@ func ListObjectVersions_list_single_object_versions(s *S3Conf) error {
 	testName := "ListObjectVersions_list_single_object_versions"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		object := "my-obj"
-		versions, err := createObjVersions(s3client, bucket, object, 5)
+		versions, err := createObjVersions(s, s3client, bucket, object, 5)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{
 			Bucket: &bucket,
 		})
@@ -11933,22 +17375,22 @@ func ListObjectVersions_list_multiple_object_versions(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj1, obj2, obj3 := "foo", "bar", "baz"
 
-		obj1Versions, err := createObjVersions(s3client, bucket, obj1, 4)
+		obj1Versions, err := createObjVersions(s, s3client, bucket, obj1, 4)
 		if err != nil {
 			return err
 		}
-		obj2Versions, err := createObjVersions(s3client, bucket, obj2, 3)
+		obj2Versions, err := createObjVersions(s, s3client, bucket, obj2, 3)
 		if err != nil {
 			return err
 		}
-		obj3Versions, err := createObjVersions(s3client, bucket, obj3, 5)
+		obj3Versions, err := createObjVersions(s, s3client, bucket, obj3, 5)
 		if err != nil {
 			return err
 		}
 
 		versions := append(append(obj2Versions, obj3Versions...), obj1Versions...)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{
 			Bucket: &bucket,
 		})
@@ -11970,15 +17412,15 @@ func ListObjectVersions_multiple_object_versions_truncated(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj1, obj2, obj3 := "foo", "bar", "baz"
 
-		obj1Versions, err := createObjVersions(s3client, bucket, obj1, 4)
+		obj1Versions, err := createObjVersions(s, s3client, bucket, obj1, 4)
 		if err != nil {
 			return err
 		}
-		obj2Versions, err := createObjVersions(s3client, bucket, obj2, 3)
+		obj2Versions, err := createObjVersions(s, s3client, bucket, obj2, 3)
 		if err != nil {
 			return err
 		}
-		obj3Versions, err := createObjVersions(s3client, bucket, obj3, 5)
+		obj3Versions, err := createObjVersions(s, s3client, bucket, obj3, 5)
 		if err != nil {
 			return err
 		}
@@ -11986,7 +17428,7 @@ func ListObjectVersions_multiple_object_versions_truncated(s *S3Conf) error {
 		versions := append(append(obj2Versions, obj3Versions...), obj1Versions...)
 		maxKeys := int32(5)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{
 			Bucket:  &bucket,
 			MaxKeys: &maxKeys,
@@ -12016,7 +17458,7 @@ func ListObjectVersions_multiple_object_versions_truncated(s *S3Conf) error {
 			return fmt.Errorf("expected the resulting object versions to be %v, instead got %v", versions[:maxKeys], out.Versions)
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		out, err = s3client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{
 			Bucket:          &bucket,
 			KeyMarker:       out.NextKeyMarker,
@@ -12052,14 +17494,14 @@ func ListObjectVersions_with_delete_markers(s *S3Conf) error {
 	testName := "ListObjectVersions_with_delete_markers"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		versions, err := createObjVersions(s3client, bucket, obj, 1)
+		versions, err := createObjVersions(s, s3client, bucket, obj, 1)
 		if err != nil {
 			return err
 		}
 
 		versions[0].IsLatest = getBoolPtr(false)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		out, err := s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -12076,7 +17518,7 @@ func ListObjectVersions_with_delete_markers(s *S3Conf) error {
 			IsLatest:  getBoolPtr(true),
 		})
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{
 			Bucket: &bucket,
 		})
@@ -12100,18 +17542,18 @@ func ListObjectVersions_containing_null_versionId_obj(s *S3Conf) error {
 	testName := "ListObjectVersions_containing_null_versionId_obj"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		versions, err := createObjVersions(s3client, bucket, obj, 3)
+		versions, err := createObjVersions(s, s3client, bucket, obj, 3)
 		if err != nil {
 			return err
 		}
 
-		err = putBucketVersioningStatus(s3client, bucket, types.BucketVersioningStatusSuspended)
+		err = putBucketVersioningStatus(s, s3client, bucket, types.BucketVersioningStatusSuspended)
 		if err != nil {
 			return err
 		}
 
 		objLgth := int64(543)
-		out, err := putObjectWithData(objLgth, &s3.PutObjectInput{
+		out, err := putObjectWithData(s, objLgth, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		}, s3client)
@@ -12136,19 +17578,19 @@ func ListObjectVersions_containing_null_versionId_obj(s *S3Conf) error {
 			},
 		}, versions...)
 
-		err = putBucketVersioningStatus(s3client, bucket, types.BucketVersioningStatusEnabled)
+		err = putBucketVersioningStatus(s, s3client, bucket, types.BucketVersioningStatusEnabled)
 		if err != nil {
 			return err
 		}
 
-		newVersions, err := createObjVersions(s3client, bucket, obj, 4)
+		newVersions, err := createObjVersions(s, s3client, bucket, obj, 4)
 		if err != nil {
 			return err
 		}
 
 		versions = append(newVersions, versions...)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{
 			Bucket: &bucket,
 		})
@@ -12169,7 +17611,7 @@ func ListObjectVersions_single_null_versionId_object(s *S3Conf) error {
 	testName := "ListObjectVersions_single_null_versionId_object"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj, objLgth := "my-obj", int64(890)
-		out, err := putObjectWithData(objLgth, &s3.PutObjectInput{
+		out, err := putObjectWithData(s, objLgth, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
 		}, s3client)
@@ -12177,12 +17619,12 @@ func ListObjectVersions_single_null_versionId_object(s *S3Conf) error {
 			return err
 		}
 
-		err = putBucketVersioningStatus(s3client, bucket, types.BucketVersioningStatusEnabled)
+		err = putBucketVersioningStatus(s, s3client, bucket, types.BucketVersioningStatusEnabled)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket: &bucket,
 			Key:    &obj,
@@ -12210,7 +17652,7 @@ func ListObjectVersions_single_null_versionId_object(s *S3Conf) error {
 			},
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		resp, err := s3client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{
 			Bucket: &bucket,
 		})
@@ -12234,13 +17676,13 @@ func Versioning_Multipart_Upload_success(s *S3Conf) error {
 	testName := "Versioning_Multipart_Upload_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		out, err := createMp(s3client, bucket, obj)
+		out, err := createMp(s, s3client, bucket, obj)
 		if err != nil {
 			return err
 		}
 
-		objSize := int64(5 * 1024 * 1024)
-		parts, _, err := uploadParts(s3client, objSize, 5, bucket, obj, *out.UploadId)
+		objSize := int64(25 * 1024 * 1024)
+		parts, _, err := uploadParts(s, s3client, objSize, 5, bucket, obj, *out.UploadId)
 		if err != nil {
 			return err
 		}
@@ -12253,7 +17695,7 @@ func Versioning_Multipart_Upload_success(s *S3Conf) error {
 			})
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
 			Bucket:   &bucket,
 			Key:      &obj,
@@ -12280,7 +17722,7 @@ func Versioning_Multipart_Upload_success(s *S3Conf) error {
 			return fmt.Errorf("expected non-empty versionId")
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		resp, err := s3client.HeadObject(ctx, &s3.HeadObjectInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -12310,17 +17752,17 @@ func Versioning_Multipart_Upload_overwrite_an_object(s *S3Conf) error {
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
 
-		objVersions, err := createObjVersions(s3client, bucket, obj, 2)
+		objVersions, err := createObjVersions(s, s3client, bucket, obj, 2)
 		if err != nil {
 			return err
 		}
-		out, err := createMp(s3client, bucket, obj)
+		out, err := createMp(s, s3client, bucket, obj)
 		if err != nil {
 			return err
 		}
 
-		objSize := int64(5 * 1024 * 1024)
-		parts, _, err := uploadParts(s3client, objSize, 5, bucket, obj, *out.UploadId)
+		objSize := int64(25 * 1024 * 1024)
+		parts, _, err := uploadParts(s, s3client, objSize, 5, bucket, obj, *out.UploadId)
 		if err != nil {
 			return err
 		}
@@ -12333,7 +17775,7 @@ func Versioning_Multipart_Upload_overwrite_an_object(s *S3Conf) error {
 			})
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
 			Bucket:   &bucket,
 			Key:      &obj,
@@ -12360,7 +17802,7 @@ func Versioning_Multipart_Upload_overwrite_an_object(s *S3Conf) error {
 			return fmt.Errorf("expected non-empty versionId")
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		resp, err := s3client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{
 			Bucket: &bucket,
 		})
@@ -12393,11 +17835,11 @@ func Versioning_Multipart_Upload_overwrite_an_object(s *S3Conf) error {
 
 func Versioning_UploadPartCopy_non_existing_versionId(s *S3Conf) error {
 	testName := "Versioning_UploadPartCopy_non_existing_versionId"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) (err error) {
 		dstBucket, dstObj, srcObj := getBucketName(), "dst-obj", "src-obj"
 
 		lgth := int64(100)
-		_, err := putObjectWithData(lgth, &s3.PutObjectInput{
+		_, err = putObjectWithData(s, lgth, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &srcObj,
 		}, s3client)
@@ -12405,17 +17847,19 @@ func Versioning_UploadPartCopy_non_existing_versionId(s *S3Conf) error {
 			return err
 		}
 
-		if err := setup(s, dstBucket); err != nil {
+		err = setup(s, dstBucket)
+		if err != nil {
 			return err
 		}
+		defer teardownAfter(s, dstBucket, &err)
 
-		mp, err := createMp(s3client, dstBucket, dstObj)
+		mp, err := createMp(s, s3client, dstBucket, dstObj)
 		if err != nil {
 			return err
 		}
 
 		pNumber := int32(1)
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.UploadPartCopy(ctx, &s3.UploadPartCopyInput{
 			Bucket:     &dstBucket,
 			Key:        &dstObj,
@@ -12428,36 +17872,33 @@ func Versioning_UploadPartCopy_non_existing_versionId(s *S3Conf) error {
 			return err
 		}
 
-		if err := teardown(s, dstBucket); err != nil {
-			return err
-		}
-
 		return nil
 	}, withVersioning(types.BucketVersioningStatusEnabled))
 }
 
 func Versioning_UploadPartCopy_from_an_object_version(s *S3Conf) error {
 	testName := "Versioning_UploadPartCopy_from_an_object_version"
-	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) (err error) {
 		srcObj, dstBucket, obj := "my-obj", getBucketName(), "dst-obj"
-		err := setup(s, dstBucket)
+		err = setup(s, dstBucket)
 		if err != nil {
 			return err
 		}
+		defer teardownAfter(s, dstBucket, &err)
 
-		srcObjVersions, err := createObjVersions(s3client, bucket, srcObj, 1)
+		srcObjVersions, err := createObjVersions(s, s3client, bucket, srcObj, 1)
 		if err != nil {
 			return err
 		}
 		srcObjVersion := srcObjVersions[0]
 
-		out, err := createMp(s3client, dstBucket, obj)
+		out, err := createMp(s, s3client, dstBucket, obj)
 		if err != nil {
 			return err
 		}
 
 		partNumber := int32(1)
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		copyOut, err := s3client.UploadPartCopy(ctx, &s3.UploadPartCopyInput{
 			Bucket:     &dstBucket,
 			CopySource: getPtr(fmt.Sprintf("%v/%v?versionId=%v", bucket, srcObj, *srcObjVersion.VersionId)),
@@ -12474,7 +17915,7 @@ func Versioning_UploadPartCopy_from_an_object_version(s *S3Conf) error {
 			return fmt.Errorf("expected the copy-source-version-id to be %v, instead got %v", *srcObjVersion.VersionId, *copyOut.CopySourceVersionId)
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.ListParts(ctx, &s3.ListPartsInput{
 			Bucket:   &dstBucket,
 			Key:      &obj,
@@ -12498,10 +17939,6 @@ func Versioning_UploadPartCopy_from_an_object_version(s *S3Conf) error {
 			return fmt.Errorf("expected part etag to be %v, instead got %v", *copyOut.CopyPartResult.ETag, *res.Parts[0].ETag)
 		}
 
-		if err := teardown(s, dstBucket); err != nil {
-			return err
-		}
-
 		return nil
 	}, withVersioning(types.BucketVersioningStatusEnabled))
 }
@@ -12509,7 +17946,7 @@ func Versioning_UploadPartCopy_from_an_object_version(s *S3Conf) error {
 func Versioning_Enable_object_lock(s *S3Conf) error {
 	testName := "Versioning_Enable_object_lock"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{
 			Bucket: &bucket,
 		})
@@ -12529,7 +17966,7 @@ func Versioning_Enable_object_lock(s *S3Conf) error {
 func Versioning_status_switch_to_suspended_with_object_lock(s *S3Conf) error {
 	testName := "Versioning_status_switch_to_suspended_with_object_lock"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		err := putBucketVersioningStatus(s3client, bucket, types.BucketVersioningStatusSuspended)
+		err := putBucketVersioningStatus(s, s3client, bucket, types.BucketVersioningStatusSuspended)
 		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrSuspendedVersioningNotAllowed)); err != nil {
 			return err
 		}
@@ -12542,13 +17979,13 @@ func Versioning_PutObjectRetention_invalid_versionId(s *S3Conf) error {
 	testName := "Versioning_PutObjectRetention_invalid_versionId"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		_, err := createObjVersions(s3client, bucket, obj, 3)
+		_, err := createObjVersions(s, s3client, bucket, obj, 3)
 		if err != nil {
 			return err
 		}
 
 		rDate := time.Now().Add(time.Hour * 48)
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -12571,12 +18008,12 @@ func Versioning_GetObjectRetention_invalid_versionId(s *S3Conf) error {
 	testName := "Versioning_GetObjectRetention_invalid_versionId"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		_, err := createObjVersions(s3client, bucket, obj, 3)
+		_, err := createObjVersions(s, s3client, bucket, obj, 3)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.GetObjectRetention(ctx, &s3.GetObjectRetentionInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -12595,14 +18032,14 @@ func Versioning_Put_GetObjectRetention_success(s *S3Conf) error {
 	testName := "Versioning_Put_GetObjectRetention_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		objVersions, err := createObjVersions(s3client, bucket, obj, 3)
+		objVersions, err := createObjVersions(s, s3client, bucket, obj, 3)
 		if err != nil {
 			return err
 		}
 		objVersion := objVersions[1]
 
 		rDate := time.Now().Add(time.Hour * 48)
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -12617,7 +18054,7 @@ func Versioning_Put_GetObjectRetention_success(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.GetObjectRetention(ctx, &s3.GetObjectRetentionInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -12632,7 +18069,7 @@ func Versioning_Put_GetObjectRetention_success(s *S3Conf) error {
 			return fmt.Errorf("expected the object retention mode to be %v, instead got %v", types.ObjectLockRetentionModeGovernance, res.Retention.Mode)
 		}
 
-		if err := changeBucketObjectLockStatus(s3client, bucket, false); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, false); err != nil {
 			return err
 		}
 
@@ -12644,12 +18081,12 @@ func Versioning_PutObjectLegalHold_invalid_versionId(s *S3Conf) error {
 	testName := "Versioning_PutObjectLegalHold_invalid_versionId"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		_, err := createObjVersions(s3client, bucket, obj, 3)
+		_, err := createObjVersions(s, s3client, bucket, obj, 3)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectLegalHold(ctx, &s3.PutObjectLegalHoldInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -12671,12 +18108,12 @@ func Versioning_GetObjectLegalHold_invalid_versionId(s *S3Conf) error {
 	testName := "Versioning_GetObjectLegalHold_invalid_versionId"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		_, err := createObjVersions(s3client, bucket, obj, 3)
+		_, err := createObjVersions(s, s3client, bucket, obj, 3)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.GetObjectLegalHold(ctx, &s3.GetObjectLegalHoldInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -12695,13 +18132,13 @@ func Versioning_Put_GetObjectLegalHold_success(s *S3Conf) error {
 	testName := "Versioning_Put_GetObjectLegalHold_success"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		objVersions, err := createObjVersions(s3client, bucket, obj, 3)
+		objVersions, err := createObjVersions(s, s3client, bucket, obj, 3)
 		if err != nil {
 			return err
 		}
 		objVersion := objVersions[1]
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectLegalHold(ctx, &s3.PutObjectLegalHoldInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -12715,7 +18152,7 @@ func Versioning_Put_GetObjectLegalHold_success(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.GetObjectLegalHold(ctx, &s3.GetObjectLegalHoldInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -12730,7 +18167,7 @@ func Versioning_Put_GetObjectLegalHold_success(s *S3Conf) error {
 			return fmt.Errorf("expected the object version legal hold status to be %v, instead got %v", types.ObjectLockLegalHoldStatusOn, res.LegalHold.Status)
 		}
 
-		if err := changeBucketObjectLockStatus(s3client, bucket, false); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, false); err != nil {
 			return err
 		}
 
@@ -12742,13 +18179,13 @@ func Versioning_WORM_obj_version_locked_with_legal_hold(s *S3Conf) error {
 	testName := "Versioning_WORM_obj_version_locked_with_legal_hold"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		objVersions, err := createObjVersions(s3client, bucket, obj, 2)
+		objVersions, err := createObjVersions(s, s3client, bucket, obj, 2)
 		if err != nil {
 			return err
 		}
 		version := objVersions[1]
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectLegalHold(ctx, &s3.PutObjectLegalHoldInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -12762,7 +18199,7 @@ func Versioning_WORM_obj_version_locked_with_legal_hold(s *S3Conf) error {
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -12773,7 +18210,7 @@ func Versioning_WORM_obj_version_locked_with_legal_hold(s *S3Conf) error {
 			return err
 		}
 
-		if err := changeBucketObjectLockStatus(s3client, bucket, false); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, false); err != nil {
 			return err
 		}
 
@@ -12785,14 +18222,14 @@ func Versioning_WORM_obj_version_locked_with_governance_retention(s *S3Conf) err
 	testName := "Versioning_WORM_obj_version_locked_with_governance_retention"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		objVersions, err := createObjVersions(s3client, bucket, obj, 2)
+		objVersions, err := createObjVersions(s, s3client, bucket, obj, 2)
 		if err != nil {
 			return err
 		}
 		version := objVersions[0]
 
 		rDate := time.Now().Add(time.Hour * 48)
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -12807,7 +18244,7 @@ func Versioning_WORM_obj_version_locked_with_governance_retention(s *S3Conf) err
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -12818,7 +18255,7 @@ func Versioning_WORM_obj_version_locked_with_governance_retention(s *S3Conf) err
 			return err
 		}
 
-		if err := changeBucketObjectLockStatus(s3client, bucket, false); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, false); err != nil {
 			return err
 		}
 
@@ -12830,14 +18267,14 @@ func Versioning_WORM_obj_version_locked_with_compliance_retention(s *S3Conf) err
 	testName := "Versioning_WORM_obj_version_locked_with_compliance_retention"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
 		obj := "my-obj"
-		objVersions, err := createObjVersions(s3client, bucket, obj, 2)
+		objVersions, err := createObjVersions(s, s3client, bucket, obj, 2)
 		if err != nil {
 			return err
 		}
 		version := objVersions[0]
 
 		rDate := time.Now().Add(time.Hour * 48)
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -12852,7 +18289,7 @@ func Versioning_WORM_obj_version_locked_with_compliance_retention(s *S3Conf) err
 			return err
 		}
 
-		ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 		_, err = s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket:    &bucket,
 			Key:       &obj,
@@ -12863,7 +18300,7 @@ func Versioning_WORM_obj_version_locked_with_compliance_retention(s *S3Conf) err
 			return err
 		}
 
-		if err := changeBucketObjectLockStatus(s3client, bucket, false); err != nil {
+		if err := changeBucketObjectLockStatus(s, s3client, bucket, false); err != nil {
 			return err
 		}
 
@@ -12874,7 +18311,7 @@ func Versioning_WORM_obj_version_locked_with_compliance_retention(s *S3Conf) err
 func VersioningDisabled_GetBucketVersioning_not_configured(s *S3Conf) error {
 	testName := "VersioningDisabled_GetBucketVersioning_not_configured"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		err := putBucketVersioningStatus(s3client, bucket, types.BucketVersioningStatusEnabled)
+		err := putBucketVersioningStatus(s, s3client, bucket, types.BucketVersioningStatusEnabled)
 		if err := checkApiErr(err, s3err.GetAPIError(s3err.ErrVersioningNotConfigured)); err != nil {
 			return err
 		}
@@ -12886,7 +18323,7 @@ func VersioningDisabled_GetBucketVersioning_not_configured(s *S3Conf) error {
 func VersioningDisabled_PutBucketVersioning_not_configured(s *S3Conf) error {
 	testName := "VersioningDisabled_PutBucketVersioning_not_configured"
 	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{
 			Bucket: &bucket,
 		})
@@ -12910,7 +18347,7 @@ func Versioning_concurrent_upload_object(s *S3Conf) error {
 		uploadVersion := func(wg *sync.WaitGroup) {
 			defer wg.Done()
 
-			ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 			res, err := s3client.PutObject(ctx, &s3.PutObjectInput{
 				Bucket: &bucket,
 				Key:    &obj,
@@ -12922,7 +18359,7 @@ func Versioning_concurrent_upload_object(s *S3Conf) error {
 				return
 			}
 
-			fmt.Printf("uploaded object successfully: versionId: %v\n", *res.VersionId)
+			fmt.Fprintf(LogOutput, "uploaded object successfully: versionId: %v\n", *res.VersionId)
 		}
 
 		wg := &sync.WaitGroup{}
@@ -12938,13 +18375,13 @@ func Versioning_concurrent_upload_object(s *S3Conf) error {
 		// Check if there were any errors
 		for err := range errCh {
 			if err != nil {
-				fmt.Printf("error uploading an object: %v\n", err.Error())
+				fmt.Fprintf(LogOutput, "error uploading an object: %v\n", err.Error())
 				return err
 			}
 		}
 
 		// List object versions after all uploads
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := s3client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{
 			Bucket: &bucket,
 		})
@@ -12960,3 +18397,197 @@ func Versioning_concurrent_upload_object(s *S3Conf) error {
 		return nil
 	}, withVersioning(types.BucketVersioningStatusEnabled))
 }
+
+// Concurrency limits tests
+func PutObject_concurrency_limit_slow_down(s *S3Conf) error {
+	testName := "PutObject_concurrency_limit_slow_down"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		if s.maxWrites <= 0 {
+			return fmt.Errorf("the gateway write concurrency limit is not specified")
+		}
+
+		// drive a few times more uploads, than the gateway allows
+		uploads := s.maxWrites * 4
+		data := make([]byte, 5*1024*1024)
+		rand.Read(data)
+
+		var throttled atomic.Int32
+		var wg sync.WaitGroup
+		errs := make(chan error, uploads)
+		for i := 0; i < uploads; i++ {
+			wg.Add(1)
+			go func(key string) {
+				defer wg.Done()
+				// retry the throttled uploads with a backoff
+				for attempt := 0; attempt < 50; attempt++ {
+					ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+					_, err := s3client.PutObject(ctx, &s3.PutObjectInput{
+						Bucket: &bucket,
+						Key:    &key,
+						Body:   bytes.NewReader(data),
+					})
+					cancel()
+					if err == nil {
+						return
+					}
+					if checkApiErr(err, s3err.GetAPIError(s3err.ErrSlowDown)) != nil {
+						errs <- err
+						return
+					}
+					throttled.Add(1)
+					time.Sleep(time.Duration(attempt+1) * 20 * time.Millisecond)
+				}
+				errs <- fmt.Errorf("%v: the upload is still throttled after all the retries", key)
+			}(fmt.Sprintf("my-obj-%v", i))
+		}
+		wg.Wait()
+		close(errs)
+
+		if err := <-errs; err != nil {
+			return err
+		}
+		if throttled.Load() == 0 {
+			return fmt.Errorf("expected some of the %v concurrent uploads to be throttled with the write limit %v", uploads, s.maxWrites)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+			Bucket: &bucket,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+		if len(out.Contents) != uploads {
+			return fmt.Errorf("expected %v uploaded objects, instead got %v", uploads, len(out.Contents))
+		}
+
+		return nil
+	})
+}
+
+func Smoke_bucket_object_lifecycle(s *S3Conf) error {
+	testName := "Smoke_bucket_object_lifecycle"
+	runF(testName)
+	// the smoke test runs against live deployments,
+	// so it shouldn't collide with the existing buckets
+	bucket := fmt.Sprintf("versitygw-smoke-%v", time.Now().UnixNano())
+	obj := "smoke-obj"
+
+	err := setup(s, bucket)
+	if err != nil {
+		failF("%v: failed to create a bucket: %v", testName, err)
+		return fmt.Errorf("%v: failed to create a bucket: %w", testName, err)
+	}
+
+	s3client := s.GetClient()
+	err = func() error {
+		r, err := putObjectWithData(s, 1024, &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		}, s3client)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		defer cancel()
+		if err != nil {
+			return err
+		}
+		defer out.Body.Close()
+
+		bdy, err := io.ReadAll(out.Body)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(bdy, r.data) {
+			return fmt.Errorf("invalid object data")
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
+		_, err = s3client.DeleteBucket(ctx, &s3.DeleteBucketInput{
+			Bucket: &bucket,
+		})
+		cancel()
+		return err
+	}()
+	if err != nil {
+		failF("%v: %v", testName, err)
+		// cleanup the bucket left by the failed step
+		if err := teardown(s, bucket); err != nil {
+			fmt.Fprintf(LogOutput, colorRed+"%v: failed to delete the bucket: %v\n", testName, err)
+		}
+		return fmt.Errorf("%v: %w", testName, err)
+	}
+
+	passF(testName)
+	return nil
+}
+
+func PutGetObject_large_object_streaming(s *S3Conf) error {
+	testName := "PutGetObject_large_object_streaming"
+	return actionHandler(s, testName, func(s3client *s3.Client, bucket string) error {
+		obj := "my-large-obj"
+		size := s.largeObjSize
+		if size == 0 {
+			size = 1024 * 1024 * 1024
+		}
+
+		// the data is generated and hashed while it's uploaded,
+		// so the object is never held in memory. The seed is
+		// reported on a mismatch to replay the same data.
+		seed := time.Now().UnixNano()
+		r := NewSeededDataReader(int(size), 1024*1024, seed)
+		start := time.Now()
+		err := s.UploadData(r, bucket, obj)
+		if err != nil {
+			return err
+		}
+		elapsed := time.Since(start)
+		fmt.Fprintf(LogOutput, "%v: uploaded %v bytes in %v (%.2f MB/s)\n",
+			testName, size, elapsed, float64(size)/elapsed.Seconds()/1048576)
+
+		start = time.Now()
+		out, err := s3client.GetObject(context.Background(), &s3.GetObjectInput{
+			Bucket: &bucket,
+			Key:    &obj,
+		})
+		if err != nil {
+			return err
+		}
+		defer out.Body.Close()
+
+		hash := sha256.New()
+		n, err := io.Copy(hash, out.Body)
+		if err != nil {
+			return err
+		}
+		elapsed = time.Since(start)
+		fmt.Fprintf(LogOutput, "%v: downloaded %v bytes in %v (%.2f MB/s)\n",
+			testName, n, elapsed, float64(n)/elapsed.Seconds()/1048576)
+
+		if n != size {
+			return fmt.Errorf("expected the object size to be %v, instead got %v", size, n)
+		}
+		if !bytes.Equal(hash.Sum(nil), r.Sum()) {
+			return fmt.Errorf("expected the object checksum to be %x, instead got %x (data seed %v)", r.Sum(), hash.Sum(nil), seed)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 Versity Software
+// This file is licensed under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/versity/versitygw/metrics"
+)
+
+// timings records the duration of every S3 operation of a run, it is
+// shared by the tests running in parallel
+type timings struct {
+	mu  sync.Mutex
+	ops map[string][]time.Duration
+}
+
+func newTimings() *timings {
+	return &timings{ops: make(map[string][]time.Duration)}
+}
+
+func (t *timings) add(op string, d time.Duration) {
+	t.mu.Lock()
+	t.ops[op] = append(t.ops[op], d)
+	t.mu.Unlock()
+}
+
+// middleware times the whole operation, including the signing and
+// the response deserialization
+func (t *timings) middleware(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("RecordTimings",
+		func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			start := time.Now()
+			out, md, err := next.HandleInitialize(ctx, in)
+			t.add(middleware.GetOperationName(ctx), time.Since(start))
+			return out, md, err
+		}), middleware.Before)
+}
+
+// PrintTimings writes the P50/P90/P99 latencies of every operation
+// type the run made, when Timings is enabled
+func (c *S3Conf) PrintTimings(w io.Writer) {
+	if !c.Timings {
+		return
+	}
+
+	c.timings.mu.Lock()
+	defer c.timings.mu.Unlock()
+
+	names := make([]string, 0, len(c.timings.ops))
+	for name := range c.timings.ops {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "operation\tcount\tp50\tp90\tp99\t")
+	for _, name := range names {
+		d := append([]time.Duration(nil), c.timings.ops[name]...)
+		sort.Slice(d, func(i, j int) bool { return d[i] < d[j] })
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t\n", name, len(d),
+			metrics.Percentile(d, 50), metrics.Percentile(d, 90), metrics.Percentile(d, 99))
+	}
+	tw.Flush()
+}
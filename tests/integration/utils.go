@@ -30,6 +30,7 @@ import (
 	"net/url"
 	"os/exec"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -43,27 +44,31 @@ import (
 )
 
 var (
-	bcktCount            = 0
 	succUsrCrt           = "The user has been created successfully"
 	failUsrCrt           = "failed to create user: update iam data: account already exists"
 	adminAccessDeniedMsg = "access denied: only admin users have access to this resource"
 	succDeleteUserMsg    = "The user has been deleted successfully"
 )
 
+// getBucketName returns a random bucket name, so that the tests can
+// run in parallel and concurrent runs can share the same endpoint
 func getBucketName() string {
-	bcktCount++
-	return fmt.Sprintf("test-bucket-%v", bcktCount)
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return "test-bucket-" + hex.EncodeToString(b)
 }
 
 func setup(s *S3Conf, bucket string, opts ...setupOpt) error {
-	s3client := s3.NewFromConfig(s.Config())
+	s3client := s.GetClient()
 
 	cfg := new(setupCfg)
 	for _, opt := range opts {
 		opt(cfg)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 	_, err := s3client.CreateBucket(ctx, &s3.CreateBucketInput{
 		Bucket:                     &bucket,
 		ObjectLockEnabledForBucket: &cfg.LockEnabled,
@@ -75,7 +80,7 @@ func setup(s *S3Conf, bucket string, opts ...setupOpt) error {
 	}
 
 	if cfg.VersioningStatus != "" {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.PutBucketVersioning(ctx, &s3.PutBucketVersioningInput{
 			Bucket: &bucket,
 			VersioningConfiguration: &types.VersioningConfiguration{
@@ -92,10 +97,10 @@ func setup(s *S3Conf, bucket string, opts ...setupOpt) error {
 }
 
 func teardown(s *S3Conf, bucket string) error {
-	s3client := s3.NewFromConfig(s.Config())
+	s3client := s.GetClient()
 
 	deleteObject := func(bucket, key, versionId *string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		_, err := s3client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket:    bucket,
 			Key:       key,
@@ -108,10 +113,10 @@ func teardown(s *S3Conf, bucket string) error {
 		return nil
 	}
 
-	if s.versioningEnabled {
+	if s.versioningEnabled || bucketVersioned(s, s3client, bucket) {
 		in := &s3.ListObjectVersionsInput{Bucket: &bucket}
 		for {
-			ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 			out, err := s3client.ListObjectVersions(ctx, in)
 			cancel()
 			if err != nil {
@@ -132,7 +137,7 @@ func teardown(s *S3Conf, bucket string) error {
 			}
 
 			if out.IsTruncated != nil && *out.IsTruncated {
-				in.KeyMarker = out.KeyMarker
+				in.KeyMarker = out.NextKeyMarker
 				in.VersionIdMarker = out.NextVersionIdMarker
 			} else {
 				break
@@ -140,7 +145,7 @@ func teardown(s *S3Conf, bucket string) error {
 		}
 	} else {
 		for {
-			ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 			out, err := s3client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
 				Bucket: &bucket,
 			})
@@ -163,14 +168,87 @@ func teardown(s *S3Conf, bucket string) error {
 		}
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
-	_, err := s3client.DeleteBucket(ctx, &s3.DeleteBucketInput{
+	err := abortMultipartUploads(s, s3client, bucket)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+	_, err = s3client.DeleteBucket(ctx, &s3.DeleteBucketInput{
 		Bucket: &bucket,
 	})
 	cancel()
 	return err
 }
 
+// bucketVersioned reports whether the bucket has had versioning
+// enabled, in which case it can hold object versions and delete
+// markers. Gateways without versioning support return an error,
+// which is the same as the bucket never having been versioned.
+func bucketVersioned(s *S3Conf, s3client *s3.Client, bucket string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+	out, err := s3client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{
+		Bucket: &bucket,
+	})
+	cancel()
+	if err != nil {
+		return false
+	}
+	return out.Status != ""
+}
+
+// abortMultipartUploads aborts the multipart uploads the test left
+// open, until the bucket has none remaining
+func abortMultipartUploads(s *S3Conf, s3client *s3.Client, bucket string) error {
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+		out, err := s3client.ListMultipartUploads(ctx, &s3.ListMultipartUploadsInput{
+			Bucket: &bucket,
+		})
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to list multipart uploads: %w", err)
+		}
+		if len(out.Uploads) == 0 {
+			return nil
+		}
+
+		for _, upload := range out.Uploads {
+			ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
+			_, err := s3client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+				Bucket:   &bucket,
+				Key:      upload.Key,
+				UploadId: upload.UploadId,
+			})
+			cancel()
+			if err != nil {
+				return fmt.Errorf("failed to abort multipart upload %v: %w", *upload.Key, err)
+			}
+		}
+	}
+}
+
+// retryDelay is the wait between the retry attempts
+const retryDelay = 500 * time.Millisecond
+
+// retry calls fn until it succeeds or the attempts run out, waiting
+// delay between the calls, and returns the last error. It is used
+// around the assertions that depend on read-after-write visibility,
+// which eventually consistent backends don't always provide.
+func retry(fn func() error, attempts int, delay time.Duration) error {
+	var err error
+	for i := 0; i < max(attempts, 1); i++ {
+		if i > 0 {
+			time.Sleep(delay)
+		}
+		err = fn()
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
 type setupCfg struct {
 	LockEnabled      bool
 	VersioningStatus types.BucketVersioningStatus
@@ -197,24 +275,47 @@ func actionHandler(s *S3Conf, testName string, handler func(s3client *s3.Client,
 		failF("%v: failed to create a bucket: %v", testName, err)
 		return fmt.Errorf("%v: failed to create a bucket: %w", testName, err)
 	}
-	client := s3.NewFromConfig(s.Config())
+	client := s.GetClient()
 	handlerErr := handler(client, bucketName)
-	if handlerErr != nil {
-		failF("%v: %v", testName, handlerErr)
-	}
 
+	// the bucket is deleted after a failure too, and a failed
+	// teardown is reported together with the test failure
 	err = teardown(s, bucketName)
 	if err != nil {
-		fmt.Printf(colorRed+"%v: failed to delete the bucket: %v", testName, err)
-		if handlerErr == nil {
-			return fmt.Errorf("%v: failed to delete the bucket: %w", testName, err)
-		}
+		handlerErr = errors.Join(handlerErr, fmt.Errorf("failed to delete the bucket: %w", err))
+	}
+	if handlerErr != nil {
+		failF("%v: %v", testName, handlerErr)
+		return fmt.Errorf("%v: %w", testName, handlerErr)
 	}
-	if handlerErr == nil {
-		passF(testName)
+
+	passF(testName)
+	return nil
+}
+
+// teardownAfter is deferred by the tests right after they create an
+// extra bucket. It deletes the bucket whether or not the test failed
+// and adds a failed teardown to the test error.
+func teardownAfter(s *S3Conf, bucket string, err *error) {
+	if terr := teardown(s, bucket); terr != nil {
+		*err = errors.Join(*err, fmt.Errorf("failed to delete the bucket %v: %w", bucket, terr))
 	}
+}
+
+// checker collects the failed checks of a test, so that the checks
+// following a failed one still run and the test reports all of its
+// failures together
+type checker struct {
+	errs []error
+}
+
+func (c *checker) errorf(format string, a ...any) {
+	c.errs = append(c.errs, fmt.Errorf(format, a...))
+}
 
-	return handlerErr
+// err returns the failed checks joined, nil if all of them passed
+func (c *checker) err() error {
+	return errors.Join(c.errs...)
 }
 
 type authConfig struct {
@@ -245,7 +346,7 @@ func authHandler(s *S3Conf, cfg *authConfig, handler func(req *http.Request) err
 
 func presignedAuthHandler(s *S3Conf, testName string, handler func(client *s3.PresignClient) error) error {
 	runF(testName)
-	clt := s3.NewPresignClient(s3.NewFromConfig(s.Config()))
+	clt := s3.NewPresignClient(s.GetClient())
 
 	err := handler(clt)
 	if err != nil {
@@ -337,11 +438,11 @@ func checkSdkApiErr(err error, code string) error {
 	return err
 }
 
-func putObjects(client *s3.Client, objs []string, bucket string) ([]types.Object, error) {
+func putObjects(s *S3Conf, client *s3.Client, objs []string, bucket string) ([]types.Object, error) {
 	var contents []types.Object
 	var size int64
 	for _, key := range objs {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := client.PutObject(ctx, &s3.PutObjectInput{
 			Key:    &key,
 			Bucket: &bucket,
@@ -367,14 +468,14 @@ func putObjects(client *s3.Client, objs []string, bucket string) ([]types.Object
 	return contents, nil
 }
 
-func listObjects(client *s3.Client, bucket, prefix, delimiter string, maxKeys int32) ([]types.Object, []types.CommonPrefix, error) {
+func listObjects(s *S3Conf, client *s3.Client, bucket, prefix, delimiter string, maxKeys int32) ([]types.Object, []types.CommonPrefix, error) {
 	var contents []types.Object
 	var commonPrefixes []types.CommonPrefix
 
 	var continuationToken *string
 
 	for {
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		res, err := client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
 			Bucket:            &bucket,
 			ContinuationToken: continuationToken,
@@ -443,14 +544,14 @@ type putObjectOutput struct {
 	res  *s3.PutObjectOutput
 }
 
-func putObjectWithData(lgth int64, input *s3.PutObjectInput, client *s3.Client) (*putObjectOutput, error) {
+func putObjectWithData(s *S3Conf, lgth int64, input *s3.PutObjectInput, client *s3.Client) (*putObjectOutput, error) {
 	data := make([]byte, lgth)
 	rand.Read(data)
 	csum := sha256.Sum256(data)
 	r := bytes.NewReader(data)
 	input.Body = r
 
-	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 	res, err := client.PutObject(ctx, input)
 	cancel()
 	if err != nil {
@@ -464,8 +565,8 @@ func putObjectWithData(lgth int64, input *s3.PutObjectInput, client *s3.Client)
 	}, nil
 }
 
-func createMp(s3client *s3.Client, bucket, key string) (*s3.CreateMultipartUploadOutput, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+func createMp(s *S3Conf, s3client *s3.Client, bucket, key string) (*s3.CreateMultipartUploadOutput, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 	out, err := s3client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
 		Bucket: &bucket,
 		Key:    &key,
@@ -564,8 +665,11 @@ func compareGrants(grts1, grts2 []types.Grant) bool {
 	return true
 }
 
+// gatewayBinary is the versitygw binary the admin commands are run with
+var gatewayBinary = "./versitygw"
+
 func execCommand(args ...string) ([]byte, error) {
-	cmd := exec.Command("./versitygw", args...)
+	cmd := exec.Command(gatewayBinary, args...)
 
 	return cmd.CombinedOutput()
 }
@@ -618,27 +722,27 @@ func compareBuckets(list1 []types.Bucket, list2 []s3response.ListAllMyBucketsEnt
 
 func compareObjects(list1, list2 []types.Object) bool {
 	if len(list1) != len(list2) {
-		fmt.Println("list lengths are not equal")
+		fmt.Fprintln(LogOutput, "list lengths are not equal")
 		return false
 	}
 
 	for i, obj := range list1 {
 		if *obj.Key != *list2[i].Key {
-			fmt.Printf("keys are not equal: %q != %q\n", *obj.Key, *list2[i].Key)
+			fmt.Fprintf(LogOutput, "keys are not equal: %q != %q\n", *obj.Key, *list2[i].Key)
 			return false
 		}
 		if *obj.ETag != *list2[i].ETag {
-			fmt.Printf("etags are not equal: (%q %q)  %q != %q\n",
+			fmt.Fprintf(LogOutput, "etags are not equal: (%q %q)  %q != %q\n",
 				*obj.Key, *list2[i].Key, *obj.ETag, *list2[i].ETag)
 			return false
 		}
 		if *obj.Size != *list2[i].Size {
-			fmt.Printf("sizes are not equal: (%q %q)  %v != %v\n",
+			fmt.Fprintf(LogOutput, "sizes are not equal: (%q %q)  %v != %v\n",
 				*obj.Key, *list2[i].Key, *obj.Size, *list2[i].Size)
 			return false
 		}
 		if obj.StorageClass != list2[i].StorageClass {
-			fmt.Printf("storage classes are not equal: (%q %q)  %v != %v\n",
+			fmt.Fprintf(LogOutput, "storage classes are not equal: (%q %q)  %v != %v\n",
 				*obj.Key, *list2[i].Key, obj.StorageClass, list2[i].StorageClass)
 			return false
 		}
@@ -706,7 +810,7 @@ func compareDelObjects(list1, list2 []types.DeletedObject) bool {
 	return true
 }
 
-func uploadParts(client *s3.Client, size, partCount int64, bucket, key, uploadId string) (parts []types.Part, csum string, err error) {
+func uploadParts(s *S3Conf, client *s3.Client, size, partCount int64, bucket, key, uploadId string) (parts []types.Part, csum string, err error) {
 	partSize := size / partCount
 
 	hash := sha256.New()
@@ -722,7 +826,7 @@ func uploadParts(client *s3.Client, size, partCount int64, bucket, key, uploadId
 		rand.Read(partBuffer)
 		hash.Write(partBuffer)
 
-		ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 		pn := int32(partNumber)
 		out, err := client.UploadPart(ctx, &s3.UploadPartInput{
 			Bucket:     &bucket,
@@ -798,6 +902,31 @@ func changeBucketsOwner(s *S3Conf, buckets []string, owner string) error {
 	return nil
 }
 
+func getBucketStats(s *S3Conf, bucket string) (count, size int64, err error) {
+	out, err := execCommand("admin", "-a", s.awsID, "-s", s.awsSecret, "-er", s.endpoint, "bucket-stats", "-b", bucket)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %s", err, out)
+	}
+
+	// the last row of the table holds the bucket stats
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(fields) != 3 || fields[0] != bucket {
+		return 0, 0, fmt.Errorf("unexpected bucket stats output: %s", out)
+	}
+
+	count, err = strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parse object count: %w", err)
+	}
+	size, err = strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parse total size: %w", err)
+	}
+
+	return count, size, nil
+}
+
 const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 
 func genRandString(length int) string {
@@ -863,16 +992,16 @@ func getUserS3Client(usr user, cfg *S3Conf) *s3.Client {
 	config.awsID = usr.access
 	config.awsSecret = usr.secret
 
-	return s3.NewFromConfig(config.Config())
+	return config.GetClient()
 }
 
 // if true enables, otherwise disables
-func changeBucketObjectLockStatus(client *s3.Client, bucket string, status bool) error {
+func changeBucketObjectLockStatus(s *S3Conf, client *s3.Client, bucket string, status bool) error {
 	cfg := types.ObjectLockConfiguration{}
 	if status {
 		cfg.ObjectLockEnabled = types.ObjectLockEnabledEnabled
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 	_, err := client.PutObjectLockConfiguration(ctx, &s3.PutObjectLockConfigurationInput{
 		Bucket:                  &bucket,
 		ObjectLockConfiguration: &cfg,
@@ -885,8 +1014,8 @@ func changeBucketObjectLockStatus(client *s3.Client, bucket string, status bool)
 	return nil
 }
 
-func putBucketVersioningStatus(client *s3.Client, bucket string, status types.BucketVersioningStatus) error {
-	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+func putBucketVersioningStatus(s *S3Conf, client *s3.Client, bucket string, status types.BucketVersioningStatus) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 	_, err := client.PutBucketVersioning(ctx, &s3.PutBucketVersioningInput{
 		Bucket: &bucket,
 		VersioningConfiguration: &types.VersioningConfiguration{
@@ -898,8 +1027,8 @@ func putBucketVersioningStatus(client *s3.Client, bucket string, status types.Bu
 	return err
 }
 
-func checkWORMProtection(client *s3.Client, bucket, object string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+func checkWORMProtection(s *S3Conf, client *s3.Client, bucket, object string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.opTimeout())
 	_, err := client.PutObject(ctx, &s3.PutObjectInput{
 		Bucket: &bucket,
 		Key:    &object,
@@ -909,7 +1038,7 @@ func checkWORMProtection(client *s3.Client, bucket, object string) error {
 		return err
 	}
 
-	ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+	ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 	_, err = client.DeleteObject(ctx, &s3.DeleteObjectInput{
 		Bucket: &bucket,
 		Key:    &object,
@@ -919,7 +1048,7 @@ func checkWORMProtection(client *s3.Client, bucket, object string) error {
 		return err
 	}
 
-	ctx, cancel = context.WithTimeout(context.Background(), shortTimeout)
+	ctx, cancel = context.WithTimeout(context.Background(), s.opTimeout())
 	_, err = client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
 		Bucket: &bucket,
 		Delete: &types.Delete{
@@ -954,7 +1083,7 @@ func pfxStrings(pfxs []types.CommonPrefix) []string {
 	return pfxStrs
 }
 
-func createObjVersions(client *s3.Client, bucket, object string, count int) ([]types.ObjectVersion, error) {
+func createObjVersions(s *S3Conf, client *s3.Client, bucket, object string, count int) ([]types.ObjectVersion, error) {
 	versions := []types.ObjectVersion{}
 	for i := 0; i < count; i++ {
 		rNumber, err := rand.Int(rand.Reader, big.NewInt(100000))
@@ -963,7 +1092,7 @@ func createObjVersions(client *s3.Client, bucket, object string, count int) ([]t
 			return nil, err
 		}
 
-		r, err := putObjectWithData(dataLength, &s3.PutObjectInput{
+		r, err := putObjectWithData(s, dataLength, &s3.PutObjectInput{
 			Bucket: &bucket,
 			Key:    &object,
 		}, client)
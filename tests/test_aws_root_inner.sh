@@ -48,7 +48,7 @@ test_complete_multipart_upload_aws_root() {
   run create_test_files "$bucket_file"
   assert_success
 
-  run dd if=/dev/urandom of="$TEST_FILE_FOLDER/$bucket_file" bs=5M count=1
+  run dd if=/dev/urandom of="$TEST_FILE_FOLDER/$bucket_file" bs=20M count=1
   assert_success
 
   run setup_bucket "aws" "$BUCKET_ONE_NAME"
@@ -84,7 +84,7 @@ test_create_multipart_upload_properties_aws_root() {
   run create_test_files "$bucket_file"
   assert_success
 
-  run dd if=/dev/urandom of="$TEST_FILE_FOLDER/$bucket_file" bs=5M count=1
+  run dd if=/dev/urandom of="$TEST_FILE_FOLDER/$bucket_file" bs=20M count=1
   assert_success
 
   run delete_bucket_or_contents_if_exists "s3api" "$BUCKET_ONE_NAME"
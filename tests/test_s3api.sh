@@ -234,7 +234,7 @@ export RUN_USERS=true
 
   run create_test_file "$bucket_file"
   assert_success
-  dd if=/dev/urandom of="$TEST_FILE_FOLDER/$bucket_file" bs=5M count=1 || fail "error adding data to test file"
+  dd if=/dev/urandom of="$TEST_FILE_FOLDER/$bucket_file" bs=20M count=1 || fail "error adding data to test file"
 
   run setup_bucket "aws" "$BUCKET_ONE_NAME"
   assert_success